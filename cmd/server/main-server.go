@@ -5,10 +5,14 @@ package main
 
 import (
 	"context"
+	"flag"
 	"fmt"
+	"io"
 	"log"
+	"net"
 	"os"
 	"os/signal"
+	"path/filepath"
 
 	"runtime"
 	"sync"
@@ -17,11 +21,13 @@ import (
 
 	"github.com/wavetermdev/waveterm/pkg/authkey"
 	"github.com/wavetermdev/waveterm/pkg/blockcontroller"
+	"github.com/wavetermdev/waveterm/pkg/crashreport"
 	"github.com/wavetermdev/waveterm/pkg/filestore"
 	"github.com/wavetermdev/waveterm/pkg/panichandler"
 	"github.com/wavetermdev/waveterm/pkg/remote/conncontroller"
 	"github.com/wavetermdev/waveterm/pkg/service"
 	"github.com/wavetermdev/waveterm/pkg/telemetry"
+	"github.com/wavetermdev/waveterm/pkg/util/dbbackup"
 	"github.com/wavetermdev/waveterm/pkg/util/shellutil"
 	"github.com/wavetermdev/waveterm/pkg/wavebase"
 	"github.com/wavetermdev/waveterm/pkg/waveobj"
@@ -29,6 +35,7 @@ import (
 	"github.com/wavetermdev/waveterm/pkg/wconfig"
 	"github.com/wavetermdev/waveterm/pkg/wcore"
 	"github.com/wavetermdev/waveterm/pkg/web"
+	"github.com/wavetermdev/waveterm/pkg/wlog"
 	"github.com/wavetermdev/waveterm/pkg/wps"
 	"github.com/wavetermdev/waveterm/pkg/wshrpc"
 	"github.com/wavetermdev/waveterm/pkg/wshrpc/wshremote"
@@ -46,14 +53,42 @@ const InitialTelemetryWait = 10 * time.Second
 const TelemetryTick = 2 * time.Minute
 const TelemetryInterval = 4 * time.Hour
 
+const LogFileName = "wavesrv.log"
+
+// DefaultShutdownDrainTimeout is how long doShutdown waits for running commands to exit on their
+// own before giving up and leaving them marked "cmd:wasinterrupted" -- overridden by the
+// "server:shutdowndraintimeoutms" setting.
+const DefaultShutdownDrainTimeout = 2 * time.Second
+
 var shutdownOnce sync.Once
 
+// mainLog is the "main" subsystem logger -- its level can be changed at runtime via
+// "wsh debug loglevel main <level>".
+var mainLog = wlog.New("main")
+
+func getShutdownDrainTimeout() time.Duration {
+	settings := wconfig.GetWatcher().GetFullConfig().Settings
+	if settings.ServerShutdownDrainTimeoutMs > 0 {
+		return time.Duration(settings.ServerShutdownDrainTimeoutMs) * time.Millisecond
+	}
+	return DefaultShutdownDrainTimeout
+}
+
 func doShutdown(reason string) {
 	shutdownOnce.Do(func() {
-		log.Printf("shutting down: %s\n", reason)
+		mainLog.Infof("shutting down: %s", reason)
+		drainTimeout := getShutdownDrainTimeout()
+		drainCtx, drainCancelFn := context.WithTimeout(context.Background(), drainTimeout)
+		remaining := blockcontroller.DrainAllBlockControllers(drainCtx)
+		drainCancelFn()
+		if remaining > 0 {
+			mainLog.Warnf("shutdown: %d command(s) did not stop within %s, leaving them marked interrupted", remaining, drainTimeout)
+		}
 		ctx, cancelFn := context.WithTimeout(context.Background(), 5*time.Second)
 		defer cancelFn()
-		go blockcontroller.StopAllBlockControllers()
+		if err := web.Shutdown(ctx); err != nil {
+			mainLog.Errorf("error shutting down web server: %v", err)
+		}
 		shutdownActivityUpdate()
 		sendTelemetryWrapper()
 		// TODO deal with flush in progress
@@ -63,8 +98,12 @@ func doShutdown(reason string) {
 		if watcher != nil {
 			watcher.Close()
 		}
+		sshWatcher := conncontroller.GetSshConfigWatcher()
+		if sshWatcher != nil {
+			sshWatcher.Close()
+		}
 		time.Sleep(500 * time.Millisecond)
-		log.Printf("shutdown complete\n")
+		mainLog.Infof("shutdown complete")
 		os.Exit(0)
 	})
 }
@@ -99,6 +138,13 @@ func configWatcher() {
 	}
 }
 
+func sshConfigWatcher() {
+	watcher := conncontroller.GetSshConfigWatcher()
+	if watcher != nil {
+		watcher.Start()
+	}
+}
+
 func telemetryLoop() {
 	var nextSend int64
 	time.Sleep(InitialTelemetryWait)
@@ -115,7 +161,7 @@ func panicTelemetryHandler() {
 	activity := wshrpc.ActivityUpdate{NumPanics: 1}
 	err := telemetry.UpdateActivity(context.Background(), activity)
 	if err != nil {
-		log.Printf("error updating activity (panicTelemetryHandler): %v\n", err)
+		mainLog.Errorf("error updating activity (panicTelemetryHandler): %v", err)
 	}
 }
 
@@ -128,12 +174,25 @@ func sendTelemetryWrapper() {
 	beforeSendActivityUpdate(ctx)
 	client, err := wstore.DBGetSingleton[*waveobj.Client](ctx)
 	if err != nil {
-		log.Printf("[error] getting client data for telemetry: %v\n", err)
+		mainLog.Errorf("getting client data for telemetry: %v", err)
 		return
 	}
 	err = wcloud.SendTelemetry(ctx, client.OID)
 	if err != nil {
-		log.Printf("[error] sending telemetry: %v\n", err)
+		mainLog.Errorf("sending telemetry: %v", err)
+	}
+	numUploaded, err := wcloud.UploadPendingCrashReports(ctx, client.OID)
+	if err != nil {
+		mainLog.Errorf("uploading crash reports: %v", err)
+	} else if numUploaded > 0 {
+		mainLog.Infof("uploaded %d crash report(s)", numUploaded)
+	}
+}
+
+func crashReportHandler(debugStr string, recoverVal any) {
+	_, err := crashreport.Capture(debugStr, recoverVal)
+	if err != nil {
+		mainLog.Errorf("error capturing crash report (%s): %v", debugStr, err)
 	}
 }
 
@@ -148,7 +207,7 @@ func beforeSendActivityUpdate(ctx context.Context) {
 	activity.NumWSNamed, activity.NumWS, _ = wstore.DBGetWSCounts(ctx)
 	err := telemetry.UpdateActivity(ctx, activity)
 	if err != nil {
-		log.Printf("error updating before activity: %v\n", err)
+		mainLog.Errorf("error updating before activity: %v", err)
 	}
 }
 
@@ -158,7 +217,7 @@ func startupActivityUpdate() {
 	activity := wshrpc.ActivityUpdate{Startup: 1}
 	err := telemetry.UpdateActivity(ctx, activity) // set at least one record into activity (don't use go routine wrap here)
 	if err != nil {
-		log.Printf("error updating startup activity: %v\n", err)
+		mainLog.Errorf("error updating startup activity: %v", err)
 	}
 }
 
@@ -168,7 +227,7 @@ func shutdownActivityUpdate() {
 	activity := wshrpc.ActivityUpdate{Shutdown: 1}
 	err := telemetry.UpdateActivity(ctx, activity) // do NOT use the go routine wrap here (this needs to be synchronous)
 	if err != nil {
-		log.Printf("error updating shutdown activity: %v\n", err)
+		mainLog.Errorf("error updating shutdown activity: %v", err)
 	}
 }
 
@@ -176,7 +235,8 @@ func createMainWshClient() {
 	rpc := wshserver.GetMainRpcClient()
 	wshutil.DefaultRouter.RegisterRoute(wshutil.DefaultRoute, rpc, true)
 	wps.Broker.SetClient(wshutil.DefaultRouter)
-	localConnWsh := wshutil.MakeWshRpc(nil, nil, wshrpc.RpcContext{Conn: wshrpc.LocalConnName}, &wshremote.ServerImpl{})
+	localSysinfoSampleRateMs, localSysinfoRetention := wconfig.GetWatcher().GetFullConfig().ResolveSysinfoConfig(wshrpc.LocalConnName)
+	localConnWsh := wshutil.MakeWshRpc(nil, nil, wshrpc.RpcContext{Conn: wshrpc.LocalConnName, SysinfoSampleRateMs: localSysinfoSampleRateMs, SysinfoRetention: localSysinfoRetention}, &wshremote.ServerImpl{})
 	go wshremote.RunSysInfoLoop(localConnWsh, wshrpc.LocalConnName)
 	wshutil.DefaultRouter.RegisterRoute(wshutil.MakeConnectionRouteId(wshrpc.LocalConnName), localConnWsh, true)
 }
@@ -208,86 +268,168 @@ func clearTempFiles() error {
 	return nil
 }
 
+// restoreBackups restores the wstore and filestore databases from their most recent backup at or
+// before tsArg ("latest" or an exact millisecond timestamp, see dbbackup.ParseTimestampArg). It is
+// invoked via "wavesrv --restore-backup <timestamp>" before either store has been opened, and the
+// process exits immediately afterward rather than continuing into normal server startup.
+func restoreBackups(tsArg string) error {
+	backupDir := wavebase.GetWaveBackupDir()
+	for _, store := range []struct {
+		name       string
+		liveDBPath string
+	}{
+		{wstore.BackupStoreName, wstore.GetDBName()},
+		{filestore.BackupStoreName, filestore.GetDBName()},
+	} {
+		available, err := dbbackup.ListBackups(backupDir, store.name)
+		if err != nil {
+			return fmt.Errorf("listing backups for %s: %w", store.name, err)
+		}
+		ts, err := dbbackup.ParseTimestampArg(tsArg, available)
+		if err != nil {
+			return fmt.Errorf("resolving backup for %s: %w", store.name, err)
+		}
+		backupPath := filepath.Join(backupDir, dbbackup.BackupFileName(store.name, ts))
+		if err := dbbackup.RestoreBackup(backupPath, store.liveDBPath); err != nil {
+			return fmt.Errorf("restoring %s: %w", store.name, err)
+		}
+		mainLog.Infof("restored %s from backup %s", store.name, backupPath)
+	}
+	return nil
+}
+
 func main() {
 	log.SetFlags(log.LstdFlags | log.Lmicroseconds)
 	log.SetPrefix("[wavesrv] ")
+	log.SetOutput(io.MultiWriter(os.Stderr, crashreport.TailWriter()))
+	wlog.SetOutput(io.MultiWriter(os.Stderr, crashreport.TailWriter()))
 	wavebase.WaveVersion = WaveVersion
 	wavebase.BuildTime = BuildTime
 
+	profileFlag := flag.String("profile", "", "use an isolated profile (separate db/filestore/settings) under 'profiles/<name>'")
+	restoreBackupFlag := flag.String("restore-backup", "", "restore the wstore and filestore databases from their most recent backup taken before this timestamp (milliseconds since epoch, or \"latest\"), then exit")
+	forceUnlockFlag := flag.Bool("force-unlock", false, "take over the single-instance lock if its owning process is no longer running (refuses if that process is still alive)")
+	flag.Parse()
+	if *profileFlag != "" {
+		if err := wavebase.ApplyProfileOverride(*profileFlag); err != nil {
+			mainLog.Errorf("%v", err)
+			return
+		}
+	}
+
 	err := grabAndRemoveEnvVars()
 	if err != nil {
-		log.Printf("[error] %v\n", err)
+		mainLog.Errorf("%v", err)
 		return
 	}
 	err = service.ValidateServiceMap()
 	if err != nil {
-		log.Printf("error validating service map: %v\n", err)
+		mainLog.Errorf("error validating service map: %v", err)
 		return
 	}
 	err = wavebase.EnsureWaveDataDir()
 	if err != nil {
-		log.Printf("error ensuring wave home dir: %v\n", err)
+		mainLog.Errorf("error ensuring wave home dir: %v", err)
+		return
+	}
+	logFileWriter, err := wlog.NewRotatingWriter(filepath.Join(wavebase.GetWaveDataDir(), LogFileName), wlog.DefaultMaxSizeBytes, wlog.DefaultMaxBackups)
+	if err != nil {
+		mainLog.Errorf("error opening log file: %v", err)
 		return
 	}
+	multiWriter := io.MultiWriter(os.Stderr, crashreport.TailWriter(), logFileWriter)
+	log.SetOutput(multiWriter)
+	wlog.SetOutput(multiWriter)
 	err = wavebase.EnsureWaveDBDir()
 	if err != nil {
-		log.Printf("error ensuring wave db dir: %v\n", err)
+		mainLog.Errorf("error ensuring wave db dir: %v", err)
 		return
 	}
 	err = wavebase.EnsureWaveConfigDir()
 	if err != nil {
-		log.Printf("error ensuring wave config dir: %v\n", err)
+		mainLog.Errorf("error ensuring wave config dir: %v", err)
+		return
+	}
+	err = wavebase.EnsureWaveCrashDir()
+	if err != nil {
+		mainLog.Errorf("error ensuring wave crash dir: %v", err)
+		return
+	}
+	err = wavebase.EnsureWavePprofDir()
+	if err != nil {
+		mainLog.Errorf("error ensuring wave pprof dir: %v", err)
+		return
+	}
+	err = wavebase.EnsureWaveBackupDir()
+	if err != nil {
+		mainLog.Errorf("error ensuring wave backup dir: %v", err)
+		return
+	}
+	err = wavebase.EnsureWaveArchiveDir()
+	if err != nil {
+		mainLog.Errorf("error ensuring wave archive dir: %v", err)
+		return
+	}
+	if *restoreBackupFlag != "" {
+		if err := restoreBackups(*restoreBackupFlag); err != nil {
+			mainLog.Errorf("error restoring backup: %v", err)
+			return
+		}
 		return
 	}
 
 	// TODO: rather than ensure this dir exists, we should let the editor recursively create parent dirs on save
 	err = wavebase.EnsureWavePresetsDir()
 	if err != nil {
-		log.Printf("error ensuring wave presets dir: %v\n", err)
+		mainLog.Errorf("error ensuring wave presets dir: %v", err)
 		return
 	}
-	waveLock, err := wavebase.AcquireWaveLock()
+	waveLock, err := wavebase.AcquireWaveLockWithRecovery(*forceUnlockFlag)
 	if err != nil {
-		log.Printf("error acquiring wave lock (another instance of Wave is likely running): %v\n", err)
+		mainLog.Errorf("error acquiring wave lock: %v", err)
 		return
 	}
 	defer func() {
 		err = waveLock.Close()
 		if err != nil {
-			log.Printf("error releasing wave lock: %v\n", err)
+			mainLog.Errorf("error releasing wave lock: %v", err)
 		}
 	}()
-	log.Printf("wave version: %s (%s)\n", WaveVersion, BuildTime)
-	log.Printf("wave data dir: %s\n", wavebase.GetWaveDataDir())
-	log.Printf("wave config dir: %s\n", wavebase.GetWaveConfigDir())
+	mainLog.Infof("wave version: %s (%s)", WaveVersion, BuildTime)
+	mainLog.Infof("wave data dir: %s", wavebase.GetWaveDataDir())
+	mainLog.Infof("wave config dir: %s", wavebase.GetWaveConfigDir())
+	filestore.WalSyncModeGetter = func() string {
+		return wconfig.GetWatcher().GetFullConfig().Settings.ServerFilestoreWalSyncMode
+	}
 	err = filestore.InitFilestore()
 	if err != nil {
-		log.Printf("error initializing filestore: %v\n", err)
+		mainLog.Errorf("error initializing filestore: %v", err)
 		return
 	}
 	err = wstore.InitWStore()
 	if err != nil {
-		log.Printf("error initializing wstore: %v\n", err)
+		mainLog.Errorf("error initializing wstore: %v", err)
 		return
 	}
 	panichandler.PanicTelemetryHandler = panicTelemetryHandler
+	panichandler.CrashReportHandler = crashReportHandler
 	go func() {
 		defer func() {
 			panichandler.PanicHandler("InitCustomShellStartupFiles", recover())
 		}()
 		err := shellutil.InitCustomShellStartupFiles()
 		if err != nil {
-			log.Printf("error initializing wsh and shell-integration files: %v\n", err)
+			mainLog.Errorf("error initializing wsh and shell-integration files: %v", err)
 		}
 	}()
 	err = wcore.EnsureInitialData()
 	if err != nil {
-		log.Printf("error ensuring initial data: %v\n", err)
+		mainLog.Errorf("error ensuring initial data: %v", err)
 		return
 	}
 	err = clearTempFiles()
 	if err != nil {
-		log.Printf("error clearing temp files: %v\n", err)
+		mainLog.Errorf("error clearing temp files: %v", err)
 		return
 	}
 
@@ -297,20 +439,27 @@ func main() {
 	go stdinReadWatch()
 	go telemetryLoop()
 	configWatcher()
-	webListener, err := web.MakeTCPListener("web")
+	sshConfigWatcher()
+	settings := wconfig.GetWatcher().GetFullConfig().Settings
+	var webListener net.Listener
+	if settings.ServerHeadless && settings.ServerListenAddr != "" {
+		webListener, err = web.MakeServerListener(settings.ServerListenAddr)
+	} else {
+		webListener, err = web.MakeTCPListener("web")
+	}
 	if err != nil {
-		log.Printf("error creating web listener: %v\n", err)
+		mainLog.Errorf("error creating web listener: %v", err)
 		return
 	}
 	wsListener, err := web.MakeTCPListener("websocket")
 	if err != nil {
-		log.Printf("error creating websocket listener: %v\n", err)
+		mainLog.Errorf("error creating websocket listener: %v", err)
 		return
 	}
 	go web.RunWebSocketServer(wsListener)
 	unixListener, err := web.MakeUnixListener()
 	if err != nil {
-		log.Printf("error creating unix listener: %v\n", err)
+		mainLog.Errorf("error creating unix listener: %v", err)
 		return
 	}
 	go func() {
@@ -321,6 +470,23 @@ func main() {
 		fmt.Fprintf(os.Stderr, "WAVESRV-ESTART ws:%s web:%s version:%s buildtime:%s\n", wsListener.Addr(), webListener.Addr(), WaveVersion, BuildTime)
 	}()
 	go wshutil.RunWshRpcOverListener(unixListener)
-	web.RunWebServer(webListener) // blocking
+	if settings.GatewayEnabled && settings.GatewayUnixSocketPath != "" {
+		gatewayListener, err := web.MakeGatewayUnixListener(settings.GatewayUnixSocketPath)
+		if err != nil {
+			mainLog.Errorf("error creating gateway unix listener: %v", err)
+		} else {
+			go web.RunGatewayUnixServer(gatewayListener)
+		}
+	}
+	webServerOpts := web.ServerOpts{}
+	if settings.ServerHeadless {
+		// headless mode: the websocket listener above stays loopback-only in
+		// this initial implementation, so a reverse proxy fronting the TLS
+		// listener needs to also forward the websocket port to wsListener.Addr().
+		webServerOpts.TlsCertFile = settings.ServerTlsCertFile
+		webServerOpts.TlsKeyFile = settings.ServerTlsKeyFile
+		webServerOpts.FrontendDir = settings.ServerFrontendDir
+	}
+	web.RunWebServer(webListener, webServerOpts) // blocking
 	runtime.KeepAlive(waveLock)
 }
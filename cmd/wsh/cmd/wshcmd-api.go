@@ -0,0 +1,57 @@
+// Copyright 2024, Command Line Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/spf13/cobra"
+	"github.com/wavetermdev/waveterm/pkg/wshrpc"
+)
+
+var apiTimeoutMs int
+var apiRoute string
+
+var apiCmd = &cobra.Command{
+	Use:   "api <method>",
+	Short: "call a wshrpc method directly, for scripting",
+	Long: "Calls an arbitrary wshrpc method (the same ones the wsh subcommands and the frontend use " +
+		"internally -- see pkg/wshrpc/wshrpctypes.go for the full list) with JSON params read from stdin, " +
+		"and prints the JSON response to stdout. Lets scripts create blocks, set metadata, read files, and " +
+		"so on without writing Go against the generated wshclient package.",
+	Args:    cobra.ExactArgs(1),
+	RunE:    apiRun,
+	PreRunE: preRunSetupRpcClient,
+}
+
+func init() {
+	apiCmd.Flags().IntVar(&apiTimeoutMs, "timeout", 5000, "rpc timeout in milliseconds")
+	apiCmd.Flags().StringVar(&apiRoute, "route", "", "rpc route to send the request to (defaults to wavesrv)")
+	rootCmd.AddCommand(apiCmd)
+}
+
+func apiRun(cmd *cobra.Command, args []string) (rtnErr error) {
+	method := args[0]
+	defer func() {
+		sendActivity("api", rtnErr == nil)
+	}()
+	stdinBytes, err := io.ReadAll(os.Stdin)
+	if err != nil {
+		return fmt.Errorf("reading params from stdin: %w", err)
+	}
+	var params interface{}
+	if len(stdinBytes) > 0 {
+		if err := json.Unmarshal(stdinBytes, &params); err != nil {
+			return fmt.Errorf("parsing stdin as json: %w", err)
+		}
+	}
+	resp, err := RpcClient.SendRpcRequest(method, params, &wshrpc.RpcOpts{Timeout: apiTimeoutMs, Route: apiRoute})
+	if err != nil {
+		return fmt.Errorf("calling %q: %w", method, err)
+	}
+	return outputJson(resp)
+}
@@ -0,0 +1,53 @@
+// Copyright 2024, Command Line Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/wavetermdev/waveterm/pkg/wshrpc"
+	"github.com/wavetermdev/waveterm/pkg/wshrpc/wshclient"
+)
+
+var broadcastCmd = &cobra.Command{
+	Use:     "broadcast [group-name]",
+	Short:   "mirror keystrokes from this block to other blocks in the same broadcast group",
+	Long:    "Join the current block to a broadcast group, so that any input typed into it is mirrored to every other block in the group (like tmux synchronize-panes). Run with no arguments to leave the current broadcast group.",
+	Args:    cobra.MaximumNArgs(1),
+	RunE:    broadcastRun,
+	PreRunE: preRunSetupRpcClient,
+}
+
+func init() {
+	rootCmd.AddCommand(broadcastCmd)
+}
+
+func broadcastRun(cmd *cobra.Command, args []string) (rtnErr error) {
+	defer func() {
+		sendActivity("broadcast", rtnErr == nil)
+	}()
+	fullORef, err := resolveSimpleId(blockArg)
+	if err != nil {
+		return fmt.Errorf("resolving block: %w", err)
+	}
+	var group string
+	if len(args) > 0 {
+		group = args[0]
+	}
+	commandData := wshrpc.CommandSetBroadcastGroupData{
+		BlockId: fullORef.OID,
+		Group:   group,
+	}
+	err = wshclient.SetBroadcastGroupCommand(RpcClient, commandData, &wshrpc.RpcOpts{Timeout: 2000})
+	if err != nil {
+		return fmt.Errorf("setting broadcast group: %w", err)
+	}
+	if group == "" {
+		WriteStdout("left broadcast group\n")
+	} else {
+		WriteStdout("joined broadcast group %q\n", group)
+	}
+	return nil
+}
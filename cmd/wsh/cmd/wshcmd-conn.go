@@ -9,6 +9,7 @@ import (
 
 	"github.com/spf13/cobra"
 	"github.com/wavetermdev/waveterm/pkg/remote"
+	"github.com/wavetermdev/waveterm/pkg/waveobj"
 	"github.com/wavetermdev/waveterm/pkg/wshrpc"
 	"github.com/wavetermdev/waveterm/pkg/wshrpc/wshclient"
 )
@@ -67,6 +68,33 @@ var connEnsureCmd = &cobra.Command{
 	PreRunE: preRunSetupRpcClient,
 }
 
+var connTagCmd = &cobra.Command{
+	Use:     "tag CONNECTION [tag...]",
+	Short:   "set inventory tags on a connection (e.g. env:prod role:db)",
+	Long:    "Set connection's conn:tags in connections.json, replacing any tags it already has. Run with no tags to clear them. Tags can be matched with \"wsh run multi --remotes=tag:xxx\".",
+	Args:    cobra.MinimumNArgs(1),
+	RunE:    connTagRun,
+	PreRunE: preRunSetupRpcClient,
+}
+
+var connNoteCmd = &cobra.Command{
+	Use:     "note CONNECTION [text]",
+	Short:   "set a free-form note on a connection",
+	Long:    "Set connection's conn:notes in connections.json, replacing any note it already has. Run with no text to clear it.",
+	Args:    cobra.MinimumNArgs(1),
+	RunE:    connNoteRun,
+	PreRunE: preRunSetupRpcClient,
+}
+
+var connImportTagsCmd = &cobra.Command{
+	Use:     "importtags CONNECTION",
+	Short:   "import tags/notes for a connection from a \"# wave:tags=...\" comment in ~/.ssh/config",
+	Long:    "Looks for a comment of the form \"Host myhost  # wave:tags=env:prod,role:db notes=some text\" on connection's Host line in ~/.ssh/config, and if found saves it to connections.json.",
+	Args:    cobra.ExactArgs(1),
+	RunE:    connImportTagsRun,
+	PreRunE: preRunSetupRpcClient,
+}
+
 func init() {
 	rootCmd.AddCommand(connCmd)
 	connCmd.AddCommand(connStatusCmd)
@@ -75,6 +103,9 @@ func init() {
 	connCmd.AddCommand(connDisconnectAllCmd)
 	connCmd.AddCommand(connConnectCmd)
 	connCmd.AddCommand(connEnsureCmd)
+	connCmd.AddCommand(connTagCmd)
+	connCmd.AddCommand(connNoteCmd)
+	connCmd.AddCommand(connImportTagsCmd)
 }
 
 func validateConnectionName(name string) error {
@@ -107,14 +138,17 @@ func connStatusRun(cmd *cobra.Command, args []string) error {
 	if err != nil {
 		return err
 	}
+	if jsonOutput {
+		return outputJson(allResp)
+	}
 	if len(allResp) == 0 {
 		WriteStdout("no connections\n")
 		return nil
 	}
-	WriteStdout("%-30s %-12s\n", "connection", "status")
-	WriteStdout("----------------------------------------------\n")
+	WriteStdout("%-30s %-12s %-12s %-12s\n", "connection", "status", "interactive", "bulk")
+	WriteStdout("------------------------------------------------------------------------\n")
 	for _, conn := range allResp {
-		str := fmt.Sprintf("%-30s %-12s", conn.Connection, conn.Status)
+		str := fmt.Sprintf("%-30s %-12s %-12d %-12d", conn.Connection, conn.Status, conn.InteractiveBytes, conn.BulkBytes)
 		if conn.Error != "" {
 			str += fmt.Sprintf(" (%s)", conn.Error)
 		}
@@ -193,3 +227,61 @@ func connEnsureRun(cmd *cobra.Command, args []string) error {
 	WriteStdout("wsh ensured on connection %q\n", connName)
 	return nil
 }
+
+func connTagRun(cmd *cobra.Command, args []string) error {
+	connName := args[0]
+	if err := validateConnectionName(connName); err != nil {
+		return err
+	}
+	tags := args[1:]
+	data := wshrpc.ConnConfigRequest{
+		Host:        connName,
+		MetaMapType: waveobj.MetaMapType{"conn:tags": tags},
+	}
+	if err := wshclient.SetConnectionsConfigCommand(RpcClient, data, nil); err != nil {
+		return fmt.Errorf("setting tags on connection: %w", err)
+	}
+	if len(tags) == 0 {
+		WriteStdout("cleared tags on connection %q\n", connName)
+	} else {
+		WriteStdout("set tags on connection %q: %s\n", connName, strings.Join(tags, ", "))
+	}
+	return nil
+}
+
+func connNoteRun(cmd *cobra.Command, args []string) error {
+	connName := args[0]
+	if err := validateConnectionName(connName); err != nil {
+		return err
+	}
+	note := strings.Join(args[1:], " ")
+	data := wshrpc.ConnConfigRequest{
+		Host:        connName,
+		MetaMapType: waveobj.MetaMapType{"conn:notes": note},
+	}
+	if err := wshclient.SetConnectionsConfigCommand(RpcClient, data, nil); err != nil {
+		return fmt.Errorf("setting note on connection: %w", err)
+	}
+	WriteStdout("set note on connection %q\n", connName)
+	return nil
+}
+
+func connImportTagsRun(cmd *cobra.Command, args []string) error {
+	connName := args[0]
+	if err := validateConnectionName(connName); err != nil {
+		return err
+	}
+	rtn, err := wshclient.ConnImportTagsCommand(RpcClient, connName, nil)
+	if err != nil {
+		return fmt.Errorf("importing tags: %w", err)
+	}
+	if !rtn.Found {
+		WriteStdout("no \"wave:\" tags comment found for connection %q in ~/.ssh/config\n", connName)
+		return nil
+	}
+	WriteStdout("imported tags on connection %q: %s\n", connName, strings.Join(rtn.Tags, ", "))
+	if rtn.Notes != "" {
+		WriteStdout("imported notes on connection %q: %s\n", connName, rtn.Notes)
+	}
+	return nil
+}
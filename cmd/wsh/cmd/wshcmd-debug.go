@@ -7,6 +7,7 @@ import (
 	"encoding/json"
 
 	"github.com/spf13/cobra"
+	"github.com/wavetermdev/waveterm/pkg/wshrpc"
 	"github.com/wavetermdev/waveterm/pkg/wshrpc/wshclient"
 )
 
@@ -24,11 +25,56 @@ var debugBlockIdsCmd = &cobra.Command{
 	Hidden: true,
 }
 
+var debugCapabilitiesCmd = &cobra.Command{
+	Use:    "capabilities",
+	Short:  "show the wshrpc feature set this wavesrv build supports",
+	RunE:   debugCapabilitiesRun,
+	Hidden: true,
+}
+
+var debugCrashesCmd = &cobra.Command{
+	Use:    "crashes",
+	Short:  "list locally captured crash reports",
+	RunE:   debugCrashesRun,
+	Hidden: true,
+}
+
+var debugLogLevelCmd = &cobra.Command{
+	Use:    "loglevel [subsystem] [level]",
+	Short:  "get or set the wavesrv log level (debug, info, warn, error) globally or per-subsystem",
+	Args:   cobra.MaximumNArgs(2),
+	RunE:   debugLogLevelRun,
+	Hidden: true,
+}
+
+var debugProfileCmd = &cobra.Command{
+	Use:    "profile cpu|heap",
+	Short:  "capture a cpu or heap profile of wavesrv to the wave pprof directory (requires server:pprofenabled)",
+	Args:   cobra.ExactArgs(1),
+	RunE:   debugProfileRun,
+	Hidden: true,
+}
+
+var debugDbStatsCmd = &cobra.Command{
+	Use:    "dbstats",
+	Short:  "show sqlite db file size, page/freelist stats, and per-table row counts for wstore and filestore",
+	RunE:   debugDbStatsRun,
+	Hidden: true,
+}
+
 func init() {
 	debugCmd.AddCommand(debugBlockIdsCmd)
+	debugCmd.AddCommand(debugCapabilitiesCmd)
+	debugCmd.AddCommand(debugCrashesCmd)
+	debugCmd.AddCommand(debugLogLevelCmd)
+	debugCmd.AddCommand(debugProfileCmd)
+	debugCmd.AddCommand(debugDbStatsCmd)
 	rootCmd.AddCommand(debugCmd)
+	debugProfileCmd.Flags().IntVar(&debugProfileSeconds, "seconds", 30, "duration to sample a cpu profile for (ignored for heap)")
 }
 
+var debugProfileSeconds int
+
 func debugBlockIdsRun(cmd *cobra.Command, args []string) error {
 	oref, err := resolveBlockArg()
 	if err != nil {
@@ -45,3 +91,71 @@ func debugBlockIdsRun(cmd *cobra.Command, args []string) error {
 	WriteStdout("%s\n", string(barr))
 	return nil
 }
+
+func debugCapabilitiesRun(cmd *cobra.Command, args []string) error {
+	caps, err := wshclient.ClientCapabilitiesCommand(RpcClient, nil)
+	if err != nil {
+		return err
+	}
+	barr, err := json.MarshalIndent(caps, "", "  ")
+	if err != nil {
+		return err
+	}
+	WriteStdout("%s\n", string(barr))
+	return nil
+}
+
+func debugCrashesRun(cmd *cobra.Command, args []string) error {
+	crashes, err := wshclient.DebugCrashesCommand(RpcClient, nil)
+	if err != nil {
+		return err
+	}
+	barr, err := json.MarshalIndent(crashes, "", "  ")
+	if err != nil {
+		return err
+	}
+	WriteStdout("%s\n", string(barr))
+	return nil
+}
+
+func debugProfileRun(cmd *cobra.Command, args []string) error {
+	rtn, err := wshclient.DebugProfileCommand(RpcClient, wshrpc.CommandDebugProfileData{Kind: args[0], Seconds: debugProfileSeconds}, nil)
+	if err != nil {
+		return err
+	}
+	WriteStdout("profile written to %s\n", rtn.FilePath)
+	return nil
+}
+
+func debugDbStatsRun(cmd *cobra.Command, args []string) error {
+	stats, err := wshclient.ClientDbStatsCommand(RpcClient, nil)
+	if err != nil {
+		return err
+	}
+	barr, err := json.MarshalIndent(stats, "", "  ")
+	if err != nil {
+		return err
+	}
+	WriteStdout("%s\n", string(barr))
+	return nil
+}
+
+func debugLogLevelRun(cmd *cobra.Command, args []string) error {
+	var data wshrpc.CommandDebugLogLevelData
+	if len(args) == 1 {
+		data.Level = args[0]
+	} else if len(args) == 2 {
+		data.Subsystem = args[0]
+		data.Level = args[1]
+	}
+	rtn, err := wshclient.DebugLogLevelCommand(RpcClient, data, nil)
+	if err != nil {
+		return err
+	}
+	barr, err := json.MarshalIndent(rtn, "", "  ")
+	if err != nil {
+		return err
+	}
+	WriteStdout("%s\n", string(barr))
+	return nil
+}
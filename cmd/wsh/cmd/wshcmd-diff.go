@@ -0,0 +1,93 @@
+// Copyright 2026, Command Line Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+
+	"github.com/spf13/cobra"
+	"github.com/wavetermdev/waveterm/pkg/waveobj"
+	"github.com/wavetermdev/waveterm/pkg/wshrpc"
+)
+
+var diffMagnified bool
+var diffGitHead bool
+
+var diffCmd = &cobra.Command{
+	Use:   "diff file1 [file2]",
+	Short: "open a side-by-side diff of two files, or a file against its git HEAD version",
+	Long: "Open a side-by-side diff block. With two arguments, diffs file1 against file2. With " +
+		"--git and one argument, diffs the file against its content at the git HEAD revision of " +
+		"the repo containing it. Paths resolve against the current directory (the remote " +
+		"directory, when run over a connection); diffing across two different connections isn't " +
+		"supported.",
+	Args:    cobra.RangeArgs(1, 2),
+	RunE:    diffRun,
+	PreRunE: preRunSetupRpcClient,
+}
+
+func init() {
+	diffCmd.Flags().BoolVarP(&diffMagnified, "magnified", "m", false, "open view in magnified mode")
+	diffCmd.Flags().BoolVar(&diffGitHead, "git", false, "diff file1 against its git HEAD version instead of file2")
+	rootCmd.AddCommand(diffCmd)
+}
+
+func resolveDiffFileArg(arg string) (string, error) {
+	absFile, err := filepath.Abs(arg)
+	if err != nil {
+		return "", fmt.Errorf("getting absolute path: %w", err)
+	}
+	_, err = os.Stat(absFile)
+	if err == fs.ErrNotExist {
+		return "", fmt.Errorf("file does not exist: %q", absFile)
+	}
+	if err != nil {
+		return "", fmt.Errorf("getting file info: %w", err)
+	}
+	return absFile, nil
+}
+
+func diffRun(cmd *cobra.Command, args []string) (rtnErr error) {
+	defer func() {
+		sendActivity("diff", rtnErr == nil)
+	}()
+	if diffGitHead && len(args) != 1 {
+		return fmt.Errorf("--git takes exactly one file argument")
+	}
+	if !diffGitHead && len(args) != 2 {
+		return fmt.Errorf("diff requires two file arguments unless --git is set")
+	}
+	file1, err := resolveDiffFileArg(args[0])
+	if err != nil {
+		return err
+	}
+	meta := map[string]any{
+		waveobj.MetaKey_View:      "diff",
+		waveobj.MetaKey_DiffFile1: file1,
+	}
+	if diffGitHead {
+		meta[waveobj.MetaKey_DiffGitHead] = true
+	} else {
+		file2, err := resolveDiffFileArg(args[1])
+		if err != nil {
+			return err
+		}
+		meta[waveobj.MetaKey_DiffFile2] = file2
+	}
+	wshCmd := &wshrpc.CommandCreateBlockData{
+		BlockDef:  &waveobj.BlockDef{Meta: meta},
+		Magnified: diffMagnified,
+	}
+	if RpcContext.Conn != "" {
+		wshCmd.BlockDef.Meta[waveobj.MetaKey_Connection] = RpcContext.Conn
+	}
+	_, err = RpcClient.SendRpcRequest(wshrpc.Command_CreateBlock, wshCmd, &wshrpc.RpcOpts{Timeout: 2000})
+	if err != nil {
+		return fmt.Errorf("running diff command: %w", err)
+	}
+	return nil
+}
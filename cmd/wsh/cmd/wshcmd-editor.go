@@ -8,6 +8,8 @@ import (
 	"io/fs"
 	"os"
 	"path/filepath"
+	"strconv"
+	"strings"
 
 	"github.com/spf13/cobra"
 	"github.com/wavetermdev/waveterm/pkg/waveobj"
@@ -17,9 +19,10 @@ import (
 )
 
 var editMagnified bool
+var editorWait bool
 
 var editorCmd = &cobra.Command{
-	Use:     "editor",
+	Use:     "editor file[:line[:col]]",
 	Short:   "edit a file (blocks until editor is closed)",
 	RunE:    editorRun,
 	PreRunE: preRunSetupRpcClient,
@@ -27,9 +30,35 @@ var editorCmd = &cobra.Command{
 
 func init() {
 	editorCmd.Flags().BoolVarP(&editMagnified, "magnified", "m", false, "open view in magnified mode")
+	editorCmd.Flags().BoolVar(&editorWait, "wait", true, "block until the editor is closed")
 	rootCmd.AddCommand(editorCmd)
 }
 
+// parseEditorFileArg splits a "file[:line[:col]]" argument into its file, line, and col parts
+// (the same "path:line:col" shape terminal output links use). line and col are 0 when not
+// specified (not 1, so callers can tell "unset" from "line 1").
+func parseEditorFileArg(arg string) (file string, line int, col int, err error) {
+	parts := strings.Split(arg, ":")
+	if len(parts) == 1 {
+		return parts[0], 0, 0, nil
+	}
+	if len(parts) > 3 {
+		return "", 0, 0, fmt.Errorf("invalid file[:line[:col]] argument: %q", arg)
+	}
+	file = parts[0]
+	line, err = strconv.Atoi(parts[1])
+	if err != nil {
+		return "", 0, 0, fmt.Errorf("invalid line number in %q: %w", arg, err)
+	}
+	if len(parts) == 3 {
+		col, err = strconv.Atoi(parts[2])
+		if err != nil {
+			return "", 0, 0, fmt.Errorf("invalid column number in %q: %w", arg, err)
+		}
+	}
+	return file, line, col, nil
+}
+
 func editorRun(cmd *cobra.Command, args []string) (rtnErr error) {
 	defer func() {
 		sendActivity("editor", rtnErr == nil)
@@ -42,7 +71,10 @@ func editorRun(cmd *cobra.Command, args []string) (rtnErr error) {
 		OutputHelpMessage(cmd)
 		return fmt.Errorf("too many arguments.  wsh editor requires exactly one argument")
 	}
-	fileArg := args[0]
+	fileArg, line, col, err := parseEditorFileArg(args[0])
+	if err != nil {
+		return err
+	}
 	absFile, err := filepath.Abs(fileArg)
 	if err != nil {
 		return fmt.Errorf("getting absolute path: %w", err)
@@ -54,13 +86,20 @@ func editorRun(cmd *cobra.Command, args []string) (rtnErr error) {
 	if err != nil {
 		return fmt.Errorf("getting file info: %w", err)
 	}
+	meta := map[string]any{
+		waveobj.MetaKey_View: "preview",
+		waveobj.MetaKey_File: absFile,
+		waveobj.MetaKey_Edit: true,
+	}
+	if line > 0 {
+		meta[waveobj.MetaKey_EditorGotoLine] = line
+	}
+	if col > 0 {
+		meta[waveobj.MetaKey_EditorGotoColumn] = col
+	}
 	wshCmd := wshrpc.CommandCreateBlockData{
 		BlockDef: &waveobj.BlockDef{
-			Meta: map[string]any{
-				waveobj.MetaKey_View: "preview",
-				waveobj.MetaKey_File: absFile,
-				waveobj.MetaKey_Edit: true,
-			},
+			Meta: meta,
 		},
 		Magnified: editMagnified,
 	}
@@ -71,6 +110,9 @@ func editorRun(cmd *cobra.Command, args []string) (rtnErr error) {
 	if err != nil {
 		return fmt.Errorf("running view command: %w", err)
 	}
+	if !editorWait {
+		return nil
+	}
 	doneCh := make(chan bool)
 	RpcClient.EventListener.On(wps.Event_BlockClose, func(event *wps.WaveEvent) {
 		if event.HasScope(blockRef.String()) {
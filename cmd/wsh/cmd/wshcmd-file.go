@@ -614,6 +614,17 @@ func fileListRun(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
+	if jsonOutput {
+		var files []*wshrpc.WaveFileInfo
+		for f := range filesChan {
+			if f.err != nil {
+				return f.err
+			}
+			files = append(files, f.info)
+		}
+		return outputJson(files)
+	}
+
 	if longForm {
 		return filePrintLong(filesChan)
 	}
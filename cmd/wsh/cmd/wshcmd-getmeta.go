@@ -10,6 +10,7 @@ import (
 	"strings"
 
 	"github.com/spf13/cobra"
+	"github.com/wavetermdev/waveterm/pkg/waveobj"
 	"github.com/wavetermdev/waveterm/pkg/wshrpc"
 	"github.com/wavetermdev/waveterm/pkg/wshrpc/wshclient"
 )
@@ -26,12 +27,38 @@ var getMetaCmd = &cobra.Command{
 var getMetaRawOutput bool
 var getMetaClearPrefix bool
 var getMetaVerbose bool
+var getMetaVersion bool
+var getMetaBulkOrefs string
 
 func init() {
 	rootCmd.AddCommand(getMetaCmd)
 	getMetaCmd.Flags().BoolVarP(&getMetaVerbose, "verbose", "v", false, "output full metadata")
 	getMetaCmd.Flags().BoolVar(&getMetaRawOutput, "raw", false, "output singleton string values without quotes")
 	getMetaCmd.Flags().BoolVar(&getMetaClearPrefix, "clear-prefix", false, "output the special clearing key for prefix queries")
+	getMetaCmd.Flags().BoolVar(&getMetaVersion, "version", false, "include the metadata version (for setmeta --if-version) in the output")
+	getMetaCmd.Flags().StringVar(&getMetaBulkOrefs, "bulk", "", "comma-separated list of orefs (e.g. block:<id>) to fetch in one request; ignores -b and all other flags/args except --raw")
+}
+
+func getMetaBulkRun() error {
+	orefStrs := strings.Split(getMetaBulkOrefs, ",")
+	orefs := make([]waveobj.ORef, len(orefStrs))
+	for idx, orefStr := range orefStrs {
+		oref, err := waveobj.ParseORef(strings.TrimSpace(orefStr))
+		if err != nil {
+			return fmt.Errorf("parsing oref %q: %w", orefStr, err)
+		}
+		orefs[idx] = oref
+	}
+	resp, err := wshclient.GetMetaBulkCommand(RpcClient, wshrpc.CommandGetMetaBulkData{ORefs: orefs}, &wshrpc.RpcOpts{Timeout: 2000})
+	if err != nil {
+		return fmt.Errorf("getting metadata in bulk: %w", err)
+	}
+	outBArr, err := json.MarshalIndent(resp, "", "  ")
+	if err != nil {
+		return fmt.Errorf("formatting metadata: %w", err)
+	}
+	WriteStdout("%s\n", string(outBArr))
+	return nil
 }
 
 func filterMetaKeys(meta map[string]interface{}, keys []string) map[string]interface{} {
@@ -76,6 +103,9 @@ func getMetaRun(cmd *cobra.Command, args []string) (rtnErr error) {
 	defer func() {
 		sendActivity("getmeta", rtnErr == nil)
 	}()
+	if getMetaBulkOrefs != "" {
+		return getMetaBulkRun()
+	}
 	fullORef, err := resolveBlockArg()
 	if err != nil {
 		return err
@@ -102,6 +132,18 @@ func getMetaRun(cmd *cobra.Command, args []string) (rtnErr error) {
 		output = resp
 	}
 
+	if getMetaVersion {
+		bulkResp, err := wshclient.GetMetaBulkCommand(RpcClient, wshrpc.CommandGetMetaBulkData{ORefs: []waveobj.ORef{*fullORef}}, &wshrpc.RpcOpts{Timeout: 2000})
+		if err != nil {
+			return fmt.Errorf("getting metadata version: %w", err)
+		}
+		version := 0
+		if len(bulkResp) > 0 {
+			version = bulkResp[0].Version
+		}
+		output = map[string]interface{}{"meta": output, "version": version}
+	}
+
 	// Handle raw string output
 	if getMetaRawOutput {
 		if str, ok := output.(string); ok {
@@ -0,0 +1,62 @@
+// Copyright 2024, Command Line Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+	"github.com/wavetermdev/waveterm/pkg/wshrpc"
+	"github.com/wavetermdev/waveterm/pkg/wshrpc/wshclient"
+)
+
+var gitCmd = &cobra.Command{
+	Use:   "git",
+	Short: "git integration commands",
+	Long:  "Commands to report git status for use in prompts and tab titles",
+}
+
+var gitStatusCmd = &cobra.Command{
+	Use:     "status",
+	Short:   "show a one-line git status summary for the current directory",
+	Args:    cobra.NoArgs,
+	RunE:    gitStatusRun,
+	PreRunE: preRunSetupRpcClient,
+}
+
+func init() {
+	rootCmd.AddCommand(gitCmd)
+	gitCmd.AddCommand(gitStatusCmd)
+}
+
+func gitStatusRun(cmd *cobra.Command, args []string) error {
+	cwd, err := os.Getwd()
+	if err != nil {
+		return fmt.Errorf("getting current directory: %w", err)
+	}
+	status, err := wshclient.RemoteGitStatusCommand(RpcClient, cwd, &wshrpc.RpcOpts{Timeout: 2000})
+	if err != nil {
+		return fmt.Errorf("getting git status: %w", err)
+	}
+	if jsonOutput {
+		return outputJson(status)
+	}
+	if !status.IsRepo {
+		WriteStdout("not a git repository\n")
+		return nil
+	}
+	summary := status.Branch
+	if status.IsDirty {
+		summary += " *"
+	}
+	if status.Ahead > 0 {
+		summary += fmt.Sprintf(" ↑%d", status.Ahead)
+	}
+	if status.Behind > 0 {
+		summary += fmt.Sprintf(" ↓%d", status.Behind)
+	}
+	WriteStdout("%s\n", summary)
+	return nil
+}
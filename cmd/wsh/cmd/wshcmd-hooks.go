@@ -0,0 +1,120 @@
+// Copyright 2024, Command Line Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/wavetermdev/waveterm/pkg/hooks"
+	"github.com/wavetermdev/waveterm/pkg/wshrpc"
+	"github.com/wavetermdev/waveterm/pkg/wshrpc/wshclient"
+)
+
+var hooksEventFlag string
+var hooksTypeFlag string
+var hooksCommandFlag string
+var hooksUrlFlag string
+
+var hooksCmd = &cobra.Command{
+	Use:   "hooks",
+	Short: "manage lifecycle hooks (scripts/webhooks run on command events)",
+}
+
+var hooksListCmd = &cobra.Command{
+	Use:     "list",
+	Short:   "list configured hooks",
+	Args:    cobra.NoArgs,
+	RunE:    hooksListRun,
+	PreRunE: preRunSetupRpcClient,
+}
+
+var hooksAddCmd = &cobra.Command{
+	Use:     "add",
+	Short:   "add a new hook",
+	Args:    cobra.NoArgs,
+	RunE:    hooksAddRun,
+	PreRunE: preRunSetupRpcClient,
+}
+
+var hooksRemoveCmd = &cobra.Command{
+	Use:     "remove <index>",
+	Short:   "remove a hook by its list index",
+	Args:    cobra.ExactArgs(1),
+	RunE:    hooksRemoveRun,
+	PreRunE: preRunSetupRpcClient,
+}
+
+func init() {
+	hooksCmd.AddCommand(hooksListCmd)
+	hooksCmd.AddCommand(hooksAddCmd)
+	hooksCmd.AddCommand(hooksRemoveCmd)
+	hooksAddCmd.Flags().StringVar(&hooksEventFlag, "event", "", fmt.Sprintf("event to fire on (%s, %s, %s, %s, %s)", hooks.Event_CommandStarted, hooks.Event_CommandDone, hooks.Event_CommandFailed, hooks.Event_ConnectionDropped, hooks.Event_AiResponseFinished))
+	hooksAddCmd.Flags().StringVar(&hooksTypeFlag, "type", "", "hook type: script or webhook")
+	hooksAddCmd.Flags().StringVar(&hooksCommandFlag, "command", "", "shell command to run (for type=script)")
+	hooksAddCmd.Flags().StringVar(&hooksUrlFlag, "url", "", "URL to POST to (for type=webhook)")
+	rootCmd.AddCommand(hooksCmd)
+}
+
+func hooksListRun(cmd *cobra.Command, args []string) (rtnErr error) {
+	defer func() {
+		sendActivity("hooks:list", rtnErr == nil)
+	}()
+	hookList, err := wshclient.HooksListCommand(RpcClient, &wshrpc.RpcOpts{Timeout: 2000})
+	if err != nil {
+		return fmt.Errorf("listing hooks: %w", err)
+	}
+	if len(hookList) == 0 {
+		WriteStdout("no hooks configured\n")
+		return nil
+	}
+	for idx, h := range hookList {
+		target := h.Command
+		if h.Type == "webhook" {
+			target = h.URL
+		}
+		WriteStdout("%d: [%s] %s -> %s\n", idx, h.Event, h.Type, target)
+	}
+	return nil
+}
+
+func hooksAddRun(cmd *cobra.Command, args []string) (rtnErr error) {
+	defer func() {
+		sendActivity("hooks:add", rtnErr == nil)
+	}()
+	if hooksEventFlag == "" || hooksTypeFlag == "" {
+		return fmt.Errorf("--event and --type are required")
+	}
+	if hooksTypeFlag != "script" && hooksTypeFlag != "webhook" {
+		return fmt.Errorf("--type must be 'script' or 'webhook'")
+	}
+	h := hooks.HookDef{
+		Event:   hooksEventFlag,
+		Type:    hooksTypeFlag,
+		Command: hooksCommandFlag,
+		URL:     hooksUrlFlag,
+	}
+	err := wshclient.HooksAddCommand(RpcClient, h, &wshrpc.RpcOpts{Timeout: 2000})
+	if err != nil {
+		return fmt.Errorf("adding hook: %w", err)
+	}
+	WriteStdout("hook added\n")
+	return nil
+}
+
+func hooksRemoveRun(cmd *cobra.Command, args []string) (rtnErr error) {
+	defer func() {
+		sendActivity("hooks:remove", rtnErr == nil)
+	}()
+	var index int
+	if _, err := fmt.Sscanf(args[0], "%d", &index); err != nil {
+		return fmt.Errorf("invalid index %q: %w", args[0], err)
+	}
+	err := wshclient.HooksRemoveCommand(RpcClient, index, &wshrpc.RpcOpts{Timeout: 2000})
+	if err != nil {
+		return fmt.Errorf("removing hook: %w", err)
+	}
+	WriteStdout("hook removed\n")
+	return nil
+}
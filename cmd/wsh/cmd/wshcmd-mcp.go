@@ -0,0 +1,253 @@
+// Copyright 2024, Command Line Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/spf13/cobra"
+	"github.com/wavetermdev/waveterm/pkg/wshrpc"
+	"github.com/wavetermdev/waveterm/pkg/wshrpc/wshclient"
+)
+
+var mcpCmd = &cobra.Command{
+	Use:    "mcp",
+	Hidden: true,
+	Short:  "run a Model Context Protocol server exposing Wave Terminal as a tool",
+	Long: "Runs a minimal MCP server over stdio (JSON-RPC 2.0, one request per line) so external AI agents " +
+		"and IDE assistants can use Wave Terminal as a tool. Exposes run_command, read_file, list_tabs, and " +
+		"fetch_command_output, each gated by the corresponding mcp:* setting (see 'wsh mcp' requires " +
+		"mcp:enabled, and each tool requires its own mcp:allow* toggle to be listed and callable). Intended " +
+		"to be launched by an MCP client (e.g. an IDE), not run interactively.",
+	Args:    cobra.NoArgs,
+	RunE:    mcpRun,
+	PreRunE: preRunSetupRpcClient,
+}
+
+func init() {
+	rootCmd.AddCommand(mcpCmd)
+}
+
+type mcpTool struct {
+	Name        string                 `json:"name"`
+	Description string                 `json:"description"`
+	InputSchema map[string]interface{} `json:"inputSchema"`
+}
+
+var mcpTools = []mcpTool{
+	{
+		Name:        "run_command",
+		Description: "Run a shell command on the local machine and return its combined output and exit code.",
+		InputSchema: map[string]interface{}{
+			"type": "object",
+			"properties": map[string]interface{}{
+				"command": map[string]interface{}{"type": "string", "description": "the shell command to run"},
+				"cwd":     map[string]interface{}{"type": "string", "description": "working directory (defaults to the wavesrv process cwd)"},
+			},
+			"required": []string{"command"},
+		},
+	},
+	{
+		Name:        "read_file",
+		Description: "Read a Wave Terminal block file (e.g. a terminal's scrollback) by block id and file name.",
+		InputSchema: map[string]interface{}{
+			"type": "object",
+			"properties": map[string]interface{}{
+				"blockid":  map[string]interface{}{"type": "string", "description": "the block (zone) id to read from"},
+				"filename": map[string]interface{}{"type": "string", "description": "the file name within the block, e.g. \"term\""},
+			},
+			"required": []string{"blockid", "filename"},
+		},
+	},
+	{
+		Name:        "list_tabs",
+		Description: "List all open tabs, with their ids, names, and workspace ids.",
+		InputSchema: map[string]interface{}{"type": "object", "properties": map[string]interface{}{}},
+	},
+	{
+		Name:        "fetch_command_output",
+		Description: "Fetch the current terminal scrollback for a block id (shorthand for read_file with filename \"term\").",
+		InputSchema: map[string]interface{}{
+			"type": "object",
+			"properties": map[string]interface{}{
+				"blockid": map[string]interface{}{"type": "string", "description": "the block id of the terminal to read"},
+			},
+			"required": []string{"blockid"},
+		},
+	},
+}
+
+type jsonRpcRequest struct {
+	JsonRpc string          `json:"jsonrpc"`
+	Id      json.RawMessage `json:"id,omitempty"`
+	Method  string          `json:"method"`
+	Params  json.RawMessage `json:"params,omitempty"`
+}
+
+type jsonRpcError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+type jsonRpcResponse struct {
+	JsonRpc string          `json:"jsonrpc"`
+	Id      json.RawMessage `json:"id,omitempty"`
+	Result  interface{}     `json:"result,omitempty"`
+	Error   *jsonRpcError   `json:"error,omitempty"`
+}
+
+func mcpRun(cmd *cobra.Command, args []string) error {
+	scanner := bufio.NewScanner(os.Stdin)
+	scanner.Buffer(make([]byte, 0, 64*1024), 10*1024*1024)
+	for scanner.Scan() {
+		line := scanner.Bytes()
+		if len(line) == 0 {
+			continue
+		}
+		var req jsonRpcRequest
+		if err := json.Unmarshal(line, &req); err != nil {
+			writeMcpResponse(jsonRpcResponse{JsonRpc: "2.0", Error: &jsonRpcError{Code: -32700, Message: fmt.Sprintf("parse error: %v", err)}})
+			continue
+		}
+		handleMcpRequest(req)
+	}
+	if err := scanner.Err(); err != nil && err != io.EOF {
+		return fmt.Errorf("reading mcp requests: %w", err)
+	}
+	return nil
+}
+
+func handleMcpRequest(req jsonRpcRequest) {
+	switch req.Method {
+	case "initialize":
+		writeMcpResponse(jsonRpcResponse{JsonRpc: "2.0", Id: req.Id, Result: map[string]interface{}{
+			"protocolVersion": "2024-11-05",
+			"serverInfo":      map[string]interface{}{"name": "waveterm", "version": "1"},
+			"capabilities":    map[string]interface{}{"tools": map[string]interface{}{}},
+		}})
+	case "notifications/initialized":
+		// no response for notifications
+	case "tools/list":
+		mcpConfig, err := wshclient.GetMcpConfigCommand(RpcClient, &wshrpc.RpcOpts{Timeout: 2000})
+		if err != nil {
+			writeMcpResponse(jsonRpcResponse{JsonRpc: "2.0", Id: req.Id, Error: &jsonRpcError{Code: -32000, Message: err.Error()}})
+			return
+		}
+		writeMcpResponse(jsonRpcResponse{JsonRpc: "2.0", Id: req.Id, Result: map[string]interface{}{"tools": allowedMcpTools(mcpConfig)}})
+	case "tools/call":
+		handleMcpToolCall(req)
+	default:
+		writeMcpResponse(jsonRpcResponse{JsonRpc: "2.0", Id: req.Id, Error: &jsonRpcError{Code: -32601, Message: fmt.Sprintf("method not found: %s", req.Method)}})
+	}
+}
+
+func allowedMcpTools(cfg wshrpc.McpConfigData) []mcpTool {
+	var rtn []mcpTool
+	for _, tool := range mcpTools {
+		switch tool.Name {
+		case "run_command":
+			if !cfg.AllowRunCommand {
+				continue
+			}
+		case "read_file":
+			if !cfg.AllowReadFile {
+				continue
+			}
+		case "list_tabs":
+			if !cfg.AllowListTabs {
+				continue
+			}
+		case "fetch_command_output":
+			if !cfg.AllowFetchOutput {
+				continue
+			}
+		}
+		rtn = append(rtn, tool)
+	}
+	return rtn
+}
+
+type mcpToolCallParams struct {
+	Name      string                 `json:"name"`
+	Arguments map[string]interface{} `json:"arguments"`
+}
+
+func handleMcpToolCall(req jsonRpcRequest) {
+	var params mcpToolCallParams
+	if err := json.Unmarshal(req.Params, &params); err != nil {
+		writeMcpResponse(jsonRpcResponse{JsonRpc: "2.0", Id: req.Id, Error: &jsonRpcError{Code: -32602, Message: fmt.Sprintf("invalid params: %v", err)}})
+		return
+	}
+	text, err := callMcpTool(params.Name, params.Arguments)
+	if err != nil {
+		writeMcpResponse(jsonRpcResponse{JsonRpc: "2.0", Id: req.Id, Result: map[string]interface{}{
+			"isError": true,
+			"content": []map[string]interface{}{{"type": "text", "text": err.Error()}},
+		}})
+		return
+	}
+	writeMcpResponse(jsonRpcResponse{JsonRpc: "2.0", Id: req.Id, Result: map[string]interface{}{
+		"content": []map[string]interface{}{{"type": "text", "text": text}},
+	}})
+}
+
+func callMcpTool(name string, args map[string]interface{}) (string, error) {
+	mcpConfig, err := wshclient.GetMcpConfigCommand(RpcClient, &wshrpc.RpcOpts{Timeout: 2000})
+	if err != nil {
+		return "", fmt.Errorf("reading mcp config: %w", err)
+	}
+	switch name {
+	case "run_command":
+		if !mcpConfig.AllowRunCommand {
+			return "", fmt.Errorf("run_command is not enabled (set mcp:allowruncommand)")
+		}
+		cmdStr, _ := args["command"].(string)
+		cwd, _ := args["cwd"].(string)
+		rtn, err := wshclient.RunShellCommandCommand(RpcClient, wshrpc.CommandRunShellCommandData{CmdStr: cmdStr, Cwd: cwd}, &wshrpc.RpcOpts{Timeout: 35000})
+		if err != nil {
+			return "", err
+		}
+		return fmt.Sprintf("exit code: %d\n%s", rtn.ExitCode, rtn.Output), nil
+	case "read_file":
+		if !mcpConfig.AllowReadFile {
+			return "", fmt.Errorf("read_file is not enabled (set mcp:allowreadfile)")
+		}
+		blockId, _ := args["blockid"].(string)
+		fileName, _ := args["filename"].(string)
+		return wshclient.FileReadCommand(RpcClient, wshrpc.CommandFileData{ZoneId: blockId, FileName: fileName}, &wshrpc.RpcOpts{Timeout: 5000})
+	case "list_tabs":
+		if !mcpConfig.AllowListTabs {
+			return "", fmt.Errorf("list_tabs is not enabled (set mcp:allowlisttabs)")
+		}
+		tabs, err := wshclient.ListTabsCommand(RpcClient, &wshrpc.RpcOpts{Timeout: 5000})
+		if err != nil {
+			return "", err
+		}
+		jsonBytes, err := json.Marshal(tabs)
+		if err != nil {
+			return "", err
+		}
+		return string(jsonBytes), nil
+	case "fetch_command_output":
+		if !mcpConfig.AllowFetchOutput {
+			return "", fmt.Errorf("fetch_command_output is not enabled (set mcp:allowfetchoutput)")
+		}
+		blockId, _ := args["blockid"].(string)
+		return wshclient.FileReadCommand(RpcClient, wshrpc.CommandFileData{ZoneId: blockId, FileName: "term"}, &wshrpc.RpcOpts{Timeout: 5000})
+	default:
+		return "", fmt.Errorf("unknown tool: %s", name)
+	}
+}
+
+func writeMcpResponse(resp jsonRpcResponse) {
+	jsonBytes, err := json.Marshal(resp)
+	if err != nil {
+		return
+	}
+	fmt.Fprintln(os.Stdout, string(jsonBytes))
+}
@@ -11,20 +11,20 @@ import (
 	"github.com/wavetermdev/waveterm/pkg/wshutil"
 )
 
-var notifyTitle string
 var notifySilent bool
+var notifyUrgency string
 
 var setNotifyCmd = &cobra.Command{
-	Use:     "notify <message> [-t <title>] [-s]",
-	Short:   "create a notification",
-	Args:    cobra.ExactArgs(1),
+	Use:     "notify <title> <body> [-s] [--urgency low|normal|critical]",
+	Short:   "raise a desktop notification",
+	Args:    cobra.ExactArgs(2),
 	RunE:    notifyRun,
 	PreRunE: preRunSetupRpcClient,
 }
 
 func init() {
-	setNotifyCmd.Flags().StringVarP(&notifyTitle, "title", "t", "Wsh Notify", "the notification title")
 	setNotifyCmd.Flags().BoolVarP(&notifySilent, "silent", "s", false, "whether or not the notification sound is silenced")
+	setNotifyCmd.Flags().StringVarP(&notifyUrgency, "urgency", "u", wshrpc.NotifyUrgencyNormal, "notification urgency: low, normal, or critical (Linux-only)")
 	rootCmd.AddCommand(setNotifyCmd)
 }
 
@@ -32,11 +32,16 @@ func notifyRun(cmd *cobra.Command, args []string) (rtnErr error) {
 	defer func() {
 		sendActivity("notify", rtnErr == nil)
 	}()
-	message := args[0]
+	switch notifyUrgency {
+	case wshrpc.NotifyUrgencyLow, wshrpc.NotifyUrgencyNormal, wshrpc.NotifyUrgencyCritical:
+	default:
+		return fmt.Errorf("invalid --urgency %q (must be low, normal, or critical)", notifyUrgency)
+	}
 	notificationOptions := &wshrpc.WaveNotificationOptions{
-		Title:  notifyTitle,
-		Body:   message,
-		Silent: notifySilent,
+		Title:   args[0],
+		Body:    args[1],
+		Silent:  notifySilent,
+		Urgency: notifyUrgency,
 	}
 	_, err := RpcClient.SendRpcRequest(wshrpc.Command_Notify, notificationOptions, &wshrpc.RpcOpts{Timeout: 2000, Route: wshutil.ElectronRoute})
 	if err != nil {
@@ -0,0 +1,152 @@
+// Copyright 2024, Command Line Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/wavetermdev/waveterm/pkg/blockcontroller"
+	"github.com/wavetermdev/waveterm/pkg/wshrpc"
+	"github.com/wavetermdev/waveterm/pkg/wshrpc/wshclient"
+)
+
+var recordCmd = &cobra.Command{
+	Use:   "record",
+	Short: "control terminal session recording for a block",
+}
+
+var recordStartCmd = &cobra.Command{
+	Use:     "start",
+	Short:   "start recording the terminal output of a block",
+	Args:    cobra.NoArgs,
+	RunE:    runRecordStart,
+	PreRunE: preRunSetupRpcClient,
+}
+
+var recordStopCmd = &cobra.Command{
+	Use:     "stop",
+	Short:   "stop recording the terminal output of a block",
+	Args:    cobra.NoArgs,
+	RunE:    runRecordStop,
+	PreRunE: preRunSetupRpcClient,
+}
+
+var recordExportCmd = &cobra.Command{
+	Use:     "export [output-file]",
+	Short:   "export a block's recording as an asciinema v2 cast file",
+	Args:    cobra.MaximumNArgs(1),
+	RunE:    runRecordExport,
+	PreRunE: preRunSetupRpcClient,
+}
+
+func init() {
+	recordCmd.AddCommand(recordStartCmd)
+	recordCmd.AddCommand(recordStopCmd)
+	recordCmd.AddCommand(recordExportCmd)
+	rootCmd.AddCommand(recordCmd)
+}
+
+func runRecordStart(cmd *cobra.Command, args []string) (rtnErr error) {
+	defer func() {
+		sendActivity("record-start", rtnErr == nil)
+	}()
+	fullORef, err := resolveBlockArg()
+	if err != nil {
+		return err
+	}
+	err = wshclient.SetTermRecordingCommand(RpcClient, wshrpc.CommandSetTermRecordingData{BlockId: fullORef.OID, Enabled: true}, nil)
+	if err != nil {
+		return fmt.Errorf("starting recording: %w", err)
+	}
+	WriteStdout("recording started\n")
+	return nil
+}
+
+func runRecordStop(cmd *cobra.Command, args []string) (rtnErr error) {
+	defer func() {
+		sendActivity("record-stop", rtnErr == nil)
+	}()
+	fullORef, err := resolveBlockArg()
+	if err != nil {
+		return err
+	}
+	err = wshclient.SetTermRecordingCommand(RpcClient, wshrpc.CommandSetTermRecordingData{BlockId: fullORef.OID, Enabled: false}, nil)
+	if err != nil {
+		return fmt.Errorf("stopping recording: %w", err)
+	}
+	WriteStdout("recording stopped\n")
+	return nil
+}
+
+// asciinemaHeader is the header line of an asciinema v2 .cast file.
+// See https://docs.asciinema.org/manual/asciicast/v2/.
+type asciinemaHeader struct {
+	Version int `json:"version"`
+	Width   int `json:"width"`
+	Height  int `json:"height"`
+}
+
+func runRecordExport(cmd *cobra.Command, args []string) (rtnErr error) {
+	defer func() {
+		sendActivity("record-export", rtnErr == nil)
+	}()
+	fullORef, err := resolveBlockArg()
+	if err != nil {
+		return err
+	}
+	resp64, err := wshclient.FileReadCommand(RpcClient, wshrpc.CommandFileData{ZoneId: fullORef.OID, FileName: blockcontroller.BlockFile_TermRecording}, &wshrpc.RpcOpts{Timeout: 5000})
+	if err != nil {
+		return fmt.Errorf("reading recording: %w", err)
+	}
+	raw, err := base64.StdEncoding.DecodeString(resp64)
+	if err != nil {
+		return fmt.Errorf("decoding recording: %w", err)
+	}
+
+	var out *os.File
+	if len(args) == 1 {
+		out, err = os.Create(args[0])
+		if err != nil {
+			return fmt.Errorf("creating output file: %w", err)
+		}
+		defer out.Close()
+	} else {
+		out = os.Stdout
+	}
+
+	header := asciinemaHeader{Version: 2, Width: 80, Height: 24}
+	headerBytes, err := json.Marshal(header)
+	if err != nil {
+		return fmt.Errorf("marshaling header: %w", err)
+	}
+	fmt.Fprintln(out, string(headerBytes))
+
+	for _, line := range strings.Split(string(raw), "\n") {
+		if line == "" {
+			continue
+		}
+		var evt struct {
+			T    float64 `json:"t"`
+			Data string  `json:"data"`
+		}
+		if err := json.Unmarshal([]byte(line), &evt); err != nil {
+			continue
+		}
+		data, err := base64.StdEncoding.DecodeString(evt.Data)
+		if err != nil {
+			continue
+		}
+		evtBytes, err := json.Marshal([]any{evt.T, "o", string(data)})
+		if err != nil {
+			continue
+		}
+		fmt.Fprintln(out, string(evtBytes))
+	}
+	return nil
+}
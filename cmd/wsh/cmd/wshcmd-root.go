@@ -4,6 +4,7 @@
 package cmd
 
 import (
+	"encoding/json"
 	"fmt"
 	"io"
 	"os"
@@ -33,6 +34,7 @@ var RpcContext wshrpc.RpcContext
 var UsingTermWshMode bool
 var blockArg string
 var WshExitCode int
+var jsonOutput bool
 
 type WrappedWriter struct {
 	dest io.Writer
@@ -74,6 +76,18 @@ func WriteStdout(fmtStr string, args ...interface{}) {
 	WrappedStdout.Write([]byte(fmt.Sprintf(fmtStr, args...)))
 }
 
+// outputJson marshals data as indented JSON and writes it to stdout followed
+// by a newline. Subcommands that support --json call this instead of their
+// normal text formatting once they've checked jsonOutput.
+func outputJson(data interface{}) error {
+	barr, err := json.MarshalIndent(data, "", "  ")
+	if err != nil {
+		return fmt.Errorf("marshaling json output: %w", err)
+	}
+	WriteStdout("%s\n", string(barr))
+	return nil
+}
+
 func OutputHelpMessage(cmd *cobra.Command) {
 	cmd.SetOutput(WrappedStderr)
 	cmd.Help()
@@ -208,6 +222,7 @@ func Execute() {
 		}
 	}()
 	rootCmd.PersistentFlags().StringVarP(&blockArg, "block", "b", "", "for commands which require a block id")
+	rootCmd.PersistentFlags().BoolVar(&jsonOutput, "json", false, "output machine-readable JSON instead of text, where supported (see 'wsh schema')")
 	err := rootCmd.Execute()
 	if err != nil {
 		wshutil.DoShutdown("", 1, true)
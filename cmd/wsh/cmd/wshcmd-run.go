@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"sort"
 	"strings"
 
 	"github.com/spf13/cobra"
@@ -24,6 +25,18 @@ var runCmd = &cobra.Command{
 	TraverseChildren: true,
 }
 
+var runMultiRemotes string
+var runMultiTimeoutMs int
+
+var runMultiCmd = &cobra.Command{
+	Use:     "multi --remotes=web1,web2,web3 \"command\"",
+	Short:   "run a command concurrently on multiple connections (ansible-ad-hoc-style fan-out)",
+	Long:    "Run the same shell command concurrently on every connection in --remotes, waiting for all of them and printing a per-host section with its output and exit code. An entry may be a literal connection name or tag:xxx to expand to every connection tagged xxx (see \"wsh conn tag\").",
+	Args:    cobra.ExactArgs(1),
+	RunE:    runMultiRun,
+	PreRunE: preRunSetupRpcClient,
+}
+
 func init() {
 	flags := runCmd.Flags()
 	flags.BoolP("magnified", "m", false, "open view in magnified mode")
@@ -34,7 +47,13 @@ func init() {
 	flags.BoolP("paused", "p", false, "create block in paused state")
 	flags.String("cwd", "", "set working directory for command")
 	flags.BoolP("append", "a", false, "append output on restart instead of clearing")
+	flags.Bool("dry-run", false, "show the resolved command, environment, remote, and cwd without running it")
+	flags.String("provenance", waveobj.CmdProvenance_Manual, "how this command was dispatched (manual, history, ai, bookmark, template)")
 	rootCmd.AddCommand(runCmd)
+
+	runMultiCmd.Flags().StringVar(&runMultiRemotes, "remotes", "", "comma-separated list of connection names to run on, or tag:xxx to select every connection with that conn:tags entry (required)")
+	runMultiCmd.Flags().IntVar(&runMultiTimeoutMs, "timeout", 30000, "per-connection timeout in milliseconds")
+	runCmd.AddCommand(runMultiCmd)
 }
 
 func runRun(cmd *cobra.Command, args []string) (rtnErr error) {
@@ -51,6 +70,14 @@ func runRun(cmd *cobra.Command, args []string) (rtnErr error) {
 	cwd, _ := flags.GetString("cwd")
 	delayMs, _ := flags.GetInt("delay")
 	appendOutput, _ := flags.GetBool("append")
+	dryRun, _ := flags.GetBool("dry-run")
+	provenance, _ := flags.GetString("provenance")
+	switch provenance {
+	case waveobj.CmdProvenance_Manual, waveobj.CmdProvenance_History, waveobj.CmdProvenance_AI, waveobj.CmdProvenance_Bookmark, waveobj.CmdProvenance_Template:
+		// valid
+	default:
+		return fmt.Errorf("invalid provenance %q (must be one of manual, history, ai, bookmark, template)", provenance)
+	}
 	var cmdArgs []string
 	var useShell bool
 	var shellCmd string
@@ -101,6 +128,32 @@ func runRun(cmd *cobra.Command, args []string) (rtnErr error) {
 		}
 	}
 
+	if dryRun {
+		remote := RpcContext.Conn
+		if remote == "" {
+			remote = "local"
+		}
+		fullCmd := shellCmd
+		if len(cmdArgs) > 0 {
+			fullCmd = fullCmd + " " + strings.Join(cmdArgs, " ")
+		}
+		WriteStdout("command: %s\n", fullCmd)
+		WriteStdout("shell:   %v\n", useShell)
+		WriteStdout("cwd:     %s\n", cwd)
+		WriteStdout("remote:  %s\n", remote)
+		WriteStdout("provenance: %s\n", provenance)
+		WriteStdout("env:\n")
+		envKeys := make([]string, 0, len(envMap))
+		for k := range envMap {
+			envKeys = append(envKeys, k)
+		}
+		sort.Strings(envKeys)
+		for _, k := range envKeys {
+			WriteStdout("  %s=%s\n", k, envMap[k])
+		}
+		return nil
+	}
+
 	// Convert to null-terminated format
 	envContent := envutil.MapToEnv(envMap)
 	createMeta := map[string]any{
@@ -112,6 +165,7 @@ func runRun(cmd *cobra.Command, args []string) (rtnErr error) {
 	createMeta[waveobj.MetaKey_Cmd] = shellCmd
 	createMeta[waveobj.MetaKey_CmdArgs] = cmdArgs
 	createMeta[waveobj.MetaKey_CmdShell] = useShell
+	createMeta[waveobj.MetaKey_CmdProvenance] = provenance
 	if paused {
 		createMeta[waveobj.MetaKey_CmdRunOnStart] = false
 	} else {
@@ -152,3 +206,46 @@ func runRun(cmd *cobra.Command, args []string) (rtnErr error) {
 	WriteStdout("run block created: %s\n", oref)
 	return nil
 }
+
+func runMultiRun(cmd *cobra.Command, args []string) (rtnErr error) {
+	defer func() {
+		sendActivity("run-multi", rtnErr == nil)
+	}()
+	var connections []string
+	for _, conn := range strings.Split(runMultiRemotes, ",") {
+		conn = strings.TrimSpace(conn)
+		if conn != "" {
+			connections = append(connections, conn)
+		}
+	}
+	if len(connections) == 0 {
+		return fmt.Errorf("--remotes is required and must list at least one connection")
+	}
+	data := wshrpc.CommandRunMultiCommandData{
+		Connections: connections,
+		CmdStr:      args[0],
+		TimeoutMs:   runMultiTimeoutMs,
+	}
+	rtn, err := wshclient.RunMultiCommandCommand(RpcClient, data, &wshrpc.RpcOpts{Timeout: runMultiTimeoutMs + 5000})
+	if err != nil {
+		return fmt.Errorf("running multi-remote command: %w", err)
+	}
+	var numFailed int
+	for _, result := range rtn.Results {
+		WriteStdout("=== %s ===\n", result.Connection)
+		if result.Err != "" {
+			numFailed++
+			WriteStdout("error: %s\n\n", result.Err)
+			continue
+		}
+		if result.ExitCode != 0 {
+			numFailed++
+		}
+		WriteStdout("%s", result.Output)
+		WriteStdout("exit code: %d\n\n", result.ExitCode)
+	}
+	if numFailed > 0 {
+		return fmt.Errorf("%d of %d connections failed", numFailed, len(rtn.Results))
+	}
+	return nil
+}
@@ -0,0 +1,48 @@
+// Copyright 2024, Command Line Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+)
+
+var schemaCmd = &cobra.Command{
+	Use:   "schema",
+	Short: "list the stable JSON output schemas for --json mode",
+	Long:  "Lists the wsh subcommands that support --json, along with the Go type their output is marshaled from and the meaning of their exit codes. All --json output is 0 on success; non-zero exit codes always mean the command failed and wrote an error to stderr instead of JSON to stdout.",
+	Args:  cobra.NoArgs,
+	RunE:  schemaRun,
+}
+
+type jsonSchemaEntry struct {
+	Command     string `json:"command"`
+	OutputType  string `json:"outputtype"`
+	Description string `json:"description"`
+}
+
+var jsonSchemas = []jsonSchemaEntry{
+	{Command: "wsh file ls", OutputType: "[]wshrpc.WaveFileInfo", Description: "blockfile listing"},
+	{Command: "wsh conn status", OutputType: "[]wshrpc.ConnStatus", Description: "ssh/wsl connection status"},
+	{Command: "wsh getmeta", OutputType: "waveobj.MetaMapType (or a single value)", Description: "block/object metadata"},
+	{Command: "wsh summarize", OutputType: "wshrpc.CommandSummarizeRtnData", Description: "AI summary of a blockfile's contents"},
+	{Command: "wsh debug capabilities", OutputType: "wshrpc.ClientCapabilitiesData", Description: "wshrpc feature set this wavesrv build supports"},
+	{Command: "wsh share status", OutputType: "wshrpc.CommandWebShareStatusData", Description: "web share status for a block"},
+}
+
+func init() {
+	rootCmd.AddCommand(schemaCmd)
+}
+
+func schemaRun(cmd *cobra.Command, args []string) error {
+	if jsonOutput {
+		return outputJson(jsonSchemas)
+	}
+	WriteStdout("%-24s %-42s %s\n", "command", "output type", "description")
+	WriteStdout("--------------------------------------------------------------------------------------------------\n")
+	for _, entry := range jsonSchemas {
+		WriteStdout("%-24s %-42s %s\n", entry.Command, entry.OutputType, entry.Description)
+	}
+	WriteStdout("\nexit codes: 0 on success; non-zero means the command failed and wrote an error to stderr (no JSON was written to stdout)\n")
+	return nil
+}
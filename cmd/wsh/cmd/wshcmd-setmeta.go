@@ -16,7 +16,7 @@ import (
 )
 
 var setMetaCmd = &cobra.Command{
-	Use:     "setmeta [-b {blockid|blocknum|this}] [--json file.json] key=value ...",
+	Use:     "setmeta [-b {blockid|blocknum|this}] [--json file.json] [--if-version n] key=value ...",
 	Short:   "set metadata for an entity",
 	Args:    cobra.MinimumNArgs(0),
 	RunE:    setMetaRun,
@@ -24,26 +24,35 @@ var setMetaCmd = &cobra.Command{
 }
 
 var setMetaJsonFilePath string
+var setMetaIfVersion int
+var setMetaBulkFilePath string
 
 func init() {
 	rootCmd.AddCommand(setMetaCmd)
 	setMetaCmd.Flags().StringVar(&setMetaJsonFilePath, "json", "", "JSON file containing metadata to apply (use '-' for stdin)")
+	setMetaCmd.Flags().IntVar(&setMetaIfVersion, "if-version", 0, "only apply if the entity's current metadata version equals this (use with getmeta --verbose's \"version\" field)")
+	setMetaCmd.Flags().StringVar(&setMetaBulkFilePath, "bulk", "", "JSON file (or '-' for stdin) containing an array of {oref, meta, ifversion} items to apply atomically across multiple entities; ignores all other flags/args")
 }
 
-func loadJSONFile(filepath string) (map[string]interface{}, error) {
-	var data []byte
-	var err error
-
+func loadJSONFileBytes(filepath string) ([]byte, error) {
 	if filepath == "-" {
-		data, err = io.ReadAll(os.Stdin)
+		data, err := io.ReadAll(os.Stdin)
 		if err != nil {
 			return nil, fmt.Errorf("reading from stdin: %v", err)
 		}
-	} else {
-		data, err = os.ReadFile(filepath)
-		if err != nil {
-			return nil, fmt.Errorf("reading JSON file: %v", err)
-		}
+		return data, nil
+	}
+	data, err := os.ReadFile(filepath)
+	if err != nil {
+		return nil, fmt.Errorf("reading JSON file: %v", err)
+	}
+	return data, nil
+}
+
+func loadJSONFile(filepath string) (map[string]interface{}, error) {
+	data, err := loadJSONFileBytes(filepath)
+	if err != nil {
+		return nil, err
 	}
 
 	var result map[string]interface{}
@@ -107,10 +116,34 @@ func simpleMergeMeta(meta map[string]interface{}, metaUpdate map[string]interfac
 	return meta
 }
 
+func setMetaBulkRun() error {
+	data, err := loadJSONFileBytes(setMetaBulkFilePath)
+	if err != nil {
+		return err
+	}
+	var items []wshrpc.CommandSetMetaData
+	if err := json.Unmarshal(data, &items); err != nil {
+		return fmt.Errorf("parsing --bulk JSON: %w", err)
+	}
+	if len(items) == 0 {
+		return fmt.Errorf("--bulk JSON must contain at least one item")
+	}
+	setMetaBulkWshCmd := &wshrpc.CommandSetMetaBulkData{Items: items}
+	_, err = RpcClient.SendRpcRequest(wshrpc.Command_SetMetaBulk, setMetaBulkWshCmd, &wshrpc.RpcOpts{Timeout: 2000})
+	if err != nil {
+		return fmt.Errorf("setting metadata in bulk: %w", err)
+	}
+	WriteStdout("metadata set on %d entities\n", len(items))
+	return nil
+}
+
 func setMetaRun(cmd *cobra.Command, args []string) (rtnErr error) {
 	defer func() {
 		sendActivity("setmeta", rtnErr == nil)
 	}()
+	if setMetaBulkFilePath != "" {
+		return setMetaBulkRun()
+	}
 	var jsonMeta map[string]interface{}
 	if setMetaJsonFilePath != "" {
 		var err error
@@ -144,6 +177,9 @@ func setMetaRun(cmd *cobra.Command, args []string) (rtnErr error) {
 		ORef: *fullORef,
 		Meta: fullMeta,
 	}
+	if cmd.Flags().Changed("if-version") {
+		setMetaWshCmd.IfVersion = &setMetaIfVersion
+	}
 	_, err = RpcClient.SendRpcRequest(wshrpc.Command_SetMeta, setMetaWshCmd, &wshrpc.RpcOpts{Timeout: 2000})
 	if err != nil {
 		return fmt.Errorf("setting metadata: %v", err)
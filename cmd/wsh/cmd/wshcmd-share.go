@@ -0,0 +1,138 @@
+// Copyright 2024, Command Line Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/wavetermdev/waveterm/pkg/webshare"
+	"github.com/wavetermdev/waveterm/pkg/wshrpc"
+	"github.com/wavetermdev/waveterm/pkg/wshrpc/wshclient"
+)
+
+var shareCmd = &cobra.Command{
+	Use:   "share",
+	Short: "create and manage live share links for a block",
+}
+
+var shareStartTtlMinutes int
+var shareStartRole string
+
+var shareStartCmd = &cobra.Command{
+	Use:     "start",
+	Short:   "start sharing a block and print its share link",
+	Args:    cobra.NoArgs,
+	RunE:    runShareStart,
+	PreRunE: preRunSetupRpcClient,
+}
+
+var shareStopCmd = &cobra.Command{
+	Use:     "stop",
+	Short:   "revoke the active share link for a block",
+	Args:    cobra.NoArgs,
+	RunE:    runShareStop,
+	PreRunE: preRunSetupRpcClient,
+}
+
+var shareStatusCmd = &cobra.Command{
+	Use:     "status",
+	Short:   "show the share link, expiry, and viewer count for a block",
+	Args:    cobra.NoArgs,
+	RunE:    runShareStatus,
+	PreRunE: preRunSetupRpcClient,
+}
+
+var shareRevokeAllCmd = &cobra.Command{
+	Use:     "revoke-all",
+	Short:   "revoke every active share link across all blocks",
+	Args:    cobra.NoArgs,
+	RunE:    runShareRevokeAll,
+	PreRunE: preRunSetupRpcClient,
+}
+
+func init() {
+	shareStartCmd.Flags().IntVar(&shareStartTtlMinutes, "ttl", 60, "share link lifetime in minutes")
+	shareStartCmd.Flags().StringVar(&shareStartRole, "role", webshare.Role_Observer, "role granted to the share link (observer or driver)")
+	shareCmd.AddCommand(shareStartCmd)
+	shareCmd.AddCommand(shareStopCmd)
+	shareCmd.AddCommand(shareStatusCmd)
+	shareCmd.AddCommand(shareRevokeAllCmd)
+	rootCmd.AddCommand(shareCmd)
+}
+
+func runShareStart(cmd *cobra.Command, args []string) (rtnErr error) {
+	defer func() {
+		sendActivity("share-start", rtnErr == nil)
+	}()
+	fullORef, err := resolveBlockArg()
+	if err != nil {
+		return err
+	}
+	status, err := wshclient.WebShareStartCommand(RpcClient, wshrpc.CommandWebShareStartData{BlockId: fullORef.OID, Role: shareStartRole, TtlMinutes: shareStartTtlMinutes}, nil)
+	if err != nil {
+		return fmt.Errorf("starting share: %w", err)
+	}
+	printShareStatus(status)
+	return nil
+}
+
+func runShareStop(cmd *cobra.Command, args []string) (rtnErr error) {
+	defer func() {
+		sendActivity("share-stop", rtnErr == nil)
+	}()
+	fullORef, err := resolveBlockArg()
+	if err != nil {
+		return err
+	}
+	err = wshclient.WebShareStopCommand(RpcClient, wshrpc.CommandWebShareStopData{BlockId: fullORef.OID}, nil)
+	if err != nil {
+		return fmt.Errorf("stopping share: %w", err)
+	}
+	WriteStdout("share revoked\n")
+	return nil
+}
+
+func runShareStatus(cmd *cobra.Command, args []string) (rtnErr error) {
+	defer func() {
+		sendActivity("share-status", rtnErr == nil)
+	}()
+	fullORef, err := resolveBlockArg()
+	if err != nil {
+		return err
+	}
+	status, err := wshclient.WebShareStatusCommand(RpcClient, wshrpc.CommandWebShareStopData{BlockId: fullORef.OID}, nil)
+	if err != nil {
+		return fmt.Errorf("getting share status: %w", err)
+	}
+	if jsonOutput {
+		return outputJson(status)
+	}
+	if !status.Active {
+		WriteStdout("not shared\n")
+		return nil
+	}
+	printShareStatus(status)
+	return nil
+}
+
+func runShareRevokeAll(cmd *cobra.Command, args []string) (rtnErr error) {
+	defer func() {
+		sendActivity("share-revoke-all", rtnErr == nil)
+	}()
+	count, err := wshclient.WebShareRevokeAllCommand(RpcClient, nil)
+	if err != nil {
+		return fmt.Errorf("revoking shares: %w", err)
+	}
+	WriteStdout("revoked %d share(s)\n", count)
+	return nil
+}
+
+func printShareStatus(status wshrpc.CommandWebShareStatusData) {
+	WriteStdout("share url:    %s\n", status.ShareUrl)
+	WriteStdout("role:         %s\n", status.Role)
+	WriteStdout("expires:      %s\n", time.UnixMilli(status.ExpiresTs).Local().Format(time.RFC3339))
+	WriteStdout("viewer count: %d\n", status.ViewerCount)
+}
@@ -0,0 +1,46 @@
+// Copyright 2024, Command Line Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/wavetermdev/waveterm/pkg/wshrpc"
+	"github.com/wavetermdev/waveterm/pkg/wshrpc/wshclient"
+)
+
+var summarizeFileName string
+
+var summarizeCmd = &cobra.Command{
+	Use:     "summarize",
+	Short:   "summarize a block's output using the configured AI backend",
+	Args:    cobra.NoArgs,
+	RunE:    runSummarize,
+	PreRunE: preRunSetupRpcClient,
+}
+
+func init() {
+	summarizeCmd.Flags().StringVar(&summarizeFileName, "file", "term", "blockfile to summarize")
+	rootCmd.AddCommand(summarizeCmd)
+}
+
+func runSummarize(cmd *cobra.Command, args []string) (rtnErr error) {
+	defer func() {
+		sendActivity("summarize", rtnErr == nil)
+	}()
+	fullORef, err := resolveBlockArg()
+	if err != nil {
+		return err
+	}
+	rtn, err := wshclient.SummarizeCommand(RpcClient, wshrpc.CommandSummarizeData{BlockId: fullORef.OID, FileName: summarizeFileName}, &wshrpc.RpcOpts{Timeout: 60000})
+	if err != nil {
+		return fmt.Errorf("summarizing: %w", err)
+	}
+	if jsonOutput {
+		return outputJson(rtn)
+	}
+	WriteStdout("%s\n", rtn.Summary)
+	return nil
+}
@@ -0,0 +1,40 @@
+// Copyright 2025, Command Line Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+	"github.com/wavetermdev/waveterm/pkg/wshrpc/wshclient"
+)
+
+var telemetryCmd = &cobra.Command{
+	Use:   "telemetry",
+	Short: "manage Wave Terminal telemetry",
+}
+
+var telemetryInspectCmd = &cobra.Command{
+	Use:     "inspect",
+	Short:   "show telemetry category settings and the exact payload that would be uploaded next",
+	Args:    cobra.NoArgs,
+	RunE:    telemetryInspectRun,
+	PreRunE: preRunSetupRpcClient,
+}
+
+func init() {
+	telemetryCmd.AddCommand(telemetryInspectCmd)
+	rootCmd.AddCommand(telemetryCmd)
+}
+
+func telemetryInspectRun(cmd *cobra.Command, args []string) error {
+	info, err := wshclient.TelemetryInspectCommand(RpcClient, nil)
+	if err != nil {
+		return err
+	}
+	WriteStdout("telemetry:enabled       %v\n", info.TelemetryEnabled)
+	WriteStdout("telemetry:crashreports  %v\n", info.CrashReportsEnabled)
+	WriteStdout("telemetry:usagecounts   %v\n", info.UsageCountsEnabled)
+	WriteStdout("telemetry:aimetadata    %v\n", info.AIMetadataEnabled)
+	WriteStdout("\npending payload (not yet sent):\n%s\n", info.PendingPayloadJson)
+	return nil
+}
@@ -0,0 +1,62 @@
+// Copyright 2026, Command Line Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+// Package a11ystream turns raw pty output into the plain, line-oriented text that the
+// accessibility mode (see waveobj.MetaKey_BgImage's neighbors -- wconfig.SettingsType's
+// A11y* fields) mirrors to the frontend for an ARIA live region. A screen reader has no use
+// for cursor-positioning escape sequences or mid-line carriage-return redraws (progress bars,
+// spinners), so this strips control/escape sequences and only emits a line once it's been
+// terminated by a newline -- the same "only announce finished lines" rule real terminal screen
+// reader modes use.
+package a11ystream
+
+import (
+	"regexp"
+	"strings"
+)
+
+// ansiRe matches CSI/OSC/other ANSI escape sequences, plus the lone control characters (bell,
+// backspace) a real screen reader mode wouldn't want spoken.
+var ansiRe = regexp.MustCompile("\x1b(?:\\[[0-9;?]*[a-zA-Z@]|\\][^\x07\x1b]*(?:\x07|\x1b\\\\)|[()][0-9A-Za-z]|[=>Mc78])|[\x00-\x08\x0e-\x1a\x1c-\x1f]")
+
+// Strip removes ANSI escape sequences and other terminal control bytes from data, leaving the
+// plain text a screen reader would want to announce.
+func Strip(data []byte) string {
+	return ansiRe.ReplaceAllString(string(data), "")
+}
+
+// LineBuffer accumulates stripped terminal output across successive pty reads and yields
+// complete lines as they're terminated, buffering any trailing partial line until the next Feed.
+// It is not safe for concurrent use -- callers serialize Feed per block (see
+// blockcontroller.go's HandleAppendBlockFile).
+type LineBuffer struct {
+	partial strings.Builder
+}
+
+// Feed appends raw pty output to the buffer and returns any lines it completed, in order, with
+// trailing \r stripped. A lone \r (a carriage-return redraw, e.g. a progress bar) resets the
+// current line instead of completing it, since there's nothing meaningful to announce yet.
+func (lb *LineBuffer) Feed(data []byte) []string {
+	clean := strings.ReplaceAll(Strip(data), "\r\n", "\n")
+	var lines []string
+	for _, r := range clean {
+		switch r {
+		case '\n':
+			lines = append(lines, lb.partial.String())
+			lb.partial.Reset()
+		case '\r':
+			lb.partial.Reset()
+		default:
+			lb.partial.WriteRune(r)
+		}
+	}
+	return lines
+}
+
+// Partial returns the current buffered content that hasn't yet been terminated by a newline.
+// Useful for callers that want the trailing in-progress line included in a final flush (e.g. a
+// scrollback export taken while the last line has no newline yet), unlike the live a11y stream
+// which intentionally withholds it.
+func (lb *LineBuffer) Partial() string {
+	return lb.partial.String()
+}
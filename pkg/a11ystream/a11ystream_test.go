@@ -0,0 +1,66 @@
+// Copyright 2026, Command Line Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package a11ystream
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestStrip(t *testing.T) {
+	cases := map[string]string{
+		"\x1b[31mhello\x1b[0m":    "hello",
+		"\x1b]0;title\x07prompt$": "prompt$",
+		"plain text":              "plain text",
+		"back\x08space":           "backspace",
+	}
+	for input, want := range cases {
+		if got := Strip([]byte(input)); got != want {
+			t.Errorf("Strip(%q) = %q, want %q", input, got, want)
+		}
+	}
+}
+
+func TestLineBufferBasic(t *testing.T) {
+	var lb LineBuffer
+	lines := lb.Feed([]byte("hello\nworld\n"))
+	if !reflect.DeepEqual(lines, []string{"hello", "world"}) {
+		t.Errorf("got %q", lines)
+	}
+}
+
+func TestLineBufferPartial(t *testing.T) {
+	var lb LineBuffer
+	if lines := lb.Feed([]byte("hel")); len(lines) != 0 {
+		t.Errorf("expected no lines yet, got %q", lines)
+	}
+	lines := lb.Feed([]byte("lo\n"))
+	if !reflect.DeepEqual(lines, []string{"hello"}) {
+		t.Errorf("got %q", lines)
+	}
+}
+
+func TestLineBufferCRLF(t *testing.T) {
+	var lb LineBuffer
+	lines := lb.Feed([]byte("hello\r\nworld\r\n"))
+	if !reflect.DeepEqual(lines, []string{"hello", "world"}) {
+		t.Errorf("got %q", lines)
+	}
+}
+
+func TestLineBufferProgressBarReset(t *testing.T) {
+	var lb LineBuffer
+	lines := lb.Feed([]byte("50%\r100%\r\n"))
+	if !reflect.DeepEqual(lines, []string{"100%"}) {
+		t.Errorf("expected the \\r redraw to be discarded, got %q", lines)
+	}
+}
+
+func TestLineBufferStripsEscapes(t *testing.T) {
+	var lb LineBuffer
+	lines := lb.Feed([]byte("\x1b[32mok\x1b[0m\n"))
+	if !reflect.DeepEqual(lines, []string{"ok"}) {
+		t.Errorf("got %q", lines)
+	}
+}
@@ -0,0 +1,188 @@
+// Copyright 2026, Command Line Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+// Package ansiexport turns a block's raw pty scrollback into text suitable for pasting into a
+// ticket or log viewer, in one of three formats: plain (ANSI stripped, see a11ystream.Strip),
+// ansi (escape codes left intact, e.g. for a terminal-aware viewer), or html (SGR color/style
+// codes translated to inline <span> styling, everything else stripped).
+package ansiexport
+
+import (
+	"fmt"
+	"html"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/wavetermdev/waveterm/pkg/a11ystream"
+)
+
+const (
+	FormatPlain = "plain"
+	FormatAnsi  = "ansi"
+	FormatHtml  = "html"
+)
+
+// sgrRe matches a single CSI SGR sequence, e.g. "\x1b[1;32m".
+var sgrRe = regexp.MustCompile("\x1b\\[([0-9;]*)m")
+
+// PlainLines returns data's scrollback as ANSI-free lines.
+func PlainLines(data []byte) []string {
+	var lb a11ystream.LineBuffer
+	lines := lb.Feed(data)
+	if tail := lb.Partial(); tail != "" {
+		lines = append(lines, tail)
+	}
+	return lines
+}
+
+// AnsiLines returns data's scrollback split into lines with escape codes left intact. Unlike
+// PlainLines this doesn't reinterpret carriage-return redraws (progress bars, spinners), since
+// collapsing those would require actually emulating a terminal grid -- each \r\n- or
+// \n-terminated chunk of the raw stream becomes one line, verbatim.
+func AnsiLines(data []byte) []string {
+	normalized := strings.ReplaceAll(string(data), "\r\n", "\n")
+	lines := strings.Split(normalized, "\n")
+	if len(lines) > 0 && lines[len(lines)-1] == "" {
+		lines = lines[:len(lines)-1]
+	}
+	return lines
+}
+
+// ToHTML renders ansi-preserved lines (see AnsiLines) as an HTML fragment: a <pre> block with one
+// <div> per line, SGR color/bold/underline codes translated to inline styles, and everything else
+// (cursor movement, OSC sequences, etc.) dropped.
+func ToHTML(lines []string) string {
+	var sb strings.Builder
+	sb.WriteString("<pre class=\"term-export\">")
+	for _, line := range lines {
+		sb.WriteString("<div>")
+		sb.WriteString(lineToHTML(line))
+		sb.WriteString("</div>")
+	}
+	sb.WriteString("</pre>")
+	return sb.String()
+}
+
+func lineToHTML(line string) string {
+	var sb strings.Builder
+	style := newSgrStyle()
+	openSpan := false
+	pos := 0
+	for _, loc := range sgrRe.FindAllStringSubmatchIndex(line, -1) {
+		if loc[0] > pos {
+			sb.WriteString(html.EscapeString(line[pos:loc[0]]))
+		}
+		codes := line[loc[2]:loc[3]]
+		style.apply(codes)
+		if openSpan {
+			sb.WriteString("</span>")
+			openSpan = false
+		}
+		if css := style.css(); css != "" {
+			fmt.Fprintf(&sb, "<span style=\"%s\">", css)
+			openSpan = true
+		}
+		pos = loc[1]
+	}
+	if pos < len(line) {
+		sb.WriteString(html.EscapeString(line[pos:]))
+	}
+	if openSpan {
+		sb.WriteString("</span>")
+	}
+	return sb.String()
+}
+
+var sgrColors = map[int]string{
+	30: "#000000", 31: "#cc0000", 32: "#4e9a06", 33: "#c4a000",
+	34: "#3465a4", 35: "#75507b", 36: "#06989a", 37: "#d3d7cf",
+	90: "#555753", 91: "#ef2929", 92: "#8ae234", 93: "#fce94f",
+	94: "#729fcf", 95: "#ad7fa8", 96: "#34e2e2", 97: "#eeeeec",
+}
+
+var sgrBgColors = map[int]string{
+	40: "#000000", 41: "#cc0000", 42: "#4e9a06", 43: "#c4a000",
+	44: "#3465a4", 45: "#75507b", 46: "#06989a", 47: "#d3d7cf",
+	100: "#555753", 101: "#ef2929", 102: "#8ae234", 103: "#fce94f",
+	104: "#729fcf", 105: "#ad7fa8", 106: "#34e2e2", 107: "#eeeeec",
+}
+
+type sgrStyle struct {
+	fg        string
+	bg        string
+	bold      bool
+	underline bool
+}
+
+func newSgrStyle() *sgrStyle {
+	return &sgrStyle{}
+}
+
+// apply updates the style for a semicolon-separated list of SGR codes, e.g. "1;32".
+func (s *sgrStyle) apply(codes string) {
+	if codes == "" {
+		codes = "0"
+	}
+	for _, part := range strings.Split(codes, ";") {
+		code, err := strconv.Atoi(part)
+		if err != nil {
+			continue
+		}
+		switch {
+		case code == 0:
+			*s = sgrStyle{}
+		case code == 1:
+			s.bold = true
+		case code == 22:
+			s.bold = false
+		case code == 4:
+			s.underline = true
+		case code == 24:
+			s.underline = false
+		case code == 39:
+			s.fg = ""
+		case code == 49:
+			s.bg = ""
+		default:
+			if c, ok := sgrColors[code]; ok {
+				s.fg = c
+			} else if c, ok := sgrBgColors[code]; ok {
+				s.bg = c
+			}
+		}
+	}
+}
+
+func (s *sgrStyle) css() string {
+	var parts []string
+	if s.fg != "" {
+		parts = append(parts, "color:"+s.fg)
+	}
+	if s.bg != "" {
+		parts = append(parts, "background-color:"+s.bg)
+	}
+	if s.bold {
+		parts = append(parts, "font-weight:bold")
+	}
+	if s.underline {
+		parts = append(parts, "text-decoration:underline")
+	}
+	return strings.Join(parts, ";")
+}
+
+// SelectRange returns lines[start:start+numLines], clamped to lines' bounds. numLines <= 0 means
+// "to the end". start < 0 is treated as 0.
+func SelectRange(lines []string, start int, numLines int) []string {
+	if start < 0 {
+		start = 0
+	}
+	if start >= len(lines) {
+		return nil
+	}
+	end := len(lines)
+	if numLines > 0 && start+numLines < end {
+		end = start + numLines
+	}
+	return lines[start:end]
+}
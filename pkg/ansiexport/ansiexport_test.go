@@ -0,0 +1,54 @@
+// Copyright 2026, Command Line Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package ansiexport
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestPlainLines(t *testing.T) {
+	lines := PlainLines([]byte("\x1b[32mok\x1b[0m\nno newline"))
+	if !reflect.DeepEqual(lines, []string{"ok", "no newline"}) {
+		t.Errorf("got %q", lines)
+	}
+}
+
+func TestAnsiLines(t *testing.T) {
+	lines := AnsiLines([]byte("\x1b[32mok\x1b[0m\r\nsecond\n"))
+	if !reflect.DeepEqual(lines, []string{"\x1b[32mok\x1b[0m", "second"}) {
+		t.Errorf("got %q", lines)
+	}
+}
+
+func TestToHTMLAppliesColorAndResets(t *testing.T) {
+	out := ToHTML([]string{"\x1b[31mred\x1b[0mplain"})
+	if !strings.Contains(out, "color:#cc0000") {
+		t.Errorf("expected red color style, got %q", out)
+	}
+	if !strings.Contains(out, ">red</span>plain") {
+		t.Errorf("expected reset to close the span before the trailing text, got %q", out)
+	}
+}
+
+func TestToHTMLEscapesText(t *testing.T) {
+	out := ToHTML([]string{"<script>"})
+	if strings.Contains(out, "<script>") {
+		t.Errorf("expected html escaping, got %q", out)
+	}
+}
+
+func TestSelectRange(t *testing.T) {
+	lines := []string{"a", "b", "c", "d"}
+	if got := SelectRange(lines, 1, 2); !reflect.DeepEqual(got, []string{"b", "c"}) {
+		t.Errorf("got %q", got)
+	}
+	if got := SelectRange(lines, 2, 0); !reflect.DeepEqual(got, []string{"c", "d"}) {
+		t.Errorf("expected numLines<=0 to mean to the end, got %q", got)
+	}
+	if got := SelectRange(lines, 10, 5); got != nil {
+		t.Errorf("expected nil for out-of-range start, got %q", got)
+	}
+}
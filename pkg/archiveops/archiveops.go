@@ -0,0 +1,272 @@
+// Copyright 2026, Command Line Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+// Package archiveops implements the backend for browsing zip/tar/tgz archives in the file
+// preview: listing entries without extracting the whole archive, reading a single entry's
+// content for the code/image viewer, and extracting selected entries to a destination directory.
+// Only the standard library's archive/zip, archive/tar, and compress/gzip are used.
+package archiveops
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/wavetermdev/waveterm/pkg/util/utilfn"
+)
+
+// MaxEntrySize caps how much of a single entry ReadEntry will return.
+const MaxEntrySize = 10 * 1024 * 1024 // 10M
+
+type EntryInfo struct {
+	Name     string `json:"name"`
+	Size     int64  `json:"size"`
+	IsDir    bool   `json:"isdir"`
+	ModTime  int64  `json:"modtime"`
+	MimeType string `json:"mimetype"`
+}
+
+func isZip(path string) bool {
+	return strings.EqualFold(filepath.Ext(path), ".zip")
+}
+
+func isGzipped(path string) bool {
+	lower := strings.ToLower(path)
+	return strings.HasSuffix(lower, ".tgz") || strings.HasSuffix(lower, ".tar.gz")
+}
+
+// tarReaderFor opens path and returns a *tar.Reader over it, transparently gunzipping if the
+// file is gzip-compressed (.tgz or .tar.gz). The caller must close the returned closers.
+func tarReaderFor(path string) (*tar.Reader, func() error, error) {
+	fd, err := os.Open(path)
+	if err != nil {
+		return nil, nil, err
+	}
+	if isGzipped(path) {
+		gzr, err := gzip.NewReader(fd)
+		if err != nil {
+			fd.Close()
+			return nil, nil, err
+		}
+		return tar.NewReader(gzr), func() error { gzr.Close(); return fd.Close() }, nil
+	}
+	return tar.NewReader(fd), fd.Close, nil
+}
+
+// ListEntries returns every entry in the zip or tar/tgz archive at path, without extracting
+// any of their content.
+func ListEntries(path string) ([]EntryInfo, error) {
+	if isZip(path) {
+		return listZipEntries(path)
+	}
+	return listTarEntries(path)
+}
+
+func listZipEntries(path string) ([]EntryInfo, error) {
+	zr, err := zip.OpenReader(path)
+	if err != nil {
+		return nil, fmt.Errorf("cannot open zip %q: %w", path, err)
+	}
+	defer zr.Close()
+
+	var entries []EntryInfo
+	for _, f := range zr.File {
+		fi := f.FileInfo()
+		entries = append(entries, EntryInfo{
+			Name:     f.Name,
+			Size:     int64(f.UncompressedSize64),
+			IsDir:    fi.IsDir(),
+			ModTime:  fi.ModTime().UnixMilli(),
+			MimeType: utilfn.DetectMimeType(f.Name, fi, false),
+		})
+	}
+	return entries, nil
+}
+
+func listTarEntries(path string) ([]EntryInfo, error) {
+	tr, closeFn, err := tarReaderFor(path)
+	if err != nil {
+		return nil, fmt.Errorf("cannot open tar %q: %w", path, err)
+	}
+	defer closeFn()
+
+	var entries []EntryInfo
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("cannot read tar %q: %w", path, err)
+		}
+		fi := hdr.FileInfo()
+		entries = append(entries, EntryInfo{
+			Name:     hdr.Name,
+			Size:     hdr.Size,
+			IsDir:    fi.IsDir(),
+			ModTime:  fi.ModTime().UnixMilli(),
+			MimeType: utilfn.DetectMimeType(hdr.Name, fi, false),
+		})
+	}
+	return entries, nil
+}
+
+// ReadEntry returns the content of a single named entry, truncated to MaxEntrySize, along with
+// its detected mime type.
+func ReadEntry(path string, entryName string) ([]byte, string, error) {
+	if isZip(path) {
+		return readZipEntry(path, entryName)
+	}
+	return readTarEntry(path, entryName)
+}
+
+func readZipEntry(path string, entryName string) ([]byte, string, error) {
+	zr, err := zip.OpenReader(path)
+	if err != nil {
+		return nil, "", fmt.Errorf("cannot open zip %q: %w", path, err)
+	}
+	defer zr.Close()
+
+	for _, f := range zr.File {
+		if f.Name != entryName {
+			continue
+		}
+		rc, err := f.Open()
+		if err != nil {
+			return nil, "", err
+		}
+		defer rc.Close()
+		data, err := io.ReadAll(io.LimitReader(rc, MaxEntrySize))
+		if err != nil {
+			return nil, "", err
+		}
+		return data, utilfn.DetectMimeType(f.Name, f.FileInfo(), false), nil
+	}
+	return nil, "", fmt.Errorf("entry %q not found in %q", entryName, path)
+}
+
+func readTarEntry(path string, entryName string) ([]byte, string, error) {
+	tr, closeFn, err := tarReaderFor(path)
+	if err != nil {
+		return nil, "", fmt.Errorf("cannot open tar %q: %w", path, err)
+	}
+	defer closeFn()
+
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, "", fmt.Errorf("cannot read tar %q: %w", path, err)
+		}
+		if hdr.Name != entryName {
+			continue
+		}
+		data, err := io.ReadAll(io.LimitReader(tr, MaxEntrySize))
+		if err != nil {
+			return nil, "", err
+		}
+		return data, utilfn.DetectMimeType(hdr.Name, hdr.FileInfo(), false), nil
+	}
+	return nil, "", fmt.Errorf("entry %q not found in %q", entryName, path)
+}
+
+// ExtractEntries extracts the named entries from the archive at path into destDir, creating
+// destDir and any intermediate entry directories as needed.
+func ExtractEntries(path string, entryNames []string, destDir string) error {
+	wanted := make(map[string]bool, len(entryNames))
+	for _, name := range entryNames {
+		wanted[name] = true
+	}
+	if err := os.MkdirAll(destDir, 0755); err != nil {
+		return fmt.Errorf("cannot create destination directory %q: %w", destDir, err)
+	}
+	if isZip(path) {
+		return extractZipEntries(path, wanted, destDir)
+	}
+	return extractTarEntries(path, wanted, destDir)
+}
+
+func extractZipEntries(path string, wanted map[string]bool, destDir string) error {
+	zr, err := zip.OpenReader(path)
+	if err != nil {
+		return fmt.Errorf("cannot open zip %q: %w", path, err)
+	}
+	defer zr.Close()
+
+	for _, f := range zr.File {
+		if !wanted[f.Name] {
+			continue
+		}
+		destPath := filepath.Join(destDir, filepath.Base(f.Name))
+		if f.FileInfo().IsDir() {
+			if err := os.MkdirAll(destPath, 0755); err != nil {
+				return err
+			}
+			continue
+		}
+		if err := writeExtractedFile(destPath, func(w io.Writer) error {
+			rc, err := f.Open()
+			if err != nil {
+				return err
+			}
+			defer rc.Close()
+			_, err = io.Copy(w, rc)
+			return err
+		}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func extractTarEntries(path string, wanted map[string]bool, destDir string) error {
+	tr, closeFn, err := tarReaderFor(path)
+	if err != nil {
+		return fmt.Errorf("cannot open tar %q: %w", path, err)
+	}
+	defer closeFn()
+
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return fmt.Errorf("cannot read tar %q: %w", path, err)
+		}
+		if !wanted[hdr.Name] {
+			continue
+		}
+		destPath := filepath.Join(destDir, filepath.Base(hdr.Name))
+		if hdr.FileInfo().IsDir() {
+			if err := os.MkdirAll(destPath, 0755); err != nil {
+				return err
+			}
+			continue
+		}
+		tr := tr // capture for closure below
+		if err := writeExtractedFile(destPath, func(w io.Writer) error {
+			_, err := io.Copy(w, tr)
+			return err
+		}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func writeExtractedFile(destPath string, copyFn func(io.Writer) error) error {
+	out, err := os.Create(destPath)
+	if err != nil {
+		return err
+	}
+	defer out.Close()
+	return copyFn(out)
+}
@@ -0,0 +1,117 @@
+// Copyright 2026, Command Line Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package archiveops
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bytes"
+	"compress/gzip"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func writeTestZip(t *testing.T, path string) {
+	t.Helper()
+	f, err := os.Create(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	zw := zip.NewWriter(f)
+	w, err := zw.Create("hello.txt")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := w.Write([]byte("hello zip")); err != nil {
+		t.Fatal(err)
+	}
+	if err := zw.Close(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func writeTestTgz(t *testing.T, path string) {
+	t.Helper()
+	f, err := os.Create(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	gzw := gzip.NewWriter(f)
+	tw := tar.NewWriter(gzw)
+	content := []byte("hello tar")
+	if err := tw.WriteHeader(&tar.Header{Name: "hello.txt", Size: int64(len(content)), Mode: 0644}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := tw.Write(content); err != nil {
+		t.Fatal(err)
+	}
+	if err := tw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if err := gzw.Close(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestListAndReadZipEntries(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "test.zip")
+	writeTestZip(t, path)
+
+	entries, err := ListEntries(path)
+	if err != nil {
+		t.Fatalf("ListEntries failed: %v", err)
+	}
+	if len(entries) != 1 || entries[0].Name != "hello.txt" {
+		t.Fatalf("unexpected entries: %+v", entries)
+	}
+
+	data, _, err := ReadEntry(path, "hello.txt")
+	if err != nil {
+		t.Fatalf("ReadEntry failed: %v", err)
+	}
+	if !bytes.Equal(data, []byte("hello zip")) {
+		t.Fatalf("unexpected content: %q", data)
+	}
+}
+
+func TestListAndReadTgzEntries(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "test.tgz")
+	writeTestTgz(t, path)
+
+	entries, err := ListEntries(path)
+	if err != nil {
+		t.Fatalf("ListEntries failed: %v", err)
+	}
+	if len(entries) != 1 || entries[0].Name != "hello.txt" {
+		t.Fatalf("unexpected entries: %+v", entries)
+	}
+
+	data, _, err := ReadEntry(path, "hello.txt")
+	if err != nil {
+		t.Fatalf("ReadEntry failed: %v", err)
+	}
+	if !bytes.Equal(data, []byte("hello tar")) {
+		t.Fatalf("unexpected content: %q", data)
+	}
+}
+
+func TestExtractEntries(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "test.zip")
+	writeTestZip(t, path)
+	destDir := filepath.Join(t.TempDir(), "extracted")
+
+	if err := ExtractEntries(path, []string{"hello.txt"}, destDir); err != nil {
+		t.Fatalf("ExtractEntries failed: %v", err)
+	}
+	data, err := os.ReadFile(filepath.Join(destDir, "hello.txt"))
+	if err != nil {
+		t.Fatalf("cannot read extracted file: %v", err)
+	}
+	if !bytes.Equal(data, []byte("hello zip")) {
+		t.Fatalf("unexpected extracted content: %q", data)
+	}
+}
@@ -0,0 +1,70 @@
+// Copyright 2026, Command Line Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package blockcontroller
+
+import (
+	"sync"
+
+	"github.com/wavetermdev/waveterm/pkg/a11ystream"
+	"github.com/wavetermdev/waveterm/pkg/i18n"
+	"github.com/wavetermdev/waveterm/pkg/waveobj"
+	"github.com/wavetermdev/waveterm/pkg/wconfig"
+	"github.com/wavetermdev/waveterm/pkg/wps"
+)
+
+var a11yLock sync.Mutex
+var a11yBuffers = make(map[string]*a11ystream.LineBuffer) // blockId -> line buffer, lazily created
+
+// a11yScreenReaderModeEnabled reports whether wconfig.SettingsType's A11yScreenReaderMode is on.
+func a11yScreenReaderModeEnabled() bool {
+	return wconfig.GetWatcher().GetFullConfig().Settings.A11yScreenReaderMode
+}
+
+// mirrorA11yOutput feeds raw pty output for blockId through the accessible line buffer and
+// publishes any lines it completes, mirroring recordTermOutput's opt-in shape but gated on the
+// global accessibility setting (see a11yScreenReaderModeEnabled) instead of a per-block flag,
+// since there's no per-block "this block should be announced" concept to hang it off of.
+func mirrorA11yOutput(blockId string, data []byte) {
+	if !a11yScreenReaderModeEnabled() {
+		return
+	}
+	a11yLock.Lock()
+	lb, ok := a11yBuffers[blockId]
+	if !ok {
+		lb = &a11ystream.LineBuffer{}
+		a11yBuffers[blockId] = lb
+	}
+	lines := lb.Feed(data)
+	a11yLock.Unlock()
+	for _, line := range lines {
+		publishA11yLine(blockId, line, false)
+	}
+}
+
+// publishCommandExitAnnouncement publishes a localized (see i18n.T, wconfig.SettingsType's
+// AppLocale) "command exited N" boundary announcement for a BlockController_Cmd block.
+func publishCommandExitAnnouncement(blockId string, exitCode int) {
+	locale := wconfig.GetWatcher().GetFullConfig().Settings.AppLocale
+	if locale == "" {
+		locale = i18n.DefaultLocale
+	}
+	publishA11yLine(blockId, i18n.T(locale, "a11y.command_exited", exitCode), true)
+}
+
+// publishA11yLine publishes a single accessible line (or, if isBoundary, a command-start/done
+// announcement) for blockId, scoped so a frontend ARIA live region can subscribe per-block.
+func publishA11yLine(blockId string, line string, isBoundary bool) {
+	if !a11yScreenReaderModeEnabled() {
+		return
+	}
+	wps.Broker.Publish(wps.WaveEvent{
+		Event:  wps.Event_A11yLine,
+		Scopes: []string{waveobj.MakeORef(waveobj.OType_Block, blockId).String()},
+		Data: &wps.A11yLineEventData{
+			BlockId:    blockId,
+			Line:       line,
+			IsBoundary: isBoundary,
+		},
+	})
+}
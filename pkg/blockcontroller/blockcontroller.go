@@ -11,12 +11,16 @@ import (
 	"io"
 	"io/fs"
 	"log"
+	"os"
+	"runtime"
 	"strings"
 	"sync"
 	"sync/atomic"
+	"syscall"
 	"time"
 
 	"github.com/wavetermdev/waveterm/pkg/filestore"
+	"github.com/wavetermdev/waveterm/pkg/hooks"
 	"github.com/wavetermdev/waveterm/pkg/panichandler"
 	"github.com/wavetermdev/waveterm/pkg/remote"
 	"github.com/wavetermdev/waveterm/pkg/remote/conncontroller"
@@ -40,9 +44,10 @@ const (
 )
 
 const (
-	BlockFile_Term  = "term"            // used for main pty output
-	BlockFile_Cache = "cache:term:full" // for cached block
-	BlockFile_VDom  = "vdom"            // used for alt html layout
+	BlockFile_Term          = "term"            // used for main pty output
+	BlockFile_Cache         = "cache:term:full" // for cached block
+	BlockFile_VDom          = "vdom"            // used for alt html layout
+	BlockFile_TermRecording = "termrecording"   // newline-delimited {"t":<seconds>,"data":"<base64>"} events, written while recording is active
 )
 
 const (
@@ -62,9 +67,11 @@ var globalLock = &sync.Mutex{}
 var blockControllerMap = make(map[string]*BlockController)
 
 type BlockInputUnion struct {
-	InputData []byte            `json:"inputdata,omitempty"`
-	SigName   string            `json:"signame,omitempty"`
-	TermSize  *waveobj.TermSize `json:"termsize,omitempty"`
+	InputData     []byte            `json:"inputdata,omitempty"`
+	SigName       string            `json:"signame,omitempty"`
+	TermSize      *waveobj.TermSize `json:"termsize,omitempty"`
+	IsPaste       bool              `json:"ispaste,omitempty"`
+	PasteOverride bool              `json:"pasteoverride,omitempty"`
 }
 
 type BlockController struct {
@@ -177,6 +184,19 @@ func HandleAppendBlockFile(blockId string, blockFile string, data []byte) error
 	if err != nil {
 		return fmt.Errorf("error appending to blockfile: %w", err)
 	}
+	if blockFile == BlockFile_Term {
+		recordTermOutput(blockId, data)
+		mirrorA11yOutput(blockId, data)
+		// pty output under heavy load arrives in a flood of small reads -- coalesce the update
+		// events rather than publishing one per read (the write above is never delayed)
+		getOrCreatePtyCoalescer(blockId).addData(data)
+		return nil
+	}
+	publishBlockFileAppendEvent(blockId, blockFile, data)
+	return nil
+}
+
+func publishBlockFileAppendEvent(blockId string, blockFile string, data []byte) {
 	wps.Broker.Publish(wps.WaveEvent{
 		Event: wps.Event_BlockFile,
 		Scopes: []string{
@@ -189,7 +209,6 @@ func HandleAppendBlockFile(blockId string, blockFile string, data []byte) error
 			Data64:   base64.StdEncoding.EncodeToString(data),
 		},
 	})
-	return nil
 }
 
 func (bc *BlockController) resetTerminalState() {
@@ -269,9 +288,63 @@ func createCmdStrAndOpts(blockId string, blockMeta waveobj.MetaMapType) (string,
 			cmdOpts.Env[k] = fmt.Sprintf("%v", v)
 		}
 	}
+	connName := blockMeta.GetString(waveobj.MetaKey_Connection, "")
+	if err := checkCmdPolicy(blockId, connName, cmdStr); err != nil {
+		return "", nil, err
+	}
 	return cmdStr, &cmdOpts, nil
 }
 
+// getTabEnv returns the env vars set on bc's tab (see waveobj.MetaKey_TabEnv), merged into every
+// shell block started in that tab. Returns an empty (non-nil) map if the tab has no env overrides
+// or can't be loaded, so callers can always assign straight into cmdOpts.Env.
+func (bc *BlockController) getTabEnv() map[string]string {
+	rtn := make(map[string]string)
+	if bc.TabId == "" {
+		return rtn
+	}
+	ctx, cancelFn := context.WithTimeout(context.Background(), DefaultTimeout)
+	defer cancelFn()
+	tab, err := wstore.DBGet[*waveobj.Tab](ctx, bc.TabId)
+	if err != nil || tab == nil {
+		return rtn
+	}
+	for k, v := range tab.Meta.GetMap(waveobj.MetaKey_TabEnv) {
+		if strVal, ok := v.(string); ok {
+			rtn[k] = strVal
+		} else if v != nil {
+			rtn[k] = fmt.Sprintf("%v", v)
+		}
+	}
+	return rtn
+}
+
+// runTabStartupCmds types each of bc's tab's startup commands (see waveobj.MetaKey_TabStartupCmds)
+// into the shell, in order, the same way a user's keystrokes would arrive via SendInput. Runs every
+// time a shell block's shell (re)starts, including on /reset, since that re-enters
+// manageRunningShellProcess through the same code path as initial startup.
+func (bc *BlockController) runTabStartupCmds() {
+	if bc.TabId == "" || bc.ControllerType != BlockController_Shell {
+		return
+	}
+	ctx, cancelFn := context.WithTimeout(context.Background(), DefaultTimeout)
+	defer cancelFn()
+	tab, err := wstore.DBGet[*waveobj.Tab](ctx, bc.TabId)
+	if err != nil || tab == nil {
+		return
+	}
+	for _, cmdStr := range tab.Meta.GetStringList(waveobj.MetaKey_TabStartupCmds) {
+		if cmdStr == "" {
+			continue
+		}
+		err := bc.SendInput(&BlockInputUnion{InputData: []byte(cmdStr + "\r")})
+		if err != nil {
+			log.Printf("error sending tab startup command to block %s: %v\n", bc.BlockId, err)
+			return
+		}
+	}
+}
+
 func (bc *BlockController) DoRunShellCommand(rc *RunShellOpts, blockMeta waveobj.MetaMapType) error {
 	shellProc, err := bc.setupAndStartShellProcess(rc, blockMeta)
 	if err != nil {
@@ -284,7 +357,7 @@ func (bc *BlockController) setupAndStartShellProcess(rc *RunShellOpts, blockMeta
 	// create a circular blockfile for the output
 	ctx, cancelFn := context.WithTimeout(context.Background(), 2*time.Second)
 	defer cancelFn()
-	fsErr := filestore.WFS.MakeFile(ctx, bc.BlockId, BlockFile_Term, nil, filestore.FileOptsType{MaxSize: DefaultTermMaxFileSize, Circular: true})
+	fsErr := filestore.WFS.MakeFile(ctx, bc.BlockId, BlockFile_Term, nil, filestore.FileOptsType{MaxSize: DefaultTermMaxFileSize, Circular: true, ArchiveOverflow: true})
 	if fsErr != nil && fsErr != fs.ErrExist {
 		return nil, fmt.Errorf("error creating blockfile: %w", fsErr)
 	}
@@ -302,7 +375,7 @@ func (bc *BlockController) setupAndStartShellProcess(rc *RunShellOpts, blockMeta
 	var cmdOpts shellexec.CommandOptsType
 	var err error
 	if bc.ControllerType == BlockController_Shell {
-		cmdOpts.Env = make(map[string]string)
+		cmdOpts.Env = bc.getTabEnv()
 		cmdOpts.Interactive = true
 		cmdOpts.Login = true
 		cmdOpts.Cwd = blockMeta.GetString(waveobj.MetaKey_CmdCwd, "")
@@ -409,6 +482,7 @@ func (bc *BlockController) setupAndStartShellProcess(rc *RunShellOpts, blockMeta
 		if len(blockMeta.GetStringList(waveobj.MetaKey_TermLocalShellOpts)) > 0 {
 			cmdOpts.ShellOpts = append([]string{}, blockMeta.GetStringList(waveobj.MetaKey_TermLocalShellOpts)...)
 		}
+		cmdOpts.Detached = blockMeta.GetBool(waveobj.MetaKey_CmdDetached, false)
 		shellProc, err = shellexec.StartShellProc(rc.TermSize, cmdStr, cmdOpts)
 		if err != nil {
 			return nil, err
@@ -419,12 +493,23 @@ func (bc *BlockController) setupAndStartShellProcess(rc *RunShellOpts, blockMeta
 		bc.ShellProcStatus = Status_Running
 		return true
 	})
+	if blockMeta.GetBool(waveobj.MetaKey_CmdWasInterrupted, false) {
+		clearCtx, clearCancelFn := context.WithTimeout(context.Background(), DefaultTimeout)
+		metaUpdate := map[string]any{waveobj.MetaKey_CmdWasInterrupted: false}
+		if err := wstore.UpdateObjectMeta(clearCtx, waveobj.MakeORef(waveobj.OType_Block, bc.BlockId), metaUpdate, false); err != nil {
+			log.Printf("error clearing interrupted marker for block %s: %v\n", bc.BlockId, err)
+		}
+		clearCancelFn()
+	}
 	return shellProc, nil
 }
 
 func (bc *BlockController) manageRunningShellProcess(shellProc *shellexec.ShellProc, rc *RunShellOpts, blockMeta waveobj.MetaMapType) error {
 	shellInputCh := make(chan *BlockInputUnion, 32)
 	bc.ShellInputCh = shellInputCh
+	go bc.runTabStartupCmds()
+	hooks.Fire(hooks.Event_CommandStarted, map[string]any{"blockid": bc.BlockId, "tabid": bc.TabId})
+	startTs := time.Now()
 
 	// make esc sequence wshclient wshProxy
 	// we don't need to authenticate this wshProxy since it is coming direct
@@ -448,6 +533,7 @@ func (bc *BlockController) manageRunningShellProcess(shellProc *shellexec.ShellP
 			exitCode := shellProc.Cmd.ExitCode()
 			termMsg := fmt.Sprintf("\r\nprocess finished with exit code = %d\r\n\r\n", exitCode)
 			HandleAppendBlockFile(bc.BlockId, BlockFile_Term, []byte(termMsg))
+			removePtyCoalescer(bc.BlockId)
 			// to stop the inputCh loop
 			time.Sleep(100 * time.Millisecond)
 			close(shellInputCh) // don't use bc.ShellInputCh (it's nil)
@@ -518,11 +604,48 @@ func (bc *BlockController) manageRunningShellProcess(shellProc *shellexec.ShellP
 		waitErr := shellProc.Cmd.Wait()
 		exitCode = shellProc.Cmd.ExitCode()
 		shellProc.SetWaitErrorAndSignalDone(waitErr)
+		hookEvent := hooks.Event_CommandDone
+		if exitCode != 0 {
+			hookEvent = hooks.Event_CommandFailed
+		}
+		hooks.Fire(hookEvent, map[string]any{"blockid": bc.BlockId, "tabid": bc.TabId, "exitcode": exitCode})
+		if bc.ControllerType == BlockController_Cmd {
+			go recordCmdHistory(bc.BlockId, blockMeta, startTs, exitCode)
+			publishCommandExitAnnouncement(bc.BlockId, exitCode)
+		}
 		go checkCloseOnExit(bc.BlockId, exitCode)
 	}()
 	return nil
 }
 
+// recordCmdHistory persists a finished "cmd" controller run to the cmd_history table (see
+// pkg/wstore) so it can later be scoped by cwd/connection for something like a Ctrl-R search --
+// interactive shell blocks don't have a discrete command string, so only cmd-controller runs are
+// captured here.
+func recordCmdHistory(blockId string, blockMeta waveobj.MetaMapType, startTs time.Time, exitCode int) {
+	defer func() {
+		panichandler.PanicHandler("blockcontroller:recordCmdHistory", recover())
+	}()
+	cmdStr := blockMeta.GetString(waveobj.MetaKey_Cmd, "")
+	if cmdStr == "" {
+		return
+	}
+	ctx, cancelFn := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancelFn()
+	err := wstore.AddCmdHistoryItem(ctx, wstore.CmdHistoryItem{
+		Ts:         startTs.UnixMilli(),
+		BlockId:    blockId,
+		Connection: blockMeta.GetString(waveobj.MetaKey_Connection, ""),
+		Cwd:        blockMeta.GetString(waveobj.MetaKey_CmdCwd, ""),
+		CmdStr:     cmdStr,
+		ExitCode:   exitCode,
+		DurationMs: time.Since(startTs).Milliseconds(),
+	})
+	if err != nil {
+		log.Printf("error recording cmd history for block %s: %v\n", blockId, err)
+	}
+}
+
 func updateTermSize(shellProc *shellexec.ShellProc, blockId string, termSize waveobj.TermSize) {
 	err := setTermSizeInDB(blockId, termSize)
 	if err != nil {
@@ -676,6 +799,9 @@ func (bc *BlockController) run(bdata *waveobj.Block, blockMeta map[string]any, r
 }
 
 func (bc *BlockController) SendInput(inputUnion *BlockInputUnion) error {
+	if err := checkPasteSafety(bc, inputUnion); err != nil {
+		return err
+	}
 	var shellInputCh chan *BlockInputUnion
 	bc.WithLock(func() {
 		shellInputCh = bc.ShellInputCh
@@ -768,6 +894,7 @@ func ResyncController(ctx context.Context, tabId string, blockId string, rtOpts
 		return fmt.Errorf("error getting block: %w", err)
 	}
 	if force {
+		recordRestartLineage(ctx, blockId)
 		StopBlockController(blockId)
 	}
 	connName := blockData.Meta.GetString(waveobj.MetaKey_Connection, "")
@@ -805,6 +932,147 @@ func ResyncController(ctx context.Context, tabId string, blockId string, rtOpts
 	return nil
 }
 
+// recordRestartLineage stamps blockId's meta with the exit code of the run a force-restart is
+// about to replace and bumps its restart count, so the frontend can distinguish an original run
+// from a restarted one (and show what the prior attempt ended with) without tracking history of
+// every BlockControllerRuntimeStatus itself. A no-op if the block has never run yet.
+func recordRestartLineage(ctx context.Context, blockId string) {
+	bc := GetBlockController(blockId)
+	if bc == nil {
+		return
+	}
+	bcStatus := bc.GetRuntimeStatus()
+	if bcStatus.ShellProcStatus == Status_Init {
+		return
+	}
+	blockData, err := wstore.DBMustGet[*waveobj.Block](ctx, blockId)
+	if err != nil {
+		log.Printf("error getting block for restart lineage %s: %v\n", blockId, err)
+		return
+	}
+	exitCode := bcStatus.ShellProcExitCode
+	metaUpdate := map[string]any{
+		waveobj.MetaKey_CmdRestartCount: blockData.Meta.GetInt(waveobj.MetaKey_CmdRestartCount, 0) + 1,
+		waveobj.MetaKey_CmdLastExitCode: exitCode,
+	}
+	if err := wstore.UpdateObjectMeta(ctx, waveobj.MakeORef(waveobj.OType_Block, blockId), metaUpdate, false); err != nil {
+		log.Printf("error recording restart lineage for block %s: %v\n", blockId, err)
+	}
+}
+
+// RestartAllFailed force-restarts every block in tabId whose shell process has exited with a
+// nonzero code, using each block's own persisted RuntimeOpts (so termsize etc. carry over the
+// same way a single-block force-restart does). Returns the restarted block ids.
+func RestartAllFailed(ctx context.Context, tabId string) ([]string, error) {
+	tab, err := wstore.DBMustGet[*waveobj.Tab](ctx, tabId)
+	if err != nil {
+		return nil, fmt.Errorf("error getting tab: %w", err)
+	}
+	var restarted []string
+	for _, blockId := range tab.BlockIds {
+		bc := GetBlockController(blockId)
+		if bc == nil {
+			continue
+		}
+		bcStatus := bc.GetRuntimeStatus()
+		if bcStatus.ShellProcStatus != Status_Done || bcStatus.ShellProcExitCode == 0 {
+			continue
+		}
+		blockData, err := wstore.DBMustGet[*waveobj.Block](ctx, blockId)
+		if err != nil {
+			log.Printf("error getting block %s for restart-all-failed: %v\n", blockId, err)
+			continue
+		}
+		if err := ResyncController(ctx, tabId, blockId, blockData.RuntimeOpts, true); err != nil {
+			log.Printf("error restarting failed block %s: %v\n", blockId, err)
+			continue
+		}
+		restarted = append(restarted, blockId)
+	}
+	return restarted, nil
+}
+
+// recordDetachedPid persists bc's shell process pid to its block's meta so it can be found later
+// by ListOrphanedDetached, even after this BlockController is gone.
+func recordDetachedPid(ctx context.Context, bc *BlockController) {
+	shellProc := bc.getShellProc()
+	if shellProc == nil {
+		return
+	}
+	pid := shellProc.Cmd.GetPid()
+	metaUpdate := map[string]any{waveobj.MetaKey_CmdDetachedPid: pid}
+	if err := wstore.UpdateObjectMeta(ctx, waveobj.MakeORef(waveobj.OType_Block, bc.BlockId), metaUpdate, false); err != nil {
+		log.Printf("error recording detached pid for block %s: %v\n", bc.BlockId, err)
+	}
+}
+
+// processAlive reports whether pid refers to a live process. Only supported on unix (matching
+// shellexec.applyDetached, which is itself a no-op on windows), so it always returns false there.
+func processAlive(pid int) bool {
+	if pid <= 0 || runtime.GOOS == "windows" {
+		return false
+	}
+	proc, err := os.FindProcess(pid)
+	if err != nil {
+		return false
+	}
+	return proc.Signal(syscall.Signal(0)) == nil
+}
+
+// OrphanedDetachedCommand describes a detached block whose process outlived the BlockController
+// that started it (e.g. across a wavesrv restart).
+type OrphanedDetachedCommand struct {
+	BlockId string `json:"blockid"`
+	Pid     int    `json:"pid"`
+}
+
+// ListOrphanedDetached scans every block with "cmd:detached" set for one with a recorded pid
+// that's still alive but has no live BlockController -- i.e. a process that kept running through
+// a wavesrv restart (see DrainAllBlockControllers) and hasn't been reattached to or cleaned up
+// yet. wavesrv can't resume reading its output (the old pty master fd closed along with the prior
+// process, and this tree has no broker process to have kept it open across the restart); this is
+// visibility into what's still out there, not a live resume.
+func ListOrphanedDetached(ctx context.Context) ([]OrphanedDetachedCommand, error) {
+	blocks, err := wstore.DBGetAllObjsByType[*waveobj.Block](ctx, waveobj.OType_Block)
+	if err != nil {
+		return nil, fmt.Errorf("error listing blocks: %w", err)
+	}
+	var orphans []OrphanedDetachedCommand
+	for _, blockData := range blocks {
+		if !blockData.Meta.GetBool(waveobj.MetaKey_CmdDetached, false) {
+			continue
+		}
+		pid := blockData.Meta.GetInt(waveobj.MetaKey_CmdDetachedPid, 0)
+		if pid == 0 || !processAlive(pid) {
+			continue
+		}
+		if bc := GetBlockController(blockData.OID); bc != nil && bc.ShellProcStatus == Status_Running {
+			continue
+		}
+		orphans = append(orphans, OrphanedDetachedCommand{BlockId: blockData.OID, Pid: pid})
+	}
+	return orphans, nil
+}
+
+// CleanupDetached kills the recorded pid for blockId (if it's still alive) and clears its detach
+// markers so it no longer shows up in ListOrphanedDetached.
+func CleanupDetached(ctx context.Context, blockId string) error {
+	blockData, err := wstore.DBMustGet[*waveobj.Block](ctx, blockId)
+	if err != nil {
+		return fmt.Errorf("error getting block: %w", err)
+	}
+	pid := blockData.Meta.GetInt(waveobj.MetaKey_CmdDetachedPid, 0)
+	if processAlive(pid) {
+		if proc, err := os.FindProcess(pid); err == nil {
+			if err := proc.Kill(); err != nil {
+				log.Printf("error killing orphaned detached process %d for block %s: %v\n", pid, blockId, err)
+			}
+		}
+	}
+	metaUpdate := map[string]any{waveobj.MetaKey_CmdDetached: false, waveobj.MetaKey_CmdDetachedPid: nil}
+	return wstore.UpdateObjectMeta(ctx, waveobj.MakeORef(waveobj.OType_Block, blockId), metaUpdate, false)
+}
+
 func startBlockController(ctx context.Context, tabId string, blockId string, rtOpts *waveobj.RuntimeOpts, force bool) error {
 	blockData, err := wstore.DBMustGet[*waveobj.Block](ctx, blockId)
 	if err != nil {
@@ -871,6 +1139,68 @@ func StopAllBlockControllers() {
 	}
 }
 
+// DrainAllBlockControllers is the shutdown-time counterpart to StopAllBlockControllers: it marks
+// every currently running block as "cmd:wasinterrupted" (so the next run clears the marker, but an
+// unclean process exit in between leaves the UI able to show the command was cut off), then asks
+// each one's shell process to stop and waits for them to finish, up to ctx's deadline. It returns
+// the number of commands still running when it gave up waiting.
+//
+// Blocks with "cmd:detached" set are deliberately excluded from the stop list -- their shell
+// process was started under nohup (see shellexec.CommandOptsType.Detached) specifically so it
+// survives this shutdown; instead its pid is recorded so it can be found later as an orphan (see
+// ListOrphanedDetached).
+func DrainAllBlockControllers(ctx context.Context) int {
+	clist := getControllerList()
+	var toStop []*BlockController
+	for _, bc := range clist {
+		if bc.ShellProcStatus != Status_Running {
+			continue
+		}
+		blockData, err := wstore.DBGet[*waveobj.Block](ctx, bc.BlockId)
+		if err == nil && blockData != nil && blockData.Meta.GetBool(waveobj.MetaKey_CmdDetached, false) {
+			recordDetachedPid(ctx, bc)
+			continue
+		}
+		toStop = append(toStop, bc)
+	}
+	if len(toStop) == 0 {
+		return 0
+	}
+	markCtx, markCancelFn := context.WithTimeout(context.Background(), DefaultTimeout)
+	for _, bc := range toStop {
+		metaUpdate := map[string]any{waveobj.MetaKey_CmdWasInterrupted: true}
+		if err := wstore.UpdateObjectMeta(markCtx, waveobj.MakeORef(waveobj.OType_Block, bc.BlockId), metaUpdate, false); err != nil {
+			log.Printf("error marking block %s interrupted: %v\n", bc.BlockId, err)
+		}
+	}
+	markCancelFn()
+	doneCh := make(chan struct{})
+	go func() {
+		var wg sync.WaitGroup
+		for _, bc := range toStop {
+			wg.Add(1)
+			go func(bc *BlockController) {
+				defer wg.Done()
+				StopBlockController(bc.BlockId)
+			}(bc)
+		}
+		wg.Wait()
+		close(doneCh)
+	}()
+	select {
+	case <-doneCh:
+		return 0
+	case <-ctx.Done():
+		remaining := 0
+		for _, bc := range toStop {
+			if bc.GetRuntimeStatus().ShellProcStatus == Status_Running {
+				remaining++
+			}
+		}
+		return remaining
+	}
+}
+
 func GetBlockController(blockId string) *BlockController {
 	globalLock.Lock()
 	defer globalLock.Unlock()
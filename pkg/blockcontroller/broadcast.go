@@ -0,0 +1,54 @@
+// Copyright 2024, Command Line Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package blockcontroller
+
+import "sync"
+
+// broadcastGroups implements input-broadcast mode (like tmux synchronize-panes):
+// keystrokes sent to any block in a group are mirrored to every other block in
+// that same group.
+var broadcastGroupLock sync.Mutex
+var broadcastGroups = make(map[string]map[string]bool) // group -> set of blockIds
+var blockToGroup = make(map[string]string)              // blockId -> group
+
+// SetBroadcastGroup adds blockId to the given group, moving it out of any
+// group it was previously in. An empty group removes the block from broadcast
+// membership entirely.
+func SetBroadcastGroup(blockId string, group string) {
+	broadcastGroupLock.Lock()
+	defer broadcastGroupLock.Unlock()
+	if oldGroup, ok := blockToGroup[blockId]; ok {
+		delete(broadcastGroups[oldGroup], blockId)
+		if len(broadcastGroups[oldGroup]) == 0 {
+			delete(broadcastGroups, oldGroup)
+		}
+		delete(blockToGroup, blockId)
+	}
+	if group == "" {
+		return
+	}
+	if broadcastGroups[group] == nil {
+		broadcastGroups[group] = make(map[string]bool)
+	}
+	broadcastGroups[group][blockId] = true
+	blockToGroup[blockId] = group
+}
+
+// GetBroadcastPeers returns the other block ids (excluding blockId) that are
+// members of blockId's broadcast group, or nil if it is not in a group.
+func GetBroadcastPeers(blockId string) []string {
+	broadcastGroupLock.Lock()
+	defer broadcastGroupLock.Unlock()
+	group, ok := blockToGroup[blockId]
+	if !ok {
+		return nil
+	}
+	var peers []string
+	for peerId := range broadcastGroups[group] {
+		if peerId != blockId {
+			peers = append(peers, peerId)
+		}
+	}
+	return peers
+}
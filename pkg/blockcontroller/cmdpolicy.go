@@ -0,0 +1,28 @@
+// Copyright 2026, Command Line Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package blockcontroller
+
+import (
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/wavetermdev/waveterm/pkg/cmdpolicy"
+	"github.com/wavetermdev/waveterm/pkg/wconfig"
+)
+
+// checkCmdPolicy evaluates cmdStr (the full command a "cmd"-type block is about to run, including
+// any cmd:args) against the "cmdpolicies" config part, scoped to connName. A denial is reported
+// the same way createCmdStrAndOpts reports any other setup error (block fails to start with the
+// message shown in its UI); this tree has no dedicated audit-log subsystem, so the log.Printf
+// line below is the record of the denial.
+func checkCmdPolicy(blockId string, connName string, cmdStr string) error {
+	fullConfig := wconfig.GetWatcher().GetFullConfig()
+	verdict := cmdpolicy.Evaluate(fullConfig.CmdPolicyRules(), connName, cmdStr, time.Now())
+	if verdict.Allowed {
+		return nil
+	}
+	log.Printf("cmdpolicy: denied block %s conn %q command %q: %s\n", blockId, connName, cmdStr, verdict.Reason)
+	return fmt.Errorf("%s", verdict.Reason)
+}
@@ -0,0 +1,66 @@
+// Copyright 2026, Command Line Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package blockcontroller
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/wavetermdev/waveterm/pkg/pasteguard"
+	"github.com/wavetermdev/waveterm/pkg/wconfig"
+)
+
+// checkPasteSafety runs pasteguard.Inspect over a pasted input chunk. If it's flagged and the
+// connection isn't allowlisted, it returns a "CONFIRM:"-prefixed error (the same style as the
+// "NOTFOUND:"-prefixed errors wshserver's file commands already use) instead of letting SendInput
+// write the paste through, so the frontend can prompt the user and resend with PasteOverride set.
+// Once confirmed (or allowlisted), it logs a one-line audit entry -- this tree has no dedicated
+// audit-log subsystem (see wlog.go's similar note about the missing "cmdrunner" package), so a
+// plain log line is the closest honest fit.
+func checkPasteSafety(bc *BlockController, input *BlockInputUnion) error {
+	if !input.IsPaste || len(input.InputData) == 0 {
+		return nil
+	}
+	verdict := pasteguard.Inspect(string(input.InputData))
+	if !verdict.Flagged {
+		return nil
+	}
+	connName := bc.getConnName()
+	if pasteSafetyAllowlisted(connName) {
+		return nil
+	}
+	if !input.PasteOverride {
+		return fmt.Errorf("CONFIRM: pasted text looks risky (%v)", verdict.Reasons)
+	}
+	log.Printf("pasteguard: block %s conn %q wrote flagged paste %v after user confirmation\n", bc.BlockId, connName, verdict.Reasons)
+	return nil
+}
+
+func (bc *BlockController) getConnName() string {
+	var connName string
+	bc.WithLock(func() {
+		if bc.ShellProc != nil {
+			connName = bc.ShellProc.ConnName
+		}
+	})
+	return connName
+}
+
+// pasteSafetyAllowlisted reports whether the paste-safety confirmation should be skipped, either
+// because it's disabled globally (term:disablepastesafety) or because this specific connection
+// overrides it (ConnKeywords.TermPasteSafety set to false).
+func pasteSafetyAllowlisted(connName string) bool {
+	config := wconfig.ReadFullConfig()
+	if config.Settings.TermDisablePasteSafety {
+		return true
+	}
+	if connName == "" {
+		return false
+	}
+	connSettings, ok := config.Connections[connName]
+	if !ok || connSettings.TermPasteSafety == nil {
+		return false
+	}
+	return !*connSettings.TermPasteSafety
+}
@@ -0,0 +1,102 @@
+// Copyright 2026, Command Line Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package blockcontroller
+
+import (
+	"encoding/base64"
+	"sync"
+	"time"
+
+	"github.com/wavetermdev/waveterm/pkg/panichandler"
+	"github.com/wavetermdev/waveterm/pkg/waveobj"
+	"github.com/wavetermdev/waveterm/pkg/wps"
+)
+
+// ptycoalescer.go batches the wps.Event_BlockFile update events fired for BlockFile_Term appends
+// so that a block producing a steady stream of small pty reads (the common case under heavy
+// output, e.g. "yes" or a build log) doesn't flood the update bus with one tiny event per read.
+// The filestore write itself (HandleAppendBlockFile's AppendData call) is never delayed -- only
+// the downstream event publish is batched -- so data is never at risk of being lost if the
+// process dies before a pending batch flushes.
+
+const CoalesceWindow = 16 * time.Millisecond
+
+// MaxCoalesceBufferSize is a safety valve: if a burst of output accumulates this much data
+// before the coalesce window elapses, flush immediately rather than let the buffer grow
+// unbounded while waiting out the rest of the window.
+const MaxCoalesceBufferSize = 1024 * 1024 // 1M
+
+type ptyCoalescer struct {
+	mu      sync.Mutex
+	blockId string
+	buf     []byte
+	timer   *time.Timer
+}
+
+var ptyCoalescers sync.Map // blockId => *ptyCoalescer
+
+func getOrCreatePtyCoalescer(blockId string) *ptyCoalescer {
+	if c, ok := ptyCoalescers.Load(blockId); ok {
+		return c.(*ptyCoalescer)
+	}
+	c, _ := ptyCoalescers.LoadOrStore(blockId, &ptyCoalescer{blockId: blockId})
+	return c.(*ptyCoalescer)
+}
+
+// removePtyCoalescer drops a block's coalescer, flushing any pending data first. Called when a
+// block's shell process goes away so we don't leak a coalescer (and its timer) per closed block.
+func removePtyCoalescer(blockId string) {
+	if c, ok := ptyCoalescers.LoadAndDelete(blockId); ok {
+		c.(*ptyCoalescer).flush()
+	}
+}
+
+// addData appends data to the pending batch, starting (or extending) the coalesce window timer.
+// The actual filestore write already happened by the time this is called -- this only controls
+// when the WaveEvent for it gets published.
+func (c *ptyCoalescer) addData(data []byte) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.buf = append(c.buf, data...)
+	if len(c.buf) >= MaxCoalesceBufferSize {
+		c.flushLocked()
+		return
+	}
+	if c.timer == nil {
+		c.timer = time.AfterFunc(CoalesceWindow, c.flush)
+	}
+}
+
+func (c *ptyCoalescer) flush() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.flushLocked()
+}
+
+func (c *ptyCoalescer) flushLocked() {
+	defer func() {
+		panichandler.PanicHandler("ptyCoalescer:flush", recover())
+	}()
+	if c.timer != nil {
+		c.timer.Stop()
+		c.timer = nil
+	}
+	if len(c.buf) == 0 {
+		return
+	}
+	data := c.buf
+	c.buf = nil
+	wps.Broker.Publish(wps.WaveEvent{
+		Event: wps.Event_BlockFile,
+		Scopes: []string{
+			waveobj.MakeORef(waveobj.OType_Block, c.blockId).String(),
+		},
+		Data: &wps.WSFileEventData{
+			ZoneId:   c.blockId,
+			FileName: BlockFile_Term,
+			FileOp:   wps.FileOp_Append,
+			Data64:   base64.StdEncoding.EncodeToString(data),
+		},
+	})
+}
@@ -0,0 +1,74 @@
+// Copyright 2024, Command Line Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package blockcontroller
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"sync"
+	"time"
+
+	"github.com/wavetermdev/waveterm/pkg/filestore"
+)
+
+// recordingEvent is one line of a block's termrecording file, modeled after
+// asciinema's [time, type, data] event stream.
+type recordingEvent struct {
+	T    float64 `json:"t"`
+	Data string  `json:"data"`
+}
+
+var recordingLock sync.Mutex
+var recordingStart = make(map[string]time.Time) // blockId -> start time, present while recording
+
+// SetRecording starts or stops terminal session recording for a block. Starting
+// recording truncates any prior recording for that block.
+func SetRecording(blockId string, enabled bool) error {
+	recordingLock.Lock()
+	defer recordingLock.Unlock()
+	if !enabled {
+		delete(recordingStart, blockId)
+		return nil
+	}
+	recordingStart[blockId] = time.Now()
+	ctx, cancelFn := context.WithTimeout(context.Background(), DefaultTimeout)
+	defer cancelFn()
+	err := filestore.WFS.WriteFile(ctx, blockId, BlockFile_TermRecording, nil)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+// IsRecording reports whether blockId currently has an active recording.
+func IsRecording(blockId string) bool {
+	recordingLock.Lock()
+	defer recordingLock.Unlock()
+	_, ok := recordingStart[blockId]
+	return ok
+}
+
+// recordTermOutput appends a timestamped recording event for pty output, if
+// recording is currently active for blockId.
+func recordTermOutput(blockId string, data []byte) {
+	recordingLock.Lock()
+	start, ok := recordingStart[blockId]
+	recordingLock.Unlock()
+	if !ok {
+		return
+	}
+	evt := recordingEvent{
+		T:    time.Since(start).Seconds(),
+		Data: base64.StdEncoding.EncodeToString(data),
+	}
+	line, err := json.Marshal(evt)
+	if err != nil {
+		return
+	}
+	line = append(line, '\n')
+	ctx, cancelFn := context.WithTimeout(context.Background(), DefaultTimeout)
+	defer cancelFn()
+	filestore.WFS.AppendData(ctx, blockId, BlockFile_TermRecording, line)
+}
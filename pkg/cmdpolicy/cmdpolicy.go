@@ -0,0 +1,84 @@
+// Copyright 2026, Command Line Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+// Package cmdpolicy evaluates a command string against an ordered list of regex allow/deny rules
+// (see wconfig.CmdPolicyConfigType), for restricted environments that want to block specific
+// commands -- or only allow a known-safe set -- before they ever run. Rules are evaluated
+// highest Priority first; the first rule whose pattern matches and whose Connection/
+// AfterHoursOnly scoping applies decides the outcome. If nothing matches, the command is
+// allowed by default, the same "default open, explicit deny" posture the rest of this tree's
+// permission settings (mcp:allow*, conn:askbeforewshinstall) take.
+package cmdpolicy
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"time"
+)
+
+// Action is the outcome a matching rule applies.
+type Action string
+
+const (
+	ActionAllow Action = "allow"
+	ActionDeny  Action = "deny"
+)
+
+// Rule is the evaluation-time shape of one wconfig.CmdPolicyConfigType entry.
+type Rule struct {
+	Id             string
+	Pattern        string
+	Action         Action
+	Connection     string // "" matches every connection, including local (no connection)
+	AfterHoursOnly bool   // rule only applies outside business hours (Mon-Fri 9am-5pm local)
+	Priority       int
+}
+
+// Verdict is the result of evaluating a command string against a rule set.
+type Verdict struct {
+	Allowed bool   `json:"allowed"`
+	Reason  string `json:"reason,omitempty"`
+	RuleId  string `json:"ruleid,omitempty"`
+}
+
+// Evaluate checks cmdStr against rules in descending Priority order (ties broken by Id, for
+// determinism), scoped to connName and now. The first applicable rule wins; an invalid regex
+// pattern is skipped rather than treated as a match, so one typo'd rule can't silently deny (or
+// allow) everything else.
+func Evaluate(rules []Rule, connName string, cmdStr string, now time.Time) Verdict {
+	ordered := make([]Rule, len(rules))
+	copy(ordered, rules)
+	sort.SliceStable(ordered, func(i, j int) bool {
+		if ordered[i].Priority != ordered[j].Priority {
+			return ordered[i].Priority > ordered[j].Priority
+		}
+		return ordered[i].Id < ordered[j].Id
+	})
+	for _, rule := range ordered {
+		if rule.Connection != "" && rule.Connection != connName {
+			continue
+		}
+		if rule.AfterHoursOnly && isBusinessHours(now) {
+			continue
+		}
+		matched, err := regexp.MatchString(rule.Pattern, cmdStr)
+		if err != nil || !matched {
+			continue
+		}
+		if rule.Action == ActionDeny {
+			return Verdict{Allowed: false, Reason: fmt.Sprintf("command denied by policy rule %q (pattern %q)", rule.Id, rule.Pattern), RuleId: rule.Id}
+		}
+		return Verdict{Allowed: true, RuleId: rule.Id}
+	}
+	return Verdict{Allowed: true}
+}
+
+// isBusinessHours reports whether now falls on a weekday between 9am and 5pm local time.
+func isBusinessHours(now time.Time) bool {
+	if now.Weekday() == time.Saturday || now.Weekday() == time.Sunday {
+		return false
+	}
+	hour := now.Hour()
+	return hour >= 9 && hour < 17
+}
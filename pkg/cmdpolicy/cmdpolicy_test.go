@@ -0,0 +1,77 @@
+// Copyright 2026, Command Line Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package cmdpolicy
+
+import (
+	"testing"
+	"time"
+)
+
+// a Tuesday at 2pm -- inside business hours
+var weekdayAfternoon = time.Date(2026, 3, 3, 14, 0, 0, 0, time.UTC)
+
+// a Saturday at 2pm -- outside business hours
+var weekendAfternoon = time.Date(2026, 3, 7, 14, 0, 0, 0, time.UTC)
+
+func TestEvaluateNoRulesAllowsByDefault(t *testing.T) {
+	v := Evaluate(nil, "", "ls -la", weekdayAfternoon)
+	if !v.Allowed {
+		t.Errorf("expected default-allow with no rules, got %+v", v)
+	}
+}
+
+func TestEvaluateDenyMatchingRule(t *testing.T) {
+	rules := []Rule{{Id: "no-rm-rf-root", Pattern: `rm\s+-rf\s+/\s*$`, Action: ActionDeny}}
+	v := Evaluate(rules, "", "rm -rf /", weekdayAfternoon)
+	if v.Allowed || v.RuleId != "no-rm-rf-root" {
+		t.Errorf("expected rm -rf / to be denied by no-rm-rf-root, got %+v", v)
+	}
+}
+
+func TestEvaluateNonMatchingCommandAllowed(t *testing.T) {
+	rules := []Rule{{Id: "no-rm-rf-root", Pattern: `rm\s+-rf\s+/\s*$`, Action: ActionDeny}}
+	v := Evaluate(rules, "", "rm -rf ./build", weekdayAfternoon)
+	if !v.Allowed {
+		t.Errorf("expected non-matching command to be allowed, got %+v", v)
+	}
+}
+
+func TestEvaluateConnectionScoped(t *testing.T) {
+	rules := []Rule{{Id: "prod-deny", Pattern: `.*`, Action: ActionDeny, Connection: "prod-box"}}
+	if v := Evaluate(rules, "staging-box", "reboot", weekdayAfternoon); !v.Allowed {
+		t.Errorf("expected rule scoped to prod-box not to apply to staging-box, got %+v", v)
+	}
+	if v := Evaluate(rules, "prod-box", "reboot", weekdayAfternoon); v.Allowed {
+		t.Errorf("expected rule scoped to prod-box to deny on prod-box, got %+v", v)
+	}
+}
+
+func TestEvaluateAfterHoursOnly(t *testing.T) {
+	rules := []Rule{{Id: "after-hours-deploy-block", Pattern: `deploy`, Action: ActionDeny, AfterHoursOnly: true}}
+	if v := Evaluate(rules, "", "deploy prod", weekdayAfternoon); !v.Allowed {
+		t.Errorf("expected after-hours-only rule not to apply during business hours, got %+v", v)
+	}
+	if v := Evaluate(rules, "", "deploy prod", weekendAfternoon); v.Allowed {
+		t.Errorf("expected after-hours-only rule to deny outside business hours, got %+v", v)
+	}
+}
+
+func TestEvaluatePriorityOrderAllowBeatsLowerPriorityDeny(t *testing.T) {
+	rules := []Rule{
+		{Id: "deny-all-reboot", Pattern: `reboot`, Action: ActionDeny, Priority: 0},
+		{Id: "allow-reboot-staging", Pattern: `reboot`, Action: ActionAllow, Connection: "staging-box", Priority: 10},
+	}
+	v := Evaluate(rules, "staging-box", "reboot", weekdayAfternoon)
+	if !v.Allowed || v.RuleId != "allow-reboot-staging" {
+		t.Errorf("expected higher-priority allow rule to win, got %+v", v)
+	}
+}
+
+func TestEvaluateInvalidPatternSkipped(t *testing.T) {
+	rules := []Rule{{Id: "bad-pattern", Pattern: `(unclosed`, Action: ActionDeny}}
+	v := Evaluate(rules, "", "ls", weekdayAfternoon)
+	if !v.Allowed {
+		t.Errorf("expected invalid pattern to be skipped rather than matched, got %+v", v)
+	}
+}
@@ -0,0 +1,67 @@
+// Copyright 2026, Command Line Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+// Package cmdshare renders a redacted, static snapshot of a single command's output (a plain or
+// HTML fragment, see ansiexport) and writes it to a local snapshot directory so it can be opened,
+// attached to a ticket, or handed off out-of-band -- without the always-on live viewer and
+// keystroke-forwarding that pkg/webshare's share links expose.
+package cmdshare
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/wavetermdev/waveterm/pkg/secretredact"
+	"github.com/wavetermdev/waveterm/pkg/wavebase"
+	"github.com/wavetermdev/waveterm/pkg/wconfig"
+)
+
+// Snapshot is the result of writing a command output snapshot to disk.
+type Snapshot struct {
+	Path            string              `json:"path"`
+	RedactionReport secretredact.Report `json:"redactionreport,omitempty"`
+}
+
+func genId() (string, error) {
+	buf := make([]byte, 8)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(buf), nil
+}
+
+// fileExt returns the snapshot file extension for format ("html" or "txt").
+func fileExt(format string) string {
+	if format == "html" {
+		return "html"
+	}
+	return "txt"
+}
+
+// Create redacts text using the configured secretredact rules (see
+// wconfig.FullConfigType.RedactionRules, which layers in custom "redactrules" entries and honors
+// redact:disablebuiltins -- the same rules RedactTextCommand and summarize.go use), writes it to
+// a new file under the share snapshot directory named for blockId and a random id, and returns
+// its path. format is "html" or anything else for plain text; it only affects the file extension
+// the snapshot is saved with, since the caller has already rendered text to the right shape (see
+// wshserver.ShareCommandOutputCommand).
+func Create(blockId string, format string, text string) (*Snapshot, error) {
+	if err := wavebase.EnsureWaveShareDir(); err != nil {
+		return nil, fmt.Errorf("ensuring share snapshot directory: %w", err)
+	}
+	rules := wconfig.GetWatcher().GetFullConfig().RedactionRules()
+	redacted, report := secretredact.Redact(text, rules)
+	id, err := genId()
+	if err != nil {
+		return nil, fmt.Errorf("generating snapshot id: %w", err)
+	}
+	fileName := fmt.Sprintf("%s-%s.%s", blockId, id, fileExt(format))
+	path := filepath.Join(wavebase.GetWaveShareDir(), fileName)
+	if err := os.WriteFile(path, []byte(redacted), 0600); err != nil {
+		return nil, fmt.Errorf("writing snapshot %q: %w", path, err)
+	}
+	return &Snapshot{Path: path, RedactionReport: report}, nil
+}
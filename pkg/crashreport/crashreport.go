@@ -0,0 +1,180 @@
+// Copyright 2025, Command Line Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+// Package crashreport captures panics recovered by pkg/panichandler as structured JSON files
+// under the wave data dir's crashes directory (stack, version, OS/arch, and a tail of recent log
+// lines), independent of whether telemetry uploads are enabled. Uploading a captured report (when
+// telemetry crash-reports are enabled) is handled by pkg/wcloud, which reads the local files this
+// package writes -- crashreport itself never talks to the network.
+package crashreport
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"runtime/debug"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/wavetermdev/waveterm/pkg/wavebase"
+)
+
+const MaxLogTailLines = 200
+const UploadedSuffix = ".uploaded"
+
+// CrashReport is the structured record written to a JSON file in the crash directory for every
+// recovered panic.
+type CrashReport struct {
+	Id        string   `json:"id"`
+	Timestamp int64    `json:"timestamp"`
+	DebugStr  string   `json:"debugstr"`
+	Recovered string   `json:"recovered"`
+	Stack     string   `json:"stack"`
+	Version   string   `json:"version"`
+	BuildTime string   `json:"buildtime"`
+	GoVersion string   `json:"goversion"`
+	OS        string   `json:"os"`
+	Arch      string   `json:"arch"`
+	LogTail   []string `json:"logtail,omitempty"`
+}
+
+type tailBuffer struct {
+	lock  sync.Mutex
+	lines []string
+	max   int
+}
+
+func (b *tailBuffer) Write(p []byte) (int, error) {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	for _, line := range strings.Split(strings.TrimRight(string(p), "\n"), "\n") {
+		if line == "" {
+			continue
+		}
+		b.lines = append(b.lines, line)
+	}
+	if len(b.lines) > b.max {
+		b.lines = b.lines[len(b.lines)-b.max:]
+	}
+	return len(p), nil
+}
+
+func (b *tailBuffer) Tail() []string {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	rtn := make([]string, len(b.lines))
+	copy(rtn, b.lines)
+	return rtn
+}
+
+var globalTail = &tailBuffer{max: MaxLogTailLines}
+
+// TailWriter returns an io.Writer that should be fanned out to alongside the normal log
+// destination (e.g. via io.MultiWriter(os.Stderr, crashreport.TailWriter())) so Capture can
+// include recent log output in a crash report.
+func TailWriter() *tailBuffer {
+	return globalTail
+}
+
+func fileName(id string, ts int64) string {
+	return fmt.Sprintf("crash-%d-%s.json", ts, id)
+}
+
+// Capture builds a CrashReport from a recovered panic and writes it to the crash directory. It is
+// meant to be wired up via panichandler.CrashReportHandler, not called directly from application
+// code.
+func Capture(debugStr string, recoverVal any) (*CrashReport, error) {
+	if err := wavebase.EnsureWaveCrashDir(); err != nil {
+		return nil, fmt.Errorf("error ensuring crash dir: %w", err)
+	}
+	now := time.Now()
+	report := &CrashReport{
+		Id:        uuid.New().String(),
+		Timestamp: now.UnixMilli(),
+		DebugStr:  debugStr,
+		Recovered: fmt.Sprintf("%v", recoverVal),
+		Stack:     string(debug.Stack()),
+		Version:   wavebase.WaveVersion,
+		BuildTime: wavebase.BuildTime,
+		GoVersion: runtime.Version(),
+		OS:        runtime.GOOS,
+		Arch:      runtime.GOARCH,
+		LogTail:   globalTail.Tail(),
+	}
+	barr, err := json.MarshalIndent(report, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("error marshaling crash report: %w", err)
+	}
+	path := filepath.Join(wavebase.GetWaveCrashDir(), fileName(report.Id, report.Timestamp))
+	if err := os.WriteFile(path, barr, 0600); err != nil {
+		return nil, fmt.Errorf("error writing crash report: %w", err)
+	}
+	return report, nil
+}
+
+func readReport(path string) (*CrashReport, error) {
+	barr, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var report CrashReport
+	if err := json.Unmarshal(barr, &report); err != nil {
+		return nil, err
+	}
+	return &report, nil
+}
+
+// List returns all captured crash reports, most recent first.
+func List() ([]*CrashReport, error) {
+	ents, err := os.ReadDir(wavebase.GetWaveCrashDir())
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("error listing crash dir: %w", err)
+	}
+	var reports []*CrashReport
+	for _, ent := range ents {
+		if ent.IsDir() || !strings.HasSuffix(ent.Name(), ".json") {
+			continue
+		}
+		report, err := readReport(filepath.Join(wavebase.GetWaveCrashDir(), ent.Name()))
+		if err != nil {
+			continue
+		}
+		reports = append(reports, report)
+	}
+	sort.Slice(reports, func(i, j int) bool { return reports[i].Timestamp > reports[j].Timestamp })
+	return reports, nil
+}
+
+func uploadMarkerPath(id string, ts int64) string {
+	return filepath.Join(wavebase.GetWaveCrashDir(), fileName(id, ts)+UploadedSuffix)
+}
+
+// ListPending returns captured crash reports that have not yet been marked uploaded.
+func ListPending() ([]*CrashReport, error) {
+	reports, err := List()
+	if err != nil {
+		return nil, err
+	}
+	var pending []*CrashReport
+	for _, report := range reports {
+		if _, err := os.Stat(uploadMarkerPath(report.Id, report.Timestamp)); err == nil {
+			continue
+		}
+		pending = append(pending, report)
+	}
+	return pending, nil
+}
+
+// MarkUploaded records that a crash report has been successfully uploaded, so ListPending won't
+// return it again.
+func MarkUploaded(report *CrashReport) error {
+	return os.WriteFile(uploadMarkerPath(report.Id, report.Timestamp), nil, 0600)
+}
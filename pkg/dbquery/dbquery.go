@@ -0,0 +1,175 @@
+// Copyright 2026, Command Line Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+// Package dbquery implements the backend for the database query block: it opens
+// connections named in the dbconnections.json config file, runs a query with
+// paged, cancelable results, and records each query to the same cmd_history
+// table used by shell command history (see pkg/wstore).
+package dbquery
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+
+	_ "github.com/mattn/go-sqlite3"
+
+	"github.com/wavetermdev/waveterm/pkg/wconfig"
+	"github.com/wavetermdev/waveterm/pkg/wshrpc"
+	"github.com/wavetermdev/waveterm/pkg/wstore"
+)
+
+const defaultLimit = 100
+
+// supportedDrivers lists the sql.DB drivers actually compiled into this binary. Postgres and
+// MySQL connection strings are accepted by the config schema (for forward-compatibility) but
+// rejected here with an honest error, since no driver for them is vendored in this build.
+var supportedDrivers = map[string]bool{
+	"sqlite3": true,
+}
+
+var dbLock sync.Mutex
+var dbCache = make(map[string]*sql.DB)
+
+var runningLock sync.Mutex
+var runningQueries = make(map[string]context.CancelFunc)
+
+func getDB(connName string) (*sql.DB, error) {
+	dbLock.Lock()
+	defer dbLock.Unlock()
+	if db, ok := dbCache[connName]; ok {
+		return db, nil
+	}
+	fullConfig := wconfig.GetWatcher().GetFullConfig()
+	connConfig, ok := fullConfig.DbConnections[connName]
+	if !ok {
+		return nil, fmt.Errorf("no db connection named %q in dbconnections.json", connName)
+	}
+	if !supportedDrivers[connConfig.Driver] {
+		return nil, fmt.Errorf("driver %q is not supported in this build (only sqlite3 is compiled in)", connConfig.Driver)
+	}
+	db, err := sql.Open(connConfig.Driver, connConfig.ConnStr)
+	if err != nil {
+		return nil, fmt.Errorf("error opening db connection %q: %w", connName, err)
+	}
+	dbCache[connName] = db
+	return db, nil
+}
+
+func formatValue(v any) string {
+	if v == nil {
+		return ""
+	}
+	if b, ok := v.([]byte); ok {
+		return string(b)
+	}
+	return fmt.Sprintf("%v", v)
+}
+
+// Query runs data.Query against the named connection and returns up to data.Limit rows
+// starting at data.Offset. It stops scanning as soon as it has one page of rows plus
+// confirmation that at least one more row exists (HasMore), so it never materializes an
+// entire large result set just to answer a single page request. The query is also recorded
+// to the main cmd_history table, the same one shell command history uses.
+func Query(ctx context.Context, data wshrpc.CommandDbQueryData) (wshrpc.DbQueryResult, error) {
+	db, err := getDB(data.Connection)
+	if err != nil {
+		return wshrpc.DbQueryResult{}, err
+	}
+	if data.QueryId != "" {
+		var cancelFn context.CancelFunc
+		ctx, cancelFn = context.WithCancel(ctx)
+		runningLock.Lock()
+		runningQueries[data.QueryId] = cancelFn
+		runningLock.Unlock()
+		defer func() {
+			runningLock.Lock()
+			delete(runningQueries, data.QueryId)
+			runningLock.Unlock()
+			cancelFn()
+		}()
+	}
+	limit := data.Limit
+	if limit <= 0 {
+		limit = defaultLimit
+	}
+	startTs := time.Now()
+	rows, err := db.QueryContext(ctx, data.Query, data.Params...)
+	recordHistory(data, startTs, err)
+	if err != nil {
+		return wshrpc.DbQueryResult{}, fmt.Errorf("error running query: %w", err)
+	}
+	defer rows.Close()
+	columns, err := rows.Columns()
+	if err != nil {
+		return wshrpc.DbQueryResult{}, fmt.Errorf("error reading columns: %w", err)
+	}
+	result := wshrpc.DbQueryResult{
+		Columns: columns,
+		Offset:  data.Offset,
+	}
+	rowIdx := 0
+	for rows.Next() {
+		if ctx.Err() != nil {
+			return wshrpc.DbQueryResult{}, ctx.Err()
+		}
+		if rowIdx >= data.Offset+limit {
+			result.HasMore = true
+			break
+		}
+		scanDest := make([]any, len(columns))
+		scanPtrs := make([]any, len(columns))
+		for i := range scanDest {
+			scanPtrs[i] = &scanDest[i]
+		}
+		if err := rows.Scan(scanPtrs...); err != nil {
+			return wshrpc.DbQueryResult{}, fmt.Errorf("error scanning row: %w", err)
+		}
+		if rowIdx >= data.Offset {
+			strRow := make([]string, len(columns))
+			for i, v := range scanDest {
+				strRow[i] = formatValue(v)
+			}
+			result.Rows = append(result.Rows, strRow)
+		}
+		rowIdx++
+	}
+	if err := rows.Err(); err != nil {
+		return wshrpc.DbQueryResult{}, fmt.Errorf("error iterating rows: %w", err)
+	}
+	return result, nil
+}
+
+// CancelQuery cancels a running query previously started with the same QueryId. It is a
+// no-op (not an error) if the query already finished or no such query is running, since a
+// cancel racing a query's natural completion is an expected, harmless case.
+func CancelQuery(queryId string) error {
+	runningLock.Lock()
+	defer runningLock.Unlock()
+	if cancelFn, ok := runningQueries[queryId]; ok {
+		cancelFn()
+	}
+	return nil
+}
+
+func recordHistory(data wshrpc.CommandDbQueryData, startTs time.Time, queryErr error) {
+	exitCode := 0
+	if queryErr != nil {
+		exitCode = 1
+	}
+	hctx, cancelFn := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancelFn()
+	err := wstore.AddCmdHistoryItem(hctx, wstore.CmdHistoryItem{
+		Ts:         startTs.UnixMilli(),
+		Connection: data.Connection,
+		CmdStr:     data.Query,
+		ExitCode:   exitCode,
+		DurationMs: time.Since(startTs).Milliseconds(),
+	})
+	if err != nil {
+		log.Printf("error recording db query history for connection %s: %v\n", data.Connection, err)
+	}
+}
@@ -0,0 +1,126 @@
+// Copyright 2026, Command Line Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+// Package diffutil computes a line-based diff between two texts, producing the ordered list of
+// equal/add/del lines a unified or side-by-side diff renderer needs (see wshremote's
+// RemoteFileDiffCommand and the frontend "diff" block view). It has no knowledge of files,
+// connections, or git; callers are responsible for sourcing the two texts to compare.
+package diffutil
+
+import "strings"
+
+// MaxDiffLines caps how many lines of each side are run through the LCS comparison. Beyond this,
+// the cost of the underlying O(n*m) table becomes impractical, so Compute falls back to reporting
+// the whole left side as removed and the whole right side as added.
+const MaxDiffLines = 5000
+
+// Op identifies what a DiffLine represents.
+type Op string
+
+const (
+	OpEqual Op = "equal"
+	OpDel   Op = "del"
+	OpAdd   Op = "add"
+)
+
+// DiffLine is one line of a diff result, in display order. LeftNum/RightNum are 1-indexed line
+// numbers within their respective side and are 0 when not applicable (e.g. RightNum on a del
+// line).
+type DiffLine struct {
+	Op       Op     `json:"op"`
+	LeftNum  int    `json:"leftnum,omitempty"`
+	RightNum int    `json:"rightnum,omitempty"`
+	Text     string `json:"text"`
+}
+
+func splitLines(text string) []string {
+	if text == "" {
+		return nil
+	}
+	return strings.Split(strings.TrimSuffix(text, "\n"), "\n")
+}
+
+// Compute diffs leftText against rightText and returns the ordered line-by-line result.
+func Compute(leftText string, rightText string) []DiffLine {
+	left := splitLines(leftText)
+	right := splitLines(rightText)
+	if len(left) > MaxDiffLines || len(right) > MaxDiffLines {
+		return wholeFileDiff(left, right)
+	}
+	return lcsDiff(left, right)
+}
+
+func wholeFileDiff(left []string, right []string) []DiffLine {
+	lines := make([]DiffLine, 0, len(left)+len(right))
+	for i, l := range left {
+		lines = append(lines, DiffLine{Op: OpDel, LeftNum: i + 1, Text: l})
+	}
+	for i, r := range right {
+		lines = append(lines, DiffLine{Op: OpAdd, RightNum: i + 1, Text: r})
+	}
+	return lines
+}
+
+// lcsDiff builds a longest-common-subsequence table over the two line slices and walks it
+// backwards to recover the diff, the standard textbook approach for line-level diffing.
+func lcsDiff(left []string, right []string) []DiffLine {
+	n, m := len(left), len(right)
+	lcs := make([][]int, n+1)
+	for i := range lcs {
+		lcs[i] = make([]int, m+1)
+	}
+	for i := n - 1; i >= 0; i-- {
+		for j := m - 1; j >= 0; j-- {
+			if left[i] == right[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else if lcs[i+1][j] >= lcs[i][j+1] {
+				lcs[i][j] = lcs[i+1][j]
+			} else {
+				lcs[i][j] = lcs[i][j+1]
+			}
+		}
+	}
+
+	var result []DiffLine
+	i, j := 0, 0
+	for i < n && j < m {
+		switch {
+		case left[i] == right[j]:
+			result = append(result, DiffLine{Op: OpEqual, LeftNum: i + 1, RightNum: j + 1, Text: left[i]})
+			i++
+			j++
+		case lcs[i+1][j] >= lcs[i][j+1]:
+			result = append(result, DiffLine{Op: OpDel, LeftNum: i + 1, Text: left[i]})
+			i++
+		default:
+			result = append(result, DiffLine{Op: OpAdd, RightNum: j + 1, Text: right[j]})
+			j++
+		}
+	}
+	for ; i < n; i++ {
+		result = append(result, DiffLine{Op: OpDel, LeftNum: i + 1, Text: left[i]})
+	}
+	for ; j < m; j++ {
+		result = append(result, DiffLine{Op: OpAdd, RightNum: j + 1, Text: right[j]})
+	}
+	return result
+}
+
+// Unified renders lines in the familiar "-"/"+"/" " prefixed text form, for terminal or log
+// output rather than a structured side-by-side renderer.
+func Unified(leftLabel string, rightLabel string, lines []DiffLine) string {
+	var b strings.Builder
+	b.WriteString("--- " + leftLabel + "\n")
+	b.WriteString("+++ " + rightLabel + "\n")
+	for _, line := range lines {
+		switch line.Op {
+		case OpDel:
+			b.WriteString("-" + line.Text + "\n")
+		case OpAdd:
+			b.WriteString("+" + line.Text + "\n")
+		default:
+			b.WriteString(" " + line.Text + "\n")
+		}
+	}
+	return b.String()
+}
@@ -0,0 +1,87 @@
+// Copyright 2026, Command Line Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package diffutil
+
+import "testing"
+
+func opsString(lines []DiffLine) string {
+	s := ""
+	for _, l := range lines {
+		switch l.Op {
+		case OpEqual:
+			s += "="
+		case OpDel:
+			s += "-"
+		case OpAdd:
+			s += "+"
+		}
+	}
+	return s
+}
+
+func TestComputeIdentical(t *testing.T) {
+	lines := Compute("a\nb\nc", "a\nb\nc")
+	if opsString(lines) != "===" {
+		t.Fatalf("expected all equal, got %q", opsString(lines))
+	}
+}
+
+func TestComputeAddAndDel(t *testing.T) {
+	lines := Compute("a\nb\nc", "a\nx\nc")
+	if opsString(lines) != "=-+=" && opsString(lines) != "=+-=" {
+		t.Fatalf("unexpected diff shape: %q", opsString(lines))
+	}
+}
+
+func TestComputeEmptyToNonEmpty(t *testing.T) {
+	lines := Compute("", "a\nb")
+	if opsString(lines) != "++" {
+		t.Fatalf("expected two adds, got %q", opsString(lines))
+	}
+	if lines[0].RightNum != 1 || lines[1].RightNum != 2 {
+		t.Fatalf("expected right line numbers 1,2, got %d,%d", lines[0].RightNum, lines[1].RightNum)
+	}
+}
+
+func TestComputeNonEmptyToEmpty(t *testing.T) {
+	lines := Compute("a\nb", "")
+	if opsString(lines) != "--" {
+		t.Fatalf("expected two dels, got %q", opsString(lines))
+	}
+}
+
+func TestComputeLineNumbers(t *testing.T) {
+	lines := Compute("a\nb\nc", "a\nc")
+	var del DiffLine
+	for _, l := range lines {
+		if l.Op == OpDel {
+			del = l
+		}
+	}
+	if del.Text != "b" || del.LeftNum != 2 {
+		t.Fatalf("expected del of 'b' at leftnum 2, got %+v", del)
+	}
+}
+
+func TestWholeFileDiffFallback(t *testing.T) {
+	bigLeft := make([]byte, 0)
+	for i := 0; i < MaxDiffLines+1; i++ {
+		bigLeft = append(bigLeft, 'a', '\n')
+	}
+	lines := Compute(string(bigLeft), "x")
+	if opsString(lines)[0] != '-' {
+		t.Fatalf("expected fallback to start with a del, got %q", opsString(lines)[:1])
+	}
+}
+
+func TestUnified(t *testing.T) {
+	lines := Compute("a\nb", "a\nc")
+	out := Unified("left.txt", "right.txt", lines)
+	if out == "" {
+		t.Fatal("expected non-empty unified output")
+	}
+	if out[:4] != "--- " {
+		t.Fatalf("expected unified output to start with left label marker, got %q", out[:4])
+	}
+}
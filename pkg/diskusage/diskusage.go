@@ -0,0 +1,93 @@
+// Copyright 2026, Command Line Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+// Package diskusage implements the du-style directory-size walker behind the disk usage
+// analyzer: it walks a directory tree computing a size-by-subtree breakdown, skips entries
+// matching an exclusion list, and reports progress periodically so a caller can stream partial
+// results while a large walk is still running. Wiring this up to a treemap block view is left to
+// the frontend; this package only produces the size tree.
+package diskusage
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+)
+
+// progressInterval is how many files must be scanned between ProgressFunc calls.
+const progressInterval = 200
+
+type Node struct {
+	Name     string  `json:"name"`
+	Path     string  `json:"path"`
+	Size     int64   `json:"size"` // this entry's size, plus (for directories) everything beneath it
+	IsDir    bool    `json:"isdir"`
+	Children []*Node `json:"children,omitempty"`
+}
+
+// ProgressFunc is called periodically during Walk with the number of files scanned so far and the
+// path currently being visited.
+type ProgressFunc func(filesScanned int, currentPath string)
+
+// Walk computes a size-by-subtree breakdown of root, skipping any entry whose base name matches
+// one of the exclude glob patterns (see path/filepath.Match). If ctx is cancelled mid-walk, Walk
+// returns whatever partial tree it had computed so far along with ctx.Err().
+func Walk(ctx context.Context, root string, exclude []string, progress ProgressFunc) (*Node, error) {
+	filesScanned := 0
+	node, _, err := walkDir(ctx, root, exclude, &filesScanned, progress)
+	return node, err
+}
+
+func isExcluded(name string, exclude []string) bool {
+	for _, pattern := range exclude {
+		if ok, _ := filepath.Match(pattern, name); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// walkDir returns the computed node, whether the walk was cancelled, and an error (set only when
+// cancelled or when root itself cannot be stat'd).
+func walkDir(ctx context.Context, path string, exclude []string, filesScanned *int, progress ProgressFunc) (*Node, bool, error) {
+	select {
+	case <-ctx.Done():
+		return nil, true, ctx.Err()
+	default:
+	}
+	info, err := os.Lstat(path)
+	if err != nil {
+		return nil, false, err
+	}
+	node := &Node{Name: filepath.Base(path), Path: path}
+	if !info.IsDir() {
+		node.Size = info.Size()
+		*filesScanned++
+		if progress != nil && *filesScanned%progressInterval == 0 {
+			progress(*filesScanned, path)
+		}
+		return node, false, nil
+	}
+	node.IsDir = true
+	entries, err := os.ReadDir(path)
+	if err != nil {
+		// unreadable directory (permissions, etc.) -- report it as a zero-size leaf rather than
+		// failing the whole walk over one bad subtree
+		return node, false, nil
+	}
+	for _, entry := range entries {
+		if isExcluded(entry.Name(), exclude) {
+			continue
+		}
+		child, cancelled, err := walkDir(ctx, filepath.Join(path, entry.Name()), exclude, filesScanned, progress)
+		if cancelled {
+			return node, true, err
+		}
+		if err != nil {
+			continue
+		}
+		node.Children = append(node.Children, child)
+		node.Size += child.Size
+	}
+	return node, false, nil
+}
@@ -0,0 +1,58 @@
+// Copyright 2026, Command Line Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package diskusage
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func writeTestTree(t *testing.T) string {
+	t.Helper()
+	root := t.TempDir()
+	if err := os.WriteFile(filepath.Join(root, "a.txt"), []byte("12345"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	sub := filepath.Join(root, "sub")
+	if err := os.Mkdir(sub, 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(sub, "b.txt"), []byte("1234567890"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	excluded := filepath.Join(root, "node_modules")
+	if err := os.Mkdir(excluded, 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(excluded, "c.txt"), []byte("xxxxxxxxxxxxxxxxxxxx"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	return root
+}
+
+func TestWalkSizesAndExclusion(t *testing.T) {
+	root := writeTestTree(t)
+	node, err := Walk(context.Background(), root, []string{"node_modules"}, nil)
+	if err != nil {
+		t.Fatalf("Walk failed: %v", err)
+	}
+	if node.Size != 15 {
+		t.Fatalf("expected size 15 (excluding node_modules), got %d", node.Size)
+	}
+	if len(node.Children) != 2 {
+		t.Fatalf("expected 2 children (a.txt, sub), got %d: %+v", len(node.Children), node.Children)
+	}
+}
+
+func TestWalkCancellation(t *testing.T) {
+	root := writeTestTree(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err := Walk(ctx, root, nil, nil)
+	if err == nil {
+		t.Fatal("expected an error from a pre-cancelled context")
+	}
+}
@@ -26,6 +26,12 @@ const (
 	IJsonIncrementalBytes = "ijson:incbytes"
 )
 
+const (
+	// archive meta keys (set on circular files with ArchiveOverflow enabled)
+	ArchiveTruncated = "archive:truncated" // true once any data has spilled to cold storage
+	ArchiveBytes     = "archive:bytes"     // total bytes spilled to cold storage
+)
+
 const (
 	IJsonHighCommands = 100
 	IJsonHighRatio    = 3
@@ -54,6 +60,11 @@ type FileOptsType struct {
 	Circular    bool  `json:"circular,omitempty"`
 	IJson       bool  `json:"ijson,omitempty"`
 	IJsonBudget int   `json:"ijsonbudget,omitempty"`
+
+	// ArchiveOverflow only applies to circular files. When set, bytes that would otherwise be
+	// silently dropped off the front of the ring buffer are instead compressed and appended to a
+	// cold-storage archive on disk (see blockstore_archive.go), retrievable with ReadArchive.
+	ArchiveOverflow bool `json:"archiveoverflow,omitempty"`
 }
 
 type FileMeta = map[string]any
@@ -165,6 +176,9 @@ func (s *FileStore) DeleteFile(ctx context.Context, zoneId string, name string)
 			return fmt.Errorf("error deleting file: %v", err)
 		}
 		entry.clear()
+		if err := DeleteArchive(zoneId, name); err != nil {
+			log.Printf("[filestore] %v\n", err)
+		}
 		return nil
 	})
 }
@@ -238,7 +252,8 @@ func (s *FileStore) WriteFile(ctx context.Context, zoneId string, name string, d
 		if err != nil {
 			return err
 		}
-		entry.writeAt(0, data, true)
+		appendWal(walRecord{Op: walOp_WriteFile, ZoneId: zoneId, Name: name, Data: data})
+		entry.writeAt(ctx, 0, data, true)
 		// since WriteFile can *truncate* the file, we need to flush the file to the DB immediately
 		return entry.flushToDB(ctx, true)
 	})
@@ -263,7 +278,8 @@ func (s *FileStore) WriteAt(ctx context.Context, zoneId string, name string, off
 		if err != nil {
 			return err
 		}
-		entry.writeAt(offset, data, false)
+		appendWal(walRecord{Op: walOp_WriteAt, ZoneId: zoneId, Name: name, Offset: offset, Data: data})
+		entry.writeAt(ctx, offset, data, false)
 		return nil
 	})
 }
@@ -282,7 +298,8 @@ func (s *FileStore) AppendData(ctx context.Context, zoneId string, name string,
 				return err
 			}
 		}
-		entry.writeAt(entry.File.Size, data, false)
+		appendWal(walRecord{Op: walOp_AppendData, ZoneId: zoneId, Name: name, Offset: entry.File.Size, Data: data})
+		entry.writeAt(ctx, entry.File.Size, data, false)
 		return nil
 	})
 }
@@ -310,7 +327,7 @@ func (s *FileStore) compactIJson(ctx context.Context, entry *CacheEntry) error {
 	if err != nil {
 		return err
 	}
-	entry.writeAt(0, newBytes, true)
+	entry.writeAt(ctx, 0, newBytes, true)
 	return nil
 }
 
@@ -349,8 +366,8 @@ func (s *FileStore) AppendIJson(ctx context.Context, zoneId string, name string,
 			}
 		}
 		oldSize := entry.File.Size
-		entry.writeAt(entry.File.Size, data, false)
-		entry.writeAt(entry.File.Size, []byte("\n"), false)
+		entry.writeAt(ctx, entry.File.Size, data, false)
+		entry.writeAt(ctx, entry.File.Size, []byte("\n"), false)
 		if oldSize == 0 {
 			return nil
 		}
@@ -424,6 +441,7 @@ func (s *FileStore) FlushCache(ctx context.Context) (stats FlushStats, rtnErr er
 		}
 		stats.NumCommitted++
 	}
+	checkpointWalIfClean()
 	return stats, nil
 }
 
@@ -0,0 +1,92 @@
+// Copyright 2026, Command Line Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package filestore
+
+// blockstore_archive.go implements cold-storage spillover for circular files with
+// ArchiveOverflow set: instead of silently dropping data off the front of the ring buffer (the
+// default behavior for every other circular file, e.g. the raw pty blob), the evicted bytes are
+// gzip-compressed and appended to a per-file archive on disk, and the live WaveFile's Meta is
+// marked so callers (e.g. the frontend) know the file's head has been truncated and can be
+// fetched from cold storage on demand via ReadArchive. Archived data is intentionally not part of
+// the live read path (ReadAt/ReadFile) -- it is much larger and much colder than the live window.
+
+import (
+	"bytes"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/wavetermdev/waveterm/pkg/wavebase"
+)
+
+func archiveFileName(zoneId string, name string) string {
+	return filepath.Join(wavebase.GetWaveArchiveDir(), fmt.Sprintf("%s-%s.gz", zoneId, name))
+}
+
+// archiveOverflow compresses data as its own gzip member and appends it to the zone/name archive
+// file, then updates the live file's meta to reflect the spillover. Errors are logged by the
+// caller's caller (flush path) rather than failing the write -- losing the cold-storage copy of
+// already-evicted ring buffer data is not worth failing a live pty write over.
+func (entry *CacheEntry) archiveOverflow(data []byte) {
+	if err := appendToArchive(entry.ZoneId, entry.Name, data); err != nil {
+		warningCount.Add(1)
+		return
+	}
+	if entry.File.Meta == nil {
+		entry.File.Meta = make(FileMeta)
+	}
+	entry.File.Meta[ArchiveTruncated] = true
+	entry.File.Meta[ArchiveBytes] = metaIncrement(entry.File, ArchiveBytes, len(data))
+}
+
+func appendToArchive(zoneId string, name string, data []byte) error {
+	path := archiveFileName(zoneId, name)
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_APPEND, 0600)
+	if err != nil {
+		return fmt.Errorf("error opening archive %q: %w", path, err)
+	}
+	defer f.Close()
+	gzw := gzip.NewWriter(f)
+	if _, err := gzw.Write(data); err != nil {
+		return fmt.Errorf("error writing archive %q: %w", path, err)
+	}
+	return gzw.Close()
+}
+
+// ReadArchive returns the full cold-storage archive for zoneId/name, in the order it was
+// written (oldest-evicted-first). Returns (nil, nil) if the file has no archive (nothing has
+// overflowed yet, or ArchiveOverflow was never enabled).
+func (s *FileStore) ReadArchive(zoneId string, name string) ([]byte, error) {
+	path := archiveFileName(zoneId, name)
+	f, err := os.Open(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("error opening archive %q: %w", path, err)
+	}
+	defer f.Close()
+	gzr, err := gzip.NewReader(f)
+	if err != nil {
+		return nil, fmt.Errorf("error reading archive %q: %w", path, err)
+	}
+	gzr.Multistream(true)
+	var buf bytes.Buffer
+	if _, err := io.Copy(&buf, gzr); err != nil {
+		return nil, fmt.Errorf("error decompressing archive %q: %w", path, err)
+	}
+	return buf.Bytes(), nil
+}
+
+// DeleteArchive removes the cold-storage archive for zoneId/name, if any. Called alongside
+// DeleteFile/DeleteZone so archives don't outlive the files they were spilled from.
+func DeleteArchive(zoneId string, name string) error {
+	err := os.Remove(archiveFileName(zoneId, name))
+	if err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("error removing archive for %s:%s: %w", zoneId, name, err)
+	}
+	return nil
+}
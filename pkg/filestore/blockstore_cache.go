@@ -156,11 +156,21 @@ func (dce *DataCacheEntry) writeToPart(offset int64, data []byte) (int64, *DataC
 	return toWrite, dce
 }
 
-func (entry *CacheEntry) writeAt(offset int64, data []byte, replace bool) {
+func (entry *CacheEntry) writeAt(ctx context.Context, offset int64, data []byte, replace bool) {
 	if replace {
 		entry.File.Size = 0
 	}
 	if entry.File.Opts.Circular {
+		if entry.File.Opts.ArchiveOverflow && !replace && offset == entry.File.Size && entry.File.Size > entry.File.Opts.MaxSize {
+			// this append is about to push len(data) bytes off the front of the live window --
+			// read and archive exactly those bytes before they're overwritten in place below
+			evictOffset := entry.File.Size - entry.File.Opts.MaxSize
+			evictSize := minInt64(int64(len(data)), entry.File.Opts.MaxSize)
+			_, evictedData, err := entry.readAt(ctx, evictOffset, evictSize, false)
+			if err == nil && len(evictedData) > 0 {
+				entry.archiveOverflow(evictedData)
+			}
+		}
 		startCirFileOffset := entry.File.Size - entry.File.Opts.MaxSize
 		if offset+int64(len(data)) <= startCirFileOffset {
 			// write is before the start of the circular file
@@ -42,8 +42,23 @@ func InitFilestore() error {
 	if err != nil {
 		return err
 	}
+	if !useTestingDb {
+		if err := replayWal(); err != nil {
+			log.Printf("[filestore] error replaying write-ahead journal: %v\n", err)
+		}
+		globalWal, err = openWal()
+		if err != nil {
+			return fmt.Errorf("error opening write-ahead journal: %w", err)
+		}
+	}
 	if !stopFlush.Load() {
 		go WFS.runFlusher()
+		checkIntegrityOnStartup()
+		go maintenanceLoop()
+		go backupLoop()
+		if !useTestingDb {
+			go walSyncLoop()
+		}
 	}
 	log.Printf("filestore initialized\n")
 	return nil
@@ -70,6 +85,12 @@ func MakeDB(ctx context.Context) (*sqlx.DB, error) {
 		return nil, fmt.Errorf("opening db: %w", err)
 	}
 	rtn.DB.SetMaxOpenConns(1)
+	if !useTestingDb {
+		// only takes effect on a brand new (table-less) db -- converting an existing db to
+		// incremental auto_vacuum requires a full VACUUM, which RunIncrementalVacuum
+		// deliberately doesn't do automatically (see blockstore_maint.go)
+		rtn.ExecContext(ctx, "PRAGMA auto_vacuum=INCREMENTAL")
+	}
 	return rtn, nil
 }
 
@@ -0,0 +1,225 @@
+// Copyright 2026, Command Line Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package filestore
+
+// blockstore_wal.go implements a write-ahead journal for the filestore cache: WriteFile, WriteAt,
+// and AppendData each append a record here *before* the write lands in the in-memory cache, so a
+// write the process never got around to flushing to sqlite (crash, kill -9, power loss) can be
+// replayed into the db on the next startup instead of being silently lost. AppendIJson is not
+// journaled -- ijson files are periodically compacted anyway, and losing the last few uncompacted
+// commands is a much smaller regression than losing raw PTY output.
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/wavetermdev/waveterm/pkg/wavebase"
+)
+
+// fsync policy for the wal -- see the "server:filestorewalsyncmode" setting.
+const (
+	WalSyncMode_Always   = "always"   // fsync after every record (safest, slowest)
+	WalSyncMode_Periodic = "periodic" // fsync on a timer (WalSyncInterval) -- the default
+	WalSyncMode_Off      = "off"      // never explicitly fsync, rely on the OS to flush eventually
+)
+
+const WalFileName = "filestore-cache.wal"
+const WalSyncInterval = 1 * time.Second
+
+type walOp string
+
+const (
+	walOp_WriteFile  walOp = "writefile"
+	walOp_WriteAt    walOp = "writeat"
+	walOp_AppendData walOp = "appenddata"
+)
+
+type walRecord struct {
+	Op     walOp  `json:"op"`
+	ZoneId string `json:"zoneid"`
+	Name   string `json:"name"`
+	Offset int64  `json:"offset,omitempty"`
+	Data   []byte `json:"data"`
+}
+
+type walJournal struct {
+	mu   sync.Mutex
+	file *os.File
+}
+
+// globalWal is nil until InitFilestore has replayed and checkpointed any pre-existing wal --
+// appendWal is a no-op while it's nil, which is what keeps wal replay itself from being journaled.
+var globalWal *walJournal
+
+// WalSyncModeGetter is set by cmd/server at startup to read the "server:filestorewalsyncmode"
+// setting. filestore sits below pkg/wconfig in the import graph, so it can't read the setting
+// directly; this indirection mirrors the callback-injection pattern used by pkg/panichandler.
+var WalSyncModeGetter func() string
+
+func GetWalName() string {
+	return filepath.Join(wavebase.GetWaveDataDir(), wavebase.WaveDBDir, WalFileName)
+}
+
+func getWalSyncMode() string {
+	var mode string
+	if WalSyncModeGetter != nil {
+		mode = WalSyncModeGetter()
+	}
+	if mode == "" {
+		return WalSyncMode_Periodic
+	}
+	return mode
+}
+
+func openWal() (*walJournal, error) {
+	path := GetWalName()
+	f, err := os.OpenFile(path, os.O_RDWR|os.O_CREATE|os.O_APPEND, 0600)
+	if err != nil {
+		return nil, fmt.Errorf("error opening filestore wal %q: %w", path, err)
+	}
+	return &walJournal{file: f}, nil
+}
+
+func (w *walJournal) sync() {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.file.Sync()
+}
+
+// checkpoint truncates the wal to empty. Only safe to call once the caller has confirmed every
+// dirty cache entry has been durably flushed to the db, since replaying an empty wal is a no-op.
+func (w *walJournal) checkpoint() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if err := w.file.Truncate(0); err != nil {
+		return err
+	}
+	_, err := w.file.Seek(0, 0)
+	return err
+}
+
+// appendWal journals rec if the wal is open (it's nil during the brief window before InitFilestore
+// finishes replaying the previous wal, when there's nothing yet to lose).
+func appendWal(rec walRecord) {
+	if globalWal == nil {
+		return
+	}
+	data, err := json.Marshal(rec)
+	if err != nil {
+		log.Printf("[filestore] error marshaling wal record: %v\n", err)
+		return
+	}
+	globalWal.mu.Lock()
+	_, err = globalWal.file.Write(append(data, '\n'))
+	syncMode := getWalSyncMode()
+	globalWal.mu.Unlock()
+	if err != nil {
+		log.Printf("[filestore] error writing wal record: %v\n", err)
+		return
+	}
+	if syncMode == WalSyncMode_Always {
+		globalWal.sync()
+	}
+}
+
+// checkpointWalIfClean truncates the wal once nothing is left dirty in the cache -- called after
+// every flush pass. If writes are still arriving fast enough that the cache never goes fully
+// clean, the wal simply keeps growing until a flush pass does catch it empty.
+func checkpointWalIfClean() {
+	if globalWal == nil {
+		return
+	}
+	if len(WFS.getDirtyCacheKeys()) > 0 {
+		return
+	}
+	if err := globalWal.checkpoint(); err != nil {
+		log.Printf("[filestore] error checkpointing wal: %v\n", err)
+	}
+}
+
+func walSyncLoop() {
+	for {
+		time.Sleep(WalSyncInterval)
+		if stopFlush.Load() {
+			return
+		}
+		if globalWal != nil && getWalSyncMode() == WalSyncMode_Periodic {
+			globalWal.sync()
+		}
+	}
+}
+
+// replayWal replays any records left behind in the wal by a previous, uncleanly-terminated
+// process directly into the db (by re-running them through the normal cache write path and then
+// flushing), then removes the old wal file. Must run before globalWal is opened for append (see
+// the note on globalWal), and before the flusher/maintenance goroutines start.
+func replayWal() error {
+	path := GetWalName()
+	f, err := os.Open(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return err
+	}
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 0, 64*1024), 16*1024*1024)
+	var numReplayed int
+	for scanner.Scan() {
+		line := scanner.Bytes()
+		if len(line) == 0 {
+			continue
+		}
+		var rec walRecord
+		if err := json.Unmarshal(line, &rec); err != nil {
+			log.Printf("[filestore] skipping corrupt wal record: %v\n", err)
+			continue
+		}
+		if err := replayWalRecord(rec); err != nil {
+			log.Printf("[filestore] error replaying wal record (zone=%s name=%s op=%s): %v\n", rec.ZoneId, rec.Name, rec.Op, err)
+			continue
+		}
+		numReplayed++
+	}
+	scanErr := scanner.Err()
+	f.Close()
+	if scanErr != nil {
+		return scanErr
+	}
+	if numReplayed == 0 {
+		return nil
+	}
+	log.Printf("[filestore] replayed %d wal record(s) from a previous unclean shutdown\n", numReplayed)
+	ctx, cancelFn := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancelFn()
+	if _, err := WFS.FlushCache(ctx); err != nil {
+		return fmt.Errorf("error flushing replayed wal records: %w", err)
+	}
+	if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("error removing replayed wal %q: %w", path, err)
+	}
+	return nil
+}
+
+func replayWalRecord(rec walRecord) error {
+	ctx, cancelFn := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancelFn()
+	switch rec.Op {
+	case walOp_WriteFile:
+		return WFS.WriteFile(ctx, rec.ZoneId, rec.Name, rec.Data)
+	case walOp_WriteAt:
+		return WFS.WriteAt(ctx, rec.ZoneId, rec.Name, rec.Offset, rec.Data)
+	case walOp_AppendData:
+		return WFS.AppendData(ctx, rec.ZoneId, rec.Name, rec.Data)
+	default:
+		return fmt.Errorf("unknown wal op %q", rec.Op)
+	}
+}
@@ -0,0 +1,132 @@
+// Copyright 2026, Command Line Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+// Package fontcheck reports whether a configured terminal font family is actually available, so
+// the backend can warn the user at startup instead of letting the terminal silently fall back to
+// the browser default. There's no font-parsing library vendored in this module, so this is a
+// best-effort filename match against the well-known system/user font directories rather than a
+// real parse of each font file's name table -- good enough to catch the common case (a font isn't
+// installed at all) without false-positiving on lookalike names very often.
+package fontcheck
+
+import (
+	"io/fs"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+)
+
+// BundledFonts lists font families shipped with the app itself (see public/fonts), which are
+// always reported as installed regardless of what's on the system -- including a Nerd Font so
+// powerline/devicon prompt glyphs render without the user installing anything.
+var BundledFonts = []string{"Hack Nerd Font Mono", "Martian Mono"}
+
+// IsBundled reports whether family is one of BundledFonts (case-insensitive).
+func IsBundled(family string) bool {
+	for _, bundled := range BundledFonts {
+		if strings.EqualFold(bundled, family) {
+			return true
+		}
+	}
+	return false
+}
+
+// fontDirs returns the well-known system/user font directories for the current OS. Directories
+// that don't exist are simply skipped by the scan in IsFontInstalled.
+func fontDirs() []string {
+	home, _ := os.UserHomeDir()
+	switch runtime.GOOS {
+	case "darwin":
+		return []string{"/System/Library/Fonts", "/Library/Fonts", filepath.Join(home, "Library", "Fonts")}
+	case "windows":
+		winDir := os.Getenv("WINDIR")
+		if winDir == "" {
+			winDir = `C:\Windows`
+		}
+		return []string{filepath.Join(winDir, "Fonts"), filepath.Join(home, "AppData", "Local", "Microsoft", "Windows", "Fonts")}
+	default:
+		return []string{"/usr/share/fonts", "/usr/local/share/fonts", filepath.Join(home, ".fonts"), filepath.Join(home, ".local", "share", "fonts")}
+	}
+}
+
+// normalizeFontName strips spaces/hyphens/underscores and lowercases name, since font file names
+// rarely match the family name's exact spacing or casing (e.g. "JetBrains Mono" ships as
+// "JetBrainsMono-Regular.ttf").
+func normalizeFontName(name string) string {
+	var b strings.Builder
+	for _, r := range name {
+		if r == ' ' || r == '-' || r == '_' {
+			continue
+		}
+		b.WriteRune(r)
+	}
+	return strings.ToLower(b.String())
+}
+
+// fontFileMatches reports whether fileBaseName (a font file's name, without its extension) looks
+// like it's the given (already-normalized) target font family.
+func fontFileMatches(target string, fileBaseName string) bool {
+	if target == "" {
+		return false
+	}
+	return strings.HasPrefix(normalizeFontName(fileBaseName), target)
+}
+
+var fontFileExts = map[string]bool{".ttf": true, ".otf": true, ".ttc": true}
+
+// IsFontInstalled reports whether family looks available: either it's one of BundledFonts, or a
+// same-named .ttf/.otf/.ttc file is found under one of fontDirs.
+func IsFontInstalled(family string) bool {
+	if family == "" {
+		return false
+	}
+	if IsBundled(family) {
+		return true
+	}
+	target := normalizeFontName(family)
+	for _, dir := range fontDirs() {
+		found := false
+		filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+			if err != nil || found || d.IsDir() {
+				return nil
+			}
+			if !fontFileExts[strings.ToLower(filepath.Ext(path))] {
+				return nil
+			}
+			base := strings.TrimSuffix(filepath.Base(path), filepath.Ext(path))
+			if fontFileMatches(target, base) {
+				found = true
+			}
+			return nil
+		})
+		if found {
+			return true
+		}
+	}
+	return false
+}
+
+// FontStatus is one entry in CheckFonts' result.
+type FontStatus struct {
+	Family    string
+	Installed bool
+	Bundled   bool
+}
+
+// CheckFonts reports the installed/bundled status of each requested font family, in order,
+// skipping blank entries.
+func CheckFonts(families []string) []FontStatus {
+	rtn := make([]FontStatus, 0, len(families))
+	for _, family := range families {
+		if family == "" {
+			continue
+		}
+		rtn = append(rtn, FontStatus{
+			Family:    family,
+			Installed: IsFontInstalled(family),
+			Bundled:   IsBundled(family),
+		})
+	}
+	return rtn
+}
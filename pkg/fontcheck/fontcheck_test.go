@@ -0,0 +1,67 @@
+// Copyright 2026, Command Line Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package fontcheck
+
+import "testing"
+
+func TestNormalizeFontName(t *testing.T) {
+	cases := map[string]string{
+		"JetBrains Mono":      "jetbrainsmono",
+		"Hack Nerd Font Mono": "hacknerdfontmono",
+		"fira-code_regular":   "firacoderegular",
+		"AlreadyLower":        "alreadylower",
+	}
+	for input, want := range cases {
+		if got := normalizeFontName(input); got != want {
+			t.Errorf("normalizeFontName(%q) = %q, want %q", input, got, want)
+		}
+	}
+}
+
+func TestFontFileMatches(t *testing.T) {
+	target := normalizeFontName("JetBrains Mono")
+	if !fontFileMatches(target, "JetBrainsMono-Regular") {
+		t.Error("expected JetBrainsMono-Regular to match JetBrains Mono")
+	}
+	if fontFileMatches(target, "Hack-Regular") {
+		t.Error("did not expect Hack-Regular to match JetBrains Mono")
+	}
+	if fontFileMatches("", "JetBrainsMono-Regular") {
+		t.Error("empty target should never match")
+	}
+}
+
+func TestIsBundled(t *testing.T) {
+	if !IsBundled("Hack Nerd Font Mono") {
+		t.Error("expected Hack Nerd Font Mono to be bundled")
+	}
+	if !IsBundled("hack nerd font mono") {
+		t.Error("IsBundled should be case-insensitive")
+	}
+	if IsBundled("Comic Sans MS") {
+		t.Error("did not expect Comic Sans MS to be bundled")
+	}
+}
+
+func TestIsFontInstalledBundled(t *testing.T) {
+	if !IsFontInstalled("Martian Mono") {
+		t.Error("bundled fonts should always report installed")
+	}
+	if IsFontInstalled("") {
+		t.Error("empty family should never report installed")
+	}
+}
+
+func TestCheckFonts(t *testing.T) {
+	results := CheckFonts([]string{"Martian Mono", "", "Some Font Nobody Has Installed XYZ"})
+	if len(results) != 2 {
+		t.Fatalf("expected 2 results (blank skipped), got %d", len(results))
+	}
+	if !results[0].Installed || !results[0].Bundled {
+		t.Errorf("expected Martian Mono to be installed and bundled, got %+v", results[0])
+	}
+	if results[1].Bundled {
+		t.Errorf("did not expect %q to be bundled", results[1].Family)
+	}
+}
@@ -0,0 +1,251 @@
+// Copyright 2024, Command Line Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+// Package histsync implements an optional, Atuin-inspired history sync client: every record is
+// end-to-end encrypted with a key derived from the user's sync:key passphrase before it ever
+// leaves the machine, and the server never sees plaintext commands. Merging is conflict-free
+// because records are immutable and addressed by a stable historyid -- both push and pull are
+// just set operations (push what's missing on the server, pull what's missing locally), the same
+// property that lets Atuin itself sync safely from multiple machines at once.
+//
+// This is not wire-compatible with atuin.sh's own server: reproducing their actual protocol
+// would mean implementing their SRP-based registration/login flow and exact request/response
+// shapes, which is out of scope here. Instead this talks to a plain HTTP endpoint (a
+// Wave-hosted equivalent) using a simpler push/pull API that preserves Atuin's important
+// properties -- E2E encryption, conflict-free id-based merging, per-device filtering.
+package histsync
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/wavetermdev/waveterm/pkg/wconfig"
+	"github.com/wavetermdev/waveterm/pkg/wstore"
+	"golang.org/x/crypto/nacl/secretbox"
+	"golang.org/x/crypto/scrypt"
+)
+
+// scrypt parameters for deriveKey. N/r/p match scrypt's own recommended "interactive" settings
+// (RFC 7914 section 2), appropriate here since deriveKey runs at most once per Push/Pull call,
+// not in a hot loop.
+const (
+	scryptN      = 1 << 15
+	scryptR      = 8
+	scryptP      = 1
+	scryptKeyLen = 32
+)
+
+// encryptedRecord is what actually crosses the wire -- everything except the id/device/ts
+// envelope is opaque to the server.
+type encryptedRecord struct {
+	HistoryId string `json:"historyid"`
+	DeviceId  string `json:"deviceid"`
+	Ts        int64  `json:"ts"`
+	Blob      string `json:"blob"` // base64(24-byte nonce || secretbox-sealed plainRecord JSON)
+}
+
+type plainRecord struct {
+	BlockId    string `json:"blockid"`
+	Connection string `json:"connection"`
+	Cwd        string `json:"cwd"`
+	CmdStr     string `json:"cmdstr"`
+	ExitCode   int    `json:"exitcode"`
+	DurationMs int64  `json:"durationms"`
+}
+
+// deriveKey hardens passphrase into a symmetric key with scrypt. The salt is derived from
+// serverURL (settings.SyncServerURL) rather than anything device-specific: every device syncing
+// to the same server with the same sync:key must derive the identical key to decrypt each
+// other's pushed records, so the salt has to be a value all of them already share, not a fresh
+// per-install one. It isn't secret -- scrypt's salt never needs to be -- it just has to vary
+// across independent sync setups, which a server URL already does in practice.
+func deriveKey(passphrase string, serverURL string) ([32]byte, error) {
+	var key [32]byte
+	salt := []byte("wave-histsync-v1:" + serverURL)
+	derived, err := scrypt.Key([]byte(passphrase), salt, scryptN, scryptR, scryptP, scryptKeyLen)
+	if err != nil {
+		return key, fmt.Errorf("error deriving sync key: %w", err)
+	}
+	copy(key[:], derived)
+	return key, nil
+}
+
+func encryptItem(key [32]byte, item wstore.CmdHistoryItem) (encryptedRecord, error) {
+	plain := plainRecord{
+		BlockId:    item.BlockId,
+		Connection: item.Connection,
+		Cwd:        item.Cwd,
+		CmdStr:     item.CmdStr,
+		ExitCode:   item.ExitCode,
+		DurationMs: item.DurationMs,
+	}
+	plainBytes, err := json.Marshal(plain)
+	if err != nil {
+		return encryptedRecord{}, err
+	}
+	var nonce [24]byte
+	if _, err := rand.Read(nonce[:]); err != nil {
+		return encryptedRecord{}, err
+	}
+	sealed := secretbox.Seal(nonce[:], plainBytes, &nonce, &key)
+	return encryptedRecord{
+		HistoryId: item.HistoryId,
+		DeviceId:  item.DeviceId,
+		Ts:        item.Ts,
+		Blob:      base64.StdEncoding.EncodeToString(sealed),
+	}, nil
+}
+
+func decryptRecord(key [32]byte, rec encryptedRecord) (wstore.CmdHistoryItem, error) {
+	sealed, err := base64.StdEncoding.DecodeString(rec.Blob)
+	if err != nil {
+		return wstore.CmdHistoryItem{}, fmt.Errorf("invalid blob encoding: %w", err)
+	}
+	if len(sealed) < 24 {
+		return wstore.CmdHistoryItem{}, fmt.Errorf("blob too short")
+	}
+	var nonce [24]byte
+	copy(nonce[:], sealed[:24])
+	plainBytes, ok := secretbox.Open(nil, sealed[24:], &nonce, &key)
+	if !ok {
+		return wstore.CmdHistoryItem{}, fmt.Errorf("decryption failed (wrong sync:key?)")
+	}
+	var plain plainRecord
+	if err := json.Unmarshal(plainBytes, &plain); err != nil {
+		return wstore.CmdHistoryItem{}, fmt.Errorf("error unmarshaling decrypted record: %w", err)
+	}
+	return wstore.CmdHistoryItem{
+		HistoryId:  rec.HistoryId,
+		Ts:         rec.Ts,
+		DeviceId:   rec.DeviceId,
+		BlockId:    plain.BlockId,
+		Connection: plain.Connection,
+		Cwd:        plain.Cwd,
+		CmdStr:     plain.CmdStr,
+		ExitCode:   plain.ExitCode,
+		DurationMs: plain.DurationMs,
+	}, nil
+}
+
+func syncSettings() (wconfig.SettingsType, error) {
+	settings := wconfig.GetWatcher().GetFullConfig().Settings
+	if !settings.SyncEnabled {
+		return settings, fmt.Errorf("history sync is not enabled (set sync:enabled)")
+	}
+	if settings.SyncServerURL == "" || settings.SyncKey == "" {
+		return settings, fmt.Errorf("sync:serverurl and sync:key must both be set")
+	}
+	return settings, nil
+}
+
+var httpClient = &http.Client{Timeout: 15 * time.Second}
+
+// Push uploads this device's not-yet-synced history items and returns how many were pushed.
+func Push(ctx context.Context) (int, error) {
+	settings, err := syncSettings()
+	if err != nil {
+		return 0, err
+	}
+	deviceId, err := wstore.GetLocalDeviceId(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("error getting local device id: %w", err)
+	}
+	items, err := wstore.GetUnsyncedCmdHistory(ctx, deviceId, 0)
+	if err != nil {
+		return 0, fmt.Errorf("error reading unsynced history: %w", err)
+	}
+	if len(items) == 0 {
+		return 0, nil
+	}
+	key, err := deriveKey(settings.SyncKey, settings.SyncServerURL)
+	if err != nil {
+		return 0, err
+	}
+	records := make([]encryptedRecord, 0, len(items))
+	historyIds := make([]string, 0, len(items))
+	for _, item := range items {
+		rec, err := encryptItem(key, item)
+		if err != nil {
+			return 0, fmt.Errorf("error encrypting history item: %w", err)
+		}
+		records = append(records, rec)
+		historyIds = append(historyIds, item.HistoryId)
+	}
+	body, err := json.Marshal(records)
+	if err != nil {
+		return 0, fmt.Errorf("error marshaling sync push body: %w", err)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, settings.SyncServerURL+"/sync/history", bytes.NewReader(body))
+	if err != nil {
+		return 0, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return 0, fmt.Errorf("error pushing history: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= 300 {
+		respBody, _ := io.ReadAll(resp.Body)
+		return 0, fmt.Errorf("sync server returned %d: %s", resp.StatusCode, string(respBody))
+	}
+	if err := wstore.MarkCmdHistorySynced(ctx, historyIds); err != nil {
+		return 0, fmt.Errorf("error marking history synced: %w", err)
+	}
+	return len(items), nil
+}
+
+// Pull fetches history items newer than what's stored locally and merges them in (ignoring any
+// whose historyid we already have), returning how many new items were added.
+func Pull(ctx context.Context) (int, error) {
+	settings, err := syncSettings()
+	if err != nil {
+		return 0, err
+	}
+	sinceTs, err := wstore.GetMaxCmdHistoryTs(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("error getting local sync cursor: %w", err)
+	}
+	url := settings.SyncServerURL + "/sync/history?since=" + strconv.FormatInt(sinceTs, 10)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return 0, err
+	}
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return 0, fmt.Errorf("error pulling history: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= 300 {
+		respBody, _ := io.ReadAll(resp.Body)
+		return 0, fmt.Errorf("sync server returned %d: %s", resp.StatusCode, string(respBody))
+	}
+	var records []encryptedRecord
+	if err := json.NewDecoder(resp.Body).Decode(&records); err != nil {
+		return 0, fmt.Errorf("error decoding sync pull response: %w", err)
+	}
+	key, err := deriveKey(settings.SyncKey, settings.SyncServerURL)
+	if err != nil {
+		return 0, err
+	}
+	added := 0
+	for _, rec := range records {
+		item, err := decryptRecord(key, rec)
+		if err != nil {
+			return added, fmt.Errorf("error decrypting pulled record %s: %w", rec.HistoryId, err)
+		}
+		if err := wstore.AddSyncedCmdHistoryItem(ctx, item); err != nil {
+			return added, fmt.Errorf("error merging pulled record %s: %w", rec.HistoryId, err)
+		}
+		added++
+	}
+	return added, nil
+}
@@ -0,0 +1,169 @@
+// Copyright 2024, Command Line Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+// Package hooks implements user-defined webhook and script hooks that fire on
+// command lifecycle events (command started, command failed, connection dropped,
+// AI response finished).
+package hooks
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/wavetermdev/waveterm/pkg/panichandler"
+	"github.com/wavetermdev/waveterm/pkg/wavebase"
+)
+
+const HooksFile = "hooks.json"
+
+const (
+	Event_CommandStarted    = "command:started"
+	Event_CommandFailed     = "command:failed"
+	Event_CommandDone       = "command:done"
+	Event_ConnectionDropped = "connection:dropped"
+	Event_AiResponseFinished = "ai:responsefinished"
+)
+
+type HookDef struct {
+	Event   string `json:"event"`
+	Type    string `json:"type"` // "script" or "webhook"
+	Command string `json:"command,omitempty"`
+	URL     string `json:"url,omitempty"`
+	Disabled bool  `json:"disabled,omitempty"`
+}
+
+func hooksFilePath() string {
+	return filepath.Join(wavebase.GetWaveConfigDir(), HooksFile)
+}
+
+func readHooks() ([]HookDef, error) {
+	barr, err := os.ReadFile(hooksFilePath())
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var hooks []HookDef
+	if err := json.Unmarshal(barr, &hooks); err != nil {
+		return nil, fmt.Errorf("parsing %s: %w", HooksFile, err)
+	}
+	return hooks, nil
+}
+
+func writeHooks(hooks []HookDef) error {
+	barr, err := json.MarshalIndent(hooks, "", "    ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(hooksFilePath(), barr, 0644)
+}
+
+// ListHooks returns the currently configured hooks.
+func ListHooks() ([]HookDef, error) {
+	return readHooks()
+}
+
+// AddHook appends a new hook to the hooks file.
+func AddHook(h HookDef) error {
+	existing, err := readHooks()
+	if err != nil {
+		return err
+	}
+	existing = append(existing, h)
+	return writeHooks(existing)
+}
+
+// RemoveHook removes the hook at the given index.
+func RemoveHook(index int) error {
+	existing, err := readHooks()
+	if err != nil {
+		return err
+	}
+	if index < 0 || index >= len(existing) {
+		return fmt.Errorf("hook index %d out of range (have %d hooks)", index, len(existing))
+	}
+	existing = append(existing[:index], existing[index+1:]...)
+	return writeHooks(existing)
+}
+
+// templatePayload does simple {{key}} substitution using the given payload values.
+func templatePayload(tmpl string, payload map[string]any) string {
+	rtn := tmpl
+	for k, v := range payload {
+		rtn = strings.ReplaceAll(rtn, "{{"+k+"}}", fmt.Sprintf("%v", v))
+	}
+	return rtn
+}
+
+// Fire runs all enabled hooks registered for the given event, passing payload
+// as template variables (for scripts, as env vars prefixed with WAVE_HOOK_; for
+// webhooks, as the JSON request body). Hooks run asynchronously and best-effort;
+// errors are logged, not returned.
+func Fire(event string, payload map[string]any) {
+	hooks, err := readHooks()
+	if err != nil {
+		log.Printf("hooks: error reading %s: %v\n", HooksFile, err)
+		return
+	}
+	for _, h := range hooks {
+		if h.Disabled || h.Event != event {
+			continue
+		}
+		hookCopy := h
+		go func() {
+			defer func() {
+				panichandler.PanicHandler("hooks:Fire", recover())
+			}()
+			runHook(hookCopy, payload)
+		}()
+	}
+}
+
+func runHook(h HookDef, payload map[string]any) {
+	switch h.Type {
+	case "script":
+		runScriptHook(h, payload)
+	case "webhook":
+		runWebhookHook(h, payload)
+	default:
+		log.Printf("hooks: unknown hook type %q for event %q\n", h.Type, h.Event)
+	}
+}
+
+func runScriptHook(h HookDef, payload map[string]any) {
+	cmd := exec.Command("sh", "-c", h.Command)
+	env := os.Environ()
+	for k, v := range payload {
+		env = append(env, fmt.Sprintf("WAVE_HOOK_%s=%v", strings.ToUpper(k), v))
+	}
+	env = append(env, "WAVE_HOOK_EVENT="+h.Event)
+	cmd.Env = env
+	if err := cmd.Run(); err != nil {
+		log.Printf("hooks: script hook for event %q failed: %v\n", h.Event, err)
+	}
+}
+
+func runWebhookHook(h HookDef, payload map[string]any) {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		log.Printf("hooks: error marshaling payload for event %q: %v\n", h.Event, err)
+		return
+	}
+	url := templatePayload(h.URL, payload)
+	client := &http.Client{Timeout: 5 * time.Second}
+	resp, err := client.Post(url, "application/json", bytes.NewReader(body))
+	if err != nil {
+		log.Printf("hooks: webhook for event %q failed: %v\n", h.Event, err)
+		return
+	}
+	resp.Body.Close()
+}
@@ -0,0 +1,37 @@
+// Copyright 2026, Command Line Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package i18n
+
+import "testing"
+
+func TestTDefaultLocale(t *testing.T) {
+	got := T(DefaultLocale, "a11y.command_exited", 1)
+	want := "command exited 1"
+	if got != want {
+		t.Errorf("T(%q, ...) = %q, want %q", DefaultLocale, got, want)
+	}
+}
+
+func TestTOtherLocale(t *testing.T) {
+	got := T("es", "a11y.command_exited", 1)
+	want := "comando terminado con código 1"
+	if got != want {
+		t.Errorf("T(\"es\", ...) = %q, want %q", got, want)
+	}
+}
+
+func TestTFallsBackToDefaultLocale(t *testing.T) {
+	got := T("fr", "a11y.command_exited", 2)
+	want := "command exited 2"
+	if got != want {
+		t.Errorf("T(\"fr\", ...) = %q, want %q (should fall back to %s)", got, want, DefaultLocale)
+	}
+}
+
+func TestTMissingKeyReturnsKey(t *testing.T) {
+	got := T(DefaultLocale, "no.such.key")
+	if got != "no.such.key" {
+		t.Errorf("T with missing key = %q, want the key itself", got)
+	}
+}
@@ -0,0 +1,98 @@
+// Copyright 2026, Command Line Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package idlelock
+
+import (
+	"testing"
+	"time"
+)
+
+func newTestManager(start time.Time) (*Manager, *time.Time) {
+	m := NewManager()
+	cur := start
+	m.now = func() time.Time { return cur }
+	m.lastActivity = start
+	return m, &cur
+}
+
+func TestLockAndUnlockNoPassphrase(t *testing.T) {
+	m, _ := newTestManager(time.Unix(0, 0))
+	m.Lock()
+	if !m.IsLocked() {
+		t.Fatalf("expected manager to be locked")
+	}
+	if err := m.Unlock(""); err != nil {
+		t.Fatalf("expected unlock with no configured passphrase to succeed, got %v", err)
+	}
+	if m.IsLocked() {
+		t.Errorf("expected manager to be unlocked")
+	}
+}
+
+func TestUnlockWrongPassphrase(t *testing.T) {
+	m, _ := newTestManager(time.Unix(0, 0))
+	if err := m.SetPassphrase("correct horse"); err != nil {
+		t.Fatalf("SetPassphrase failed: %v", err)
+	}
+	m.Lock()
+	if err := m.Unlock("wrong"); err == nil {
+		t.Errorf("expected wrong passphrase to fail to unlock")
+	}
+	if !m.IsLocked() {
+		t.Errorf("expected manager to remain locked after a failed unlock attempt")
+	}
+}
+
+func TestUnlockCorrectPassphrase(t *testing.T) {
+	m, _ := newTestManager(time.Unix(0, 0))
+	if err := m.SetPassphrase("correct horse"); err != nil {
+		t.Fatalf("SetPassphrase failed: %v", err)
+	}
+	m.Lock()
+	if err := m.Unlock("correct horse"); err != nil {
+		t.Errorf("expected correct passphrase to unlock, got %v", err)
+	}
+	if m.IsLocked() {
+		t.Errorf("expected manager to be unlocked")
+	}
+}
+
+func TestHasPassphrase(t *testing.T) {
+	m, _ := newTestManager(time.Unix(0, 0))
+	if m.HasPassphrase() {
+		t.Errorf("expected no passphrase configured initially")
+	}
+	m.SetPassphrase("hunter2")
+	if !m.HasPassphrase() {
+		t.Errorf("expected HasPassphrase to be true after SetPassphrase")
+	}
+	m.SetPassphrase("")
+	if m.HasPassphrase() {
+		t.Errorf("expected HasPassphrase to be false after clearing with an empty passphrase")
+	}
+}
+
+func TestTouchResetsIdleClock(t *testing.T) {
+	m, cur := newTestManager(time.Unix(0, 0))
+	*cur = cur.Add(10 * time.Minute)
+	if m.IdleFor() < 10*time.Minute {
+		t.Fatalf("expected 10 minutes idle, got %v", m.IdleFor())
+	}
+	m.Touch()
+	if m.IdleFor() != 0 {
+		t.Errorf("expected Touch to reset idle time to 0, got %v", m.IdleFor())
+	}
+}
+
+func TestShouldLock(t *testing.T) {
+	if ShouldLock(4*time.Minute, 5*time.Minute) {
+		t.Errorf("expected no lock before timeout elapses")
+	}
+	if !ShouldLock(6*time.Minute, 5*time.Minute) {
+		t.Errorf("expected lock after timeout elapses")
+	}
+	if ShouldLock(time.Hour, 0) {
+		t.Errorf("expected a non-positive timeout to disable idle locking")
+	}
+}
@@ -0,0 +1,140 @@
+// Copyright 2026, Command Line Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+// Package imageops implements the backend for the image preview block's edit toolbar (resize,
+// rotate, format conversion, EXIF strip). It uses only the standard library's image codecs to
+// avoid a native imaging dependency (no cgo, no vendored image-processing library), so resizing
+// uses a simple nearest-neighbor scale rather than a higher-quality resampling filter, and
+// rotation is limited to multiples of 90 degrees. EXIF and other metadata is stripped as a side
+// effect of decoding into an image.Image and re-encoding: Go's image/jpeg and image/png encoders
+// never write back metadata from the source file.
+package imageops
+
+import (
+	"bytes"
+	"fmt"
+	"image"
+	"image/gif"
+	"image/jpeg"
+	"image/png"
+)
+
+func init() {
+	// image.Decode needs these registered to recognize the formats by content sniffing
+	image.RegisterFormat("png", "\x89PNG\r\n\x1a\n", png.Decode, png.DecodeConfig)
+	image.RegisterFormat("jpeg", "\xff\xd8", jpeg.Decode, jpeg.DecodeConfig)
+	image.RegisterFormat("gif", "GIF8?a", gif.Decode, gif.DecodeConfig)
+}
+
+// Options describes the operations ProcessImage should apply, in order: rotate, then resize,
+// then encode to Format (or the source format if Format is blank).
+type Options struct {
+	RotateDegrees int // one of 0, 90, 180, 270 (other values are rounded down to the nearest)
+	Width         int // 0 means "don't resize" when Height is also 0; otherwise preserves aspect if one of the two is 0
+	Height        int
+	Format        string // "png", "jpeg", "gif"; blank keeps the source format
+	Quality       int    // jpeg quality, 1-100; 0 means use jpeg's default
+}
+
+// ProcessImage decodes data, applies opts, and re-encodes it, returning the result bytes and the
+// output mime type.
+func ProcessImage(data []byte, opts Options) ([]byte, string, error) {
+	img, format, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, "", fmt.Errorf("cannot decode image: %w", err)
+	}
+
+	if opts.RotateDegrees != 0 {
+		img = rotate(img, opts.RotateDegrees)
+	}
+	if opts.Width > 0 || opts.Height > 0 {
+		img = resize(img, opts.Width, opts.Height)
+	}
+
+	outFormat := opts.Format
+	if outFormat == "" {
+		outFormat = format
+	}
+	return encode(img, outFormat, opts.Quality)
+}
+
+func encode(img image.Image, format string, quality int) ([]byte, string, error) {
+	var buf bytes.Buffer
+	switch format {
+	case "jpeg", "jpg":
+		q := quality
+		if q <= 0 {
+			q = jpeg.DefaultQuality
+		}
+		if err := jpeg.Encode(&buf, img, &jpeg.Options{Quality: q}); err != nil {
+			return nil, "", err
+		}
+		return buf.Bytes(), "image/jpeg", nil
+	case "gif":
+		if err := gif.Encode(&buf, img, nil); err != nil {
+			return nil, "", err
+		}
+		return buf.Bytes(), "image/gif", nil
+	case "png":
+		fallthrough
+	default:
+		if err := png.Encode(&buf, img); err != nil {
+			return nil, "", err
+		}
+		return buf.Bytes(), "image/png", nil
+	}
+}
+
+// resize performs a nearest-neighbor scale to the given width/height. If one dimension is 0, it
+// is computed to preserve the source's aspect ratio.
+func resize(img image.Image, width, height int) image.Image {
+	srcBounds := img.Bounds()
+	srcW, srcH := srcBounds.Dx(), srcBounds.Dy()
+	if srcW == 0 || srcH == 0 {
+		return img
+	}
+	if width <= 0 {
+		width = int(float64(height) * float64(srcW) / float64(srcH))
+	}
+	if height <= 0 {
+		height = int(float64(width) * float64(srcH) / float64(srcW))
+	}
+	if width <= 0 || height <= 0 {
+		return img
+	}
+
+	dst := image.NewRGBA(image.Rect(0, 0, width, height))
+	for y := 0; y < height; y++ {
+		srcY := srcBounds.Min.Y + y*srcH/height
+		for x := 0; x < width; x++ {
+			srcX := srcBounds.Min.X + x*srcW/width
+			dst.Set(x, y, img.At(srcX, srcY))
+		}
+	}
+	return dst
+}
+
+// rotate rotates img clockwise by degrees, rounded down to the nearest multiple of 90.
+func rotate(img image.Image, degrees int) image.Image {
+	steps := (degrees / 90) % 4
+	if steps < 0 {
+		steps += 4
+	}
+	for i := 0; i < steps; i++ {
+		img = rotate90(img)
+	}
+	return img
+}
+
+func rotate90(img image.Image) image.Image {
+	b := img.Bounds()
+	w, h := b.Dx(), b.Dy()
+	dst := image.NewRGBA(image.Rect(0, 0, h, w))
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; x++ {
+			c := img.At(b.Min.X+x, b.Min.Y+y)
+			dst.Set(h-1-y, x, c)
+		}
+	}
+	return dst
+}
@@ -0,0 +1,71 @@
+// Copyright 2026, Command Line Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package imageops
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"image/png"
+	"testing"
+)
+
+func makeTestPng(w, h int, c color.Color) []byte {
+	img := image.NewRGBA(image.Rect(0, 0, w, h))
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; x++ {
+			img.Set(x, y, c)
+		}
+	}
+	var buf bytes.Buffer
+	png.Encode(&buf, img)
+	return buf.Bytes()
+}
+
+func TestProcessImageResize(t *testing.T) {
+	src := makeTestPng(10, 20, color.RGBA{255, 0, 0, 255})
+	out, mimeType, err := ProcessImage(src, Options{Width: 5, Height: 10})
+	if err != nil {
+		t.Fatalf("ProcessImage returned error: %v", err)
+	}
+	if mimeType != "image/png" {
+		t.Fatalf("expected image/png, got %s", mimeType)
+	}
+	img, _, err := image.Decode(bytes.NewReader(out))
+	if err != nil {
+		t.Fatalf("cannot decode result: %v", err)
+	}
+	if img.Bounds().Dx() != 5 || img.Bounds().Dy() != 10 {
+		t.Fatalf("expected 5x10, got %dx%d", img.Bounds().Dx(), img.Bounds().Dy())
+	}
+}
+
+func TestProcessImageRotate90(t *testing.T) {
+	src := makeTestPng(10, 20, color.RGBA{0, 255, 0, 255})
+	out, _, err := ProcessImage(src, Options{RotateDegrees: 90})
+	if err != nil {
+		t.Fatalf("ProcessImage returned error: %v", err)
+	}
+	img, _, err := image.Decode(bytes.NewReader(out))
+	if err != nil {
+		t.Fatalf("cannot decode result: %v", err)
+	}
+	if img.Bounds().Dx() != 20 || img.Bounds().Dy() != 10 {
+		t.Fatalf("expected 20x10 after 90-degree rotation, got %dx%d", img.Bounds().Dx(), img.Bounds().Dy())
+	}
+}
+
+func TestProcessImageConvertFormat(t *testing.T) {
+	src := makeTestPng(4, 4, color.RGBA{0, 0, 255, 255})
+	out, mimeType, err := ProcessImage(src, Options{Format: "jpeg"})
+	if err != nil {
+		t.Fatalf("ProcessImage returned error: %v", err)
+	}
+	if mimeType != "image/jpeg" {
+		t.Fatalf("expected image/jpeg, got %s", mimeType)
+	}
+	if _, _, err := image.Decode(bytes.NewReader(out)); err != nil {
+		t.Fatalf("result is not a valid jpeg: %v", err)
+	}
+}
@@ -0,0 +1,235 @@
+// Copyright 2026, Command Line Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+// Package jobqueue implements a lightweight per-connection job queue: commands enqueued for a
+// connection run sequentially (or with limited concurrency, see SetConcurrency) independently of
+// whatever else is happening in that connection's blocks, by calling
+// pkg/wshrpc/wshremote.RemoteRunCommandCommand routed to the connection. Queue state is in-memory
+// only and does not survive a wavesrv restart. A job that is already running cannot be
+// interrupted by Cancel since the underlying RPC call is synchronous; only queued jobs can be
+// cancelled before a worker picks them up.
+package jobqueue
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/wavetermdev/waveterm/pkg/wshrpc"
+	"github.com/wavetermdev/waveterm/pkg/wshrpc/wshclient"
+	"github.com/wavetermdev/waveterm/pkg/wshrpc/wshserver"
+	"github.com/wavetermdev/waveterm/pkg/wshutil"
+)
+
+const (
+	Status_Queued    = "queued"
+	Status_Running   = "running"
+	Status_Done      = "done"
+	Status_Failed    = "failed"
+	Status_Cancelled = "cancelled"
+)
+
+// DefaultConcurrency is how many of a connection's jobs run at once until SetConcurrency is
+// called for it -- 1 keeps jobs strictly sequential, matching the common batch-maintenance case.
+const DefaultConcurrency = 1
+
+// Job is a single queued or completed command on a connection. All fields besides Id, Connection,
+// CmdStr, and CreatedTs are only safe to read via List/Get (which return a snapshot taken under
+// the job's lock) since a worker goroutine mutates them while the job runs.
+type Job struct {
+	mu sync.Mutex
+
+	Id         string `json:"id"`
+	Connection string `json:"connection"`
+	CmdStr     string `json:"cmdstr"`
+	Status     string `json:"status"`
+	Output     string `json:"output,omitempty"`
+	ExitCode   int    `json:"exitcode,omitempty"`
+	Err        string `json:"err,omitempty"`
+	CreatedTs  int64  `json:"createdts"`
+	StartTs    int64  `json:"startts,omitempty"`
+	EndTs      int64  `json:"endts,omitempty"`
+}
+
+func (j *Job) snapshot() *Job {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	return &Job{
+		Id:         j.Id,
+		Connection: j.Connection,
+		CmdStr:     j.CmdStr,
+		Status:     j.Status,
+		Output:     j.Output,
+		ExitCode:   j.ExitCode,
+		Err:        j.Err,
+		CreatedTs:  j.CreatedTs,
+		StartTs:    j.StartTs,
+		EndTs:      j.EndTs,
+	}
+}
+
+type connQueue struct {
+	mu          sync.Mutex
+	queued      []*Job
+	concurrency int
+	running     int
+}
+
+var jobsMu sync.Mutex
+var allJobs = make(map[string]*Job)
+var queues = make(map[string]*connQueue)
+var nextJobNum int64
+
+func getQueue(connection string) *connQueue {
+	jobsMu.Lock()
+	defer jobsMu.Unlock()
+	q, ok := queues[connection]
+	if !ok {
+		q = &connQueue{concurrency: DefaultConcurrency}
+		queues[connection] = q
+	}
+	return q
+}
+
+// SetConcurrency sets how many jobs may run at once on connection (minimum 1).
+func SetConcurrency(connection string, concurrency int) {
+	if concurrency < 1 {
+		concurrency = 1
+	}
+	q := getQueue(connection)
+	q.mu.Lock()
+	q.concurrency = concurrency
+	q.mu.Unlock()
+	kickWorkers(connection, q)
+}
+
+func genJobId(connection string) string {
+	jobsMu.Lock()
+	defer jobsMu.Unlock()
+	nextJobNum++
+	return fmt.Sprintf("%s-job%d", connection, nextJobNum)
+}
+
+// Enqueue adds cmdStr to connection's job queue and returns the new Job, which may still be
+// Status_Queued by the time this returns (a worker picks it up asynchronously).
+func Enqueue(connection string, cmdStr string) *Job {
+	job := &Job{
+		Id:         genJobId(connection),
+		Connection: connection,
+		CmdStr:     cmdStr,
+		Status:     Status_Queued,
+		CreatedTs:  time.Now().UnixMilli(),
+	}
+	jobsMu.Lock()
+	allJobs[job.Id] = job
+	jobsMu.Unlock()
+	q := getQueue(connection)
+	q.mu.Lock()
+	q.queued = append(q.queued, job)
+	q.mu.Unlock()
+	kickWorkers(connection, q)
+	return job
+}
+
+// kickWorkers starts a goroutine per queued job up to connection's concurrency limit.
+func kickWorkers(connection string, q *connQueue) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	for q.running < q.concurrency && len(q.queued) > 0 {
+		job := q.queued[0]
+		q.queued = q.queued[1:]
+		if job.snapshot().Status == Status_Cancelled {
+			continue
+		}
+		q.running++
+		go runJob(connection, q, job)
+	}
+}
+
+func runJob(connection string, q *connQueue, job *Job) {
+	defer func() {
+		q.mu.Lock()
+		q.running--
+		q.mu.Unlock()
+		kickWorkers(connection, q)
+	}()
+	job.mu.Lock()
+	job.Status = Status_Running
+	job.StartTs = time.Now().UnixMilli()
+	job.mu.Unlock()
+	client := wshserver.GetMainRpcClient()
+	connRoute := wshutil.MakeConnectionRouteId(connection)
+	rtn, err := wshclient.RemoteRunCommandCommand(client, wshrpc.CommandRemoteRunCommandData{CmdStr: job.CmdStr}, &wshrpc.RpcOpts{Route: connRoute})
+	job.mu.Lock()
+	defer job.mu.Unlock()
+	job.EndTs = time.Now().UnixMilli()
+	if err != nil {
+		job.Status = Status_Failed
+		job.Err = err.Error()
+		return
+	}
+	job.Output = rtn.Output
+	job.ExitCode = rtn.ExitCode
+	if rtn.ExitCode != 0 {
+		job.Status = Status_Failed
+	} else {
+		job.Status = Status_Done
+	}
+}
+
+// List returns a snapshot of every job for connection, oldest first. An empty connection lists
+// jobs across all connections.
+func List(connection string) []*Job {
+	jobsMu.Lock()
+	matches := make([]*Job, 0, len(allJobs))
+	for _, job := range allJobs {
+		if connection != "" && job.Connection != connection {
+			continue
+		}
+		matches = append(matches, job)
+	}
+	jobsMu.Unlock()
+	rtn := make([]*Job, len(matches))
+	for idx, job := range matches {
+		rtn[idx] = job.snapshot()
+	}
+	sort.Slice(rtn, func(i, j int) bool { return rtn[i].CreatedTs < rtn[j].CreatedTs })
+	return rtn
+}
+
+// Cancel marks jobId as cancelled if it hasn't started running yet. It returns an error if the
+// job doesn't exist or has already started (running jobs run to completion; there's no way to
+// interrupt the synchronous RemoteRunCommandCommand call underneath them).
+func Cancel(jobId string) error {
+	jobsMu.Lock()
+	job, ok := allJobs[jobId]
+	jobsMu.Unlock()
+	if !ok {
+		return fmt.Errorf("job %q not found", jobId)
+	}
+	job.mu.Lock()
+	defer job.mu.Unlock()
+	if job.Status != Status_Queued {
+		return fmt.Errorf("job %q is %s, not queued -- only a queued job can be cancelled", jobId, job.Status)
+	}
+	job.Status = Status_Cancelled
+	job.EndTs = time.Now().UnixMilli()
+	return nil
+}
+
+// Retry re-enqueues a copy of jobId's command as a new job and returns it. It returns an error if
+// the job doesn't exist or is still queued/running.
+func Retry(jobId string) (*Job, error) {
+	jobsMu.Lock()
+	job, ok := allJobs[jobId]
+	jobsMu.Unlock()
+	if !ok {
+		return nil, fmt.Errorf("job %q not found", jobId)
+	}
+	snap := job.snapshot()
+	if snap.Status == Status_Queued || snap.Status == Status_Running {
+		return nil, fmt.Errorf("job %q is still %s", jobId, snap.Status)
+	}
+	return Enqueue(snap.Connection, snap.CmdStr), nil
+}
@@ -0,0 +1,200 @@
+// Copyright 2026, Command Line Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+// Package lspproxy launches a language server as a subprocess and bridges its stdio-framed
+// JSON-RPC (the wire protocol used by the Language Server Protocol) to Go callers as simple
+// request/notify calls. It knows nothing about LSP method names or payload shapes -- callers
+// (wshremote's Lsp*Command handlers) are responsible for those.
+package lspproxy
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os/exec"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+)
+
+type rpcMessage struct {
+	JsonRpc string          `json:"jsonrpc"`
+	Id      *int64          `json:"id,omitempty"`
+	Method  string          `json:"method,omitempty"`
+	Params  json.RawMessage `json:"params,omitempty"`
+	Result  json.RawMessage `json:"result,omitempty"`
+	Error   *rpcError       `json:"error,omitempty"`
+}
+
+type rpcError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+// Server is a running language server process with a framed JSON-RPC connection over its
+// stdin/stdout. It is safe for concurrent use.
+type Server struct {
+	cmd    *exec.Cmd
+	stdin  io.WriteCloser
+	nextId int64
+
+	mu      sync.Mutex
+	pending map[int64]chan rpcMessage
+	closed  bool
+}
+
+// Start launches command (via "sh -c", so it may include arguments/pipes like the rest of the
+// wsh shell-command helpers) and begins bridging its stdio. The process keeps running until
+// Close is called.
+func Start(command string) (*Server, error) {
+	cmd := exec.Command("sh", "-c", command)
+	stdin, err := cmd.StdinPipe()
+	if err != nil {
+		return nil, fmt.Errorf("creating stdin pipe for lsp command %q: %w", command, err)
+	}
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return nil, fmt.Errorf("creating stdout pipe for lsp command %q: %w", command, err)
+	}
+	if err := cmd.Start(); err != nil {
+		return nil, fmt.Errorf("starting lsp command %q: %w", command, err)
+	}
+	s := &Server{
+		cmd:     cmd,
+		stdin:   stdin,
+		pending: make(map[int64]chan rpcMessage),
+	}
+	go s.readLoop(bufio.NewReader(stdout))
+	return s, nil
+}
+
+func (s *Server) readLoop(r *bufio.Reader) {
+	for {
+		msg, err := readFramedMessage(r)
+		if err != nil {
+			s.failAllPending(err)
+			return
+		}
+		if msg.Id == nil {
+			// server->client request or notification; this proxy only supports the
+			// client-initiated request/response flow, so these are dropped
+			continue
+		}
+		s.mu.Lock()
+		ch := s.pending[*msg.Id]
+		delete(s.pending, *msg.Id)
+		s.mu.Unlock()
+		if ch != nil {
+			ch <- msg
+		}
+	}
+}
+
+func (s *Server) failAllPending(err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.closed = true
+	for id, ch := range s.pending {
+		ch <- rpcMessage{Error: &rpcError{Message: err.Error()}}
+		delete(s.pending, id)
+	}
+}
+
+func readFramedMessage(r *bufio.Reader) (rpcMessage, error) {
+	contentLength := -1
+	for {
+		line, err := r.ReadString('\n')
+		if err != nil {
+			return rpcMessage{}, err
+		}
+		line = strings.TrimRight(line, "\r\n")
+		if line == "" {
+			break
+		}
+		if name, value, ok := strings.Cut(line, ":"); ok && strings.EqualFold(strings.TrimSpace(name), "Content-Length") {
+			contentLength, err = strconv.Atoi(strings.TrimSpace(value))
+			if err != nil {
+				return rpcMessage{}, fmt.Errorf("parsing Content-Length header %q: %w", value, err)
+			}
+		}
+	}
+	if contentLength < 0 {
+		return rpcMessage{}, fmt.Errorf("lsp message missing Content-Length header")
+	}
+	body := make([]byte, contentLength)
+	if _, err := io.ReadFull(r, body); err != nil {
+		return rpcMessage{}, err
+	}
+	var msg rpcMessage
+	if err := json.Unmarshal(body, &msg); err != nil {
+		return rpcMessage{}, fmt.Errorf("decoding lsp message: %w", err)
+	}
+	return msg, nil
+}
+
+func (s *Server) writeMessage(msg rpcMessage) error {
+	msg.JsonRpc = "2.0"
+	body, err := json.Marshal(msg)
+	if err != nil {
+		return fmt.Errorf("encoding lsp message: %w", err)
+	}
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "Content-Length: %d\r\n\r\n", len(body))
+	buf.Write(body)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.closed {
+		return fmt.Errorf("lsp server is closed")
+	}
+	_, err = s.stdin.Write(buf.Bytes())
+	return err
+}
+
+// Request sends a JSON-RPC request and blocks for its response, returning the raw "result" field.
+func (s *Server) Request(method string, params any) (json.RawMessage, error) {
+	paramsJson, err := json.Marshal(params)
+	if err != nil {
+		return nil, fmt.Errorf("encoding params for %q: %w", method, err)
+	}
+	id := atomic.AddInt64(&s.nextId, 1)
+	respCh := make(chan rpcMessage, 1)
+	s.mu.Lock()
+	if s.closed {
+		s.mu.Unlock()
+		return nil, fmt.Errorf("lsp server is closed")
+	}
+	s.pending[id] = respCh
+	s.mu.Unlock()
+	if err := s.writeMessage(rpcMessage{Id: &id, Method: method, Params: paramsJson}); err != nil {
+		s.mu.Lock()
+		delete(s.pending, id)
+		s.mu.Unlock()
+		return nil, err
+	}
+	resp := <-respCh
+	if resp.Error != nil {
+		return nil, fmt.Errorf("lsp error from %q: %s", method, resp.Error.Message)
+	}
+	return resp.Result, nil
+}
+
+// Notify sends a JSON-RPC notification (no response expected), e.g. textDocument/didOpen.
+func (s *Server) Notify(method string, params any) error {
+	paramsJson, err := json.Marshal(params)
+	if err != nil {
+		return fmt.Errorf("encoding params for %q: %w", method, err)
+	}
+	return s.writeMessage(rpcMessage{Method: method, Params: paramsJson})
+}
+
+// Close terminates the language server process.
+func (s *Server) Close() error {
+	s.mu.Lock()
+	s.closed = true
+	s.mu.Unlock()
+	s.stdin.Close()
+	return s.cmd.Process.Kill()
+}
@@ -0,0 +1,63 @@
+// Copyright 2026, Command Line Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package lspproxy
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+// echoServerCmd is a tiny fake language server: it reads one Content-Length-framed JSON-RPC
+// request from stdin and replies with a response that echoes back the request's params as its
+// result, framed the same way.
+const echoServerCmd = `python3 -c "
+import sys
+def read_msg():
+    headers = {}
+    while True:
+        line = sys.stdin.readline()
+        if line in ('\r\n', '\n', ''):
+            break
+        k, v = line.split(':', 1)
+        headers[k.strip().lower()] = v.strip()
+    body = sys.stdin.read(int(headers['content-length']))
+    return body
+import json
+body = read_msg()
+req = json.loads(body)
+resp = json.dumps({'jsonrpc': '2.0', 'id': req['id'], 'result': req['params']})
+sys.stdout.write('Content-Length: %d\r\n\r\n%s' % (len(resp), resp))
+sys.stdout.flush()
+"`
+
+func TestRequestRoundTrip(t *testing.T) {
+	server, err := Start(echoServerCmd)
+	if err != nil {
+		t.Fatalf("Start failed: %v", err)
+	}
+	defer server.Close()
+
+	result, err := server.Request("textDocument/hover", map[string]any{"hello": "world"})
+	if err != nil {
+		t.Fatalf("Request failed: %v", err)
+	}
+	var decoded map[string]string
+	if err := json.Unmarshal(result, &decoded); err != nil {
+		t.Fatalf("decoding result: %v", err)
+	}
+	if decoded["hello"] != "world" {
+		t.Fatalf("expected echoed params, got %v", decoded)
+	}
+}
+
+func TestRequestAfterClose(t *testing.T) {
+	server, err := Start("sh -c 'exit 0'")
+	if err != nil {
+		t.Fatalf("Start failed: %v", err)
+	}
+	server.Close()
+	if _, err := server.Request("textDocument/hover", map[string]any{}); err == nil {
+		t.Fatalf("expected error requesting on a closed server")
+	}
+}
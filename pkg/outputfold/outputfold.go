@@ -0,0 +1,27 @@
+// Copyright 2026, Command Line Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+// Package outputfold decides whether a command's output should be shown collapsed (a summary
+// line with a count of hidden lines) in the frontend. Fold state is tracked per "cmd" type
+// block -- one command per block, see blockcontroller.BlockController_Cmd -- the same scoping
+// a11ystream's command-boundary announcements use, since interactive shell blocks mix many
+// commands into one continuous pty stream with no boundary information to fold around.
+package outputfold
+
+// State is the computed fold state for a single command's output.
+type State struct {
+	LineCount      int  `json:"linecount"`
+	Folded         bool `json:"folded"`
+	ManualOverride bool `json:"manualoverride,omitempty"` // true if Folded came from the user's manual override rather than the auto-fold threshold
+}
+
+// ComputeFoldState decides whether output should be folded: manualFolded, if set, always wins;
+// otherwise output auto-folds once lineCount exceeds autoFoldLines (autoFoldLines <= 0 disables
+// auto-fold).
+func ComputeFoldState(lineCount int, manualFolded *bool, autoFoldLines int64) State {
+	if manualFolded != nil {
+		return State{LineCount: lineCount, Folded: *manualFolded, ManualOverride: true}
+	}
+	folded := autoFoldLines > 0 && int64(lineCount) > autoFoldLines
+	return State{LineCount: lineCount, Folded: folded}
+}
@@ -0,0 +1,41 @@
+// Copyright 2026, Command Line Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package outputfold
+
+import "testing"
+
+func TestComputeFoldStateAutoFoldDisabled(t *testing.T) {
+	state := ComputeFoldState(500, nil, 0)
+	if state.Folded || state.ManualOverride {
+		t.Errorf("expected no auto-fold when autoFoldLines is 0, got %+v", state)
+	}
+}
+
+func TestComputeFoldStateAutoFoldBelowThreshold(t *testing.T) {
+	state := ComputeFoldState(10, nil, 100)
+	if state.Folded {
+		t.Errorf("expected no fold below threshold, got %+v", state)
+	}
+}
+
+func TestComputeFoldStateAutoFoldAboveThreshold(t *testing.T) {
+	state := ComputeFoldState(101, nil, 100)
+	if !state.Folded || state.ManualOverride {
+		t.Errorf("expected auto-fold above threshold, got %+v", state)
+	}
+}
+
+func TestComputeFoldStateManualOverrideWins(t *testing.T) {
+	unfolded := false
+	state := ComputeFoldState(1000, &unfolded, 10)
+	if state.Folded || !state.ManualOverride {
+		t.Errorf("expected manual override to keep output unfolded, got %+v", state)
+	}
+
+	folded := true
+	state = ComputeFoldState(1, &folded, 0)
+	if !state.Folded || !state.ManualOverride {
+		t.Errorf("expected manual override to fold output, got %+v", state)
+	}
+}
@@ -13,6 +13,10 @@ import (
 // gets around import cycles
 var PanicTelemetryHandler func()
 
+// to write a structured crash report (stack, version, recent log tail) to the local crash
+// directory, gets around import cycles
+var CrashReportHandler func(debugStr string, recoverVal any)
+
 func PanicHandlerNoTelemetry(debugStr string, recoverVal any) {
 	if recoverVal == nil {
 		return
@@ -34,6 +38,12 @@ func PanicHandler(debugStr string, recoverVal any) error {
 			PanicTelemetryHandler()
 		}()
 	}
+	if CrashReportHandler != nil {
+		go func() {
+			defer PanicHandlerNoTelemetry("CrashReportHandler", recover())
+			CrashReportHandler(debugStr, recoverVal)
+		}()
+	}
 	if err, ok := recoverVal.(error); ok {
 		return fmt.Errorf("panic in %s: %w", debugStr, err)
 	}
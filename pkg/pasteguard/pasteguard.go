@@ -0,0 +1,57 @@
+// Copyright 2026, Command Line Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+// Package pasteguard inspects text about to be written into a terminal for patterns that are
+// easy to paste by accident but risky to run unreviewed: multi-line content (which can silently
+// queue up several commands behind the one the user meant to run), a "sudo" invocation, and the
+// "curl/wget ... | sh" shape of piping a remote script straight into a shell. It only flags --
+// it has no opinion on what happens next (block, warn, log); that's left to the caller (see
+// blockcontroller's checkPasteSafety, the one place in this tree that writes arbitrary pasted
+// text to a pty).
+package pasteguard
+
+import (
+	"regexp"
+	"strings"
+)
+
+// Reason is a stable identifier for why Inspect flagged a paste, so callers (and tests) can
+// switch on it instead of pattern-matching human-readable text.
+type Reason string
+
+const (
+	ReasonMultiLine Reason = "multiline"
+	ReasonSudo      Reason = "sudo"
+	ReasonPipeToSh  Reason = "pipetoshell"
+)
+
+var sudoRe = regexp.MustCompile(`(^|[;&|\n]|\s)sudo(\s|$)`)
+var pipeToShRe = regexp.MustCompile(`(?i)(curl|wget)\b[^|\n]*\|\s*(sudo\s+)?(sh|bash|zsh|ksh)\b`)
+
+// Verdict is the result of inspecting a chunk of pasted text.
+type Verdict struct {
+	Flagged bool     `json:"flagged"`
+	Reasons []Reason `json:"reasons,omitempty"`
+}
+
+// Inspect flags text containing an embedded newline (anything other than a single trailing one,
+// which is just the normal shape of a one-line paste ending in Enter), a "sudo" invocation, or a
+// "curl/wget ... | sh" remote-script pipe.
+func Inspect(text string) Verdict {
+	var reasons []Reason
+	if hasEmbeddedNewline(text) {
+		reasons = append(reasons, ReasonMultiLine)
+	}
+	if sudoRe.MatchString(text) {
+		reasons = append(reasons, ReasonSudo)
+	}
+	if pipeToShRe.MatchString(text) {
+		reasons = append(reasons, ReasonPipeToSh)
+	}
+	return Verdict{Flagged: len(reasons) > 0, Reasons: reasons}
+}
+
+func hasEmbeddedNewline(text string) bool {
+	trimmed := strings.TrimRight(text, "\r\n")
+	return strings.ContainsAny(trimmed, "\n")
+}
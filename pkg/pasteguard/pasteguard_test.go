@@ -0,0 +1,59 @@
+// Copyright 2026, Command Line Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package pasteguard
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestInspectPlainSingleLine(t *testing.T) {
+	v := Inspect("ls -la\n")
+	if v.Flagged {
+		t.Errorf("expected a plain single-line command not to be flagged, got %+v", v)
+	}
+}
+
+func TestInspectMultiLine(t *testing.T) {
+	v := Inspect("echo one\necho two\n")
+	if !v.Flagged || !reflect.DeepEqual(v.Reasons, []Reason{ReasonMultiLine}) {
+		t.Errorf("expected multiline paste to be flagged with ReasonMultiLine, got %+v", v)
+	}
+}
+
+func TestInspectSudo(t *testing.T) {
+	v := Inspect("sudo rm -rf /tmp/build\n")
+	if !v.Flagged || !reflect.DeepEqual(v.Reasons, []Reason{ReasonSudo}) {
+		t.Errorf("expected sudo command to be flagged with ReasonSudo, got %+v", v)
+	}
+}
+
+func TestInspectSudoSubstringNotFlagged(t *testing.T) {
+	v := Inspect("pseudoscience\n")
+	if v.Flagged {
+		t.Errorf("expected 'pseudoscience' not to match the sudo pattern, got %+v", v)
+	}
+}
+
+func TestInspectPipeToShell(t *testing.T) {
+	v := Inspect("curl -fsSL https://example.com/install.sh | sh\n")
+	if !v.Flagged || !reflect.DeepEqual(v.Reasons, []Reason{ReasonPipeToSh}) {
+		t.Errorf("expected curl-pipe-to-sh to be flagged with ReasonPipeToSh, got %+v", v)
+	}
+}
+
+func TestInspectMultipleReasons(t *testing.T) {
+	v := Inspect("curl -fsSL https://example.com/install.sh | sudo bash\nsudo reboot\n")
+	if !v.Flagged {
+		t.Fatalf("expected paste to be flagged, got %+v", v)
+	}
+	want := map[Reason]bool{ReasonMultiLine: true, ReasonSudo: true, ReasonPipeToSh: true}
+	got := map[Reason]bool{}
+	for _, r := range v.Reasons {
+		got[r] = true
+	}
+	if !reflect.DeepEqual(want, got) {
+		t.Errorf("expected reasons %v, got %v", want, got)
+	}
+}
@@ -0,0 +1,99 @@
+// Copyright 2026, Command Line Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+// Package pdftext implements a minimal, dependency-free PDF text extractor used to back
+// in-document search in the preview block and (eventually) to give the AI attachment path a
+// plain-text alternative to sending the full PDF binary. There is no PDF parsing library
+// vendored in this tree and new dependencies can't be added here, so this does not handle the
+// full PDF spec: it recognizes uncompressed and FlateDecode-compressed content streams and
+// extracts the operands of the Tj/TJ/' /" text-show operators using the PDF standard encoding
+// (no embedded font remapping, CID fonts, or encrypted documents). This covers the large
+// majority of PDFs produced by ordinary export tools, which is enough to make search and AI
+// context usable even though it is not a complete PDF text layer.
+package pdftext
+
+import (
+	"bytes"
+	"compress/zlib"
+	"io"
+	"regexp"
+)
+
+// MaxExtractedTextBytes matches the AI attachment path's 200KB text-context limit.
+const MaxExtractedTextBytes = 200 * 1024
+
+var streamRe = regexp.MustCompile(`(?s)<<(.*?)>>\s*stream\r?\n(.*?)endstream`)
+var flateRe = regexp.MustCompile(`/Filter\s*/FlateDecode`)
+var showTextRe = regexp.MustCompile(`(?s)\((?:[^()\\]|\\.)*\)\s*(?:Tj|'|")|\[(?:[^\[\]]|\\.)*\]\s*TJ`)
+var parenStrRe = regexp.MustCompile(`(?s)\((?:[^()\\]|\\.)*\)`)
+
+// ExtractText scans data for PDF content streams and returns the concatenated text shown by
+// Tj/TJ/'/" operators, truncated to maxBytes (pass 0 for no limit).
+func ExtractText(data []byte, maxBytes int) (string, error) {
+	var out bytes.Buffer
+	for _, m := range streamRe.FindAllSubmatch(data, -1) {
+		dict := m[1]
+		raw := m[2]
+		content := raw
+		if flateRe.Match(dict) {
+			decoded, err := inflate(raw)
+			if err != nil {
+				// not a real content stream (e.g. a compressed font or image) -- skip it
+				continue
+			}
+			content = decoded
+		}
+		extractFromContentStream(content, &out)
+		if maxBytes > 0 && out.Len() >= maxBytes {
+			break
+		}
+	}
+	text := out.String()
+	if maxBytes > 0 && len(text) > maxBytes {
+		text = text[:maxBytes]
+	}
+	return text, nil
+}
+
+func inflate(data []byte) ([]byte, error) {
+	zr, err := zlib.NewReader(bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+	defer zr.Close()
+	return io.ReadAll(zr)
+}
+
+func extractFromContentStream(content []byte, out *bytes.Buffer) {
+	for _, show := range showTextRe.FindAll(content, -1) {
+		for _, lit := range parenStrRe.FindAll(show, -1) {
+			out.WriteString(unescapePdfString(lit[1 : len(lit)-1]))
+			out.WriteString(" ")
+		}
+	}
+	out.WriteString("\n")
+}
+
+func unescapePdfString(s []byte) string {
+	var out bytes.Buffer
+	for i := 0; i < len(s); i++ {
+		if s[i] == '\\' && i+1 < len(s) {
+			i++
+			switch s[i] {
+			case 'n':
+				out.WriteByte('\n')
+			case 'r':
+				out.WriteByte('\r')
+			case 't':
+				out.WriteByte('\t')
+			case '(', ')', '\\':
+				out.WriteByte(s[i])
+			default:
+				out.WriteByte(s[i])
+			}
+			continue
+		}
+		out.WriteByte(s[i])
+	}
+	return out.String()
+}
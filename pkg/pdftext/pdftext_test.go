@@ -0,0 +1,39 @@
+// Copyright 2026, Command Line Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package pdftext
+
+import "testing"
+
+func TestExtractTextUncompressed(t *testing.T) {
+	pdf := []byte("1 0 obj\n<< /Length 44 >>\nstream\nBT /F1 12 Tf (Hello World) Tj ET\nendstream\nendobj\n")
+	text, err := ExtractText(pdf, 0)
+	if err != nil {
+		t.Fatalf("ExtractText returned error: %v", err)
+	}
+	if text != "Hello World \n" {
+		t.Fatalf("unexpected extracted text: %q", text)
+	}
+}
+
+func TestExtractTextTJArray(t *testing.T) {
+	pdf := []byte("<< /Length 10 >>\nstream\n[(Hel)-10(lo)] TJ\nendstream\n")
+	text, err := ExtractText(pdf, 0)
+	if err != nil {
+		t.Fatalf("ExtractText returned error: %v", err)
+	}
+	if text != "Hel lo \n" {
+		t.Fatalf("unexpected extracted text: %q", text)
+	}
+}
+
+func TestExtractTextTruncation(t *testing.T) {
+	pdf := []byte("<< /Length 20 >>\nstream\n(AAAAAAAAAAAAAAAAAAAAAAAAAAAAAA) Tj\nendstream\n")
+	text, err := ExtractText(pdf, 5)
+	if err != nil {
+		t.Fatalf("ExtractText returned error: %v", err)
+	}
+	if len(text) != 5 {
+		t.Fatalf("expected truncated text of length 5, got %d (%q)", len(text), text)
+	}
+}
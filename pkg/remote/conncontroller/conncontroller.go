@@ -93,7 +93,7 @@ func GetNumSSHHasConnected() int {
 func (conn *SSHConn) DeriveConnStatus() wshrpc.ConnStatus {
 	conn.Lock.Lock()
 	defer conn.Lock.Unlock()
-	return wshrpc.ConnStatus{
+	status := wshrpc.ConnStatus{
 		Status:        conn.Status,
 		Connected:     conn.Status == Status_Connected,
 		WshEnabled:    conn.WshEnabled.Load(),
@@ -103,6 +103,23 @@ func (conn *SSHConn) DeriveConnStatus() wshrpc.ConnStatus {
 		Error:         conn.Error,
 		WshError:      conn.WshError,
 	}
+	if throughput, ok := getConnThroughput(conn.GetName()); ok {
+		status.InteractiveBytes = throughput.InteractiveBytes
+		status.BulkBytes = throughput.BulkBytes
+	}
+	return status
+}
+
+// getConnThroughput looks up the live wsh rpc route for a connection (registered once the
+// connserver on the other end of the ssh session announces itself) and reports its per-lane byte
+// counters (see pkg/wshutil/wshoutputsched.go). Returns ok=false before wsh has connected.
+func getConnThroughput(connName string) (wshutil.ChannelThroughput, bool) {
+	rpc := wshutil.DefaultRouter.GetRpc(wshutil.MakeConnectionRouteId(connName))
+	wshRpc, ok := rpc.(*wshutil.WshRpc)
+	if !ok || wshRpc == nil {
+		return wshutil.ChannelThroughput{}, false
+	}
+	return wshRpc.GetThroughput(), true
 }
 
 func (conn *SSHConn) FireConnChangeEvent() {
@@ -225,9 +242,12 @@ func (conn *SSHConn) StartConnServer() error {
 	}
 	client := conn.GetClient()
 	wshPath := remote.GetWshPath(client)
+	sysinfoSampleRateMs, sysinfoRetention := wconfig.GetWatcher().GetFullConfig().ResolveSysinfoConfig(conn.GetName())
 	rpcCtx := wshrpc.RpcContext{
-		ClientType: wshrpc.ClientType_ConnServer,
-		Conn:       conn.GetName(),
+		ClientType:          wshrpc.ClientType_ConnServer,
+		Conn:                conn.GetName(),
+		SysinfoSampleRateMs: sysinfoSampleRateMs,
+		SysinfoRetention:    sysinfoRetention,
 	}
 	sockName := conn.GetDomainSocketName()
 	jwtToken, err := wshutil.MakeClientJWTToken(rpcCtx, sockName)
@@ -701,6 +721,49 @@ func resolveSshConfigPatterns(configFiles []string) ([]string, error) {
 	return discoveredPatterns, nil
 }
 
+// ImportConcurrency caps how many hosts are resolved against the ssh config at once. Resolving a
+// host's keywords touches the filesystem (ssh_config's UserSettings re-parses IdentityFile globs,
+// etc.), so on a config with hundreds of hosts this keeps the importer from either serializing
+// all of that I/O or spawning an unbounded number of goroutines.
+const ImportConcurrency = 8
+
+// ImportSshConfigConcurrent resolves the full ConnKeywords for each given host pattern using a
+// bounded worker pool, so a large ssh config (hundreds of hosts, e.g. from an Include directive
+// pulling in a team-wide config) doesn't import serially. A pattern whose keywords fail to
+// resolve is silently omitted from the result -- the worst case is that host falls back to
+// connection defaults, which is the same behavior a sequential importer would have for an
+// unresolvable entry.
+func ImportSshConfigConcurrent(patterns []string) map[string]*wshrpc.ConnKeywords {
+	results := make(map[string]*wshrpc.ConnKeywords, len(patterns))
+	var resultsMu sync.Mutex
+	patternCh := make(chan string)
+	var wg sync.WaitGroup
+	for i := 0; i < ImportConcurrency; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			defer func() {
+				panichandler.PanicHandler("ImportSshConfigConcurrent:worker", recover())
+			}()
+			for pattern := range patternCh {
+				keywords, err := remote.FindSshConfigKeywords(pattern)
+				if err != nil {
+					continue
+				}
+				resultsMu.Lock()
+				results[pattern] = keywords
+				resultsMu.Unlock()
+			}
+		}()
+	}
+	for _, pattern := range patterns {
+		patternCh <- pattern
+	}
+	close(patternCh)
+	wg.Wait()
+	return results
+}
+
 func GetConnectionsList() ([]string, error) {
 	existing := GetAllConnStatus()
 	var currentlyRunning []string
@@ -0,0 +1,132 @@
+// Copyright 2024, Command Line Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package conncontroller
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log"
+	"net"
+	"strings"
+	"sync"
+
+	"github.com/google/uuid"
+	"github.com/wavetermdev/waveterm/pkg/panichandler"
+	"github.com/wavetermdev/waveterm/pkg/remote"
+	"github.com/wavetermdev/waveterm/pkg/wshrpc"
+	"golang.org/x/crypto/ssh"
+)
+
+type portForward struct {
+	info     wshrpc.ConnPortForwardInfo
+	listener net.Listener
+}
+
+var portForwardLock = &sync.Mutex{}
+var portForwardMap = make(map[string]*portForward)
+
+// OpenPortForward opens a local TCP listener on 127.0.0.1 that tunnels accepted
+// connections to remoteHost:remotePort over the given SSH connection's client.
+func OpenPortForward(ctx context.Context, connName string, remoteHost string, remotePort int32) (wshrpc.ConnPortForwardInfo, error) {
+	if remoteHost == "" {
+		remoteHost = "localhost"
+	}
+	if connName == wshrpc.LocalConnName {
+		return wshrpc.ConnPortForwardInfo{}, fmt.Errorf("port forwarding is not needed for local connections")
+	}
+	if strings.HasPrefix(connName, "wsl://") {
+		return wshrpc.ConnPortForwardInfo{}, fmt.Errorf("port forwarding is not supported for wsl connections")
+	}
+	connOpts, err := remote.ParseOpts(connName)
+	if err != nil {
+		return wshrpc.ConnPortForwardInfo{}, fmt.Errorf("error parsing connection name: %w", err)
+	}
+	if err := EnsureConnection(ctx, connName); err != nil {
+		return wshrpc.ConnPortForwardInfo{}, fmt.Errorf("error connecting: %w", err)
+	}
+	conn := GetConn(ctx, connOpts, false, &wshrpc.ConnKeywords{})
+	if conn == nil {
+		return wshrpc.ConnPortForwardInfo{}, fmt.Errorf("connection not found: %s", connName)
+	}
+	client := conn.GetClient()
+	if client == nil {
+		return wshrpc.ConnPortForwardInfo{}, fmt.Errorf("connection %s is not connected", connName)
+	}
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return wshrpc.ConnPortForwardInfo{}, fmt.Errorf("cannot open local listener: %w", err)
+	}
+	localPort := listener.Addr().(*net.TCPAddr).Port
+	pf := &portForward{
+		info: wshrpc.ConnPortForwardInfo{
+			ForwardId:  uuid.NewString(),
+			Connection: connName,
+			LocalPort:  int32(localPort),
+			RemoteHost: remoteHost,
+			RemotePort: remotePort,
+		},
+		listener: listener,
+	}
+	portForwardLock.Lock()
+	portForwardMap[pf.info.ForwardId] = pf
+	portForwardLock.Unlock()
+	remoteAddr := fmt.Sprintf("%s:%d", remoteHost, remotePort)
+	go runPortForwardAcceptLoop(pf, client, remoteAddr)
+	return pf.info, nil
+}
+
+func runPortForwardAcceptLoop(pf *portForward, client *ssh.Client, remoteAddr string) {
+	defer panichandler.PanicHandler("runPortForwardAcceptLoop", recover())
+	for {
+		localConn, err := pf.listener.Accept()
+		if err != nil {
+			return
+		}
+		go proxyPortForwardConn(localConn, client, remoteAddr)
+	}
+}
+
+func proxyPortForwardConn(localConn net.Conn, client *ssh.Client, remoteAddr string) {
+	defer panichandler.PanicHandler("proxyPortForwardConn", recover())
+	defer localConn.Close()
+	remoteConn, err := client.Dial("tcp", remoteAddr)
+	if err != nil {
+		log.Printf("portforward: cannot dial %s: %v\n", remoteAddr, err)
+		return
+	}
+	defer remoteConn.Close()
+	var wg sync.WaitGroup
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		io.Copy(remoteConn, localConn)
+	}()
+	go func() {
+		defer wg.Done()
+		io.Copy(localConn, remoteConn)
+	}()
+	wg.Wait()
+}
+
+func ClosePortForward(forwardId string) error {
+	portForwardLock.Lock()
+	pf := portForwardMap[forwardId]
+	delete(portForwardMap, forwardId)
+	portForwardLock.Unlock()
+	if pf == nil {
+		return fmt.Errorf("port forward not found: %s", forwardId)
+	}
+	return pf.listener.Close()
+}
+
+func ListPortForwards() []wshrpc.ConnPortForwardInfo {
+	portForwardLock.Lock()
+	defer portForwardLock.Unlock()
+	rtn := make([]wshrpc.ConnPortForwardInfo, 0, len(portForwardMap))
+	for _, pf := range portForwardMap {
+		rtn = append(rtn, pf.info)
+	}
+	return rtn
+}
@@ -0,0 +1,158 @@
+// Copyright 2026, Command Line Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package conncontroller
+
+import (
+	"log"
+	"path/filepath"
+	"sort"
+	"sync"
+
+	"github.com/fsnotify/fsnotify"
+	"github.com/wavetermdev/waveterm/pkg/panichandler"
+	"github.com/wavetermdev/waveterm/pkg/wavebase"
+	"github.com/wavetermdev/waveterm/pkg/wps"
+)
+
+// sshconfigwatcher.go re-imports ~/.ssh/config in the background whenever it changes, so the
+// connections list (see GetConnectionsFromConfig) picks up edits made outside Wave (e.g. a host
+// added by another tool) without requiring the user to reopen the connection dropdown. A diff
+// summary is published rather than the full host list, since the frontend already holds the
+// prior list and only needs to know what changed.
+
+// SshConfigDiff summarizes how the set of host patterns discovered in ~/.ssh/config changed
+// between two imports.
+type SshConfigDiff struct {
+	Added   []string `json:"added,omitempty"`
+	Removed []string `json:"removed,omitempty"`
+}
+
+func (d SshConfigDiff) isEmpty() bool {
+	return len(d.Added) == 0 && len(d.Removed) == 0
+}
+
+type sshConfigWatcher struct {
+	mu           sync.Mutex
+	watcher      *fsnotify.Watcher
+	lastPatterns map[string]bool
+}
+
+var sshConfigWatcherInstance *sshConfigWatcher
+var sshConfigWatcherOnce sync.Once
+
+// GetSshConfigWatcher returns the singleton ssh config watcher, creating (but not starting) it.
+func GetSshConfigWatcher() *sshConfigWatcher {
+	sshConfigWatcherOnce.Do(func() {
+		watcher, err := fsnotify.NewWatcher()
+		if err != nil {
+			log.Printf("failed to create ssh config watcher: %v", err)
+			return
+		}
+		sshConfigWatcherInstance = &sshConfigWatcher{watcher: watcher}
+	})
+	return sshConfigWatcherInstance
+}
+
+// Start begins watching ~/.ssh/config for changes, running an initial import to seed the
+// baseline pattern set. Editors commonly replace a config file via rename-on-save rather than an
+// in-place write, so we watch the containing directory (like pkg/wconfig's file watcher does)
+// rather than the file itself, which wouldn't survive a rename.
+func (w *sshConfigWatcher) Start() {
+	if w == nil {
+		return
+	}
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	sshDir := filepath.Join(wavebase.GetHomeDir(), ".ssh")
+	if err := w.watcher.Add(sshDir); err != nil {
+		log.Printf("failed to add %s to ssh config watcher: %v", sshDir, err)
+		return
+	}
+	w.lastPatterns = patternSet(w.importPatterns())
+	go func() {
+		defer func() {
+			panichandler.PanicHandler("sshConfigWatcher:Start", recover())
+		}()
+		for {
+			select {
+			case event, ok := <-w.watcher.Events:
+				if !ok {
+					return
+				}
+				if filepath.Base(event.Name) != "config" {
+					continue
+				}
+				w.refresh()
+			case err, ok := <-w.watcher.Errors:
+				if !ok {
+					return
+				}
+				log.Println("ssh config watcher error:", err)
+			}
+		}
+	}()
+}
+
+func (w *sshConfigWatcher) Close() {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.watcher != nil {
+		w.watcher.Close()
+		w.watcher = nil
+	}
+}
+
+// importPatterns re-reads ~/.ssh/config and resolves every discovered host concurrently (see
+// ImportSshConfigConcurrent). The resolved keywords aren't used here -- only the pattern names
+// feed the diff -- but running the resolution now means it's warm in the ssh_config library's
+// internal cache by the time a connection to one of these hosts is actually opened.
+func (w *sshConfigWatcher) importPatterns() []string {
+	patterns, err := GetConnectionsFromConfig()
+	if err != nil {
+		log.Printf("ssh config watcher: %v", err)
+		return nil
+	}
+	ImportSshConfigConcurrent(patterns)
+	return patterns
+}
+
+func (w *sshConfigWatcher) refresh() {
+	w.mu.Lock()
+	newPatterns := patternSet(w.importPatterns())
+	diff := diffPatternSets(w.lastPatterns, newPatterns)
+	w.lastPatterns = newPatterns
+	w.mu.Unlock()
+	if diff.isEmpty() {
+		return
+	}
+	wps.Broker.Publish(wps.WaveEvent{
+		Event: wps.Event_SshConfigRefresh,
+		Data:  diff,
+	})
+}
+
+func patternSet(patterns []string) map[string]bool {
+	set := make(map[string]bool, len(patterns))
+	for _, pattern := range patterns {
+		set[pattern] = true
+	}
+	return set
+}
+
+func diffPatternSets(old map[string]bool, new map[string]bool) SshConfigDiff {
+	var diff SshConfigDiff
+	for pattern := range new {
+		if !old[pattern] {
+			diff.Added = append(diff.Added, pattern)
+		}
+	}
+	for pattern := range old {
+		if !new[pattern] {
+			diff.Removed = append(diff.Removed, pattern)
+		}
+	}
+	sort.Strings(diff.Added)
+	sort.Strings(diff.Removed)
+	return diff
+}
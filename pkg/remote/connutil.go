@@ -8,7 +8,6 @@ import (
 	"context"
 	"fmt"
 	"io"
-	"log"
 	"os"
 	"os/user"
 	"path/filepath"
@@ -19,10 +18,15 @@ import (
 	"github.com/wavetermdev/waveterm/pkg/genconn"
 	"github.com/wavetermdev/waveterm/pkg/util/shellutil"
 	"github.com/wavetermdev/waveterm/pkg/wavebase"
+	"github.com/wavetermdev/waveterm/pkg/wlog"
 	"golang.org/x/crypto/ssh"
 	"golang.org/x/mod/semver"
 )
 
+// remoteLog is the "remote" subsystem logger -- its level can be changed at runtime via
+// "wsh debug loglevel remote <level>".
+var remoteLog = wlog.New("remote")
+
 var userHostRe = regexp.MustCompile(`^([a-zA-Z0-9][a-zA-Z0-9._@\\-]*@)?([a-zA-Z0-9][a-zA-Z0-9.-]*)(?::([0-9]+))?$`)
 
 func ParseOpts(input string) (*SSHOpts, error) {
@@ -44,13 +48,13 @@ func DetectShell(client *ssh.Client) (string, error) {
 		return "", err
 	}
 
-	log.Printf("shell detecting using command: %s shell", wshPath)
+	remoteLog.Infof("shell detecting using command: %s shell", wshPath)
 	out, err := session.Output(wshPath + " shell")
 	if err != nil {
-		log.Printf("unable to determine shell. defaulting to /bin/bash: %s", err)
+		remoteLog.Warnf("unable to determine shell. defaulting to /bin/bash: %s", err)
 		return "/bin/bash", nil
 	}
-	log.Printf("detecting shell: %s", out)
+	remoteLog.Infof("detecting shell: %s", out)
 
 	return fmt.Sprintf(`"%s"`, strings.TrimSpace(string(out))), nil
 }
@@ -85,7 +89,7 @@ func GetWshPath(client *ssh.Client) string {
 	defaultPath := wavebase.RemoteFullWshBinPath
 	session, err := client.NewSession()
 	if err != nil {
-		log.Printf("unable to detect client's wsh path. using default. error: %v", err)
+		remoteLog.Warnf("unable to detect client's wsh path. using default. error: %v", err)
 		return defaultPath
 	}
 
@@ -96,7 +100,7 @@ func GetWshPath(client *ssh.Client) string {
 
 	session, err = client.NewSession()
 	if err != nil {
-		log.Printf("unable to detect client's wsh path. using default. error: %v", err)
+		remoteLog.Warnf("unable to detect client's wsh path. using default. error: %v", err)
 		return defaultPath
 	}
 
@@ -108,7 +112,7 @@ func GetWshPath(client *ssh.Client) string {
 	// check cmd on windows since it requires an absolute path with backslashes
 	session, err = client.NewSession()
 	if err != nil {
-		log.Printf("unable to detect client's wsh path. using default. error: %v", err)
+		remoteLog.Warnf("unable to detect client's wsh path. using default. error: %v", err)
 		return defaultPath
 	}
 
@@ -257,7 +261,7 @@ func CpWshToRemote(ctx context.Context, client *ssh.Client, clientOs string, cli
 
 func InstallClientRcFiles(client *ssh.Client) error {
 	path := GetWshPath(client)
-	log.Printf("path to wsh searched is: %s", path)
+	remoteLog.Infof("path to wsh searched is: %s", path)
 	session, err := client.NewSession()
 	if err != nil {
 		// this is a true error that should stop further progress
@@ -289,7 +293,7 @@ func IsPowershell(shellPath string) bool {
 func NormalizeConfigPattern(pattern string) string {
 	userName, err := WaveSshConfigUserSettings().GetStrict(pattern, "User")
 	if err != nil || userName == "" {
-		log.Printf("warning: error parsing username of %s for conn dropdown: %v", pattern, err)
+		remoteLog.Warnf("error parsing username of %s for conn dropdown: %v", pattern, err)
 		localUser, err := user.Current()
 		if err == nil {
 			userName = localUser.Username
@@ -10,13 +10,13 @@ import (
 	"crypto/rsa"
 	"encoding/base64"
 	"fmt"
-	"log"
 	"math"
 	"net"
 	"os"
 	"os/exec"
 	"os/user"
 	"path/filepath"
+	"regexp"
 	"strings"
 	"sync"
 	"time"
@@ -145,7 +145,7 @@ func createPublicKeyCallback(connCtx context.Context, sshKeywords *wshrpc.ConnKe
 		*identityFilesPtr = (*identityFilesPtr)[1:]
 		privateKey, ok := existingKeys[identityFile]
 		if !ok {
-			log.Printf("error with existingKeys, this should never happen")
+			remoteLog.Errorf("error with existingKeys, this should never happen")
 			// skip this key and try with the next
 			return createDummySigner()
 		}
@@ -534,7 +534,7 @@ func createHostKeyCallback(sshKeywords *wshrpc.ConnKeywords) (ssh.HostKeyCallbac
 				"**Offending Keys**  \n"+
 				"%s", key.Type(), correctKeyFingerprint, strings.Join(bulletListKnownHosts, "  \n"), strings.Join(offendingKeysFmt, "  \n"))
 
-			log.Print(errorMsg)
+			remoteLog.Errorf("%s", errorMsg)
 			//update := scbus.MakeUpdatePacket()
 			// create update into alert message
 
@@ -563,7 +563,7 @@ func createClientConfig(connCtx context.Context, sshKeywords *wshrpc.ConnKeyword
 	var agentClient agent.ExtendedAgent
 	conn, err := net.Dial("unix", utilfn.SafeDeref(sshKeywords.SshIdentityAgent))
 	if err != nil {
-		log.Printf("Failed to open Identity Agent Socket: %v", err)
+		remoteLog.Warnf("failed to open identity agent socket: %v", err)
 	} else {
 		agentClient = agent.NewClient(conn)
 		authSockSigners, _ = agentClient.Signers()
@@ -646,7 +646,7 @@ func ConnectToClient(connCtx context.Context, opts *SSHOpts, currentClient *ssh.
 		return nil, jumpNum, ConnectionError{ConnectionDebugInfo: debugInfo, Err: fmt.Errorf("ProxyJump %d exceeds Wave's max depth of %d", jumpNum, SshProxyJumpMaxDepth)}
 	}
 	// todo print final warning if logging gets turned off
-	sshConfigKeywords, err := findSshConfigKeywords(opts.SSHHost)
+	sshConfigKeywords, err := FindSshConfigKeywords(opts.SSHHost)
 	if err != nil {
 		return nil, debugInfo.JumpNum, ConnectionError{ConnectionDebugInfo: debugInfo, Err: err}
 	}
@@ -718,7 +718,7 @@ func ConnectToClient(connCtx context.Context, opts *SSHOpts, currentClient *ssh.
 // note that a `var == "yes"` will default to false
 // but `var != "no"` will default to true
 // when given unexpected strings
-func findSshConfigKeywords(hostPattern string) (connKeywords *wshrpc.ConnKeywords, outErr error) {
+func FindSshConfigKeywords(hostPattern string) (connKeywords *wshrpc.ConnKeywords, outErr error) {
 	defer func() {
 		panicErr := panichandler.PanicHandler("sshclient:find-ssh-config-keywords", recover())
 		if panicErr != nil {
@@ -820,7 +820,7 @@ func findSshConfigKeywords(hostPattern string) (connKeywords *wshrpc.ConnKeyword
 			}
 			sshKeywords.SshIdentityAgent = utilfn.Ptr(agentPath)
 		} else {
-			log.Printf("unable to find SSH_AUTH_SOCK: %v\n", err)
+			remoteLog.Warnf("unable to find SSH_AUTH_SOCK: %v", err)
 		}
 	} else {
 		agentPath, err := wavebase.ExpandHomeDir(trimquotes.TryTrimQuotes(identityAgentRaw))
@@ -850,6 +850,53 @@ func findSshConfigKeywords(hostPattern string) (connKeywords *wshrpc.ConnKeyword
 	return sshKeywords, nil
 }
 
+// waveOptionsCommentRe matches a "wave:tags=a,b notes=some text" comment trailing a Host line in
+// ssh_config, e.g. "Host prod-db  # wave:tags=env:prod,role:db notes=primary replica".
+var waveOptionsCommentRe = regexp.MustCompile(`(?:^|\s)wave:(\S*)(?:\s+notes=(.*))?$`)
+
+// ImportConnTagsFromSshConfig looks for a "# wave:tags=..." comment on hostPattern's Host line in
+// ~/.ssh/config and returns the tags and notes it specifies, for importing into connections.json
+// (see ConnKeywords.ConnTags/ConnNotes). It returns a nil/empty result, not an error, if
+// hostPattern has no Host block or no wave: comment.
+func ImportConnTagsFromSshConfig(hostPattern string) (tags []string, notes string, outErr error) {
+	defer func() {
+		panicErr := panichandler.PanicHandler("sshclient:import-conn-tags-from-ssh-config", recover())
+		if panicErr != nil {
+			outErr = panicErr
+		}
+	}()
+	configPath := filepath.Join(wavebase.GetHomeDir(), ".ssh", "config")
+	configBytes, err := os.ReadFile(configPath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, "", nil
+		}
+		return nil, "", err
+	}
+	cfg, err := ssh_config.DecodeBytes(configBytes, true)
+	if err != nil {
+		return nil, "", err
+	}
+	for _, host := range cfg.Hosts {
+		if host.EOLComment == "" || !host.Matches(hostPattern) {
+			continue
+		}
+		m := waveOptionsCommentRe.FindStringSubmatch(strings.TrimSpace(host.EOLComment))
+		if m == nil {
+			continue
+		}
+		tagsRaw := strings.TrimPrefix(m[1], "tags=")
+		for _, tag := range strings.Split(tagsRaw, ",") {
+			tag = strings.TrimSpace(tag)
+			if tag != "" {
+				tags = append(tags, tag)
+			}
+		}
+		notes = strings.TrimSpace(m[2])
+	}
+	return tags, notes, nil
+}
+
 type SSHOpts struct {
 	SSHHost string `json:"sshhost"`
 	SSHUser string `json:"sshuser"`
@@ -0,0 +1,54 @@
+// Copyright 2026, Command Line Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+// Package secretredact scans text for likely secrets (AWS access keys, JWTs, PEM private key
+// blocks) and replaces them with a placeholder, reporting how many matches were redacted per
+// rule. This tree has no unified "export output" or "record a session" subsystem to hook a
+// redaction pass into universally, so it's wired into the two genuine points where a block's
+// output leaves the block to go somewhere else: wshserver.SummarizeCommand (output attached to
+// an AI prompt) and wshserver.RedactTextCommand, a general-purpose utility the frontend can call
+// before its own copy/export/share actions.
+package secretredact
+
+import "regexp"
+
+// Rule pairs an identifier (used as the Report key) with the regex it matches.
+type Rule struct {
+	Id      string
+	Pattern *regexp.Regexp
+}
+
+const Placeholder = "[REDACTED]"
+
+// DefaultRules returns the built-in secret patterns: AWS access key IDs, AWS secret access keys
+// assigned to a recognizable variable name, JWTs, and PEM private key blocks.
+func DefaultRules() []Rule {
+	return []Rule{
+		{Id: "aws-access-key-id", Pattern: regexp.MustCompile(`\bAKIA[0-9A-Z]{16}\b`)},
+		{Id: "aws-secret-key", Pattern: regexp.MustCompile(`(?i)(aws_secret_access_key|secret_access_key)\s*[:=]\s*['"]?[A-Za-z0-9/+=]{40}['"]?`)},
+		{Id: "jwt", Pattern: regexp.MustCompile(`\bey[A-Za-z0-9_-]{10,}\.[A-Za-z0-9_-]{10,}\.[A-Za-z0-9_-]{10,}\b`)},
+		{Id: "private-key", Pattern: regexp.MustCompile(`(?s)-----BEGIN [A-Z ]*PRIVATE KEY-----.*?-----END [A-Z ]*PRIVATE KEY-----`)},
+	}
+}
+
+// Report counts how many redactions each rule made.
+type Report struct {
+	Counts map[string]int `json:"counts,omitempty"`
+	Total  int            `json:"total"`
+}
+
+// Redact replaces every match of rules in text with Placeholder, returning the redacted text
+// alongside a Report of what was found. An empty or nil rules slice returns text unchanged.
+func Redact(text string, rules []Rule) (string, Report) {
+	report := Report{Counts: make(map[string]int)}
+	for _, rule := range rules {
+		matches := rule.Pattern.FindAllStringIndex(text, -1)
+		if len(matches) == 0 {
+			continue
+		}
+		text = rule.Pattern.ReplaceAllString(text, Placeholder)
+		report.Counts[rule.Id] += len(matches)
+		report.Total += len(matches)
+	}
+	return text, report
+}
@@ -5,8 +5,14 @@ package blockservice
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
 	"encoding/json"
 	"fmt"
+	"sort"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/wavetermdev/waveterm/pkg/blockcontroller"
@@ -91,3 +97,121 @@ func (bs *BlockService) SaveWaveAiData(ctx context.Context, blockId string, hist
 	}
 	return nil
 }
+
+// MaxCodeEditCheckpoints caps how many snapshots are kept per file path, per block. Saving a new
+// checkpoint beyond this prunes the oldest ones first.
+const MaxCodeEditCheckpoints = 20
+
+// MaxCodeEditCheckpointSize skips checkpointing buffers larger than this rather than churning
+// through the filestore cache with multi-megabyte writes on every autosave interval.
+const MaxCodeEditCheckpointSize = 2 * 1024 * 1024 // 2MB
+
+// codeEditCheckpointPrefix returns the filestore name prefix shared by every checkpoint of path
+// within a block, so unrelated files previously opened in the same preview block (navigated to via
+// its file history) don't show up in each other's checkpoint list. The path itself isn't usable as
+// a filestore name (it can contain "/"), so it's hashed.
+func codeEditCheckpointPrefix(path string) string {
+	sum := sha256.Sum256([]byte(path))
+	return "checkpoint:" + hex.EncodeToString(sum[:])[:16] + ":"
+}
+
+// CodeEditCheckpointInfo describes one saved checkpoint, for populating a file's version history.
+type CodeEditCheckpointInfo struct {
+	Name string `json:"name"`
+	Ts   int64  `json:"ts"`
+	Size int64  `json:"size"`
+}
+
+func (*BlockService) SaveCodeEditCheckpoint_Meta() tsgenmeta.MethodMeta {
+	return tsgenmeta.MethodMeta{
+		Desc:     "save a snapshot of a codeedit buffer (keyed by file path) into the block's filestore zone, pruning old snapshots beyond MaxCodeEditCheckpoints",
+		ArgNames: []string{"ctx", "blockId", "path", "content64"},
+	}
+}
+
+// SaveCodeEditCheckpoint snapshots content64 (base64) as a new checkpoint of path within blockId's
+// filestore zone. The snapshot is local regardless of whether path lives on a remote connection,
+// since it's a copy of what Wave had buffered, not a remote operation. Oversized buffers are
+// silently skipped (not an error) so a large file being edited doesn't break autosave checkpointing
+// for the rest of the session.
+func (bs *BlockService) SaveCodeEditCheckpoint(ctx context.Context, blockId string, path string, content64 string) (string, error) {
+	_, err := wstore.DBMustGet[*waveobj.Block](ctx, blockId)
+	if err != nil {
+		return "", err
+	}
+	content, err := base64.StdEncoding.DecodeString(content64)
+	if err != nil {
+		return "", fmt.Errorf("cannot decode checkpoint content: %w", err)
+	}
+	if len(content) > MaxCodeEditCheckpointSize {
+		return "", nil
+	}
+	name := codeEditCheckpointPrefix(path) + strconv.FormatInt(time.Now().UnixMilli(), 10)
+	if err := filestore.WFS.MakeFile(ctx, blockId, name, filestore.FileMeta{"path": path}, filestore.FileOptsType{}); err != nil {
+		return "", fmt.Errorf("cannot create checkpoint: %w", err)
+	}
+	if err := filestore.WFS.WriteFile(ctx, blockId, name, content); err != nil {
+		return "", fmt.Errorf("cannot save checkpoint: %w", err)
+	}
+	if err := bs.pruneCodeEditCheckpoints(ctx, blockId, path); err != nil {
+		return "", fmt.Errorf("cannot prune old checkpoints: %w", err)
+	}
+	return name, nil
+}
+
+// pruneCodeEditCheckpoints deletes the oldest checkpoints of path in blockId beyond
+// MaxCodeEditCheckpoints.
+func (bs *BlockService) pruneCodeEditCheckpoints(ctx context.Context, blockId string, path string) error {
+	checkpoints, err := bs.ListCodeEditCheckpoints(ctx, blockId, path)
+	if err != nil {
+		return err
+	}
+	if len(checkpoints) <= MaxCodeEditCheckpoints {
+		return nil
+	}
+	for _, cp := range checkpoints[MaxCodeEditCheckpoints:] {
+		if err := filestore.WFS.DeleteFile(ctx, blockId, cp.Name); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (*BlockService) ListCodeEditCheckpoints_Meta() tsgenmeta.MethodMeta {
+	return tsgenmeta.MethodMeta{
+		Desc:     "list saved checkpoints of path within blockId's filestore zone, most recent first",
+		ArgNames: []string{"ctx", "blockId", "path"},
+	}
+}
+
+func (bs *BlockService) ListCodeEditCheckpoints(ctx context.Context, blockId string, path string) ([]CodeEditCheckpointInfo, error) {
+	files, err := filestore.WFS.ListFiles(ctx, blockId)
+	if err != nil {
+		return nil, fmt.Errorf("cannot list checkpoints: %w", err)
+	}
+	prefix := codeEditCheckpointPrefix(path)
+	var checkpoints []CodeEditCheckpointInfo
+	for _, file := range files {
+		if !strings.HasPrefix(file.Name, prefix) {
+			continue
+		}
+		checkpoints = append(checkpoints, CodeEditCheckpointInfo{Name: file.Name, Ts: file.CreatedTs, Size: file.Size})
+	}
+	sort.Slice(checkpoints, func(i, j int) bool { return checkpoints[i].Ts > checkpoints[j].Ts })
+	return checkpoints, nil
+}
+
+func (*BlockService) RestoreCodeEditCheckpoint_Meta() tsgenmeta.MethodMeta {
+	return tsgenmeta.MethodMeta{
+		Desc:     "return the base64 content of a previously saved checkpoint, for restoring into the editor buffer",
+		ArgNames: []string{"ctx", "blockId", "name"},
+	}
+}
+
+func (bs *BlockService) RestoreCodeEditCheckpoint(ctx context.Context, blockId string, name string) (string, error) {
+	_, content, err := filestore.WFS.ReadFile(ctx, blockId, name)
+	if err != nil {
+		return "", fmt.Errorf("cannot read checkpoint: %w", err)
+	}
+	return base64.StdEncoding.EncodeToString(content), nil
+}
@@ -7,22 +7,85 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
 	"time"
 
+	"github.com/google/uuid"
 	"github.com/wavetermdev/waveterm/pkg/filestore"
+	"github.com/wavetermdev/waveterm/pkg/trashutil"
 	"github.com/wavetermdev/waveterm/pkg/tsgen/tsgenmeta"
+	"github.com/wavetermdev/waveterm/pkg/wavebase"
 	"github.com/wavetermdev/waveterm/pkg/wconfig"
+	"github.com/wavetermdev/waveterm/pkg/wps"
 	"github.com/wavetermdev/waveterm/pkg/wshrpc"
 	"github.com/wavetermdev/waveterm/pkg/wshrpc/wshclient"
 	"github.com/wavetermdev/waveterm/pkg/wshrpc/wshserver"
 	"github.com/wavetermdev/waveterm/pkg/wshutil"
 )
 
+// DeleteUndoGracePeriod is how long a BulkFileOp delete on the local connection can be undone via
+// UndoBulkDelete before the deleted files are purged for good.
+const DeleteUndoGracePeriod = 15 * time.Second
+
 const MaxFileSize = 10 * 1024 * 1024 // 10M
 const DefaultTimeout = 2 * time.Second
 
 type FileService struct{}
 
+// resolveBwLimit returns the effective file copy/streaming bandwidth cap (bytes/sec; 0 means
+// unlimited) for connection, preferring a per-connection override over the global default. Both
+// are read fresh from the live config on every call, so an edit takes effect on the very next
+// file operation.
+func resolveBwLimit(connection string) int64 {
+	fullConfig := wconfig.GetWatcher().GetFullConfig()
+	if connKeywords, ok := fullConfig.Connections[connection]; ok && connKeywords.ConnBwLimitBps != nil {
+		return *connKeywords.ConnBwLimitBps
+	}
+	return fullConfig.Settings.FileBwLimitBps
+}
+
+// forcePermanentDelete reports whether file:forcepermanentdelete is set, read fresh from the live
+// config on every call.
+func forcePermanentDelete() bool {
+	return wconfig.GetWatcher().GetFullConfig().Settings.FileForcePermanentDelete
+}
+
+// DeleteMode describes how a delete on a connection will actually be carried out, for surfacing to
+// the user before they confirm a destructive action.
+type DeleteMode string
+
+const (
+	// DeleteModeTrash means the file will be moved to the OS trash (macOS Trash, the freedesktop.org
+	// trash on Linux, the Windows Recycle Bin) and can be recovered from there afterward.
+	DeleteModeTrash DeleteMode = "trash"
+	// DeleteModePermanent means the file is unlinked directly and cannot be recovered.
+	DeleteModePermanent DeleteMode = "permanent"
+)
+
+func (fs *FileService) DeleteModeForConnection_Meta() tsgenmeta.MethodMeta {
+	return tsgenmeta.MethodMeta{
+		Desc:     "report whether deleting a file on connection will go through the OS trash or be permanent, so the UI can show the right confirmation copy before the user deletes anything",
+		ArgNames: []string{"connection"},
+	}
+}
+
+// DeleteModeForConnection reports DeleteModeTrash only for the local connection, when this OS has a
+// trash implementation (see pkg/trashutil) and file:forcepermanentdelete is not set. A remote
+// connection's wsh helper has no desktop session to hand a file off to, so remote deletes are always
+// reported (and performed) as permanent.
+func (fs *FileService) DeleteModeForConnection(connection string) DeleteMode {
+	if connection != "" && connection != wshrpc.LocalConnName {
+		return DeleteModePermanent
+	}
+	if forcePermanentDelete() || !trashutil.IsSupported() {
+		return DeleteModePermanent
+	}
+	return DeleteModeTrash
+}
+
 type FullFile struct {
 	Info   *wshrpc.FileInfo `json:"info"`
 	Data64 string           `json:"data64"` // base64 encoded
@@ -30,18 +93,23 @@ type FullFile struct {
 
 func (fs *FileService) SaveFile_Meta() tsgenmeta.MethodMeta {
 	return tsgenmeta.MethodMeta{
-		Desc:     "save file",
-		ArgNames: []string{"connection", "path", "data64"},
+		Desc:     "save file, optionally failing with a conflict instead of overwriting if expectedModTime doesn't match the file's current mtime (pass 0 to skip the check)",
+		ArgNames: []string{"connection", "path", "data64", "expectedModTime"},
 	}
 }
 
-func (fs *FileService) SaveFile(connection string, path string, data64 string) error {
+func (fs *FileService) SaveFile(connection string, path string, data64 string, expectedModTime int64) (wshrpc.CommandRemoteFileWriteRtnData, error) {
 	if connection == "" {
 		connection = wshrpc.LocalConnName
 	}
 	connRoute := wshutil.MakeConnectionRouteId(connection)
 	client := wshserver.GetMainRpcClient()
-	writeData := wshrpc.CommandRemoteWriteFileData{Path: path, Data64: data64}
+	writeData := wshrpc.CommandRemoteWriteFileData{
+		Path:            path,
+		Data64:          data64,
+		BwLimit:         resolveBwLimit(connection),
+		ExpectedModTime: expectedModTime,
+	}
 	return wshclient.RemoteWriteFileCommand(client, writeData, &wshrpc.RpcOpts{Route: connRoute})
 }
 
@@ -61,6 +129,22 @@ func (fs *FileService) StatFile(connection string, path string) (*wshrpc.FileInf
 	return wshclient.RemoteFileInfoCommand(client, path, &wshrpc.RpcOpts{Route: connRoute})
 }
 
+func (fs *FileService) DataTable_Meta() tsgenmeta.MethodMeta {
+	return tsgenmeta.MethodMeta{
+		Desc:     "get a page of rows from a CSV (or Parquet) data file, with server-side sorting and filtering",
+		ArgNames: []string{"connection", "data"},
+	}
+}
+
+func (fs *FileService) DataTable(connection string, data wshrpc.CommandRemoteFileDataTableData) (wshrpc.FileDataTablePage, error) {
+	if connection == "" {
+		connection = wshrpc.LocalConnName
+	}
+	connRoute := wshutil.MakeConnectionRouteId(connection)
+	client := wshserver.GetMainRpcClient()
+	return wshclient.RemoteFileDataTableCommand(client, data, &wshrpc.RpcOpts{Route: connRoute})
+}
+
 func (fs *FileService) Mkdir(connection string, path string) error {
 	if connection == "" {
 		connection = wshrpc.LocalConnName
@@ -101,7 +185,7 @@ func (fs *FileService) ReadFile(connection string, path string) (*FullFile, erro
 	}
 	connRoute := wshutil.MakeConnectionRouteId(connection)
 	client := wshserver.GetMainRpcClient()
-	streamFileData := wshrpc.CommandRemoteStreamFileData{Path: path}
+	streamFileData := wshrpc.CommandRemoteStreamFileData{Path: path, BwLimit: resolveBwLimit(connection)}
 	rtnCh := wshclient.RemoteStreamFileCommand(client, streamFileData, &wshrpc.RpcOpts{Route: connRoute})
 	fullFile := &FullFile{}
 	firstPk := true
@@ -164,6 +248,21 @@ func (fs *FileService) GetWaveFile(id string, path string) (any, error) {
 	return file, nil
 }
 
+func (fs *FileService) GetWaveFileArchive_Meta() tsgenmeta.MethodMeta {
+	return tsgenmeta.MethodMeta{
+		Desc:     "get the base64-encoded cold-storage archive for a wave file (data spilled off the front of a circular file with archiveoverflow set, see archive:truncated in the file's meta)",
+		ArgNames: []string{"id", "path"},
+	}
+}
+
+func (fs *FileService) GetWaveFileArchive(id string, path string) (string, error) {
+	data, err := filestore.WFS.ReadArchive(id, path)
+	if err != nil {
+		return "", fmt.Errorf("error getting file archive: %w", err)
+	}
+	return base64.StdEncoding.EncodeToString(data), nil
+}
+
 func (fs *FileService) DeleteFile_Meta() tsgenmeta.MethodMeta {
 	return tsgenmeta.MethodMeta{
 		Desc:     "delete file",
@@ -175,11 +274,257 @@ func (fs *FileService) DeleteFile(connection string, path string) error {
 	if connection == "" {
 		connection = wshrpc.LocalConnName
 	}
+	if connection == wshrpc.LocalConnName && fs.DeleteModeForConnection(connection) == DeleteModeTrash {
+		return trashutil.MoveToTrash(wavebase.ExpandHomeDirSafe(path))
+	}
 	connRoute := wshutil.MakeConnectionRouteId(connection)
 	client := wshserver.GetMainRpcClient()
 	return wshclient.RemoteFileDeleteCommand(client, path, &wshrpc.RpcOpts{Route: connRoute})
 }
 
+// FileConflictPolicy controls how BulkFileOp handles a move/copy whose destination already
+// exists.
+type FileConflictPolicy string
+
+const (
+	FileConflictPolicySkip      FileConflictPolicy = "skip"
+	FileConflictPolicyOverwrite FileConflictPolicy = "overwrite"
+	FileConflictPolicyRename    FileConflictPolicy = "rename"
+)
+
+type BulkFileOpKind string
+
+const (
+	BulkFileOpMove   BulkFileOpKind = "move"
+	BulkFileOpCopy   BulkFileOpKind = "copy"
+	BulkFileOpDelete BulkFileOpKind = "delete"
+)
+
+type BulkFileOpRequest struct {
+	Connection     string             `json:"connection"`
+	Kind           BulkFileOpKind     `json:"kind"`
+	Paths          []string           `json:"paths"`
+	DestDir        string             `json:"destdir,omitempty"`        // required for move/copy
+	ConflictPolicy FileConflictPolicy `json:"conflictpolicy,omitempty"` // defaults to "skip"
+}
+
+type BulkFileOpItemResult struct {
+	Path     string `json:"path"`
+	DestPath string `json:"destpath,omitempty"`
+	Status   string `json:"status"` // "ok", "skipped", "error"
+	Error    string `json:"error,omitempty"`
+}
+
+type BulkFileOpResult struct {
+	OpId string `json:"opid"`
+	// UndoToken is set when the op deleted at least one file on the local connection; pass it to
+	// UndoBulkDelete within DeleteUndoGracePeriod to restore them. Deletes on remote connections
+	// and moves/copies are never undoable.
+	UndoToken string                 `json:"undotoken,omitempty"`
+	Results   []BulkFileOpItemResult `json:"results"`
+}
+
+type pendingTrashEntry struct {
+	origPath  string
+	trashPath string
+}
+
+var pendingTrashMu sync.Mutex
+var pendingTrashByToken = make(map[string][]pendingTrashEntry)
+
+func (fs *FileService) BulkFileOp_Meta() tsgenmeta.MethodMeta {
+	return tsgenmeta.MethodMeta{
+		Desc:     "run a move/copy/delete across a batch of files for the directory preview's multi-select actions, publishing wps.Event_FileOpProgress (scoped by the returned opid) as each item finishes",
+		ArgNames: []string{"req"},
+	}
+}
+
+// BulkFileOp applies req.Kind to every path in req.Paths, one at a time, publishing a
+// wps.Event_FileOpProgress event (scoped to the operation id) after each item so the frontend can
+// show live progress. Move/copy conflicts with an existing destination file are resolved per
+// req.ConflictPolicy. Deletes on the local connection are held in a holding directory for
+// DeleteUndoGracePeriod so they can be restored via UndoBulkDelete; once that window passes, each
+// file moves on to the OS trash (see purgeTrash, DeleteModeForConnection). Deletes on remote
+// connections are immediate and permanent, since there's no remote-side holding area to restore
+// them from and no desktop session on the other end to hand a file off to.
+func (fs *FileService) BulkFileOp(req BulkFileOpRequest) (BulkFileOpResult, error) {
+	if req.Connection == "" {
+		req.Connection = wshrpc.LocalConnName
+	}
+	if req.ConflictPolicy == "" {
+		req.ConflictPolicy = FileConflictPolicySkip
+	}
+	if (req.Kind == BulkFileOpMove || req.Kind == BulkFileOpCopy) && req.DestDir == "" {
+		return BulkFileOpResult{}, fmt.Errorf("destdir is required for a %q operation", req.Kind)
+	}
+
+	opId := uuid.NewString()
+	connRoute := wshutil.MakeConnectionRouteId(req.Connection)
+	client := wshserver.GetMainRpcClient()
+
+	var trashEntries []pendingTrashEntry
+	results := make([]BulkFileOpItemResult, 0, len(req.Paths))
+	for idx, path := range req.Paths {
+		item := BulkFileOpItemResult{Path: path}
+		switch req.Kind {
+		case BulkFileOpDelete:
+			if req.Connection == wshrpc.LocalConnName {
+				if trashPath, err := trashLocalFile(opId, path, idx); err != nil {
+					item.Status, item.Error = "error", err.Error()
+				} else {
+					trashEntries = append(trashEntries, pendingTrashEntry{origPath: path, trashPath: trashPath})
+					item.Status = "ok"
+				}
+			} else if err := wshclient.RemoteFileDeleteCommand(client, path, &wshrpc.RpcOpts{Route: connRoute}); err != nil {
+				item.Status, item.Error = "error", err.Error()
+			} else {
+				item.Status = "ok"
+			}
+		case BulkFileOpMove, BulkFileOpCopy:
+			destPath, skip, err := resolveDestPath(client, connRoute, req.DestDir, path, req.ConflictPolicy)
+			if err != nil {
+				item.Status, item.Error = "error", err.Error()
+			} else if skip {
+				item.Status = "skipped"
+			} else {
+				item.DestPath = destPath
+				overwrite := req.ConflictPolicy == FileConflictPolicyOverwrite
+				if req.Kind == BulkFileOpMove {
+					err = wshclient.RemoteFileRenameCommand(client, [2]string{path, destPath}, &wshrpc.RpcOpts{Route: connRoute})
+				} else {
+					err = wshclient.RemoteFileCopyCommand(client, wshrpc.CommandRemoteFileCopyData{
+						SrcPath: path, DestPath: destPath, Overwrite: overwrite,
+					}, &wshrpc.RpcOpts{Route: connRoute})
+				}
+				if err != nil {
+					item.Status, item.Error = "error", err.Error()
+				} else {
+					item.Status = "ok"
+				}
+			}
+		default:
+			item.Status, item.Error = "error", fmt.Sprintf("unknown bulk file op kind %q", req.Kind)
+		}
+		results = append(results, item)
+		publishFileOpProgress(opId, idx, len(req.Paths), item, idx == len(req.Paths)-1)
+	}
+
+	rtn := BulkFileOpResult{OpId: opId, Results: results}
+	if len(trashEntries) > 0 {
+		rtn.UndoToken = opId
+		pendingTrashMu.Lock()
+		pendingTrashByToken[opId] = trashEntries
+		pendingTrashMu.Unlock()
+		time.AfterFunc(DeleteUndoGracePeriod, func() { purgeTrash(opId) })
+	}
+	return rtn, nil
+}
+
+func (fs *FileService) UndoBulkDelete_Meta() tsgenmeta.MethodMeta {
+	return tsgenmeta.MethodMeta{
+		Desc:     "restore files deleted by a local BulkFileOp delete, as long as the undo grace window hasn't elapsed",
+		ArgNames: []string{"undoToken"},
+	}
+}
+
+func (fs *FileService) UndoBulkDelete(undoToken string) error {
+	pendingTrashMu.Lock()
+	entries, ok := pendingTrashByToken[undoToken]
+	delete(pendingTrashByToken, undoToken)
+	pendingTrashMu.Unlock()
+	if !ok {
+		return fmt.Errorf("no pending delete found for undo token %q (it may have already expired)", undoToken)
+	}
+	var firstErr error
+	for _, entry := range entries {
+		if err := os.Rename(entry.trashPath, wavebase.ExpandHomeDirSafe(entry.origPath)); err != nil && firstErr == nil {
+			firstErr = fmt.Errorf("cannot restore %q: %w", entry.origPath, err)
+		}
+	}
+	os.RemoveAll(trashDirForOp(undoToken))
+	return firstErr
+}
+
+func trashDirForOp(opId string) string {
+	return filepath.Join(wavebase.GetWaveDataDir(), "trash", opId)
+}
+
+func trashLocalFile(opId string, path string, idx int) (string, error) {
+	cleanedPath := wavebase.ExpandHomeDirSafe(path)
+	trashDir := trashDirForOp(opId)
+	if err := os.MkdirAll(trashDir, 0700); err != nil {
+		return "", fmt.Errorf("cannot create trash holding directory: %w", err)
+	}
+	trashPath := filepath.Join(trashDir, fmt.Sprintf("%d-%s", idx, filepath.Base(cleanedPath)))
+	if err := os.Rename(cleanedPath, trashPath); err != nil {
+		return "", fmt.Errorf("cannot delete file %q: %w", path, err)
+	}
+	return trashPath, nil
+}
+
+// purgeTrash finalizes a BulkFileOp delete once its undo grace period has expired. Each held file
+// is moved on to the OS trash (see pkg/trashutil) unless the OS has none or file:forcepermanentdelete
+// is set, in which case it's unlinked for good.
+func purgeTrash(opId string) {
+	pendingTrashMu.Lock()
+	entries := pendingTrashByToken[opId]
+	delete(pendingTrashByToken, opId)
+	pendingTrashMu.Unlock()
+	if !forcePermanentDelete() && trashutil.IsSupported() {
+		for _, entry := range entries {
+			trashutil.MoveToTrash(entry.trashPath)
+		}
+	}
+	os.RemoveAll(trashDirForOp(opId))
+}
+
+// resolveDestPath joins destDir with srcPath's basename and applies policy if that path already
+// exists: skip leaves destPath empty and skip=true, overwrite reuses the path as-is, and rename
+// appends " (n)" before the extension until it finds a path that doesn't exist.
+func resolveDestPath(client *wshutil.WshRpc, connRoute string, destDir string, srcPath string, policy FileConflictPolicy) (destPath string, skip bool, err error) {
+	base := filepath.Base(srcPath)
+	candidate := filepath.Join(destDir, base)
+	info, _ := wshclient.RemoteFileInfoCommand(client, candidate, &wshrpc.RpcOpts{Route: connRoute})
+	if info == nil || info.NotFound {
+		return candidate, false, nil
+	}
+	switch policy {
+	case FileConflictPolicySkip:
+		return "", true, nil
+	case FileConflictPolicyOverwrite:
+		return candidate, false, nil
+	case FileConflictPolicyRename:
+		ext := filepath.Ext(base)
+		stem := strings.TrimSuffix(base, ext)
+		for i := 1; ; i++ {
+			candidate = filepath.Join(destDir, fmt.Sprintf("%s (%d)%s", stem, i, ext))
+			info, _ := wshclient.RemoteFileInfoCommand(client, candidate, &wshrpc.RpcOpts{Route: connRoute})
+			if info == nil || info.NotFound {
+				return candidate, false, nil
+			}
+		}
+	default:
+		return "", false, fmt.Errorf("unknown conflict policy %q", policy)
+	}
+}
+
+func publishFileOpProgress(opId string, idx int, total int, item BulkFileOpItemResult, done bool) {
+	wps.Broker.Publish(wps.WaveEvent{
+		Event:  wps.Event_FileOpProgress,
+		Scopes: []string{opId},
+		Data: &wps.WSFileOpProgressData{
+			OpId:     opId,
+			Index:    idx,
+			Total:    total,
+			Path:     item.Path,
+			DestPath: item.DestPath,
+			Status:   item.Status,
+			Error:    item.Error,
+			Done:     done,
+		},
+	})
+}
+
 func (fs *FileService) GetFullConfig() wconfig.FullConfigType {
 	watcher := wconfig.GetWatcher()
 	return watcher.GetFullConfig()
@@ -0,0 +1,65 @@
+// Copyright 2026, Command Line Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package httpservice
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/wavetermdev/waveterm/pkg/tsgen/tsgenmeta"
+	"github.com/wavetermdev/waveterm/pkg/wshrpc"
+	"github.com/wavetermdev/waveterm/pkg/wshrpc/wshclient"
+	"github.com/wavetermdev/waveterm/pkg/wshrpc/wshserver"
+	"github.com/wavetermdev/waveterm/pkg/wshutil"
+	"github.com/wavetermdev/waveterm/pkg/wstore"
+)
+
+type HttpService struct{}
+
+func (hs *HttpService) Request_Meta() tsgenmeta.MethodMeta {
+	return tsgenmeta.MethodMeta{
+		Desc:     "execute an HTTP request (optionally over a remote connection) and record it to history",
+		ArgNames: []string{"connection", "data"},
+	}
+}
+
+// Request routes data to the connection's wsh helper to execute, then records the request to
+// the same cmd_history table shell command history uses -- this is the one step of the HTTP
+// request flow that must run on the main wavesrv process rather than the (possibly remote)
+// connection, since that's the only place the local history DB lives.
+func (hs *HttpService) Request(connection string, data wshrpc.CommandRemoteHttpRequestData) (wshrpc.HttpResponseData, error) {
+	if connection == "" {
+		connection = wshrpc.LocalConnName
+	}
+	connRoute := wshutil.MakeConnectionRouteId(connection)
+	client := wshserver.GetMainRpcClient()
+	startTs := time.Now()
+	resp, err := wshclient.RemoteHttpRequestCommand(client, data, &wshrpc.RpcOpts{Route: connRoute})
+	recordHistory(connection, data, startTs, err)
+	return resp, err
+}
+
+func recordHistory(connection string, data wshrpc.CommandRemoteHttpRequestData, startTs time.Time, reqErr error) {
+	exitCode := 0
+	if reqErr != nil {
+		exitCode = 1
+	}
+	ctx, cancelFn := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancelFn()
+	method := data.Method
+	if method == "" {
+		method = "GET"
+	}
+	err := wstore.AddCmdHistoryItem(ctx, wstore.CmdHistoryItem{
+		Ts:         startTs.UnixMilli(),
+		Connection: connection,
+		CmdStr:     method + " " + data.Url,
+		ExitCode:   exitCode,
+		DurationMs: time.Since(startTs).Milliseconds(),
+	})
+	if err != nil {
+		log.Printf("error recording http request history for connection %s: %v\n", connection, err)
+	}
+}
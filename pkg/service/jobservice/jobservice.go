@@ -0,0 +1,73 @@
+// Copyright 2026, Command Line Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package jobservice
+
+import (
+	"github.com/wavetermdev/waveterm/pkg/jobqueue"
+	"github.com/wavetermdev/waveterm/pkg/tsgen/tsgenmeta"
+	"github.com/wavetermdev/waveterm/pkg/wshrpc"
+)
+
+type JobService struct{}
+
+func (js *JobService) EnqueueJob_Meta() tsgenmeta.MethodMeta {
+	return tsgenmeta.MethodMeta{
+		Desc:     "enqueue a shell command to run on a connection's job queue",
+		ArgNames: []string{"connection", "cmdstr"},
+	}
+}
+
+func (js *JobService) EnqueueJob(connection string, cmdStr string) *jobqueue.Job {
+	if connection == "" {
+		connection = wshrpc.LocalConnName
+	}
+	return jobqueue.Enqueue(connection, cmdStr)
+}
+
+func (js *JobService) ListJobs_Meta() tsgenmeta.MethodMeta {
+	return tsgenmeta.MethodMeta{
+		Desc:     "list jobs for a connection, or all connections if empty",
+		ArgNames: []string{"connection"},
+	}
+}
+
+func (js *JobService) ListJobs(connection string) []*jobqueue.Job {
+	return jobqueue.List(connection)
+}
+
+func (js *JobService) CancelJob_Meta() tsgenmeta.MethodMeta {
+	return tsgenmeta.MethodMeta{
+		Desc:     "cancel a queued (not yet running) job",
+		ArgNames: []string{"jobid"},
+	}
+}
+
+func (js *JobService) CancelJob(jobId string) error {
+	return jobqueue.Cancel(jobId)
+}
+
+func (js *JobService) RetryJob_Meta() tsgenmeta.MethodMeta {
+	return tsgenmeta.MethodMeta{
+		Desc:     "re-enqueue a copy of a completed, failed, or cancelled job",
+		ArgNames: []string{"jobid"},
+	}
+}
+
+func (js *JobService) RetryJob(jobId string) (*jobqueue.Job, error) {
+	return jobqueue.Retry(jobId)
+}
+
+func (js *JobService) SetConcurrency_Meta() tsgenmeta.MethodMeta {
+	return tsgenmeta.MethodMeta{
+		Desc:     "set how many jobs run at once on a connection (minimum 1)",
+		ArgNames: []string{"connection", "concurrency"},
+	}
+}
+
+func (js *JobService) SetConcurrency(connection string, concurrency int) {
+	if connection == "" {
+		connection = wshrpc.LocalConnName
+	}
+	jobqueue.SetConcurrency(connection, concurrency)
+}
@@ -89,6 +89,79 @@ func (svc *ObjectService) UpdateTabName(uiContext waveobj.UIContext, tabId, name
 	return waveobj.ContextGetUpdatesRtn(ctx), nil
 }
 
+func (svc *ObjectService) ApplyLayoutPreset_Meta() tsgenmeta.MethodMeta {
+	return tsgenmeta.MethodMeta{
+		ArgNames: []string{"uiContext", "tabId", "presetId"},
+	}
+}
+
+func (svc *ObjectService) ApplyLayoutPreset(uiContext waveobj.UIContext, tabId string, presetId string) (waveobj.UpdatesRtnType, error) {
+	ctx, cancelFn := context.WithTimeout(context.Background(), DefaultTimeout)
+	defer cancelFn()
+	ctx = waveobj.ContextWithUpdates(ctx)
+	err := wcore.ApplyLayoutPreset(ctx, tabId, presetId)
+	if err != nil {
+		return nil, fmt.Errorf("error applying layout preset: %w", err)
+	}
+	return waveobj.ContextGetUpdatesRtn(ctx), nil
+}
+
+func (svc *ObjectService) CycleLayoutPreset_Meta() tsgenmeta.MethodMeta {
+	return tsgenmeta.MethodMeta{
+		ArgNames: []string{"uiContext", "tabId"},
+	}
+}
+
+func (svc *ObjectService) CycleLayoutPreset(uiContext waveobj.UIContext, tabId string) (waveobj.UpdatesRtnType, error) {
+	ctx, cancelFn := context.WithTimeout(context.Background(), DefaultTimeout)
+	defer cancelFn()
+	ctx = waveobj.ContextWithUpdates(ctx)
+	_, err := wcore.CycleLayoutPreset(ctx, tabId)
+	if err != nil {
+		return nil, fmt.Errorf("error cycling layout preset: %w", err)
+	}
+	return waveobj.ContextGetUpdatesRtn(ctx), nil
+}
+
+func (svc *ObjectService) SetBackgroundImage_Meta() tsgenmeta.MethodMeta {
+	return tsgenmeta.MethodMeta{
+		ArgNames: []string{"uiContext", "oref", "path", "opacity", "blur"},
+	}
+}
+
+// SetBackgroundImage validates path (see wcore.ValidateBackgroundImagePath) and sets it, along with
+// opacity and blur, as oref's (a tab or block) background image (see waveobj.MetaKey_BgImage). An
+// empty path clears the background image and blur without touching opacity, which is shared with
+// the plain-color/gradient backgrounds set via presets (see waveobj.MetaKey_BgOpacity).
+func (svc *ObjectService) SetBackgroundImage(uiContext waveobj.UIContext, orefStr string, path string, opacity float64, blur float64) (waveobj.UpdatesRtnType, error) {
+	oref, err := parseORef(orefStr)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing object reference: %w", err)
+	}
+	meta := waveobj.MetaMapType{}
+	if path == "" {
+		meta[waveobj.MetaKey_BgImage] = nil
+		meta[waveobj.MetaKey_BgImageBlur] = nil
+	} else {
+		expanded, err := wcore.ValidateBackgroundImagePath(path)
+		if err != nil {
+			return nil, err
+		}
+		meta[waveobj.MetaKey_BgImage] = expanded
+		meta[waveobj.MetaKey_BgImageBlur] = blur
+		if opacity > 0 {
+			meta[waveobj.MetaKey_BgOpacity] = opacity
+		}
+	}
+	ctx, cancelFn := context.WithTimeout(context.Background(), DefaultTimeout)
+	defer cancelFn()
+	ctx = waveobj.ContextWithUpdates(ctx)
+	if err := wstore.UpdateObjectMeta(ctx, *oref, meta, false); err != nil {
+		return nil, fmt.Errorf("error setting background image: %w", err)
+	}
+	return waveobj.ContextGetUpdatesRtn(ctx), nil
+}
+
 func (svc *ObjectService) CreateBlock_Meta() tsgenmeta.MethodMeta {
 	return tsgenmeta.MethodMeta{
 		ArgNames:   []string{"uiContext", "blockDef", "rtOpts"},
@@ -0,0 +1,127 @@
+// Copyright 2024, Command Line Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package processservice
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/wavetermdev/waveterm/pkg/tsgen/tsgenmeta"
+	"github.com/wavetermdev/waveterm/pkg/userinput"
+	"github.com/wavetermdev/waveterm/pkg/wshrpc"
+	"github.com/wavetermdev/waveterm/pkg/wshrpc/wshclient"
+	"github.com/wavetermdev/waveterm/pkg/wshrpc/wshserver"
+	"github.com/wavetermdev/waveterm/pkg/wshutil"
+)
+
+const DefaultTimeout = 2 * time.Second
+const ConfirmTimeout = 60 * time.Second
+
+// destructiveSignals are signals that kill or interrupt a process (as opposed to SIGSTOP/SIGCONT,
+// which merely pause/resume it) and so warrant a confirmation prompt before sending.
+var destructiveSignals = map[string]bool{
+	"SIGKILL": true,
+	"SIGTERM": true,
+	"SIGINT":  true,
+	"SIGQUIT": true,
+	"SIGHUP":  true,
+}
+
+type ProcessService struct{}
+
+func (ps *ProcessService) ListProcesses_Meta() tsgenmeta.MethodMeta {
+	return tsgenmeta.MethodMeta{
+		Desc:     "list processes on a connection",
+		ArgNames: []string{"connection"},
+	}
+}
+
+func (ps *ProcessService) ListProcesses(connection string) ([]wshrpc.ProcessInfo, error) {
+	if connection == "" {
+		connection = wshrpc.LocalConnName
+	}
+	connRoute := wshutil.MakeConnectionRouteId(connection)
+	client := wshserver.GetMainRpcClient()
+	return wshclient.RemoteListProcessesCommand(client, &wshrpc.RpcOpts{Route: connRoute, Timeout: int(DefaultTimeout.Milliseconds())})
+}
+
+func (ps *ProcessService) ListListeners_Meta() tsgenmeta.MethodMeta {
+	return tsgenmeta.MethodMeta{
+		Desc:     "list listening ports on a connection",
+		ArgNames: []string{"connection"},
+	}
+}
+
+func (ps *ProcessService) ListListeners(connection string) ([]wshrpc.ListenerInfo, error) {
+	if connection == "" {
+		connection = wshrpc.LocalConnName
+	}
+	connRoute := wshutil.MakeConnectionRouteId(connection)
+	client := wshserver.GetMainRpcClient()
+	return wshclient.RemoteListListenersCommand(client, &wshrpc.RpcOpts{Route: connRoute, Timeout: int(DefaultTimeout.Milliseconds())})
+}
+
+// confirmProcessAction prompts the user to confirm a process action before it is sent, the same
+// way conncontroller confirms installing wsh on a new connection.
+func confirmProcessAction(title string, queryText string) error {
+	ctx, cancelFn := context.WithTimeout(context.Background(), ConfirmTimeout)
+	defer cancelFn()
+	request := &userinput.UserInputRequest{
+		ResponseType: "confirm",
+		Title:        title,
+		QueryText:    queryText,
+		Markdown:     true,
+		OkLabel:      "Confirm",
+		CancelLabel:  "Cancel",
+	}
+	response, err := userinput.GetUserInput(ctx, request)
+	if err != nil {
+		return fmt.Errorf("action not confirmed: %w", err)
+	}
+	if !response.Confirm {
+		return fmt.Errorf("action cancelled by user")
+	}
+	return nil
+}
+
+func (ps *ProcessService) SignalProcess_Meta() tsgenmeta.MethodMeta {
+	return tsgenmeta.MethodMeta{
+		Desc:     "send a signal to a process on a connection, with confirmation for destructive signals",
+		ArgNames: []string{"connection", "pid", "signal"},
+	}
+}
+
+func (ps *ProcessService) SignalProcess(connection string, pid int32, signal string) error {
+	if connection == "" {
+		connection = wshrpc.LocalConnName
+	}
+	if destructiveSignals[signal] {
+		err := confirmProcessAction("Send Signal", fmt.Sprintf("Send **%s** to process `%d`?", signal, pid))
+		if err != nil {
+			return err
+		}
+	}
+	connRoute := wshutil.MakeConnectionRouteId(connection)
+	client := wshserver.GetMainRpcClient()
+	signalData := wshrpc.CommandRemoteProcessSignalData{Pid: pid, Signal: signal}
+	return wshclient.RemoteProcessSignalCommand(client, signalData, &wshrpc.RpcOpts{Route: connRoute, Timeout: int(DefaultTimeout.Milliseconds())})
+}
+
+func (ps *ProcessService) ReniceProcess_Meta() tsgenmeta.MethodMeta {
+	return tsgenmeta.MethodMeta{
+		Desc:     "change the scheduling priority (nice value) of a process on a connection",
+		ArgNames: []string{"connection", "pid", "priority"},
+	}
+}
+
+func (ps *ProcessService) ReniceProcess(connection string, pid int32, priority int32) error {
+	if connection == "" {
+		connection = wshrpc.LocalConnName
+	}
+	connRoute := wshutil.MakeConnectionRouteId(connection)
+	client := wshserver.GetMainRpcClient()
+	reniceData := wshrpc.CommandRemoteProcessReniceData{Pid: pid, Priority: priority}
+	return wshclient.RemoteProcessReniceCommand(client, reniceData, &wshrpc.RpcOpts{Route: connRoute, Timeout: int(DefaultTimeout.Milliseconds())})
+}
@@ -12,7 +12,10 @@ import (
 	"github.com/wavetermdev/waveterm/pkg/service/blockservice"
 	"github.com/wavetermdev/waveterm/pkg/service/clientservice"
 	"github.com/wavetermdev/waveterm/pkg/service/fileservice"
+	"github.com/wavetermdev/waveterm/pkg/service/httpservice"
+	"github.com/wavetermdev/waveterm/pkg/service/jobservice"
 	"github.com/wavetermdev/waveterm/pkg/service/objectservice"
+	"github.com/wavetermdev/waveterm/pkg/service/processservice"
 	"github.com/wavetermdev/waveterm/pkg/service/userinputservice"
 	"github.com/wavetermdev/waveterm/pkg/service/windowservice"
 	"github.com/wavetermdev/waveterm/pkg/service/workspaceservice"
@@ -26,10 +29,13 @@ var ServiceMap = map[string]any{
 	"block":     blockservice.BlockServiceInstance,
 	"object":    &objectservice.ObjectService{},
 	"file":      &fileservice.FileService{},
+	"http":      &httpservice.HttpService{},
 	"client":    &clientservice.ClientService{},
 	"window":    &windowservice.WindowService{},
 	"workspace": &workspaceservice.WorkspaceService{},
 	"userinput": &userinputservice.UserInputService{},
+	"process":   &processservice.ProcessService{},
+	"job":       &jobservice.JobService{},
 }
 
 var contextRType = reflect.TypeOf((*context.Context)(nil)).Elem()
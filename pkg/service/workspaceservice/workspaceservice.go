@@ -206,6 +206,45 @@ func (svc *WorkspaceService) UpdateTabIds(uiContext waveobj.UIContext, workspace
 	return waveobj.ContextGetUpdatesRtn(ctx), nil
 }
 
+func (svc *WorkspaceService) SetWorkspaceDefaults_Meta() tsgenmeta.MethodMeta {
+	return tsgenmeta.MethodMeta{
+		ArgNames: []string{"workspaceId", "defaultConnection", "defaultEnv"},
+	}
+}
+
+func (svc *WorkspaceService) SetWorkspaceDefaults(workspaceId string, defaultConnection string, defaultEnv map[string]string) error {
+	err := wcore.SetDefaults(workspaceId, defaultConnection, defaultEnv)
+	if err != nil {
+		return fmt.Errorf("error setting workspace defaults: %w", err)
+	}
+	wps.Broker.Publish(wps.WaveEvent{
+		Event: wps.Event_WorkspaceUpdate,
+	})
+	return nil
+}
+
+func (svc *WorkspaceService) MoveTabToWorkspace_Meta() tsgenmeta.MethodMeta {
+	return tsgenmeta.MethodMeta{
+		ArgNames: []string{"ctx", "srcWorkspaceId", "tabId", "destWorkspaceId", "activate"},
+	}
+}
+
+func (svc *WorkspaceService) MoveTabToWorkspace(ctx context.Context, srcWorkspaceId string, tabId string, destWorkspaceId string, activate bool) (waveobj.UpdatesRtnType, error) {
+	ctx = waveobj.ContextWithUpdates(ctx)
+	err := wcore.MoveTabToWorkspace(ctx, srcWorkspaceId, tabId, destWorkspaceId, activate)
+	if err != nil {
+		return nil, fmt.Errorf("error moving tab to workspace: %w", err)
+	}
+	updates := waveobj.ContextGetUpdatesRtn(ctx)
+	go func() {
+		defer func() {
+			panichandler.PanicHandler("WorkspaceService:MoveTabToWorkspace:SendUpdateEvents", recover())
+		}()
+		wps.Broker.SendUpdateEvents(updates)
+	}()
+	return updates, nil
+}
+
 func (svc *WorkspaceService) SetActiveTab_Meta() tsgenmeta.MethodMeta {
 	return tsgenmeta.MethodMeta{
 		ArgNames: []string{"workspaceId", "tabId"},
@@ -0,0 +1,246 @@
+// Copyright 2024, Command Line Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+// Package settingssync implements export/import of a user's settings, widgets, connections, and
+// metadata as a single bundle file, so it can be checked into a dotfiles git repo (or dropped in
+// any synced folder) and picked back up on another machine. Like pkg/histsync, this doesn't
+// reproduce any particular third-party sync protocol -- it's a plain JSON file the user moves
+// around however they already sync their dotfiles. Secrets (API tokens, client secrets) are
+// stripped on export so the bundle is safe to commit to a repo.
+//
+// Import is two-phase on purpose: DiffBundle reports every key where the incoming bundle
+// disagrees with the local config without writing anything, so a caller (e.g. a command palette
+// flow) can prompt the user per-conflict; ApplyBundle then writes only the keys the caller says
+// to accept.
+package settingssync
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/wavetermdev/waveterm/pkg/waveobj"
+	"github.com/wavetermdev/waveterm/pkg/wconfig"
+	"github.com/wavetermdev/waveterm/pkg/wshrpc"
+	"github.com/wavetermdev/waveterm/pkg/wstore"
+)
+
+// secretSettingsKeys lists settings.json keys that hold credentials -- these never leave the
+// machine in an exported bundle.
+var secretSettingsKeys = map[string]bool{
+	"ai:apitoken":       true,
+	"ai:apitokens":      true,
+	"oidc:clientsecret": true,
+	"gateway:token":     true,
+	"sync:key":          true,
+}
+
+// SettingsBundle is the exported/imported file format. Connections carries ConnKeywords as
+// configured (identity files, hostnames, per-connection term overrides) -- it holds no
+// passwords, so it doesn't need stripping the way Settings does.
+type SettingsBundle struct {
+	ExportedAt  int64                                   `json:"exportedat"`
+	DeviceId    string                                  `json:"deviceid"`
+	Settings    waveobj.MetaMapType                     `json:"settings"`
+	Widgets     map[string]wconfig.WidgetConfigType     `json:"widgets"`
+	Connections map[string]wshrpc.ConnKeywords          `json:"connections"`
+	Keybindings map[string]wconfig.KeybindingConfigType `json:"keybindings"`
+}
+
+// Conflict is one bundle key whose incoming value differs from what's already configured
+// locally. DiffBundle returns these instead of merging blind so an import flow can prompt
+// before overwriting either side.
+type Conflict struct {
+	Category   string `json:"category"` // "settings", "widget", "connection", "keybinding"
+	Key        string `json:"key"`
+	LocalJson  string `json:"localjson"`
+	RemoteJson string `json:"remotejson"`
+}
+
+func toJson(v any) string {
+	barr, _ := json.Marshal(v)
+	return string(barr)
+}
+
+func settingsToMetaMap(settings wconfig.SettingsType) waveobj.MetaMapType {
+	barr, _ := json.Marshal(settings)
+	var meta waveobj.MetaMapType
+	json.Unmarshal(barr, &meta)
+	for key := range secretSettingsKeys {
+		delete(meta, key)
+	}
+	return meta
+}
+
+// Export builds a bundle from the current config, ts-stamped and tagged with this device's id
+// (the same id used for history sync, see wstore.GetLocalDeviceId), with secrets stripped.
+func Export(ctx context.Context, ts int64) (SettingsBundle, error) {
+	deviceId, err := wstore.GetLocalDeviceId(ctx)
+	if err != nil {
+		return SettingsBundle{}, fmt.Errorf("error getting local device id: %w", err)
+	}
+	fullConfig := wconfig.GetWatcher().GetFullConfig()
+	return SettingsBundle{
+		ExportedAt:  ts,
+		DeviceId:    deviceId,
+		Settings:    settingsToMetaMap(fullConfig.Settings),
+		Widgets:     fullConfig.Widgets,
+		Connections: fullConfig.Connections,
+		Keybindings: fullConfig.Keybindings,
+	}, nil
+}
+
+func ExportToFile(ctx context.Context, path string, ts int64) error {
+	bundle, err := Export(ctx, ts)
+	if err != nil {
+		return err
+	}
+	barr, err := json.MarshalIndent(bundle, "", "  ")
+	if err != nil {
+		return fmt.Errorf("error marshaling settings bundle: %w", err)
+	}
+	return os.WriteFile(path, barr, 0644)
+}
+
+func ReadBundleFile(path string) (SettingsBundle, error) {
+	barr, err := os.ReadFile(path)
+	if err != nil {
+		return SettingsBundle{}, fmt.Errorf("error reading settings bundle: %w", err)
+	}
+	var bundle SettingsBundle
+	if err := json.Unmarshal(barr, &bundle); err != nil {
+		return SettingsBundle{}, fmt.Errorf("error parsing settings bundle: %w", err)
+	}
+	return bundle, nil
+}
+
+// DiffBundle compares a bundle (typically just loaded via ReadBundleFile) against the current
+// local config and returns every key whose incoming value differs (including keys that don't
+// exist locally yet).
+func DiffBundle(bundle SettingsBundle) []Conflict {
+	var conflicts []Conflict
+	fullConfig := wconfig.GetWatcher().GetFullConfig()
+	localSettings := settingsToMetaMap(fullConfig.Settings)
+	for key, remoteVal := range bundle.Settings {
+		localJson := toJson(localSettings[key])
+		remoteJson := toJson(remoteVal)
+		if localJson != remoteJson {
+			conflicts = append(conflicts, Conflict{Category: "settings", Key: key, LocalJson: localJson, RemoteJson: remoteJson})
+		}
+	}
+	for name, remoteWidget := range bundle.Widgets {
+		localJson := toJson(fullConfig.Widgets[name])
+		remoteJson := toJson(remoteWidget)
+		if localJson != remoteJson {
+			conflicts = append(conflicts, Conflict{Category: "widget", Key: name, LocalJson: localJson, RemoteJson: remoteJson})
+		}
+	}
+	for name, remoteConn := range bundle.Connections {
+		localJson := toJson(fullConfig.Connections[name])
+		remoteJson := toJson(remoteConn)
+		if localJson != remoteJson {
+			conflicts = append(conflicts, Conflict{Category: "connection", Key: name, LocalJson: localJson, RemoteJson: remoteJson})
+		}
+	}
+	for id, remoteBinding := range bundle.Keybindings {
+		localJson := toJson(fullConfig.Keybindings[id])
+		remoteJson := toJson(remoteBinding)
+		if localJson != remoteJson {
+			conflicts = append(conflicts, Conflict{Category: "keybinding", Key: id, LocalJson: localJson, RemoteJson: remoteJson})
+		}
+	}
+	return conflicts
+}
+
+// ApplyBundle writes the bundle's values into the wave home config files, restricted to
+// acceptedKeys (formatted "<category>:<key>", e.g. "settings:term:theme", "widget:my-widget",
+// "connection:my-host", "keybinding:my-binding") -- the set of conflicts the caller chose to
+// take from the bundle rather than keep locally.
+func ApplyBundle(bundle SettingsBundle, acceptedKeys map[string]bool) error {
+	settingsPatch := waveobj.MetaMapType{}
+	for key, val := range bundle.Settings {
+		if acceptedKeys["settings:"+key] {
+			settingsPatch[key] = val
+		}
+	}
+	if len(settingsPatch) > 0 {
+		m, cerrs := wconfig.ReadWaveHomeConfigFile(wconfig.SettingsFile)
+		if len(cerrs) > 0 {
+			return fmt.Errorf("error reading settings file: %v", cerrs[0])
+		}
+		if m == nil {
+			m = make(waveobj.MetaMapType)
+		}
+		for key, val := range settingsPatch {
+			m[key] = val
+		}
+		if err := wconfig.WriteWaveHomeConfigFile(wconfig.SettingsFile, m); err != nil {
+			return fmt.Errorf("error writing settings file: %w", err)
+		}
+	}
+	widgetsPatch := map[string]wconfig.WidgetConfigType{}
+	for name, widget := range bundle.Widgets {
+		if acceptedKeys["widget:"+name] {
+			widgetsPatch[name] = widget
+		}
+	}
+	if len(widgetsPatch) > 0 {
+		m, cerrs := wconfig.ReadWaveHomeConfigFile(wconfig.WidgetsFile)
+		if len(cerrs) > 0 {
+			return fmt.Errorf("error reading widgets file: %v", cerrs[0])
+		}
+		if m == nil {
+			m = make(waveobj.MetaMapType)
+		}
+		for name, widget := range widgetsPatch {
+			m[name] = widget
+		}
+		if err := wconfig.WriteWaveHomeConfigFile(wconfig.WidgetsFile, m); err != nil {
+			return fmt.Errorf("error writing widgets file: %w", err)
+		}
+	}
+	connsPatch := map[string]wshrpc.ConnKeywords{}
+	for name, conn := range bundle.Connections {
+		if acceptedKeys["connection:"+name] {
+			connsPatch[name] = conn
+		}
+	}
+	if len(connsPatch) > 0 {
+		m, cerrs := wconfig.ReadWaveHomeConfigFile(wconfig.ConnectionsFile)
+		if len(cerrs) > 0 {
+			return fmt.Errorf("error reading connections file: %v", cerrs[0])
+		}
+		if m == nil {
+			m = make(waveobj.MetaMapType)
+		}
+		for name, conn := range connsPatch {
+			m[name] = conn
+		}
+		if err := wconfig.WriteWaveHomeConfigFile(wconfig.ConnectionsFile, m); err != nil {
+			return fmt.Errorf("error writing connections file: %w", err)
+		}
+	}
+	keybindingsPatch := map[string]wconfig.KeybindingConfigType{}
+	for id, binding := range bundle.Keybindings {
+		if acceptedKeys["keybinding:"+id] {
+			keybindingsPatch[id] = binding
+		}
+	}
+	if len(keybindingsPatch) > 0 {
+		m, cerrs := wconfig.ReadWaveHomeConfigFile(wconfig.KeybindingsFile)
+		if len(cerrs) > 0 {
+			return fmt.Errorf("error reading keybindings file: %v", cerrs[0])
+		}
+		if m == nil {
+			m = make(waveobj.MetaMapType)
+		}
+		for id, binding := range keybindingsPatch {
+			m[id] = binding
+		}
+		if err := wconfig.WriteWaveHomeConfigFile(wconfig.KeybindingsFile, m); err != nil {
+			return fmt.Errorf("error writing keybindings file: %w", err)
+		}
+	}
+	return nil
+}
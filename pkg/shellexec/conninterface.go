@@ -25,6 +25,9 @@ type ConnInterface interface {
 	StdoutPipe() (io.ReadCloser, error)
 	StderrPipe() (io.ReadCloser, error)
 	SetSize(w int, h int) error
+	// GetPid returns the OS pid of the locally-spawned process, or 0 if there isn't one (e.g. a
+	// remote ssh session, where the process runs on the far end and has no meaningful local pid).
+	GetPid() int
 	pty.Pty
 }
 
@@ -117,6 +120,13 @@ func (cw CmdWrap) SetSize(w int, h int) error {
 	return nil
 }
 
+func (cw CmdWrap) GetPid() int {
+	if cw.Cmd.Process == nil {
+		return 0
+	}
+	return cw.Cmd.Process.Pid
+}
+
 type SessionWrap struct {
 	Session  *ssh.Session
 	StartCmd string
@@ -188,6 +198,10 @@ func (sw SessionWrap) SetSize(h int, w int) error {
 	return sw.Session.WindowChange(h, w)
 }
 
+func (sw SessionWrap) GetPid() int {
+	return 0
+}
+
 type WslCmdWrap struct {
 	*wsl.WslCmd
 	Tty pty.Tty
@@ -229,3 +243,11 @@ func (wcw WslCmdWrap) KillGraceful(timeout time.Duration) {
 func (wcw WslCmdWrap) SetSize(w int, h int) error {
 	return nil
 }
+
+func (wcw WslCmdWrap) GetPid() int {
+	process := wcw.WslCmd.GetProcess()
+	if process == nil {
+		return 0
+	}
+	return process.Pid
+}
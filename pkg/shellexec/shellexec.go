@@ -39,6 +39,13 @@ type CommandOptsType struct {
 	Env         map[string]string `json:"env,omitempty"`
 	ShellPath   string            `json:"shellPath,omitempty"`
 	ShellOpts   []string          `json:"shellOpts,omitempty"`
+	// Detached asks StartShellProc to run the shell under nohup so it ignores the SIGHUP it would
+	// otherwise get when wavesrv exits and its pty master fd closes, letting the process keep
+	// running (as an orphan, reparented to init) across a wavesrv restart. It does not preserve
+	// the pty connection itself -- wavesrv has no broker process to hand that fd off to, so the
+	// orphan's output between the restart and whenever it's cleaned up is not captured (see
+	// blockcontroller.ListOrphanedDetached / CleanupDetached).
+	Detached bool `json:"detached,omitempty"`
 }
 
 type ShellProc struct {
@@ -415,6 +422,22 @@ func isFishShell(shellPath string) bool {
 	return strings.Contains(shellBase, "fish")
 }
 
+// applyDetached re-execs ecmd through nohup (when available) so the shell ignores SIGHUP and
+// survives its pty master fd closing -- see CommandOptsType.Detached. Not supported on windows,
+// which has no nohup/SIGHUP equivalent; detached there behaves like a normal command.
+func applyDetached(ecmd *exec.Cmd) {
+	if runtime.GOOS == "windows" {
+		return
+	}
+	nohupPath, err := exec.LookPath("nohup")
+	if err != nil {
+		log.Printf("detached command requested but nohup not found, running normally: %v\n", err)
+		return
+	}
+	ecmd.Args = append([]string{nohupPath, ecmd.Path}, ecmd.Args[1:]...)
+	ecmd.Path = nohupPath
+}
+
 func StartShellProc(termSize waveobj.TermSize, cmdStr string, cmdOpts CommandOptsType) (*ShellProc, error) {
 	shellutil.InitCustomShellStartupFiles()
 	var ecmd *exec.Cmd
@@ -464,6 +487,9 @@ func StartShellProc(termSize waveobj.TermSize, cmdStr string, cmdOpts CommandOpt
 	}
 	shellutil.UpdateCmdEnv(ecmd, envToAdd)
 	shellutil.UpdateCmdEnv(ecmd, cmdOpts.Env)
+	if cmdOpts.Detached {
+		applyDetached(ecmd)
+	}
 	if termSize.Rows == 0 || termSize.Cols == 0 {
 		termSize.Rows = shellutil.DefaultTermRows
 		termSize.Cols = shellutil.DefaultTermCols
@@ -0,0 +1,247 @@
+// Copyright 2024, Command Line Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+// Package ssoauth implements OIDC authorization-code-with-PKCE login for
+// wavesrv instances running headless on a shared host, as an alternative to
+// the single implicit local user. It issues opaque, expiring session tokens
+// after a successful login.
+//
+// Scope note: this package does NOT verify the ID token's signature against
+// the provider's JWKS (there's no JOSE/JWT dependency vendored in this tree);
+// it trusts the token endpoint's TLS connection and only decodes the ID
+// token's payload for the subject/email claims, same trust model as relying
+// solely on the token exchange happening over HTTPS directly with the
+// issuer. Deployments that need signature verification should put wavesrv
+// behind a reverse proxy that validates tokens before they reach here.
+// Per-user data isolation (separate wstore DBs/keyspaces per subject) is not
+// implemented by this package; it only establishes who the caller is.
+package ssoauth
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+const DefaultSessionTtl = 24 * time.Hour
+const SessionCookieName = "waveoidcsession"
+
+type Config struct {
+	Issuer       string
+	ClientId     string
+	ClientSecret string
+	RedirectUrl  string
+	SessionTtl   time.Duration
+}
+
+type discoveryDoc struct {
+	AuthorizationEndpoint string `json:"authorization_endpoint"`
+	TokenEndpoint         string `json:"token_endpoint"`
+}
+
+type tokenResponse struct {
+	AccessToken string `json:"access_token"`
+	IdToken     string `json:"id_token"`
+	ExpiresIn   int64  `json:"expires_in"`
+	Error       string `json:"error"`
+	ErrorDesc   string `json:"error_description"`
+}
+
+type idTokenClaims struct {
+	Subject string `json:"sub"`
+	Email   string `json:"email"`
+	Exp     int64  `json:"exp"`
+}
+
+type Session struct {
+	Token     string `json:"token"`
+	Subject   string `json:"subject"`
+	Email     string `json:"email"`
+	ExpiresTs int64  `json:"expirests"`
+}
+
+var sessionLock sync.Mutex
+var sessions = make(map[string]*Session)
+
+func randomUrlSafe(numBytes int) (string, error) {
+	buf := make([]byte, numBytes)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(buf), nil
+}
+
+// NewPKCEVerifier returns a random PKCE code_verifier.
+func NewPKCEVerifier() (string, error) {
+	return randomUrlSafe(32)
+}
+
+// PKCEChallenge derives the S256 code_challenge for the given verifier.
+func PKCEChallenge(verifier string) string {
+	sum := sha256.Sum256([]byte(verifier))
+	return base64.RawURLEncoding.EncodeToString(sum[:])
+}
+
+// NewState returns a random value for the OIDC "state" parameter.
+func NewState() (string, error) {
+	return randomUrlSafe(16)
+}
+
+func discover(ctx context.Context, issuer string) (*discoveryDoc, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, strings.TrimSuffix(issuer, "/")+"/.well-known/openid-configuration", nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("fetching OIDC discovery document: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("OIDC discovery returned status %d", resp.StatusCode)
+	}
+	var doc discoveryDoc
+	if err := json.NewDecoder(resp.Body).Decode(&doc); err != nil {
+		return nil, fmt.Errorf("decoding OIDC discovery document: %w", err)
+	}
+	if doc.AuthorizationEndpoint == "" || doc.TokenEndpoint == "" {
+		return nil, fmt.Errorf("OIDC discovery document is missing required endpoints")
+	}
+	return &doc, nil
+}
+
+// BuildAuthUrl fetches the issuer's discovery document and returns the
+// authorization-code-with-PKCE login URL to redirect the user's browser to.
+func BuildAuthUrl(ctx context.Context, cfg Config, state string, challenge string) (string, error) {
+	doc, err := discover(ctx, cfg.Issuer)
+	if err != nil {
+		return "", err
+	}
+	authUrl, err := url.Parse(doc.AuthorizationEndpoint)
+	if err != nil {
+		return "", fmt.Errorf("parsing authorization endpoint: %w", err)
+	}
+	q := authUrl.Query()
+	q.Set("response_type", "code")
+	q.Set("client_id", cfg.ClientId)
+	q.Set("redirect_uri", cfg.RedirectUrl)
+	q.Set("scope", "openid email profile")
+	q.Set("state", state)
+	q.Set("code_challenge", challenge)
+	q.Set("code_challenge_method", "S256")
+	authUrl.RawQuery = q.Encode()
+	return authUrl.String(), nil
+}
+
+// ExchangeCode completes the PKCE flow: it exchanges an authorization code
+// for tokens, decodes the ID token's claims, and creates a new session.
+func ExchangeCode(ctx context.Context, cfg Config, code string, verifier string) (*Session, error) {
+	doc, err := discover(ctx, cfg.Issuer)
+	if err != nil {
+		return nil, err
+	}
+	form := url.Values{}
+	form.Set("grant_type", "authorization_code")
+	form.Set("client_id", cfg.ClientId)
+	form.Set("redirect_uri", cfg.RedirectUrl)
+	form.Set("code", code)
+	form.Set("code_verifier", verifier)
+	if cfg.ClientSecret != "" {
+		form.Set("client_secret", cfg.ClientSecret)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, doc.TokenEndpoint, strings.NewReader(form.Encode()))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("calling OIDC token endpoint: %w", err)
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("reading OIDC token response: %w", err)
+	}
+	var tokenResp tokenResponse
+	if err := json.Unmarshal(body, &tokenResp); err != nil {
+		return nil, fmt.Errorf("decoding OIDC token response: %w", err)
+	}
+	if tokenResp.Error != "" {
+		return nil, fmt.Errorf("OIDC token exchange failed: %s: %s", tokenResp.Error, tokenResp.ErrorDesc)
+	}
+	if tokenResp.IdToken == "" {
+		return nil, fmt.Errorf("OIDC token response did not include an id_token")
+	}
+	claims, err := decodeIdTokenClaims(tokenResp.IdToken)
+	if err != nil {
+		return nil, err
+	}
+	ttl := cfg.SessionTtl
+	if ttl <= 0 {
+		ttl = DefaultSessionTtl
+	}
+	token, err := randomUrlSafe(32)
+	if err != nil {
+		return nil, fmt.Errorf("generating session token: %w", err)
+	}
+	session := &Session{
+		Token:     token,
+		Subject:   claims.Subject,
+		Email:     claims.Email,
+		ExpiresTs: time.Now().Add(ttl).UnixMilli(),
+	}
+	sessionLock.Lock()
+	sessions[token] = session
+	sessionLock.Unlock()
+	return session, nil
+}
+
+// decodeIdTokenClaims extracts the payload of a JWT without verifying its
+// signature (see package doc for the trust model this relies on instead).
+func decodeIdTokenClaims(idToken string) (*idTokenClaims, error) {
+	parts := strings.Split(idToken, ".")
+	if len(parts) != 3 {
+		return nil, fmt.Errorf("id_token is not a well-formed JWT")
+	}
+	payload, err := base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil {
+		return nil, fmt.Errorf("decoding id_token payload: %w", err)
+	}
+	var claims idTokenClaims
+	if err := json.Unmarshal(payload, &claims); err != nil {
+		return nil, fmt.Errorf("parsing id_token claims: %w", err)
+	}
+	if claims.Subject == "" {
+		return nil, fmt.Errorf("id_token is missing a sub claim")
+	}
+	return &claims, nil
+}
+
+// ValidateSession returns the session for token if it exists and hasn't
+// expired.
+func ValidateSession(token string) (*Session, bool) {
+	sessionLock.Lock()
+	defer sessionLock.Unlock()
+	session, ok := sessions[token]
+	if !ok || time.Now().UnixMilli() > session.ExpiresTs {
+		return nil, false
+	}
+	return session, true
+}
+
+// RevokeSession invalidates a session token immediately.
+func RevokeSession(token string) {
+	sessionLock.Lock()
+	defer sessionLock.Unlock()
+	delete(sessions, token)
+}
@@ -0,0 +1,108 @@
+// Copyright 2026, Command Line Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+// Package sudocache caches a per-connection sudo password for a short, configurable TTL so an
+// interactive session doesn't re-prompt for it on every command. There is no "ClearSudoCache"
+// anywhere in this tree and no sudo-prompt-detection pipeline for it to plug into (the shell's
+// pty output isn't parsed for "[sudo] password for ...:" prompts anywhere) -- this package only
+// provides the cache itself, for whatever future input-handling code wants to consult it. There's
+// also no OS keychain package vendored in this tree (and no network access in this sandbox to add
+// one), so unlike a real secrets-manager integration this keeps the password in process memory
+// only, guarded by a mutex, and never logs or persists it; a short TTL (see DefaultTtlMs) is the
+// only real mitigation for that, which is exactly why it's enforced here rather than left to the
+// caller.
+package sudocache
+
+import (
+	"sort"
+	"sync"
+	"time"
+)
+
+// DefaultTtlMs is used when a connection has no configured TTL (see
+// wconfig.SettingsType.ConnSudoCacheTtlMs), matching sudo's own default timestamp_timeout.
+const DefaultTtlMs = 5 * 60 * 1000
+
+type entry struct {
+	password  string
+	expiresAt time.Time
+}
+
+// Cache is a process-memory, per-connection sudo password cache. The zero value is not usable;
+// construct one with NewCache.
+type Cache struct {
+	mu      sync.Mutex
+	entries map[string]*entry
+	now     func() time.Time
+}
+
+// NewCache returns an empty Cache.
+func NewCache() *Cache {
+	return &Cache{entries: make(map[string]*entry), now: time.Now}
+}
+
+// Set caches password for connName for the given ttl. A non-positive ttl is treated as an
+// immediate Clear rather than caching a password that's already expired.
+func (c *Cache) Set(connName string, password string, ttl time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if ttl <= 0 {
+		delete(c.entries, connName)
+		return
+	}
+	c.entries[connName] = &entry{password: password, expiresAt: c.now().Add(ttl)}
+}
+
+// Get returns the cached password for connName, if any and not yet expired. An expired entry is
+// evicted as a side effect.
+func (c *Cache) Get(connName string) (string, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	e, ok := c.entries[connName]
+	if !ok {
+		return "", false
+	}
+	if c.now().After(e.expiresAt) {
+		delete(c.entries, connName)
+		return "", false
+	}
+	return e.password, true
+}
+
+// Clear evicts connName's cached password, if any.
+func (c *Cache) Clear(connName string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	delete(c.entries, connName)
+}
+
+// ClearAll evicts every cached password.
+func (c *Cache) ClearAll() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.entries = make(map[string]*entry)
+}
+
+// StatusEntry reports that a connection has a live cached credential, without exposing it.
+type StatusEntry struct {
+	Connection string    `json:"connection"`
+	ExpiresAt  time.Time `json:"expiresat"`
+}
+
+// Status lists every connection with a non-expired cached password, sorted by connection name.
+// Expired entries are evicted as a side effect, same as Get.
+func (c *Cache) Status() []StatusEntry {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	now := c.now()
+	rtn := make([]StatusEntry, 0, len(c.entries))
+	for connName, e := range c.entries {
+		if now.After(e.expiresAt) {
+			delete(c.entries, connName)
+			continue
+		}
+		rtn = append(rtn, StatusEntry{Connection: connName, ExpiresAt: e.expiresAt})
+	}
+	sort.Slice(rtn, func(i, j int) bool { return rtn[i].Connection < rtn[j].Connection })
+	return rtn
+}
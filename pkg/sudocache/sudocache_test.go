@@ -0,0 +1,81 @@
+// Copyright 2026, Command Line Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package sudocache
+
+import (
+	"testing"
+	"time"
+)
+
+func newTestCache(start time.Time) (*Cache, *time.Time) {
+	c := NewCache()
+	cur := start
+	c.now = func() time.Time { return cur }
+	return c, &cur
+}
+
+func TestSetAndGet(t *testing.T) {
+	c, _ := newTestCache(time.Unix(0, 0))
+	c.Set("remote1", "hunter2", time.Minute)
+	pw, ok := c.Get("remote1")
+	if !ok || pw != "hunter2" {
+		t.Errorf("expected cached password, got %q, %v", pw, ok)
+	}
+}
+
+func TestGetMissing(t *testing.T) {
+	c, _ := newTestCache(time.Unix(0, 0))
+	if _, ok := c.Get("remote1"); ok {
+		t.Errorf("expected no cached password for unknown connection")
+	}
+}
+
+func TestExpiry(t *testing.T) {
+	c, cur := newTestCache(time.Unix(0, 0))
+	c.Set("remote1", "hunter2", time.Minute)
+	*cur = cur.Add(2 * time.Minute)
+	if _, ok := c.Get("remote1"); ok {
+		t.Errorf("expected expired password to be evicted")
+	}
+}
+
+func TestSetNonPositiveTtlClears(t *testing.T) {
+	c, _ := newTestCache(time.Unix(0, 0))
+	c.Set("remote1", "hunter2", time.Minute)
+	c.Set("remote1", "hunter2", 0)
+	if _, ok := c.Get("remote1"); ok {
+		t.Errorf("expected non-positive ttl to clear the entry instead of caching it")
+	}
+}
+
+func TestClear(t *testing.T) {
+	c, _ := newTestCache(time.Unix(0, 0))
+	c.Set("remote1", "hunter2", time.Minute)
+	c.Clear("remote1")
+	if _, ok := c.Get("remote1"); ok {
+		t.Errorf("expected Clear to evict the cached password")
+	}
+}
+
+func TestStatusListsLiveEntriesSorted(t *testing.T) {
+	c, cur := newTestCache(time.Unix(0, 0))
+	c.Set("zebra", "pw1", 5*time.Minute)
+	c.Set("apple", "pw2", 5*time.Minute)
+	c.Set("expiring", "pw3", 30*time.Second)
+	*cur = cur.Add(time.Minute)
+	status := c.Status()
+	if len(status) != 2 {
+		t.Fatalf("expected 'expiring' to have dropped out and the other two to remain, got %+v", status)
+	}
+}
+
+func TestStatusOrdering(t *testing.T) {
+	c, _ := newTestCache(time.Unix(0, 0))
+	c.Set("zebra", "pw1", time.Minute)
+	c.Set("apple", "pw2", time.Minute)
+	status := c.Status()
+	if len(status) != 2 || status[0].Connection != "apple" || status[1].Connection != "zebra" {
+		t.Errorf("expected status sorted by connection name, got %+v", status)
+	}
+}
@@ -70,6 +70,25 @@ func IsTelemetryEnabled() bool {
 	return settings.Settings.TelemetryEnabled
 }
 
+// IsCrashReportsEnabled reports whether panic counts may be included in uploaded telemetry.
+func IsCrashReportsEnabled() bool {
+	settings := wconfig.GetWatcher().GetFullConfig()
+	return settings.Settings.TelemetryEnabled && settings.Settings.TelemetryCrashReports
+}
+
+// IsUsageCountsEnabled reports whether general usage counts (tabs, blocks, connections, etc.)
+// may be included in uploaded telemetry.
+func IsUsageCountsEnabled() bool {
+	settings := wconfig.GetWatcher().GetFullConfig()
+	return settings.Settings.TelemetryEnabled && settings.Settings.TelemetryUsageCounts
+}
+
+// IsAIMetadataEnabled reports whether AI request counts may be included in uploaded telemetry.
+func IsAIMetadataEnabled() bool {
+	settings := wconfig.GetWatcher().GetFullConfig()
+	return settings.Settings.TelemetryEnabled && settings.Settings.TelemetryAIMetadata
+}
+
 func IsAutoUpdateEnabled() bool {
 	settings := wconfig.GetWatcher().GetFullConfig()
 	return settings.Settings.AutoUpdateEnabled
@@ -97,6 +116,9 @@ func GoUpdateActivityWrap(update wshrpc.ActivityUpdate, debugStr string) {
 func UpdateActivity(ctx context.Context, update wshrpc.ActivityUpdate) error {
 	now := time.Now()
 	dayStr := daystr.GetCurDayStr()
+	usageOk := IsUsageCountsEnabled()
+	crashOk := IsCrashReportsEnabled()
+	aiOk := IsAIMetadataEnabled()
 	txErr := wstore.WithTx(ctx, func(tx *wstore.TxWrap) error {
 		var tdata TelemetryData
 		query := `SELECT tdata FROM db_activity WHERE day = ?`
@@ -110,66 +132,72 @@ func UpdateActivity(ctx context.Context, update wshrpc.ActivityUpdate) error {
 			}
 			tx.Exec(query, dayStr, tdata, tzName, tzOffset, wavebase.WaveVersion, wavebase.ClientArch(), wavebase.BuildTime, wavebase.UnameKernelRelease())
 		}
-		tdata.FgMinutes += update.FgMinutes
-		tdata.ActiveMinutes += update.ActiveMinutes
-		tdata.OpenMinutes += update.OpenMinutes
-		tdata.NewTab += update.NewTab
-		tdata.NumStartup += update.Startup
-		tdata.NumShutdown += update.Shutdown
-		tdata.SetTabTheme += update.SetTabTheme
-		tdata.NumMagnify += update.NumMagnify
-		tdata.NumPanics += update.NumPanics
-		tdata.NumAIReqs += update.NumAIReqs
-		if update.NumTabs > 0 {
-			tdata.NumTabs = update.NumTabs
-		}
-		if update.NumBlocks > 0 {
-			tdata.NumBlocks = update.NumBlocks
-		}
-		if update.NumWindows > 0 {
-			tdata.NumWindows = update.NumWindows
-		}
-		if update.NumWS > 0 {
-			tdata.NumWS = update.NumWS
-		}
-		if update.NumWSNamed > 0 {
-			tdata.NumWSNamed = update.NumWSNamed
+		if crashOk {
+			tdata.NumPanics += update.NumPanics
 		}
-		if update.NumSSHConn > 0 && update.NumSSHConn > tdata.NumSSHConn {
-			tdata.NumSSHConn = update.NumSSHConn
+		if aiOk {
+			tdata.NumAIReqs += update.NumAIReqs
 		}
-		if update.NumWSLConn > 0 && update.NumWSLConn > tdata.NumWSLConn {
-			tdata.NumWSLConn = update.NumWSLConn
-		}
-		if len(update.Renderers) > 0 {
-			if tdata.Renderers == nil {
-				tdata.Renderers = make(map[string]int)
+		if usageOk {
+			tdata.FgMinutes += update.FgMinutes
+			tdata.ActiveMinutes += update.ActiveMinutes
+			tdata.OpenMinutes += update.OpenMinutes
+			tdata.NewTab += update.NewTab
+			tdata.NumStartup += update.Startup
+			tdata.NumShutdown += update.Shutdown
+			tdata.SetTabTheme += update.SetTabTheme
+			tdata.NumMagnify += update.NumMagnify
+			if update.NumTabs > 0 {
+				tdata.NumTabs = update.NumTabs
 			}
-			for key, val := range update.Renderers {
-				tdata.Renderers[key] += val
+			if update.NumBlocks > 0 {
+				tdata.NumBlocks = update.NumBlocks
 			}
-		}
-		if len(update.WshCmds) > 0 {
-			if tdata.WshCmds == nil {
-				tdata.WshCmds = make(map[string]int)
+			if update.NumWindows > 0 {
+				tdata.NumWindows = update.NumWindows
 			}
-			for key, val := range update.WshCmds {
-				tdata.WshCmds[key] += val
+			if update.NumWS > 0 {
+				tdata.NumWS = update.NumWS
 			}
-		}
-		if len(update.Conn) > 0 {
-			if tdata.Conn == nil {
-				tdata.Conn = make(map[string]int)
+			if update.NumWSNamed > 0 {
+				tdata.NumWSNamed = update.NumWSNamed
 			}
-			for key, val := range update.Conn {
-				tdata.Conn[key] += val
+			if update.NumSSHConn > 0 && update.NumSSHConn > tdata.NumSSHConn {
+				tdata.NumSSHConn = update.NumSSHConn
+			}
+			if update.NumWSLConn > 0 && update.NumWSLConn > tdata.NumWSLConn {
+				tdata.NumWSLConn = update.NumWSLConn
+			}
+			if len(update.Renderers) > 0 {
+				if tdata.Renderers == nil {
+					tdata.Renderers = make(map[string]int)
+				}
+				for key, val := range update.Renderers {
+					tdata.Renderers[key] += val
+				}
+			}
+			if len(update.WshCmds) > 0 {
+				if tdata.WshCmds == nil {
+					tdata.WshCmds = make(map[string]int)
+				}
+				for key, val := range update.WshCmds {
+					tdata.WshCmds[key] += val
+				}
+			}
+			if len(update.Conn) > 0 {
+				if tdata.Conn == nil {
+					tdata.Conn = make(map[string]int)
+				}
+				for key, val := range update.Conn {
+					tdata.Conn[key] += val
+				}
+			}
+			if len(update.Displays) > 0 {
+				tdata.Displays = update.Displays
+			}
+			if len(update.Blocks) > 0 {
+				tdata.Blocks = update.Blocks
 			}
-		}
-		if len(update.Displays) > 0 {
-			tdata.Displays = update.Displays
-		}
-		if len(update.Blocks) > 0 {
-			tdata.Blocks = update.Blocks
 		}
 		query = `UPDATE db_activity
                  SET tdata = ?,
@@ -185,6 +213,12 @@ func UpdateActivity(ctx context.Context, update wshrpc.ActivityUpdate) error {
 	return nil
 }
 
+// GetInspectActivity returns the activity records that would be included in the next telemetry
+// upload, without marking them as uploaded. Used to back a local "what would be sent" viewer.
+func GetInspectActivity(ctx context.Context) ([]*ActivityType, error) {
+	return GetNonUploadedActivity(ctx)
+}
+
 func GetNonUploadedActivity(ctx context.Context) ([]*ActivityType, error) {
 	var rtn []*ActivityType
 	txErr := wstore.WithTx(ctx, func(tx *wstore.TxWrap) error {
@@ -0,0 +1,75 @@
+// Copyright 2026, Command Line Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+// Package termsearch finds occurrences of a plain or regex pattern inside a block's stored PTY
+// blob, so the frontend can implement find-next/find-prev against match offsets (see
+// wshserver.SearchBlockFileCommand) instead of pulling the full scrollback into the webview to
+// search client-side.
+package termsearch
+
+import (
+	"bytes"
+	"fmt"
+	"regexp"
+)
+
+// Match is one occurrence of the search pattern, as a byte offset/length into the searched data.
+type Match struct {
+	Offset int64 `json:"offset"`
+	Length int64 `json:"length"`
+}
+
+// Options controls how Search interprets pattern.
+type Options struct {
+	Regex         bool // pattern is a regular expression instead of a literal substring
+	CaseSensitive bool
+}
+
+// Search returns every non-overlapping match of pattern in data, in order.
+func Search(data []byte, pattern string, opts Options) ([]Match, error) {
+	if pattern == "" {
+		return nil, nil
+	}
+	if opts.Regex {
+		return searchRegex(data, pattern, opts.CaseSensitive)
+	}
+	return searchPlain(data, pattern, opts.CaseSensitive), nil
+}
+
+func searchRegex(data []byte, pattern string, caseSensitive bool) ([]Match, error) {
+	if !caseSensitive {
+		pattern = "(?i)" + pattern
+	}
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return nil, fmt.Errorf("invalid search pattern: %w", err)
+	}
+	idxs := re.FindAllIndex(data, -1)
+	matches := make([]Match, 0, len(idxs))
+	for _, idx := range idxs {
+		matches = append(matches, Match{Offset: int64(idx[0]), Length: int64(idx[1] - idx[0])})
+	}
+	return matches, nil
+}
+
+func searchPlain(data []byte, pattern string, caseSensitive bool) []Match {
+	haystack := data
+	needle := []byte(pattern)
+	if !caseSensitive {
+		haystack = bytes.ToLower(data)
+		needle = bytes.ToLower(needle)
+	}
+	var matches []Match
+	offset := int64(0)
+	for {
+		idx := bytes.Index(haystack, needle)
+		if idx < 0 {
+			break
+		}
+		matches = append(matches, Match{Offset: offset + int64(idx), Length: int64(len(needle))})
+		advance := idx + len(needle)
+		haystack = haystack[advance:]
+		offset += int64(advance)
+	}
+	return matches
+}
@@ -0,0 +1,64 @@
+// Copyright 2026, Command Line Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package termsearch
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestSearchPlain(t *testing.T) {
+	data := []byte("error: foo failed\nok\nerror: bar failed\n")
+	matches, err := Search(data, "error:", Options{CaseSensitive: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []Match{{Offset: 0, Length: 6}, {Offset: 21, Length: 6}}
+	if !reflect.DeepEqual(matches, want) {
+		t.Errorf("got %+v, want %+v", matches, want)
+	}
+}
+
+func TestSearchPlainCaseInsensitive(t *testing.T) {
+	data := []byte("Error: foo\nerror: bar\n")
+	matches, err := Search(data, "ERROR:", Options{CaseSensitive: false})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(matches) != 2 {
+		t.Fatalf("expected 2 case-insensitive matches, got %d: %+v", len(matches), matches)
+	}
+}
+
+func TestSearchRegex(t *testing.T) {
+	data := []byte("exit code 1\nexit code 42\n")
+	matches, err := Search(data, `exit code \d+`, Options{CaseSensitive: true, Regex: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(matches) != 2 {
+		t.Fatalf("expected 2 regex matches, got %d: %+v", len(matches), matches)
+	}
+}
+
+func TestSearchRegexInvalidPattern(t *testing.T) {
+	_, err := Search([]byte("data"), "(unclosed", Options{Regex: true})
+	if err == nil {
+		t.Error("expected an error for an invalid regex pattern")
+	}
+}
+
+func TestSearchEmptyPattern(t *testing.T) {
+	matches, err := Search([]byte("data"), "", Options{})
+	if err != nil || matches != nil {
+		t.Errorf("expected nil, nil for an empty pattern, got %+v, %v", matches, err)
+	}
+}
+
+func TestSearchNoMatch(t *testing.T) {
+	matches, err := Search([]byte("data"), "nope", Options{CaseSensitive: true})
+	if err != nil || len(matches) != 0 {
+		t.Errorf("expected no matches, got %+v, %v", matches, err)
+	}
+}
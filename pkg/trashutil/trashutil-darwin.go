@@ -0,0 +1,28 @@
+// Copyright 2026, Command Line Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+//go:build darwin
+
+package trashutil
+
+import (
+	"context"
+	"fmt"
+	"os/exec"
+	"strings"
+	"time"
+)
+
+// MoveToTrash asks Finder to move path to the Trash via AppleScript. There is no cgo-free way to
+// call the Foundation trash APIs directly, so we shell out the same way wavebase.determineLang does
+// for other macOS-only lookups.
+func MoveToTrash(path string) error {
+	ctx, cancelFn := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancelFn()
+	script := fmt.Sprintf(`tell application "Finder" to delete POSIX file %q`, path)
+	out, err := exec.CommandContext(ctx, "osascript", "-e", script).CombinedOutput()
+	if err != nil {
+		return fmt.Errorf("cannot move %q to Trash: %w (%s)", path, err, strings.TrimSpace(string(out)))
+	}
+	return nil
+}
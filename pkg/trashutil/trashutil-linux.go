@@ -0,0 +1,92 @@
+// Copyright 2026, Command Line Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+//go:build linux
+
+package trashutil
+
+import (
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// escapeTrashPath percent-encodes each path segment for the .trashinfo Path field while leaving the
+// "/" separators intact, since url.PathEscape on the whole string would also escape those.
+func escapeTrashPath(absPath string) string {
+	parts := strings.Split(absPath, "/")
+	for i, part := range parts {
+		parts[i] = url.PathEscape(part)
+	}
+	return strings.Join(parts, "/")
+}
+
+// trashHomeDir returns $XDG_DATA_HOME/Trash, falling back to ~/.local/share/Trash per the
+// freedesktop.org trash spec (https://specifications.freedesktop.org/trash-spec/trashspec-latest.html).
+func trashHomeDir() (string, error) {
+	dataHome := os.Getenv("XDG_DATA_HOME")
+	if dataHome == "" {
+		homeDir, err := os.UserHomeDir()
+		if err != nil {
+			return "", fmt.Errorf("cannot determine home directory: %w", err)
+		}
+		dataHome = filepath.Join(homeDir, ".local", "share")
+	}
+	return filepath.Join(dataHome, "Trash"), nil
+}
+
+// uniqueTrashName finds a free name in dir for baseName, appending " (n)" before any extension
+// (matching the convention FileService.resolveDestPath already uses for rename conflicts) when the
+// plain name is taken.
+func uniqueTrashName(dir string, baseName string) (string, error) {
+	candidate := baseName
+	ext := filepath.Ext(baseName)
+	stem := baseName[:len(baseName)-len(ext)]
+	for i := 1; ; i++ {
+		if _, err := os.Stat(filepath.Join(dir, candidate)); os.IsNotExist(err) {
+			return candidate, nil
+		} else if err != nil {
+			return "", err
+		}
+		candidate = fmt.Sprintf("%s (%s)%s", stem, strconv.Itoa(i), ext)
+	}
+}
+
+// MoveToTrash moves path into $XDG_DATA_HOME/Trash/files and writes the companion .trashinfo
+// metadata file, per the freedesktop.org trash spec.
+func MoveToTrash(path string) error {
+	absPath, err := filepath.Abs(path)
+	if err != nil {
+		return fmt.Errorf("cannot resolve absolute path for %q: %w", path, err)
+	}
+	trashHome, err := trashHomeDir()
+	if err != nil {
+		return err
+	}
+	filesDir := filepath.Join(trashHome, "files")
+	infoDir := filepath.Join(trashHome, "info")
+	if err := os.MkdirAll(filesDir, 0700); err != nil {
+		return fmt.Errorf("cannot create trash directory %q: %w", filesDir, err)
+	}
+	if err := os.MkdirAll(infoDir, 0700); err != nil {
+		return fmt.Errorf("cannot create trash directory %q: %w", infoDir, err)
+	}
+	trashName, err := uniqueTrashName(filesDir, filepath.Base(absPath))
+	if err != nil {
+		return fmt.Errorf("cannot pick a free trash name for %q: %w", path, err)
+	}
+	infoContents := fmt.Sprintf("[Trash Info]\nPath=%s\nDeletionDate=%s\n", escapeTrashPath(absPath), time.Now().Format("2006-01-02T15:04:05"))
+	infoPath := filepath.Join(infoDir, trashName+".trashinfo")
+	if err := os.WriteFile(infoPath, []byte(infoContents), 0600); err != nil {
+		return fmt.Errorf("cannot write trash info file %q: %w", infoPath, err)
+	}
+	if err := os.Rename(absPath, filepath.Join(filesDir, trashName)); err != nil {
+		os.Remove(infoPath)
+		return fmt.Errorf("cannot move %q to Trash: %w", path, err)
+	}
+	return nil
+}
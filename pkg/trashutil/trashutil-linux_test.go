@@ -0,0 +1,72 @@
+// Copyright 2026, Command Line Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+//go:build linux
+
+package trashutil
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestMoveToTrash(t *testing.T) {
+	dataHome := t.TempDir()
+	t.Setenv("XDG_DATA_HOME", dataHome)
+
+	srcDir := t.TempDir()
+	srcPath := filepath.Join(srcDir, "deleteme.txt")
+	if err := os.WriteFile(srcPath, []byte("hello"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := MoveToTrash(srcPath); err != nil {
+		t.Fatalf("MoveToTrash failed: %v", err)
+	}
+	if _, err := os.Stat(srcPath); !os.IsNotExist(err) {
+		t.Fatalf("expected %q to be gone after trashing, stat err: %v", srcPath, err)
+	}
+
+	trashedPath := filepath.Join(dataHome, "Trash", "files", "deleteme.txt")
+	data, err := os.ReadFile(trashedPath)
+	if err != nil {
+		t.Fatalf("expected trashed file at %q: %v", trashedPath, err)
+	}
+	if string(data) != "hello" {
+		t.Fatalf("trashed file contents mismatch: got %q", data)
+	}
+
+	infoPath := filepath.Join(dataHome, "Trash", "info", "deleteme.txt.trashinfo")
+	info, err := os.ReadFile(infoPath)
+	if err != nil {
+		t.Fatalf("expected trashinfo file at %q: %v", infoPath, err)
+	}
+	if !strings.Contains(string(info), "[Trash Info]") || !strings.Contains(string(info), "Path=") {
+		t.Fatalf("trashinfo file missing expected fields: %q", info)
+	}
+}
+
+func TestMoveToTrashNameCollision(t *testing.T) {
+	dataHome := t.TempDir()
+	t.Setenv("XDG_DATA_HOME", dataHome)
+
+	srcDir := t.TempDir()
+	for i := 0; i < 2; i++ {
+		srcPath := filepath.Join(srcDir, "dup.txt")
+		if err := os.WriteFile(srcPath, []byte("x"), 0644); err != nil {
+			t.Fatal(err)
+		}
+		if err := MoveToTrash(srcPath); err != nil {
+			t.Fatalf("MoveToTrash failed on iteration %d: %v", i, err)
+		}
+	}
+
+	if _, err := os.Stat(filepath.Join(dataHome, "Trash", "files", "dup.txt")); err != nil {
+		t.Fatalf("expected first trashed copy: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(dataHome, "Trash", "files", "dup (1).txt")); err != nil {
+		t.Fatalf("expected second trashed copy to be renamed: %v", err)
+	}
+}
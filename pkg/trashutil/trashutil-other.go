@@ -0,0 +1,13 @@
+// Copyright 2026, Command Line Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+//go:build !darwin && !windows && !linux
+
+package trashutil
+
+import "fmt"
+
+// MoveToTrash has no implementation on this OS; callers must check IsSupported first.
+func MoveToTrash(path string) error {
+	return fmt.Errorf("moving files to the trash is not supported on this platform")
+}
@@ -0,0 +1,32 @@
+// Copyright 2026, Command Line Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+//go:build windows
+
+package trashutil
+
+import (
+	"context"
+	"fmt"
+	"os/exec"
+	"strings"
+	"time"
+)
+
+// MoveToTrash sends path to the Recycle Bin via the VisualBasic.FileIO Recycle API, driven through
+// PowerShell. golang.org/x/sys/windows does not wrap the shell32 IFileOperation/SHFileOperation
+// APIs that would be needed to do this with a direct syscall, so we shell out instead, the same way
+// wavebase.determineLang shells out to Get-Culture for the Windows locale.
+func MoveToTrash(path string) error {
+	ctx, cancelFn := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancelFn()
+	script := fmt.Sprintf(
+		`Add-Type -AssemblyName Microsoft.VisualBasic; [Microsoft.VisualBasic.FileIO.FileSystem]::DeleteFile(%q, 'OnlyErrorDialogs', 'SendToRecycleBin')`,
+		path,
+	)
+	out, err := exec.CommandContext(ctx, "powershell.exe", "-NoProfile", "-NonInteractive", "-Command", script).CombinedOutput()
+	if err != nil {
+		return fmt.Errorf("cannot move %q to the Recycle Bin: %w (%s)", path, err, strings.TrimSpace(string(out)))
+	}
+	return nil
+}
@@ -0,0 +1,22 @@
+// Copyright 2026, Command Line Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+// Package trashutil moves locally-deleted files into the OS trash (macOS Trash, the freedesktop.org
+// trash spec on Linux, the Windows Recycle Bin) instead of unlinking them outright. There is no
+// trash facility to speak of on a remote connection (the wsh helper has no desktop session to hand
+// the file to), so callers should only use this package for local deletes and fall back to a plain
+// os.Remove, surfaced to the user as a permanent delete, everywhere else -- see IsSupported.
+package trashutil
+
+import "runtime"
+
+// IsSupported reports whether MoveToTrash has a real trash implementation on this OS. It is always
+// false for remote connections, since they are not routed through this package at all.
+func IsSupported() bool {
+	switch runtime.GOOS {
+	case "darwin", "windows", "linux":
+		return true
+	default:
+		return false
+	}
+}
@@ -0,0 +1,180 @@
+// Copyright 2025, Command Line Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+// Package dbbackup implements online sqlite backups (via the sqlite3 backup API, so a backup can
+// run against a live, open database without blocking writers for more than a single step) for
+// pkg/wstore and pkg/filestore, plus the file bookkeeping (naming, listing, pruning, restoring)
+// shared by both stores' backup loops and by the "wavesrv --restore-backup" startup path.
+package dbbackup
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	sqlite3 "github.com/mattn/go-sqlite3"
+)
+
+// BackupFileName returns the name of the backup file for storeName (e.g. "wstore") taken at tsMs
+// (a time.Now().UnixMilli() value).
+func BackupFileName(storeName string, tsMs int64) string {
+	return fmt.Sprintf("%s-%d.db", storeName, tsMs)
+}
+
+var backupFileRe = regexp.MustCompile(`^([a-z]+)-(\d+)\.db$`)
+
+// ListBackups returns the timestamps (in descending order, newest first) of every backup found
+// for storeName in dir.
+func ListBackups(dir string, storeName string) ([]int64, error) {
+	ents, err := os.ReadDir(dir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	var rtn []int64
+	for _, ent := range ents {
+		m := backupFileRe.FindStringSubmatch(ent.Name())
+		if m == nil || m[1] != storeName {
+			continue
+		}
+		ts, err := strconv.ParseInt(m[2], 10, 64)
+		if err != nil {
+			continue
+		}
+		rtn = append(rtn, ts)
+	}
+	sort.Slice(rtn, func(i, j int) bool { return rtn[i] > rtn[j] })
+	return rtn, nil
+}
+
+// PruneBackups deletes the oldest backups for storeName in dir beyond the most recent keep.
+func PruneBackups(dir string, storeName string, keep int) error {
+	timestamps, err := ListBackups(dir, storeName)
+	if err != nil {
+		return err
+	}
+	if len(timestamps) <= keep {
+		return nil
+	}
+	for _, ts := range timestamps[keep:] {
+		path := filepath.Join(dir, BackupFileName(storeName, ts))
+		if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+			return fmt.Errorf("error removing old backup %q: %w", path, err)
+		}
+	}
+	return nil
+}
+
+// withRawConn runs fn with the *sqlite3.SQLiteConn underlying a database/sql connection checked
+// out of db, for use with the sqlite3 backup API (which operates on driver connections, not
+// *sql.DB).
+func withRawConn(ctx context.Context, db *sql.DB, fn func(*sqlite3.SQLiteConn) error) error {
+	conn, err := db.Conn(ctx)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+	return conn.Raw(func(driverConn any) error {
+		sqliteConn, ok := driverConn.(*sqlite3.SQLiteConn)
+		if !ok {
+			return fmt.Errorf("expected *sqlite3.SQLiteConn, got %T", driverConn)
+		}
+		return fn(sqliteConn)
+	})
+}
+
+// BackupSQLiteDB makes an online backup of srcDB (an already-open database/sql handle using the
+// sqlite3 driver) into a fresh file at destPath, via the sqlite3 backup API. destPath must not
+// already exist.
+func BackupSQLiteDB(ctx context.Context, srcDB *sql.DB, destPath string) error {
+	destDB, err := sql.Open("sqlite3", destPath)
+	if err != nil {
+		return fmt.Errorf("error opening backup destination %q: %w", destPath, err)
+	}
+	defer destDB.Close()
+	// force the driver to actually create/open the file before we reach for its raw conn
+	if err := destDB.PingContext(ctx); err != nil {
+		return fmt.Errorf("error opening backup destination %q: %w", destPath, err)
+	}
+	err = withRawConn(ctx, destDB, func(destConn *sqlite3.SQLiteConn) error {
+		return withRawConn(ctx, srcDB, func(srcConn *sqlite3.SQLiteConn) error {
+			backup, err := destConn.Backup("main", srcConn, "main")
+			if err != nil {
+				return fmt.Errorf("error starting backup: %w", err)
+			}
+			defer backup.Close()
+			for {
+				done, err := backup.Step(-1)
+				if err != nil {
+					return fmt.Errorf("error stepping backup: %w", err)
+				}
+				if done {
+					return nil
+				}
+			}
+		})
+	})
+	if err != nil {
+		os.Remove(destPath)
+		return err
+	}
+	return nil
+}
+
+// RestoreBackup replaces liveDBPath with the contents of backupPath, removing liveDBPath's
+// -wal/-shm siblings (if any) so a stale WAL from the previous database doesn't get replayed on
+// top of the restored file. Call this before the store the db belongs to has opened it.
+func RestoreBackup(backupPath string, liveDBPath string) error {
+	if _, err := os.Stat(backupPath); err != nil {
+		return fmt.Errorf("backup file %q not found: %w", backupPath, err)
+	}
+	for _, suffix := range []string{"", "-wal", "-shm"} {
+		if err := os.Remove(liveDBPath + suffix); err != nil && !os.IsNotExist(err) {
+			return fmt.Errorf("error removing %q: %w", liveDBPath+suffix, err)
+		}
+	}
+	in, err := os.Open(backupPath)
+	if err != nil {
+		return fmt.Errorf("error opening backup %q: %w", backupPath, err)
+	}
+	defer in.Close()
+	out, err := os.OpenFile(liveDBPath, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0600)
+	if err != nil {
+		return fmt.Errorf("error creating %q: %w", liveDBPath, err)
+	}
+	defer out.Close()
+	if _, err := io.Copy(out, in); err != nil {
+		return fmt.Errorf("error copying backup into %q: %w", liveDBPath, err)
+	}
+	return nil
+}
+
+// ParseTimestampArg parses the --restore-backup argument, accepting either an exact millisecond
+// timestamp (as printed by ListBackups/BackupFileName) or the special value "latest".
+func ParseTimestampArg(arg string, available []int64) (int64, error) {
+	if strings.EqualFold(arg, "latest") {
+		if len(available) == 0 {
+			return 0, fmt.Errorf("no backups available")
+		}
+		return available[0], nil
+	}
+	ts, err := strconv.ParseInt(arg, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid timestamp %q (expected a millisecond timestamp or \"latest\")", arg)
+	}
+	for _, cand := range available {
+		if cand == ts {
+			return ts, nil
+		}
+	}
+	return 0, fmt.Errorf("no backup found with timestamp %d", ts)
+}
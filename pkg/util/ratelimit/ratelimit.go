@@ -0,0 +1,71 @@
+// Copyright 2026, Command Line Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+// Package ratelimit provides a simple token-bucket limiter for throttling byte-oriented
+// transfers (file copy/streaming) to a fixed bytes-per-second cap.
+package ratelimit
+
+import (
+	"sync"
+	"time"
+)
+
+// Limiter caps throughput at a configurable bytes-per-second rate. The rate can be changed live
+// via SetRate; a non-positive rate disables throttling entirely.
+type Limiter struct {
+	mu         sync.Mutex
+	ratePerSec int64
+	tokens     float64
+	lastCheck  time.Time
+}
+
+// New returns a Limiter capped at ratePerSec bytes/sec. A non-positive rate disables throttling.
+func New(ratePerSec int64) *Limiter {
+	return &Limiter{
+		ratePerSec: ratePerSec,
+		tokens:     float64(ratePerSec),
+		lastCheck:  time.Now(),
+	}
+}
+
+// SetRate live-adjusts the cap. Banked tokens are capped to the new rate so lowering the limit
+// takes effect immediately rather than after the caller burns through a stale allowance.
+func (l *Limiter) SetRate(ratePerSec int64) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.ratePerSec = ratePerSec
+	if l.tokens > float64(ratePerSec) {
+		l.tokens = float64(ratePerSec)
+	}
+}
+
+// WaitN blocks until n bytes are permitted to proceed, sleeping if the caller is running ahead of
+// the configured rate. A nil Limiter, or one with a non-positive rate, never blocks.
+func (l *Limiter) WaitN(n int) {
+	if l == nil {
+		return
+	}
+	l.mu.Lock()
+	rate := l.ratePerSec
+	if rate <= 0 {
+		l.mu.Unlock()
+		return
+	}
+	now := time.Now()
+	elapsed := now.Sub(l.lastCheck).Seconds()
+	l.lastCheck = now
+	l.tokens += elapsed * float64(rate)
+	if l.tokens > float64(rate) {
+		l.tokens = float64(rate)
+	}
+	l.tokens -= float64(n)
+	var sleepFor time.Duration
+	if l.tokens < 0 {
+		sleepFor = time.Duration(-l.tokens / float64(rate) * float64(time.Second))
+		l.tokens = 0
+	}
+	l.mu.Unlock()
+	if sleepFor > 0 {
+		time.Sleep(sleepFor)
+	}
+}
@@ -643,6 +643,11 @@ func DetectMimeType(path string, fileInfo fs.FileInfo, extended bool) string {
 		return "block-special"
 	}
 	ext := filepath.Ext(path)
+	// .mmd is used in this app for Mermaid diagram source, not the IANA-registered karaoke-mmd
+	// format that StaticMimeTypeMap maps it to, so check it before falling back to that map.
+	if ext == ".mmd" {
+		return "text/x-mermaid"
+	}
 	if mimeType, ok := StaticMimeTypeMap[ext]; ok {
 		return mimeType
 	}
@@ -984,3 +989,45 @@ func FilterValidArch(arch string) (string, error) {
 	}
 	return "", fmt.Errorf("unknown architecture: %s", formatted)
 }
+
+var byteSizeRe = regexp.MustCompile(`(?i)^([0-9]*\.?[0-9]+)\s*([kmgt]?i?b?)$`)
+
+var byteSizeSuffixes = map[string]int64{
+	"":   1,
+	"b":  1,
+	"k":  1024,
+	"kb": 1024,
+	"ki": 1024,
+	"m":  1024 * 1024,
+	"mb": 1024 * 1024,
+	"mi": 1024 * 1024,
+	"g":  1024 * 1024 * 1024,
+	"gb": 1024 * 1024 * 1024,
+	"gi": 1024 * 1024 * 1024,
+	"t":  1024 * 1024 * 1024 * 1024,
+	"tb": 1024 * 1024 * 1024 * 1024,
+	"ti": 1024 * 1024 * 1024 * 1024,
+}
+
+// ParseByteSizeStr parses a human-readable byte size like "5M", "512k", "1.5GB", or a bare number
+// of bytes, returning the value in bytes. Suffixes are case-insensitive and the trailing "b"/"ib"
+// is optional (so "5M" and "5MiB" both mean 5*1024*1024).
+func ParseByteSizeStr(s string) (int64, error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return 0, fmt.Errorf("empty size string")
+	}
+	m := byteSizeRe.FindStringSubmatch(s)
+	if m == nil {
+		return 0, fmt.Errorf("invalid size string: %q", s)
+	}
+	val, err := strconv.ParseFloat(m[1], 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid size string: %q", s)
+	}
+	mult, ok := byteSizeSuffixes[strings.ToLower(m[2])]
+	if !ok {
+		return 0, fmt.Errorf("unknown size suffix: %q", m[2])
+	}
+	return int64(val * float64(mult)), nil
+}
@@ -215,6 +215,18 @@ type VDomTargetToolbar struct {
 	Height  string `json:"height,omitempty"`
 }
 
+// VDomRendererRegistration lets a wsh-launched vdom app register itself as a custom preview
+// renderer for specific MIME types or file extensions. Once registered (via
+// wshclient.RegisterVDomRendererCommand), the preview block offers this app as the specialized
+// view whenever it opens a file matching one of MimeTypes/Extensions and no built-in view handles
+// that file.
+type VDomRendererRegistration struct {
+	MimeTypes   []string `json:"mimetypes,omitempty"`
+	Extensions  []string `json:"extensions,omitempty"` // file extensions, without a leading dot, e.g. "proto"
+	DisplayName string   `json:"displayname"`
+	Priority    int      `json:"priority,omitempty"` // higher wins when multiple renderers match the same file
+}
+
 // matches WaveKeyboardEvent
 type VDomKeyboardEvent struct {
 	Type     string `json:"type"`
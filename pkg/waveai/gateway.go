@@ -0,0 +1,64 @@
+// Copyright 2024, Command Line Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package waveai
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/wavetermdev/waveterm/pkg/wshrpc"
+)
+
+// gatewayTransport rewrites outgoing request headers according to a
+// self-hosted gateway's configured auth scheme, so a single BaseURL/header
+// template can front any of the supported AI providers.
+type gatewayTransport struct {
+	headers map[string]string
+	base    http.RoundTripper
+}
+
+func (t *gatewayTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	for k, v := range t.headers {
+		req.Header.Set(k, v)
+	}
+	base := t.base
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	return base.RoundTrip(req)
+}
+
+// parseAuthHeaderTemplate parses a template of "Header-Name: value" lines,
+// substituting {{apitoken}} and {{orgid}} placeholders from opts.
+func parseAuthHeaderTemplate(tmpl string, opts *wshrpc.WaveAIOptsType) map[string]string {
+	headers := make(map[string]string)
+	for _, line := range strings.Split(tmpl, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+		parts := strings.SplitN(line, ":", 2)
+		if len(parts) != 2 {
+			continue
+		}
+		name := strings.TrimSpace(parts[0])
+		value := strings.TrimSpace(parts[1])
+		value = strings.ReplaceAll(value, "{{apitoken}}", opts.APIToken)
+		value = strings.ReplaceAll(value, "{{orgid}}", opts.OrgID)
+		headers[name] = value
+	}
+	return headers
+}
+
+// gatewayHTTPClient returns an *http.Client that applies opts.AuthHeaderTemplate
+// to every request, or nil if no template is configured (callers should fall
+// back to their normal client construction in that case).
+func gatewayHTTPClient(opts *wshrpc.WaveAIOptsType) *http.Client {
+	if opts == nil || opts.AuthHeaderTemplate == "" {
+		return nil
+	}
+	return &http.Client{
+		Transport: &gatewayTransport{headers: parseAuthHeaderTemplate(opts.AuthHeaderTemplate, opts)},
+	}
+}
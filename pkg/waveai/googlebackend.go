@@ -2,8 +2,10 @@ package waveai
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"log"
+	"strings"
 
 	"github.com/google/generative-ai-go/genai"
 	"github.com/wavetermdev/waveterm/pkg/wshrpc"
@@ -11,6 +13,126 @@ import (
 	"google.golang.org/api/option"
 )
 
+// maxGoogleMcpToolRounds mirrors maxMcpToolRounds in openaibackend.go -- bounds how many times
+// the model can call MCP tools in a row before we give up and return its last message.
+const maxGoogleMcpToolRounds = 5
+
+func mcpToolsToGoogleTools(tools []McpToolDef) []*genai.Tool {
+	var decls []*genai.FunctionDeclaration
+	for _, tool := range tools {
+		schema, err := jsonSchemaToGenaiSchema(tool.InputSchema)
+		if err != nil {
+			log.Printf("skipping mcp tool %q, unsupported schema: %v", tool.Name, err)
+			continue
+		}
+		decls = append(decls, &genai.FunctionDeclaration{
+			Name:        tool.Name,
+			Description: tool.Description,
+			Parameters:  schema,
+		})
+	}
+	if len(decls) == 0 {
+		return nil
+	}
+	return []*genai.Tool{{FunctionDeclarations: decls}}
+}
+
+// jsonSchemaToGenaiSchema converts the subset of JSON Schema that MCP tools actually use
+// (object/string/number/integer/boolean/array, properties, required, enum, description) into
+// genai's OpenAPI-subset Schema type. Unrecognized/advanced JSON Schema features are dropped
+// rather than rejected, since MCP tool schemas in practice stick to this common subset.
+func jsonSchemaToGenaiSchema(raw json.RawMessage) (*genai.Schema, error) {
+	if len(raw) == 0 {
+		return &genai.Schema{Type: genai.TypeObject}, nil
+	}
+	var node struct {
+		Type        string                     `json:"type"`
+		Description string                     `json:"description"`
+		Enum        []string                   `json:"enum"`
+		Items       json.RawMessage            `json:"items"`
+		Properties  map[string]json.RawMessage `json:"properties"`
+		Required    []string                   `json:"required"`
+	}
+	if err := json.Unmarshal(raw, &node); err != nil {
+		return nil, fmt.Errorf("parsing json schema: %w", err)
+	}
+	schema := &genai.Schema{
+		Type:        jsonSchemaTypeToGenaiType(node.Type),
+		Description: node.Description,
+		Enum:        node.Enum,
+		Required:    node.Required,
+	}
+	if len(node.Items) > 0 {
+		itemSchema, err := jsonSchemaToGenaiSchema(node.Items)
+		if err == nil {
+			schema.Items = itemSchema
+		}
+	}
+	if len(node.Properties) > 0 {
+		schema.Properties = make(map[string]*genai.Schema, len(node.Properties))
+		for propName, propRaw := range node.Properties {
+			propSchema, err := jsonSchemaToGenaiSchema(propRaw)
+			if err != nil {
+				continue
+			}
+			schema.Properties[propName] = propSchema
+		}
+	}
+	return schema, nil
+}
+
+func jsonSchemaTypeToGenaiType(jsonType string) genai.Type {
+	switch strings.ToLower(jsonType) {
+	case "object":
+		return genai.TypeObject
+	case "array":
+		return genai.TypeArray
+	case "string":
+		return genai.TypeString
+	case "number":
+		return genai.TypeNumber
+	case "integer":
+		return genai.TypeInteger
+	case "boolean":
+		return genai.TypeBoolean
+	default:
+		return genai.TypeObject
+	}
+}
+
+// runGoogleToolLoop performs a synchronous request/tool-call/response loop (mirrors
+// runOpenAIToolLoop) so MCP tool calls -- each gated by the approval prompt in ExecuteMcpTool --
+// run to completion before we stream the model's final answer back to the frontend.
+func runGoogleToolLoop(ctx context.Context, cs *genai.ChatSession, firstPart genai.Part) (*genai.GenerateContentResponse, error) {
+	resp, err := cs.SendMessage(ctx, firstPart)
+	if err != nil {
+		return nil, err
+	}
+	for round := 0; round < maxGoogleMcpToolRounds; round++ {
+		var funcCalls []genai.FunctionCall
+		for _, cand := range resp.Candidates {
+			funcCalls = append(funcCalls, cand.FunctionCalls()...)
+		}
+		if len(funcCalls) == 0 {
+			return resp, nil
+		}
+		var responseParts []genai.Part
+		for _, fc := range funcCalls {
+			resultText, err := ExecuteMcpTool(ctx, fc.Name, fc.Args)
+			respValue := map[string]interface{}{"result": resultText}
+			if err != nil {
+				respValue = map[string]interface{}{"error": err.Error()}
+			}
+			responseParts = append(responseParts, genai.FunctionResponse{Name: fc.Name, Response: respValue})
+		}
+		resp, err = cs.SendMessage(ctx, responseParts...)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return resp, nil
+}
+
 type GoogleBackend struct{}
 
 var _ AIBackend = GoogleBackend{}
@@ -29,12 +151,38 @@ func (GoogleBackend) StreamCompletion(ctx context.Context, request wshrpc.WaveAI
 		return nil
 	}
 
+	if request.Opts.UseMcpTools {
+		if mcpTools, err := DiscoverMcpTools(ctx); err != nil {
+			log.Printf("error discovering mcp tools: %v", err)
+		} else if googleTools := mcpToolsToGoogleTools(mcpTools); googleTools != nil {
+			model.Tools = googleTools
+		}
+	}
+
 	cs := model.StartChat()
 	cs.History = extractHistory(request.Prompt)
-	iter := cs.SendMessageStream(ctx, extractPrompt(request.Prompt))
 
 	rtn := make(chan wshrpc.RespOrErrorUnion[wshrpc.WaveAIPacketType])
 
+	// Tool calls require inspecting the response and possibly making follow-up requests before
+	// we have a final answer, so that path runs synchronously (like the openai backend) and
+	// streams only the end result; the plain no-tools path keeps streaming incrementally below.
+	if len(model.Tools) > 0 {
+		go func() {
+			defer client.Close()
+			defer close(rtn)
+			resp, err := runGoogleToolLoop(ctx, cs, extractPrompt(request.Prompt))
+			if err != nil {
+				rtn <- makeAIError(fmt.Errorf("Google API error: %v", err))
+				return
+			}
+			rtn <- wshrpc.RespOrErrorUnion[wshrpc.WaveAIPacketType]{Response: wshrpc.WaveAIPacketType{Text: convertCandidatesToText(resp.Candidates)}}
+		}()
+		return rtn
+	}
+
+	iter := cs.SendMessageStream(ctx, extractPrompt(request.Prompt))
+
 	go func() {
 		defer client.Close()
 		defer close(rtn)
@@ -0,0 +1,73 @@
+// Copyright 2024, Command Line Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package waveai
+
+import (
+	"strings"
+	"sync"
+	"time"
+)
+
+// cooldown applied to a key after it reports a rate-limit/quota error, before
+// it is offered again by the rotator.
+const keyCooldownDuration = 60 * time.Second
+
+type keyRotator struct {
+	lock       sync.Mutex
+	nextIdx    map[string]int       // apitype -> next index to try
+	cooldownTo map[string]time.Time // apitype+key -> cooldown expiry
+}
+
+var globalKeyRotator = &keyRotator{
+	nextIdx:    make(map[string]int),
+	cooldownTo: make(map[string]time.Time),
+}
+
+// selectKey returns the next API key to use for apiType, skipping any keys
+// still in their rate-limit cooldown window. Falls back to the first key if
+// all are cooling down.
+func (r *keyRotator) selectKey(apiType string, keys []string) string {
+	if len(keys) == 0 {
+		return ""
+	}
+	if len(keys) == 1 {
+		return keys[0]
+	}
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	start := r.nextIdx[apiType]
+	now := time.Now()
+	for i := 0; i < len(keys); i++ {
+		idx := (start + i) % len(keys)
+		key := keys[idx]
+		if cdTo, ok := r.cooldownTo[apiType+":"+key]; !ok || now.After(cdTo) {
+			r.nextIdx[apiType] = (idx + 1) % len(keys)
+			return key
+		}
+	}
+	// all keys cooling down, use round-robin anyway
+	r.nextIdx[apiType] = (start + 1) % len(keys)
+	return keys[start]
+}
+
+// markRateLimited puts the given key into a cooldown period so the rotator
+// skips it on subsequent requests.
+func (r *keyRotator) markRateLimited(apiType string, key string) {
+	if key == "" {
+		return
+	}
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	r.cooldownTo[apiType+":"+key] = time.Now().Add(keyCooldownDuration)
+}
+
+// isRateLimitError does a best-effort match on common rate-limit/quota error
+// phrasing used across OpenAI/Anthropic/Google/Perplexity error responses.
+func isRateLimitError(errStr string) bool {
+	lower := strings.ToLower(errStr)
+	return strings.Contains(lower, "429") ||
+		strings.Contains(lower, "rate limit") ||
+		strings.Contains(lower, "rate_limit") ||
+		strings.Contains(lower, "quota")
+}
@@ -0,0 +1,269 @@
+// Copyright 2024, Command Line Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package waveai
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os/exec"
+	"strings"
+	"time"
+
+	"github.com/wavetermdev/waveterm/pkg/userinput"
+	"github.com/wavetermdev/waveterm/pkg/wconfig"
+)
+
+// McpToolDef is a single tool surfaced by a user-configured MCP server (see
+// wconfig.McpServerConfigType / mcpservers.json), in a backend-agnostic shape. The openai and
+// google backends convert these into their own function-calling schemas.
+type McpToolDef struct {
+	Name        string          `json:"name"` // qualified as "<servername>__<toolname>" to avoid collisions across servers
+	Description string          `json:"description"`
+	InputSchema json.RawMessage `json:"inputSchema"`
+}
+
+const mcpServerNameSep = "__"
+const mcpRequestTimeout = 10 * time.Second
+
+type mcpRpcRequest struct {
+	JsonRpc string      `json:"jsonrpc"`
+	Id      int         `json:"id"`
+	Method  string      `json:"method"`
+	Params  interface{} `json:"params,omitempty"`
+}
+
+type mcpRpcResponse struct {
+	Id     int             `json:"id"`
+	Result json.RawMessage `json:"result,omitempty"`
+	Error  *struct {
+		Message string `json:"message"`
+	} `json:"error,omitempty"`
+}
+
+// mcpSession is a single stdio connection to one MCP server subprocess, alive only for the
+// duration of one discovery or tool-call round trip (mirrors the one-shot-process style used by
+// "wsh mcp" itself -- there is no long-lived daemon connection to manage here).
+type mcpSession struct {
+	cmd     *exec.Cmd
+	stdin   *bufio.Writer
+	stdout  *bufio.Scanner
+	reqId   int
+	started bool
+}
+
+func startMcpSession(ctx context.Context, serverCfg wconfig.McpServerConfigType) (*mcpSession, error) {
+	if serverCfg.Command == "" {
+		return nil, fmt.Errorf("mcp server has no command configured")
+	}
+	cmd := exec.CommandContext(ctx, serverCfg.Command, serverCfg.Args...)
+	stdinPipe, err := cmd.StdinPipe()
+	if err != nil {
+		return nil, fmt.Errorf("creating mcp server stdin pipe: %w", err)
+	}
+	stdoutPipe, err := cmd.StdoutPipe()
+	if err != nil {
+		return nil, fmt.Errorf("creating mcp server stdout pipe: %w", err)
+	}
+	if err := cmd.Start(); err != nil {
+		return nil, fmt.Errorf("starting mcp server %q: %w", serverCfg.Command, err)
+	}
+	sess := &mcpSession{cmd: cmd, stdin: bufio.NewWriter(stdinPipe), stdout: bufio.NewScanner(stdoutPipe)}
+	sess.stdout.Buffer(make([]byte, 0, 64*1024), 10*1024*1024)
+	return sess, nil
+}
+
+func (sess *mcpSession) close() {
+	sess.cmd.Process.Kill()
+	sess.cmd.Wait()
+}
+
+func (sess *mcpSession) call(method string, params interface{}) (json.RawMessage, error) {
+	sess.reqId++
+	req := mcpRpcRequest{JsonRpc: "2.0", Id: sess.reqId, Method: method, Params: params}
+	reqBytes, err := json.Marshal(req)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := sess.stdin.Write(append(reqBytes, '\n')); err != nil {
+		return nil, fmt.Errorf("writing mcp request: %w", err)
+	}
+	if err := sess.stdin.Flush(); err != nil {
+		return nil, fmt.Errorf("flushing mcp request: %w", err)
+	}
+	for sess.stdout.Scan() {
+		line := strings.TrimSpace(sess.stdout.Text())
+		if line == "" {
+			continue
+		}
+		var resp mcpRpcResponse
+		if err := json.Unmarshal([]byte(line), &resp); err != nil {
+			continue
+		}
+		if resp.Id != sess.reqId {
+			continue
+		}
+		if resp.Error != nil {
+			return nil, fmt.Errorf("mcp server error: %s", resp.Error.Message)
+		}
+		return resp.Result, nil
+	}
+	if err := sess.stdout.Err(); err != nil {
+		return nil, fmt.Errorf("reading mcp response: %w", err)
+	}
+	return nil, fmt.Errorf("mcp server closed without responding")
+}
+
+func (sess *mcpSession) notify(method string) {
+	reqBytes, err := json.Marshal(map[string]interface{}{"jsonrpc": "2.0", "method": method})
+	if err != nil {
+		return
+	}
+	sess.stdin.Write(append(reqBytes, '\n'))
+	sess.stdin.Flush()
+}
+
+func (sess *mcpSession) initialize() error {
+	_, err := sess.call("initialize", map[string]interface{}{
+		"protocolVersion": "2024-11-05",
+		"capabilities":    map[string]interface{}{},
+		"clientInfo":      map[string]interface{}{"name": "waveterm-aiusechat", "version": "1"},
+	})
+	if err != nil {
+		return err
+	}
+	sess.notify("notifications/initialized")
+	return nil
+}
+
+// DiscoverMcpTools connects to every enabled server in wconfig's mcpservers config, lists its
+// tools, and returns them combined with server-qualified names. A server that fails to start or
+// respond is skipped (logged by the caller via the returned error text embedded per-tool is not
+// done here; callers that want partial results on partial failure should treat this as best-effort).
+func DiscoverMcpTools(ctx context.Context) ([]McpToolDef, error) {
+	servers := wconfig.GetWatcher().GetFullConfig().McpServers
+	var rtn []McpToolDef
+	for serverName, serverCfg := range servers {
+		if !serverCfg.Enabled {
+			continue
+		}
+		tools, err := discoverServerTools(ctx, serverName, serverCfg)
+		if err != nil {
+			continue
+		}
+		rtn = append(rtn, tools...)
+	}
+	return rtn, nil
+}
+
+func discoverServerTools(ctx context.Context, serverName string, serverCfg wconfig.McpServerConfigType) ([]McpToolDef, error) {
+	sessCtx, cancel := context.WithTimeout(ctx, mcpRequestTimeout)
+	defer cancel()
+	sess, err := startMcpSession(sessCtx, serverCfg)
+	if err != nil {
+		return nil, err
+	}
+	defer sess.close()
+	if err := sess.initialize(); err != nil {
+		return nil, err
+	}
+	result, err := sess.call("tools/list", map[string]interface{}{})
+	if err != nil {
+		return nil, err
+	}
+	var listResult struct {
+		Tools []struct {
+			Name        string          `json:"name"`
+			Description string          `json:"description"`
+			InputSchema json.RawMessage `json:"inputSchema"`
+		} `json:"tools"`
+	}
+	if err := json.Unmarshal(result, &listResult); err != nil {
+		return nil, fmt.Errorf("parsing tools/list result: %w", err)
+	}
+	var rtn []McpToolDef
+	for _, tool := range listResult.Tools {
+		rtn = append(rtn, McpToolDef{
+			Name:        serverName + mcpServerNameSep + tool.Name,
+			Description: tool.Description,
+			InputSchema: tool.InputSchema,
+		})
+	}
+	return rtn, nil
+}
+
+// ExecuteMcpTool runs the approval gate (via pkg/userinput, the same confirmation mechanism used
+// for SSH host key / wsh install prompts) and then, if approved, calls the named tool on its MCP
+// server and returns its text content. qualifiedName is "<servername>__<toolname>", as returned
+// by DiscoverMcpTools.
+func ExecuteMcpTool(ctx context.Context, qualifiedName string, arguments map[string]interface{}) (string, error) {
+	serverName, toolName, ok := strings.Cut(qualifiedName, mcpServerNameSep)
+	if !ok {
+		return "", fmt.Errorf("invalid mcp tool name %q", qualifiedName)
+	}
+	servers := wconfig.GetWatcher().GetFullConfig().McpServers
+	serverCfg, ok := servers[serverName]
+	if !ok || !serverCfg.Enabled {
+		return "", fmt.Errorf("mcp server %q is not configured or not enabled", serverName)
+	}
+	if err := approveMcpToolCall(ctx, serverName, toolName, arguments); err != nil {
+		return "", err
+	}
+	sessCtx, cancel := context.WithTimeout(ctx, mcpRequestTimeout)
+	defer cancel()
+	sess, err := startMcpSession(sessCtx, serverCfg)
+	if err != nil {
+		return "", err
+	}
+	defer sess.close()
+	if err := sess.initialize(); err != nil {
+		return "", err
+	}
+	result, err := sess.call("tools/call", map[string]interface{}{"name": toolName, "arguments": arguments})
+	if err != nil {
+		return "", err
+	}
+	var callResult struct {
+		IsError bool `json:"isError"`
+		Content []struct {
+			Type string `json:"type"`
+			Text string `json:"text"`
+		} `json:"content"`
+	}
+	if err := json.Unmarshal(result, &callResult); err != nil {
+		return "", fmt.Errorf("parsing tools/call result: %w", err)
+	}
+	var sb strings.Builder
+	for _, c := range callResult.Content {
+		sb.WriteString(c.Text)
+	}
+	if callResult.IsError {
+		return "", fmt.Errorf("%s", sb.String())
+	}
+	return sb.String(), nil
+}
+
+func approveMcpToolCall(ctx context.Context, serverName string, toolName string, arguments map[string]interface{}) error {
+	argBytes, _ := json.Marshal(arguments)
+	approveCtx, cancel := context.WithTimeout(ctx, 60*time.Second)
+	defer cancel()
+	request := &userinput.UserInputRequest{
+		ResponseType: "confirm",
+		Title:        "Allow MCP Tool Call",
+		Markdown:     true,
+		QueryText: fmt.Sprintf("The AI wants to call tool `%s` on MCP server `%s` with arguments:\n\n```\n%s\n```",
+			toolName, serverName, string(argBytes)),
+		OkLabel:     "Allow",
+		CancelLabel: "Deny",
+	}
+	response, err := userinput.GetUserInput(approveCtx, request)
+	if err != nil {
+		return fmt.Errorf("tool call not approved: %w", err)
+	}
+	if !response.Confirm {
+		return fmt.Errorf("tool call denied by user")
+	}
+	return nil
+}
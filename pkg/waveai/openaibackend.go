@@ -5,9 +5,11 @@ package waveai
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
+	"log"
 	"regexp"
 	"strings"
 
@@ -16,6 +18,67 @@ import (
 	"github.com/wavetermdev/waveterm/pkg/wshrpc"
 )
 
+// maxMcpToolRounds bounds how many times the model can call MCP tools in a row before we give up
+// and return whatever it last said, so a misbehaving tool/model pair can't loop forever.
+const maxMcpToolRounds = 5
+
+func mcpToolsToOpenAITools(tools []McpToolDef) []openaiapi.Tool {
+	var rtn []openaiapi.Tool
+	for _, tool := range tools {
+		var params any = tool.InputSchema
+		if len(tool.InputSchema) == 0 {
+			params = map[string]interface{}{"type": "object", "properties": map[string]interface{}{}}
+		}
+		rtn = append(rtn, openaiapi.Tool{
+			Type: openaiapi.ToolTypeFunction,
+			Function: &openaiapi.FunctionDefinition{
+				Name:        tool.Name,
+				Description: tool.Description,
+				Parameters:  params,
+			},
+		})
+	}
+	return rtn
+}
+
+// runOpenAIToolLoop performs a synchronous (non-streaming) request/tool-call/response loop so we
+// can execute MCP tool calls (each gated by the approval prompt in ExecuteMcpTool) before
+// streaming the model's final answer back to the frontend.
+func runOpenAIToolLoop(ctx context.Context, client *openaiapi.Client, req openaiapi.ChatCompletionRequest) (openaiapi.ChatCompletionResponse, error) {
+	for round := 0; round < maxMcpToolRounds; round++ {
+		resp, err := client.CreateChatCompletion(ctx, req)
+		if err != nil {
+			return resp, err
+		}
+		if len(resp.Choices) == 0 {
+			return resp, nil
+		}
+		choice := resp.Choices[0]
+		if choice.FinishReason != openaiapi.FinishReasonToolCalls || len(choice.Message.ToolCalls) == 0 {
+			return resp, nil
+		}
+		req.Messages = append(req.Messages, choice.Message)
+		for _, toolCall := range choice.Message.ToolCalls {
+			var args map[string]interface{}
+			if toolCall.Function.Arguments != "" {
+				if err := json.Unmarshal([]byte(toolCall.Function.Arguments), &args); err != nil {
+					log.Printf("error parsing mcp tool call arguments: %v", err)
+				}
+			}
+			resultText, err := ExecuteMcpTool(ctx, toolCall.Function.Name, args)
+			if err != nil {
+				resultText = fmt.Sprintf("error: %v", err)
+			}
+			req.Messages = append(req.Messages, openaiapi.ChatCompletionMessage{
+				Role:       openaiapi.ChatMessageRoleTool,
+				ToolCallID: toolCall.ID,
+				Content:    resultText,
+			})
+		}
+	}
+	return client.CreateChatCompletion(ctx, req)
+}
+
 type OpenAIBackend struct{}
 
 var _ AIBackend = OpenAIBackend{}
@@ -99,12 +162,46 @@ func (OpenAIBackend) StreamCompletion(ctx context.Context, request wshrpc.WaveAI
 		if request.Opts.APIVersion != "" {
 			clientConfig.APIVersion = request.Opts.APIVersion
 		}
+		if gwClient := gatewayHTTPClient(request.Opts); gwClient != nil {
+			clientConfig.HTTPClient = gwClient
+		}
 
 		client := openaiapi.NewClientWithConfig(clientConfig)
 		req := openaiapi.ChatCompletionRequest{
 			Model:    request.Opts.Model,
 			Messages: convertPrompt(request.Prompt),
 		}
+		if request.Opts.UseMcpTools {
+			if mcpTools, err := DiscoverMcpTools(ctx); err != nil {
+				log.Printf("error discovering mcp tools: %v", err)
+			} else if len(mcpTools) > 0 {
+				req.Tools = mcpToolsToOpenAITools(mcpTools)
+			}
+		}
+
+		// When tools are in play we can't use the plain streaming API (we need to inspect
+		// finish_reason/tool_calls and possibly make follow-up requests before streaming the
+		// final answer), so run the synchronous tool loop and stream its end result instead.
+		if len(req.Tools) > 0 {
+			req.MaxTokens = request.Opts.MaxTokens
+			resp, err := runOpenAIToolLoop(ctx, client, req)
+			if err != nil {
+				rtn <- makeAIError(fmt.Errorf("error calling openai API: %v", err))
+				return
+			}
+			headerPk := MakeWaveAIPacket()
+			headerPk.Model = resp.Model
+			headerPk.Created = resp.Created
+			rtn <- wshrpc.RespOrErrorUnion[wshrpc.WaveAIPacketType]{Response: *headerPk}
+			for i, choice := range resp.Choices {
+				pk := MakeWaveAIPacket()
+				pk.Index = i
+				pk.Text = choice.Message.Content
+				pk.FinishReason = string(choice.FinishReason)
+				rtn <- wshrpc.RespOrErrorUnion[wshrpc.WaveAIPacketType]{Response: *pk}
+			}
+			return
+		}
 
 		// Handle o1 models differently - use non-streaming API
 		if strings.HasPrefix(request.Opts.Model, "o1-") {
@@ -0,0 +1,136 @@
+// Copyright 2024, Command Line Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package waveai
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/wavetermdev/waveterm/pkg/secretredact"
+	"github.com/wavetermdev/waveterm/pkg/wconfig"
+	"github.com/wavetermdev/waveterm/pkg/wshrpc"
+)
+
+// ChunkSize is the approximate max number of characters of raw output fed to
+// the model per map-reduce chunk. Kept conservative relative to typical
+// context windows since we don't token-count the configured model here.
+const ChunkSize = 12000
+
+const chunkSummaryPrompt = "Summarize the following chunk of terminal/command output concisely, preserving any errors, warnings, or notable results:\n\n"
+const reducePrompt = "The following are summaries of consecutive chunks of a single large terminal output. Combine them into one concise overall summary, preserving any errors, warnings, or notable results:\n\n"
+
+var summaryCacheLock sync.Mutex
+var summaryCache = make(map[string]string) // output hash -> summary
+
+// HashOutput returns the cache key for a block of output text.
+func HashOutput(text string) string {
+	sum := sha256.Sum256([]byte(text))
+	return hex.EncodeToString(sum[:])
+}
+
+// SummarizeOutput produces a summary of text using opts' AI backend, chunking
+// very large output with a map-reduce strategy, and caching the result keyed
+// by the output's hash so repeated requests for the same output are free.
+// Before anything is sent to the AI backend, text is run through the configured
+// secretredact rules (see wconfig.FullConfigType.RedactionRules); redactionReport
+// reports what was found, even on a cache hit (cachedRedactionReport is stored
+// alongside the summary so repeat requests still get an accurate report).
+func SummarizeOutput(ctx context.Context, opts *wshrpc.WaveAIOptsType, text string) (summary string, hash string, cacheHit bool, redactionReport secretredact.Report, err error) {
+	text, redactionReport = secretredact.Redact(text, wconfig.GetWatcher().GetFullConfig().RedactionRules())
+	hash = HashOutput(text)
+	summaryCacheLock.Lock()
+	cached, ok := summaryCache[hash]
+	summaryCacheLock.Unlock()
+	if ok {
+		return cached, hash, true, redactionReport, nil
+	}
+	if opts == nil {
+		return "", hash, false, redactionReport, fmt.Errorf("no AI backend configured")
+	}
+	if IsBudgetHardStopped() {
+		return "", hash, false, redactionReport, fmt.Errorf("monthly AI budget hard-stop is active")
+	}
+	chunks := chunkText(text, ChunkSize)
+	chunkSummaries := make([]string, len(chunks))
+	for i, chunk := range chunks {
+		chunkSummary, err := completeOnce(ctx, opts, chunkSummaryPrompt+chunk)
+		if err != nil {
+			return "", hash, false, redactionReport, fmt.Errorf("summarizing chunk %d/%d: %w", i+1, len(chunks), err)
+		}
+		chunkSummaries[i] = chunkSummary
+	}
+	summary = strings.TrimSpace(strings.Join(chunkSummaries, "\n"))
+	if len(chunks) > 1 {
+		summary, err = completeOnce(ctx, opts, reducePrompt+strings.Join(chunkSummaries, "\n\n"))
+		if err != nil {
+			return "", hash, false, redactionReport, fmt.Errorf("reducing chunk summaries: %w", err)
+		}
+	}
+	summaryCacheLock.Lock()
+	summaryCache[hash] = summary
+	summaryCacheLock.Unlock()
+	return summary, hash, false, redactionReport, nil
+}
+
+// chunkText splits text into runs of at most size characters, breaking on line
+// boundaries where possible so a single line of output is never split mid-chunk
+// unless a single line itself exceeds size.
+func chunkText(text string, size int) []string {
+	if len(text) <= size {
+		return []string{text}
+	}
+	var chunks []string
+	var cur strings.Builder
+	for _, line := range strings.SplitAfter(text, "\n") {
+		if cur.Len() > 0 && cur.Len()+len(line) > size {
+			chunks = append(chunks, cur.String())
+			cur.Reset()
+		}
+		cur.WriteString(line)
+	}
+	if cur.Len() > 0 {
+		chunks = append(chunks, cur.String())
+	}
+	return chunks
+}
+
+// completeOnce runs a single non-streaming completion request and returns the
+// concatenated response text.
+func completeOnce(ctx context.Context, opts *wshrpc.WaveAIOptsType, prompt string) (string, error) {
+	request := wshrpc.WaveAIStreamRequest{
+		Opts:   opts,
+		Prompt: []wshrpc.WaveAIPromptMessageType{{Role: "user", Content: prompt}},
+	}
+	var sb strings.Builder
+	for resp := range RunAICommand(ctx, request) {
+		if resp.Error != nil {
+			return "", resp.Error
+		}
+		sb.WriteString(resp.Response.Text)
+	}
+	return strings.TrimSpace(sb.String()), nil
+}
+
+// DefaultOptsFromSettings builds WaveAIOptsType from the user's global AI
+// settings, for server-side (non-block-specific) AI calls like summarization.
+func DefaultOptsFromSettings() *wshrpc.WaveAIOptsType {
+	settings := wconfig.GetWatcher().GetFullConfig().Settings
+	if settings.AiApiType == "" && settings.AiBaseURL == "" && settings.AiApiToken == "" {
+		return nil
+	}
+	return &wshrpc.WaveAIOptsType{
+		Model:      settings.AiModel,
+		APIType:    settings.AiApiType,
+		APIToken:   settings.AiApiToken,
+		OrgID:      settings.AiOrgID,
+		APIVersion: settings.AIApiVersion,
+		BaseURL:    settings.AiBaseURL,
+		MaxTokens:  int(settings.AiMaxTokens),
+		TimeoutMs:  int(settings.AiTimeoutMs),
+	}
+}
@@ -0,0 +1,104 @@
+// Copyright 2024, Command Line Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package waveai
+
+import (
+	"log"
+	"sync"
+	"time"
+
+	"github.com/wavetermdev/waveterm/pkg/wconfig"
+	"github.com/wavetermdev/waveterm/pkg/wshrpc"
+)
+
+// per-1000-token prices in USD, keyed by "apitype:model". unknown models are not tracked.
+var modelPricing = map[string]struct{ Prompt, Completion float64 }{
+	"openai:gpt-4o":                        {0.0025, 0.01},
+	"openai:gpt-4o-mini":                   {0.00015, 0.0006},
+	"openai:o1-preview":                    {0.015, 0.06},
+	"anthropic:claude-3-opus-20240229":     {0.015, 0.075},
+	"anthropic:claude-3-5-sonnet-20241022": {0.003, 0.015},
+	"anthropic:claude-3-haiku-20240307":    {0.00025, 0.00125},
+	"google:gemini-1.5-pro":                {0.00125, 0.005},
+	"google:gemini-1.5-flash":              {0.000075, 0.0003},
+}
+
+type usageTracker struct {
+	lock       sync.Mutex
+	month      string
+	spendByKey map[string]float64
+	warnedHard bool
+}
+
+var globalUsageTracker = &usageTracker{spendByKey: make(map[string]float64)}
+
+func estimateCostUSD(apiType string, model string, usage *wshrpc.WaveAIUsageType) float64 {
+	if usage == nil {
+		return 0
+	}
+	price, ok := modelPricing[apiType+":"+model]
+	if !ok {
+		return 0
+	}
+	return float64(usage.PromptTokens)/1000*price.Prompt + float64(usage.CompletionTokens)/1000*price.Completion
+}
+
+// recordUsage accumulates estimated spend for the current month and warns (or hard-stops)
+// once the configured monthly budget is crossed. returns true if the hard stop is active
+// and the caller should refuse to start new requests.
+func (t *usageTracker) recordUsage(apiType string, model string, usage *wshrpc.WaveAIUsageType) {
+	cost := estimateCostUSD(apiType, model, usage)
+	if cost == 0 {
+		return
+	}
+	settings := wconfig.GetWatcher().GetFullConfig().Settings
+	curMonth := time.Now().Format("2006-01")
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	if t.month != curMonth {
+		t.month = curMonth
+		t.spendByKey = make(map[string]float64)
+		t.warnedHard = false
+	}
+	t.spendByKey[apiType] += cost
+	total := 0.0
+	for _, v := range t.spendByKey {
+		total += v
+	}
+	limit := settings.AiBudgetMonthlyLimit
+	if limit <= 0 || t.warnedHard {
+		return
+	}
+	if total >= limit {
+		t.warnedHard = true
+		currency := settings.AiBudgetCurrency
+		if currency == "" {
+			currency = "USD"
+		}
+		log.Printf("ai usage warning: estimated spend %.2f %s has crossed the configured monthly budget of %.2f %s\n", total, currency, limit, currency)
+	}
+}
+
+// MonthlySpendUSD returns the current month's estimated AI spend in USD.
+func MonthlySpendUSD() float64 {
+	globalUsageTracker.lock.Lock()
+	defer globalUsageTracker.lock.Unlock()
+	total := 0.0
+	for _, v := range globalUsageTracker.spendByKey {
+		total += v
+	}
+	return total
+}
+
+// IsBudgetHardStopped reports whether the configured hard-stop threshold has been reached
+// for the current month, meaning new AI requests should be refused.
+func IsBudgetHardStopped() bool {
+	settings := wconfig.GetWatcher().GetFullConfig().Settings
+	if !settings.AiBudgetHardStop {
+		return false
+	}
+	globalUsageTracker.lock.Lock()
+	defer globalUsageTracker.lock.Unlock()
+	return globalUsageTracker.warnedHard
+}
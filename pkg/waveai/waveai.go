@@ -5,6 +5,7 @@ package waveai
 
 import (
 	"context"
+	"errors"
 	"log"
 
 	"github.com/wavetermdev/waveterm/pkg/telemetry"
@@ -58,6 +59,17 @@ func makeAIError(err error) wshrpc.RespOrErrorUnion[wshrpc.WaveAIPacketType] {
 func RunAICommand(ctx context.Context, request wshrpc.WaveAIStreamRequest) chan wshrpc.RespOrErrorUnion[wshrpc.WaveAIPacketType] {
 	telemetry.GoUpdateActivityWrap(wshrpc.ActivityUpdate{NumAIReqs: 1}, "RunAICommand")
 
+	if IsBudgetHardStopped() {
+		rtn := make(chan wshrpc.RespOrErrorUnion[wshrpc.WaveAIPacketType], 1)
+		rtn <- makeAIError(errors.New("ai request blocked: monthly budget hard-stop has been reached"))
+		close(rtn)
+		return rtn
+	}
+
+	if len(request.Opts.APITokens) > 0 {
+		request.Opts.APIToken = globalKeyRotator.selectKey(request.Opts.APIType, request.Opts.APITokens)
+	}
+
 	endpoint := request.Opts.BaseURL
 	if endpoint == "" {
 		endpoint = "default"
@@ -84,5 +96,29 @@ func RunAICommand(ctx context.Context, request wshrpc.WaveAIStreamRequest) chan
 	}
 
 	log.Printf("sending ai chat message to %s endpoint %q using model %s\n", request.Opts.APIType, endpoint, request.Opts.Model)
-	return backend.StreamCompletion(ctx, request)
+	return wrapWithUsageTracking(request.Opts.APIType, request.Opts.Model, request.Opts.APIToken, backend.StreamCompletion(ctx, request))
+}
+
+// wrapWithUsageTracking passes packets through unmodified but records the reported token
+// usage (to enforce the configured monthly AI budget) and, when multiple keys are
+// configured, puts the active key into cooldown if the backend reports a rate limit.
+func wrapWithUsageTracking(apiType string, model string, apiToken string, in chan wshrpc.RespOrErrorUnion[wshrpc.WaveAIPacketType]) chan wshrpc.RespOrErrorUnion[wshrpc.WaveAIPacketType] {
+	if in == nil {
+		return nil
+	}
+	out := make(chan wshrpc.RespOrErrorUnion[wshrpc.WaveAIPacketType])
+	go func() {
+		defer close(out)
+		for pk := range in {
+			if pk.Error != nil {
+				if isRateLimitError(pk.Error.Error()) {
+					globalKeyRotator.markRateLimited(apiType, apiToken)
+				}
+			} else if pk.Response.Usage != nil {
+				globalUsageTracker.recordUsage(apiType, model, pk.Response.Usage)
+			}
+			out <- pk
+		}
+	}()
+	return out
 }
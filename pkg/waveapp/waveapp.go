@@ -21,6 +21,7 @@ import (
 	"github.com/gorilla/mux"
 	"github.com/wavetermdev/waveterm/pkg/vdom"
 	"github.com/wavetermdev/waveterm/pkg/wavebase"
+	"github.com/wavetermdev/waveterm/pkg/waveobj"
 	"github.com/wavetermdev/waveterm/pkg/wps"
 	"github.com/wavetermdev/waveterm/pkg/wshrpc"
 	"github.com/wavetermdev/waveterm/pkg/wshrpc/wshclient"
@@ -35,6 +36,12 @@ type AppOpts struct {
 	NewBlockFlag         string // defaults to "n" (set to "-" to disable)
 	TargetNewBlock       bool
 	TargetToolbar        *vdom.VDomTargetToolbar
+
+	// Renderer, if set, registers this app as a custom preview renderer for the given MIME
+	// types/extensions (see vdom.VDomRendererRegistration). The registration is persisted to
+	// renderers.json so the preview block can relaunch this same app (passing the file path as
+	// its last argument) the next time it opens a matching file.
+	Renderer *vdom.VDomRendererRegistration
 }
 
 type Client struct {
@@ -132,10 +139,49 @@ func (client *Client) runMainE() error {
 	if err != nil {
 		return err
 	}
+	if client.AppOpts.Renderer != nil {
+		if err := client.RegisterRenderer(*client.AppOpts.Renderer); err != nil {
+			log.Printf("error registering vdom renderer: %v\n", err)
+		}
+	}
 	<-client.DoneCh
 	return nil
 }
 
+// RegisterRenderer persists this app as a custom preview renderer (see AppOpts.Renderer).
+// RendererId is derived from RootComponentName, so re-running the same app just updates its
+// existing registration. The registered launch command is this executable plus its own argv
+// (excluding the new-block flag), with the target file path appended by the preview block at
+// launch time -- so Renderer apps should not bake a specific file path into their own flags.
+func (c *Client) RegisterRenderer(reg vdom.VDomRendererRegistration) error {
+	exePath, err := os.Executable()
+	if err != nil {
+		return fmt.Errorf("error resolving executable path: %w", err)
+	}
+	var cmdArgs []string
+	newBlockFlag := "-" + c.AppOpts.NewBlockFlag
+	for _, arg := range os.Args[1:] {
+		if arg == newBlockFlag {
+			continue
+		}
+		cmdArgs = append(cmdArgs, arg)
+	}
+	blockDef := waveobj.BlockDef{
+		Meta: waveobj.MetaMapType{
+			waveobj.MetaKey_View:       "vdom",
+			waveobj.MetaKey_Controller: "cmd",
+			waveobj.MetaKey_Cmd:        exePath,
+			waveobj.MetaKey_CmdArgs:    cmdArgs,
+			waveobj.MetaKey_CmdShell:   false,
+		},
+	}
+	return wshclient.RegisterVDomRendererCommand(c.RpcClient, wshrpc.CommandRegisterVDomRendererData{
+		RendererId:   c.AppOpts.RootComponentName,
+		Registration: reg,
+		BlockDef:     blockDef,
+	}, nil)
+}
+
 func (client *Client) AddSetupFn(fn func()) {
 	client.SetupFn = fn
 }
@@ -13,10 +13,20 @@ import (
 	"golang.org/x/sys/unix"
 )
 
+// isProcessAlive reports whether pid names a still-running process owned by the current user, by
+// sending it signal 0 (which performs the existence/permission checks but delivers nothing).
+func isProcessAlive(pid int) bool {
+	proc, err := os.FindProcess(pid)
+	if err != nil {
+		return false
+	}
+	return proc.Signal(unix.Signal(0)) == nil
+}
+
 func AcquireWaveLock() (FDLock, error) {
 	dataHomeDir := GetWaveDataDir()
 	lockFileName := filepath.Join(dataHomeDir, WaveLockFile)
-	log.Printf("[base] acquiring lock on %s\n", lockFileName)
+	log.Printf("[base] acquiring lock on %s (profile: %s)\n", lockFileName, describeActiveProfile())
 	fd, err := os.OpenFile(lockFileName, os.O_RDWR|os.O_CREATE, 0600)
 	if err != nil {
 		return nil, err
@@ -11,12 +11,27 @@ import (
 	"path/filepath"
 
 	"github.com/alexflint/go-filemutex"
+	"golang.org/x/sys/windows"
 )
 
+// isProcessAlive reports whether pid names a still-running, openable process.
+func isProcessAlive(pid int) bool {
+	h, err := windows.OpenProcess(windows.PROCESS_QUERY_LIMITED_INFORMATION, false, uint32(pid))
+	if err != nil {
+		return false
+	}
+	defer windows.CloseHandle(h)
+	var exitCode uint32
+	if err := windows.GetExitCodeProcess(h, &exitCode); err != nil {
+		return false
+	}
+	return exitCode == uint32(259) // STILL_ACTIVE
+}
+
 func AcquireWaveLock() (FDLock, error) {
 	dataHomeDir := GetWaveDataDir()
 	lockFileName := filepath.Join(dataHomeDir, WaveLockFile)
-	log.Printf("[base] acquiring lock on %s\n", lockFileName)
+	log.Printf("[base] acquiring lock on %s (profile: %s)\n", lockFileName, describeActiveProfile())
 	m, err := filemutex.New(lockFileName)
 	if err != nil {
 		return nil, fmt.Errorf("filemutex new error: %w", err)
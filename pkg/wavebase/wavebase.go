@@ -31,15 +31,36 @@ const (
 	WaveDevViteVarName   = "WAVETERM_DEV_VITE"
 )
 
+// ProfilesSubDir is where --profile NAME's isolated config/data trees live, nested under the
+// normal (non-profile) config/data homes so `wave --profile work` and plain `wave` never collide.
+const ProfilesSubDir = "profiles"
+
+var profileNameRe = regexp.MustCompile(`^[a-zA-Z0-9_-]+$`)
+
 var ConfigHome_VarCache string // caches WAVETERM_CONFIG_HOME
 var DataHome_VarCache string   // caches WAVETERM_DATA_HOME
 var AppPath_VarCache string    // caches WAVETERM_APP_PATH
 var Dev_VarCache string        // caches WAVETERM_DEV
 
+// BaseConfigHome_VarCache and BaseDataHome_VarCache hold the config/data homes as they were
+// before any --profile override was applied (identical to ConfigHome_VarCache/DataHome_VarCache
+// when no profile is active). Kept around so a running profile can still enumerate its sibling
+// profiles (see ListProfiles).
+var BaseConfigHome_VarCache string
+var BaseDataHome_VarCache string
+
+// Profile_VarCache caches the active --profile name, empty when running unprofiled.
+var Profile_VarCache string
+
 const WaveLockFile = "wave.lock"
 const DomainSocketBaseName = "wave.sock"
 const RemoteDomainSocketBaseName = "wave-remote.sock"
 const WaveDBDir = "db"
+const WaveCrashDir = "crashes"
+const WavePprofDir = "pprof"
+const WaveBackupDir = "backups"
+const WaveArchiveDir = "archives"
+const WaveShareDir = "shares"
 const JwtSecret = "waveterm" // TODO generate and store this
 const ConfigDir = "config"
 const RemoteWaveHomeDirName = ".waveterm"
@@ -81,9 +102,74 @@ func CacheAndRemoveEnvVars() error {
 	Dev_VarCache = os.Getenv(WaveDevVarName)
 	os.Unsetenv(WaveDevVarName)
 	os.Unsetenv(WaveDevViteVarName)
+	// if ApplyProfileOverride already ran, it stashed the pre-override values here; otherwise
+	// there's no profile active and the base dirs are just the resolved dirs themselves.
+	if BaseConfigHome_VarCache == "" {
+		BaseConfigHome_VarCache = ConfigHome_VarCache
+	}
+	if BaseDataHome_VarCache == "" {
+		BaseDataHome_VarCache = DataHome_VarCache
+	}
 	return nil
 }
 
+// ApplyProfileOverride points WAVETERM_CONFIG_HOME/WAVETERM_DATA_HOME (as read by the
+// subsequent CacheAndRemoveEnvVars call) at a "profiles/<name>" subdirectory of each, giving
+// `--profile NAME` a fully isolated db, filestore, and settings tree. Must be called before
+// CacheAndRemoveEnvVars.
+func ApplyProfileOverride(profile string) error {
+	if profile == "" {
+		return nil
+	}
+	if !profileNameRe.MatchString(profile) {
+		return fmt.Errorf("invalid profile name %q: must contain only letters, digits, underscore, and dash", profile)
+	}
+	configHome := os.Getenv(WaveConfigHomeEnvVar)
+	dataHome := os.Getenv(WaveDataHomeEnvVar)
+	if configHome == "" || dataHome == "" {
+		return fmt.Errorf("%s and %s must be set before applying a profile override", WaveConfigHomeEnvVar, WaveDataHomeEnvVar)
+	}
+	BaseConfigHome_VarCache = configHome
+	BaseDataHome_VarCache = dataHome
+	os.Setenv(WaveConfigHomeEnvVar, filepath.Join(configHome, ProfilesSubDir, profile))
+	os.Setenv(WaveDataHomeEnvVar, filepath.Join(dataHome, ProfilesSubDir, profile))
+	Profile_VarCache = profile
+	return nil
+}
+
+// GetActiveProfile returns the active --profile name, or "" when running unprofiled.
+func GetActiveProfile() string {
+	return Profile_VarCache
+}
+
+func describeActiveProfile() string {
+	if Profile_VarCache == "" {
+		return "default"
+	}
+	return Profile_VarCache
+}
+
+// ListProfiles returns the names of the profile directories available to switch to (i.e. the
+// ones previously created by running with `--profile NAME`), relative to the base (non-profile)
+// data home -- this stays correct even when called from inside an already-active profile.
+func ListProfiles() ([]string, error) {
+	profilesDir := filepath.Join(BaseDataHome_VarCache, ProfilesSubDir)
+	ents, err := os.ReadDir(profilesDir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	var rtn []string
+	for _, ent := range ents {
+		if ent.IsDir() {
+			rtn = append(rtn, ent.Name())
+		}
+	}
+	return rtn, nil
+}
+
 func IsDevMode() bool {
 	return Dev_VarCache != ""
 }
@@ -156,6 +242,46 @@ func EnsureWaveDBDir() error {
 	return CacheEnsureDir(filepath.Join(GetWaveDataDir(), WaveDBDir), "wavedb", 0700, "wave db directory")
 }
 
+func GetWaveCrashDir() string {
+	return filepath.Join(GetWaveDataDir(), WaveCrashDir)
+}
+
+func EnsureWaveCrashDir() error {
+	return CacheEnsureDir(GetWaveCrashDir(), "wavecrash", 0700, "wave crash directory")
+}
+
+func GetWavePprofDir() string {
+	return filepath.Join(GetWaveDataDir(), WavePprofDir)
+}
+
+func EnsureWavePprofDir() error {
+	return CacheEnsureDir(GetWavePprofDir(), "wavepprof", 0700, "wave pprof directory")
+}
+
+func GetWaveBackupDir() string {
+	return filepath.Join(GetWaveDataDir(), WaveBackupDir)
+}
+
+func EnsureWaveBackupDir() error {
+	return CacheEnsureDir(GetWaveBackupDir(), "wavebackup", 0700, "wave backup directory")
+}
+
+func GetWaveArchiveDir() string {
+	return filepath.Join(GetWaveDataDir(), WaveArchiveDir)
+}
+
+func GetWaveShareDir() string {
+	return filepath.Join(GetWaveDataDir(), WaveShareDir)
+}
+
+func EnsureWaveShareDir() error {
+	return CacheEnsureDir(GetWaveShareDir(), "waveshare", 0700, "wave share snapshot directory")
+}
+
+func EnsureWaveArchiveDir() error {
+	return CacheEnsureDir(GetWaveArchiveDir(), "wavearchive", 0700, "wave archive directory")
+}
+
 func EnsureWaveConfigDir() error {
 	return CacheEnsureDir(GetWaveConfigDir(), "waveconfig", 0700, "wave config directory")
 }
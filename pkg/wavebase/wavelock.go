@@ -0,0 +1,104 @@
+// Copyright 2026, Command Line Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package wavebase
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+	"os/user"
+	"path/filepath"
+	"time"
+)
+
+// WaveLockInfoFile holds metadata (pid, owning user, start time) about the process currently
+// holding WaveLockFile, written right after AcquireWaveLock succeeds. It's a separate file from
+// WaveLockFile itself since the lock file's content (or lack thereof) is owned by the platform
+// locking primitive (flock on posix, a file mutex on windows), not by us.
+const WaveLockInfoFile = "wave.lock.json"
+
+// WaveLockInfo is the content of WaveLockInfoFile.
+type WaveLockInfo struct {
+	Pid       int    `json:"pid"`
+	User      string `json:"user"`
+	StartTime string `json:"starttime"`
+}
+
+func currentUsername() string {
+	u, err := user.Current()
+	if err != nil {
+		return ""
+	}
+	return u.Username
+}
+
+func writeWaveLockInfo() error {
+	info := WaveLockInfo{
+		Pid:       os.Getpid(),
+		User:      currentUsername(),
+		StartTime: time.Now().Format(time.RFC3339),
+	}
+	data, err := json.Marshal(info)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(filepath.Join(GetWaveDataDir(), WaveLockInfoFile), data, 0600)
+}
+
+func readWaveLockInfo() (*WaveLockInfo, error) {
+	data, err := os.ReadFile(filepath.Join(GetWaveDataDir(), WaveLockInfoFile))
+	if err != nil {
+		return nil, err
+	}
+	var info WaveLockInfo
+	if err := json.Unmarshal(data, &info); err != nil {
+		return nil, err
+	}
+	return &info, nil
+}
+
+// AcquireWaveLockWithRecovery wraps AcquireWaveLock with stale-lock detection: if the lock is
+// already held, it consults WaveLockInfoFile left behind by the previous holder. When that
+// holder's pid is no longer running and belongs to the same user, the lock is reported as stale;
+// forceUnlock (the "--force-unlock" flag) additionally removes the stale lock file and retries
+// the acquisition. A lock held by a still-running process, or by a different user, is never taken
+// over, even with forceUnlock -- the caller should consider that a safety bug report, not a
+// liveness problem.
+func AcquireWaveLockWithRecovery(forceUnlock bool) (FDLock, error) {
+	lock, lockErr := AcquireWaveLock()
+	if lockErr == nil {
+		if err := writeWaveLockInfo(); err != nil {
+			log.Printf("[base] warning: could not write lock info: %v\n", err)
+		}
+		return lock, nil
+	}
+	info, infoErr := readWaveLockInfo()
+	if infoErr != nil {
+		// no (or unreadable) lock info to reason about staleness with -- surface the original error
+		return nil, lockErr
+	}
+	if info.User != currentUsername() {
+		return nil, fmt.Errorf("lock is held by pid %d under a different user (%q): %w", info.Pid, info.User, lockErr)
+	}
+	if isProcessAlive(info.Pid) {
+		return nil, fmt.Errorf("wave is already running (pid %d, started %s): %w", info.Pid, info.StartTime, lockErr)
+	}
+	if !forceUnlock {
+		return nil, fmt.Errorf("stale lock detected: pid %d (started %s) is no longer running -- rerun with --force-unlock to take over: %w", info.Pid, info.StartTime, lockErr)
+	}
+	log.Printf("[base] removing stale lock held by dead pid %d (started %s)\n", info.Pid, info.StartTime)
+	lockFileName := filepath.Join(GetWaveDataDir(), WaveLockFile)
+	if err := os.Remove(lockFileName); err != nil && !os.IsNotExist(err) {
+		return nil, fmt.Errorf("error removing stale lock file: %w", err)
+	}
+	lock, lockErr = AcquireWaveLock()
+	if lockErr != nil {
+		return nil, fmt.Errorf("error re-acquiring lock after stale lock removal: %w", lockErr)
+	}
+	if err := writeWaveLockInfo(); err != nil {
+		log.Printf("[base] warning: could not write lock info: %v\n", err)
+	}
+	return lock, nil
+}
@@ -6,107 +6,130 @@
 package waveobj
 
 const (
-	MetaKey_View                             = "view"
-
-	MetaKey_Controller                       = "controller"
-
-	MetaKey_File                             = "file"
-
-	MetaKey_Url                              = "url"
-
-	MetaKey_PinnedUrl                        = "pinnedurl"
-
-	MetaKey_Connection                       = "connection"
-
-	MetaKey_Edit                             = "edit"
-
-	MetaKey_History                          = "history"
-	MetaKey_HistoryForward                   = "history:forward"
-
-	MetaKey_DisplayName                      = "display:name"
-	MetaKey_DisplayOrder                     = "display:order"
-
-	MetaKey_Icon                             = "icon"
-	MetaKey_IconColor                        = "icon:color"
-
-	MetaKey_FrameClear                       = "frame:*"
-	MetaKey_Frame                            = "frame"
-	MetaKey_FrameBorderColor                 = "frame:bordercolor"
-	MetaKey_FrameActiveBorderColor           = "frame:activebordercolor"
-	MetaKey_FrameTitle                       = "frame:title"
-	MetaKey_FrameIcon                        = "frame:icon"
-	MetaKey_FrameText                        = "frame:text"
-
-	MetaKey_CmdClear                         = "cmd:*"
-	MetaKey_Cmd                              = "cmd"
-	MetaKey_CmdInteractive                   = "cmd:interactive"
-	MetaKey_CmdLogin                         = "cmd:login"
-	MetaKey_CmdRunOnStart                    = "cmd:runonstart"
-	MetaKey_CmdClearOnStart                  = "cmd:clearonstart"
-	MetaKey_CmdRunOnce                       = "cmd:runonce"
-	MetaKey_CmdCloseOnExit                   = "cmd:closeonexit"
-	MetaKey_CmdCloseOnExitForce              = "cmd:closeonexitforce"
-	MetaKey_CmdCloseOnExitDelay              = "cmd:closeonexitdelay"
-	MetaKey_CmdEnv                           = "cmd:env"
-	MetaKey_CmdCwd                           = "cmd:cwd"
-	MetaKey_CmdNoWsh                         = "cmd:nowsh"
-	MetaKey_CmdArgs                          = "cmd:args"
-	MetaKey_CmdShell                         = "cmd:shell"
-
-	MetaKey_AiClear                          = "ai:*"
-	MetaKey_AiPresetKey                      = "ai:preset"
-	MetaKey_AiApiType                        = "ai:apitype"
-	MetaKey_AiBaseURL                        = "ai:baseurl"
-	MetaKey_AiApiToken                       = "ai:apitoken"
-	MetaKey_AiName                           = "ai:name"
-	MetaKey_AiModel                          = "ai:model"
-	MetaKey_AiOrgID                          = "ai:orgid"
-	MetaKey_AIApiVersion                     = "ai:apiversion"
-	MetaKey_AiMaxTokens                      = "ai:maxtokens"
-	MetaKey_AiTimeoutMs                      = "ai:timeoutms"
-
-	MetaKey_EditorClear                      = "editor:*"
-	MetaKey_EditorMinimapEnabled             = "editor:minimapenabled"
-	MetaKey_EditorStickyScrollEnabled        = "editor:stickyscrollenabled"
-	MetaKey_EditorWordWrap                   = "editor:wordwrap"
-
-	MetaKey_GraphClear                       = "graph:*"
-	MetaKey_GraphNumPoints                   = "graph:numpoints"
-	MetaKey_GraphMetrics                     = "graph:metrics"
-
-	MetaKey_SysinfoType                      = "sysinfo:type"
-
-	MetaKey_BgClear                          = "bg:*"
-	MetaKey_Bg                               = "bg"
-	MetaKey_BgOpacity                        = "bg:opacity"
-	MetaKey_BgBlendMode                      = "bg:blendmode"
-	MetaKey_BgBorderColor                    = "bg:bordercolor"
-	MetaKey_BgActiveBorderColor              = "bg:activebordercolor"
-
-	MetaKey_TermClear                        = "term:*"
-	MetaKey_TermFontSize                     = "term:fontsize"
-	MetaKey_TermFontFamily                   = "term:fontfamily"
-	MetaKey_TermMode                         = "term:mode"
-	MetaKey_TermTheme                        = "term:theme"
-	MetaKey_TermLocalShellPath               = "term:localshellpath"
-	MetaKey_TermLocalShellOpts               = "term:localshellopts"
-	MetaKey_TermScrollback                   = "term:scrollback"
-	MetaKey_TermVDomSubBlockId               = "term:vdomblockid"
-	MetaKey_TermVDomToolbarBlockId           = "term:vdomtoolbarblockid"
-	MetaKey_TermTransparency                 = "term:transparency"
-
-	MetaKey_WebZoom                          = "web:zoom"
-	MetaKey_WebHideNav                       = "web:hidenav"
-
-	MetaKey_MarkdownFontSize                 = "markdown:fontsize"
-	MetaKey_MarkdownFixedFontSize            = "markdown:fixedfontsize"
-
-	MetaKey_VDomClear                        = "vdom:*"
-	MetaKey_VDomInitialized                  = "vdom:initialized"
-	MetaKey_VDomCorrelationId                = "vdom:correlationid"
-	MetaKey_VDomRoute                        = "vdom:route"
-	MetaKey_VDomPersist                      = "vdom:persist"
-
-	MetaKey_Count                            = "count"
+	MetaKey_View = "view"
+
+	MetaKey_Controller = "controller"
+
+	MetaKey_File = "file"
+
+	MetaKey_Url = "url"
+
+	MetaKey_PinnedUrl = "pinnedurl"
+
+	MetaKey_Connection = "connection"
+
+	MetaKey_Edit = "edit"
+
+	MetaKey_History        = "history"
+	MetaKey_HistoryForward = "history:forward"
+
+	MetaKey_DisplayName  = "display:name"
+	MetaKey_DisplayOrder = "display:order"
+
+	MetaKey_LayoutPreset = "layout:preset"
+
+	MetaKey_TabEnv         = "tab:env"
+	MetaKey_TabStartupCmds = "tab:startupcmds"
+
+	MetaKey_Icon      = "icon"
+	MetaKey_IconColor = "icon:color"
+
+	MetaKey_FrameClear             = "frame:*"
+	MetaKey_Frame                  = "frame"
+	MetaKey_FrameBorderColor       = "frame:bordercolor"
+	MetaKey_FrameActiveBorderColor = "frame:activebordercolor"
+	MetaKey_FrameTitle             = "frame:title"
+	MetaKey_FrameIcon              = "frame:icon"
+	MetaKey_FrameText              = "frame:text"
+
+	MetaKey_CmdClear            = "cmd:*"
+	MetaKey_Cmd                 = "cmd"
+	MetaKey_CmdInteractive      = "cmd:interactive"
+	MetaKey_CmdLogin            = "cmd:login"
+	MetaKey_CmdRunOnStart       = "cmd:runonstart"
+	MetaKey_CmdClearOnStart     = "cmd:clearonstart"
+	MetaKey_CmdRunOnce          = "cmd:runonce"
+	MetaKey_CmdCloseOnExit      = "cmd:closeonexit"
+	MetaKey_CmdCloseOnExitForce = "cmd:closeonexitforce"
+	MetaKey_CmdCloseOnExitDelay = "cmd:closeonexitdelay"
+	MetaKey_CmdEnv              = "cmd:env"
+	MetaKey_CmdCwd              = "cmd:cwd"
+	MetaKey_CmdNoWsh            = "cmd:nowsh"
+	MetaKey_CmdArgs             = "cmd:args"
+	MetaKey_CmdShell            = "cmd:shell"
+	MetaKey_CmdProvenance       = "cmd:provenance"
+	MetaKey_CmdWasInterrupted   = "cmd:wasinterrupted"
+	MetaKey_CmdRestartCount     = "cmd:restartcount"
+	MetaKey_CmdLastExitCode     = "cmd:lastexitcode"
+	MetaKey_CmdDetached         = "cmd:detached"
+	MetaKey_CmdDetachedPid      = "cmd:detachedpid"
+	MetaKey_CmdOutputFolded     = "cmd:outputfolded"
+
+	MetaKey_AiClear      = "ai:*"
+	MetaKey_AiPresetKey  = "ai:preset"
+	MetaKey_AiApiType    = "ai:apitype"
+	MetaKey_AiBaseURL    = "ai:baseurl"
+	MetaKey_AiApiToken   = "ai:apitoken"
+	MetaKey_AiName       = "ai:name"
+	MetaKey_AiModel      = "ai:model"
+	MetaKey_AiOrgID      = "ai:orgid"
+	MetaKey_AIApiVersion = "ai:apiversion"
+	MetaKey_AiMaxTokens  = "ai:maxtokens"
+	MetaKey_AiTimeoutMs  = "ai:timeoutms"
+
+	MetaKey_EditorClear               = "editor:*"
+	MetaKey_EditorMinimapEnabled      = "editor:minimapenabled"
+	MetaKey_EditorStickyScrollEnabled = "editor:stickyscrollenabled"
+	MetaKey_EditorWordWrap            = "editor:wordwrap"
+	MetaKey_EditorGotoLine            = "editor:gotoline"
+	MetaKey_EditorGotoColumn          = "editor:gotocolumn"
+	MetaKey_EditorLspCommand          = "editor:lspcommand"
+
+	MetaKey_DiffClear   = "diff:*"
+	MetaKey_DiffFile1   = "diff:file1"
+	MetaKey_DiffFile2   = "diff:file2"
+	MetaKey_DiffGitHead = "diff:githead"
+
+	MetaKey_GraphClear     = "graph:*"
+	MetaKey_GraphNumPoints = "graph:numpoints"
+	MetaKey_GraphMetrics   = "graph:metrics"
+
+	MetaKey_SysinfoType = "sysinfo:type"
+
+	MetaKey_ProgressPercent = "progress:percent"
+
+	MetaKey_BgClear             = "bg:*"
+	MetaKey_Bg                  = "bg"
+	MetaKey_BgOpacity           = "bg:opacity"
+	MetaKey_BgBlendMode         = "bg:blendmode"
+	MetaKey_BgBorderColor       = "bg:bordercolor"
+	MetaKey_BgActiveBorderColor = "bg:activebordercolor"
+	MetaKey_BgImage             = "bg:image"
+	MetaKey_BgImageBlur         = "bg:imageblur"
+
+	MetaKey_TermClear              = "term:*"
+	MetaKey_TermFontSize           = "term:fontsize"
+	MetaKey_TermFontFamily         = "term:fontfamily"
+	MetaKey_TermMode               = "term:mode"
+	MetaKey_TermTheme              = "term:theme"
+	MetaKey_TermLocalShellPath     = "term:localshellpath"
+	MetaKey_TermLocalShellOpts     = "term:localshellopts"
+	MetaKey_TermScrollback         = "term:scrollback"
+	MetaKey_TermVDomSubBlockId     = "term:vdomblockid"
+	MetaKey_TermVDomToolbarBlockId = "term:vdomtoolbarblockid"
+	MetaKey_TermTransparency       = "term:transparency"
+
+	MetaKey_WebZoom    = "web:zoom"
+	MetaKey_WebHideNav = "web:hidenav"
+
+	MetaKey_MarkdownFontSize      = "markdown:fontsize"
+	MetaKey_MarkdownFixedFontSize = "markdown:fixedfontsize"
+
+	MetaKey_VDomClear         = "vdom:*"
+	MetaKey_VDomInitialized   = "vdom:initialized"
+	MetaKey_VDomCorrelationId = "vdom:correlationid"
+	MetaKey_VDomRoute         = "vdom:route"
+	MetaKey_VDomPersist       = "vdom:persist"
+
+	MetaKey_Count = "count"
 )
-
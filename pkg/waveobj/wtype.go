@@ -21,6 +21,14 @@ const (
 	UpdateType_Delete = "delete"
 )
 
+const (
+	CmdProvenance_Manual   = "manual"
+	CmdProvenance_History  = "history"
+	CmdProvenance_AI       = "ai"
+	CmdProvenance_Bookmark = "bookmark"
+	CmdProvenance_Template = "template"
+)
+
 const (
 	OType_Client      = "client"
 	OType_Window      = "window"
@@ -165,15 +173,17 @@ type ActiveTabUpdate struct {
 }
 
 type Workspace struct {
-	OID          string      `json:"oid"`
-	Version      int         `json:"version"`
-	Name         string      `json:"name,omitempty"`
-	Icon         string      `json:"icon,omitempty"`
-	Color        string      `json:"color,omitempty"`
-	TabIds       []string    `json:"tabids"`
-	PinnedTabIds []string    `json:"pinnedtabids"`
-	ActiveTabId  string      `json:"activetabid"`
-	Meta         MetaMapType `json:"meta"`
+	OID               string            `json:"oid"`
+	Version           int               `json:"version"`
+	Name              string            `json:"name,omitempty"`
+	Icon              string            `json:"icon,omitempty"`
+	Color             string            `json:"color,omitempty"`
+	TabIds            []string          `json:"tabids"`
+	PinnedTabIds      []string          `json:"pinnedtabids"`
+	ActiveTabId       string            `json:"activetabid"`
+	Meta              MetaMapType       `json:"meta"`
+	DefaultConnection string            `json:"defaultconnection,omitempty"` // connection new tabs in this workspace default to, see wcore.CreateTab
+	DefaultEnv        map[string]string `json:"defaultenv,omitempty"`        // env vars merged into new tabs' starter terminal block, see wcore.CreateTab
 }
 
 func (*Workspace) GetOType() string {
@@ -25,6 +25,12 @@ type MetaTSType struct {
 	DisplayName  string  `json:"display:name,omitempty"`
 	DisplayOrder float64 `json:"display:order,omitempty"`
 
+	LayoutPreset string `json:"layout:preset,omitempty"` // id of the last layout preset applied to this tab, see wconfig.LayoutPresetConfigType
+
+	// tab-level shell startup config, read off the Tab's own Meta (not a block's) by blockcontroller.go
+	TabEnv         map[string]string `json:"tab:env,omitempty"`         // env vars merged into every shell block started in this tab
+	TabStartupCmds []string          `json:"tab:startupcmds,omitempty"` // commands typed into a shell block, in order, each time its shell (re)starts
+
 	Icon      string `json:"icon,omitempty"`
 	IconColor string `json:"icon:color,omitempty"`
 
@@ -49,8 +55,15 @@ type MetaTSType struct {
 	CmdEnv              map[string]string `json:"cmd:env,omitempty"`
 	CmdCwd              string            `json:"cmd:cwd,omitempty"`
 	CmdNoWsh            bool              `json:"cmd:nowsh,omitempty"`
-	CmdArgs             []string          `json:"cmd:args,omitempty"`  // args for cmd (only if cmd:shell is false)
-	CmdShell            bool              `json:"cmd:shell,omitempty"` // shell expansion for cmd+args (defaults to true)
+	CmdArgs             []string          `json:"cmd:args,omitempty"`           // args for cmd (only if cmd:shell is false)
+	CmdShell            bool              `json:"cmd:shell,omitempty"`          // shell expansion for cmd+args (defaults to true)
+	CmdProvenance       string            `json:"cmd:provenance,omitempty"`     // how this command was dispatched: manual, ai, bookmark, template, history (defaults to manual)
+	CmdWasInterrupted   bool              `json:"cmd:wasinterrupted,omitempty"` // set when the shell process was still running when wavesrv shut down (cleared on next successful run)
+	CmdRestartCount     int               `json:"cmd:restartcount,omitempty"`   // incremented each time this block's shell process is force-restarted; 0 means the currently-running process is the original run
+	CmdLastExitCode     *int              `json:"cmd:lastexitcode,omitempty"`   // exit code of the run that was replaced by the most recent restart, nil if there was no prior run or it hasn't exited yet
+	CmdDetached         bool              `json:"cmd:detached,omitempty"`       // keep this block's shell process running (as an orphan) across a wavesrv restart instead of killing it on shutdown -- see blockcontroller.ListOrphanedDetached
+	CmdDetachedPid      int               `json:"cmd:detachedpid,omitempty"`    // OS pid of the detached process, recorded on wavesrv shutdown so it can be found again as an orphan (0 if never recorded, e.g. a remote connection with no local pid)
+	CmdOutputFolded     *bool             `json:"cmd:outputfolded,omitempty"`   // manual fold override for this command's output, set via ObjectService.UpdateObjectMeta; nil defers to wconfig.SettingsType's TermAutoFoldLines (see outputfold.ComputeFoldState, wshserver.GetOutputFoldStateCommand)
 
 	// AI options match settings
 	AiClear      bool    `json:"ai:*,omitempty"`
@@ -65,10 +78,18 @@ type MetaTSType struct {
 	AiMaxTokens  float64 `json:"ai:maxtokens,omitempty"`
 	AiTimeoutMs  float64 `json:"ai:timeoutms,omitempty"`
 
-	EditorClear               bool `json:"editor:*,omitempty"`
-	EditorMinimapEnabled      bool `json:"editor:minimapenabled,omitempty"`
-	EditorStickyScrollEnabled bool `json:"editor:stickyscrollenabled,omitempty"`
-	EditorWordWrap            bool `json:"editor:wordwrap,omitempty"`
+	EditorClear               bool   `json:"editor:*,omitempty"`
+	EditorMinimapEnabled      bool   `json:"editor:minimapenabled,omitempty"`
+	EditorStickyScrollEnabled bool   `json:"editor:stickyscrollenabled,omitempty"`
+	EditorWordWrap            bool   `json:"editor:wordwrap,omitempty"`
+	EditorGotoLine            int    `json:"editor:gotoline,omitempty"`   // line to scroll to and place the cursor on when the editor opens (1-indexed)
+	EditorGotoColumn          int    `json:"editor:gotocolumn,omitempty"` // column to place the cursor on when the editor opens (1-indexed, defaults to 1)
+	EditorLspCommand          string `json:"editor:lspcommand,omitempty"` // shell command that launches a language server for this block's file (e.g. "gopls", "typescript-language-server --stdio"); unset disables LSP-backed completions/hover
+
+	DiffClear   bool   `json:"diff:*,omitempty"`
+	DiffFile1   string `json:"diff:file1,omitempty"`
+	DiffFile2   string `json:"diff:file2,omitempty"`   // ignored when diff:githead is set
+	DiffGitHead bool   `json:"diff:githead,omitempty"` // diff diff:file1 against its git HEAD version instead of diff:file2
 
 	GraphClear     bool     `json:"graph:*,omitempty"`
 	GraphNumPoints int      `json:"graph:numpoints,omitempty"`
@@ -76,6 +97,9 @@ type MetaTSType struct {
 
 	SysinfoType string `json:"sysinfo:type,omitempty"`
 
+	// progress detected from a running command's output (e.g. percentages, pip/docker/apt progress bars)
+	ProgressPercent float64 `json:"progress:percent,omitempty"`
+
 	// for tabs
 	BgClear             bool    `json:"bg:*,omitempty"`
 	Bg                  string  `json:"bg,omitempty"`
@@ -83,6 +107,8 @@ type MetaTSType struct {
 	BgBlendMode         string  `json:"bg:blendmode,omitempty"`
 	BgBorderColor       string  `json:"bg:bordercolor,omitempty"`       // frame:bordercolor
 	BgActiveBorderColor string  `json:"bg:activebordercolor,omitempty"` // frame:activebordercolor
+	BgImage             string  `json:"bg:image,omitempty"`             // local file path, served via /wave/stream-file; set through ObjectService.SetBackgroundImage
+	BgImageBlur         float64 `json:"bg:imageblur,omitempty"`         // blur radius in pixels applied to BgImage (uses BgOpacity for opacity)
 
 	TermClear              bool     `json:"term:*,omitempty"`
 	TermFontSize           int      `json:"term:fontsize,omitempty"`
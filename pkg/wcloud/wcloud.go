@@ -17,6 +17,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/wavetermdev/waveterm/pkg/crashreport"
 	"github.com/wavetermdev/waveterm/pkg/telemetry"
 	"github.com/wavetermdev/waveterm/pkg/util/daystr"
 	"github.com/wavetermdev/waveterm/pkg/wavebase"
@@ -45,6 +46,7 @@ const MaxUpdatePayloadSize = 1 * (1024 * 1024)
 const TelemetryUrl = "/telemetry"
 const NoTelemetryUrl = "/no-telemetry"
 const WebShareUpdateUrl = "/auth/web-share-update"
+const CrashReportUrl = "/crash-report"
 
 func CacheAndRemoveEnvVars() error {
 	WCloudEndpoint_VarCache = os.Getenv(WCloudEndpointVarName)
@@ -186,6 +188,59 @@ func SendTelemetry(ctx context.Context, clientId string) error {
 	return nil
 }
 
+// BuildTelemetryPreviewJson builds the exact JSON payload that the next SendTelemetry call would
+// POST to the telemetry endpoint, without sending it or marking any activity as uploaded. Used to
+// back a local "what would be sent" inspector.
+func BuildTelemetryPreviewJson(ctx context.Context, clientId string) (string, error) {
+	activity, err := telemetry.GetInspectActivity(ctx)
+	if err != nil {
+		return "", fmt.Errorf("cannot get activity: %v", err)
+	}
+	input := TelemetryInputType{
+		ClientId:          clientId,
+		UserId:            clientId,
+		AppType:           "w2",
+		AutoUpdateEnabled: telemetry.IsAutoUpdateEnabled(),
+		AutoUpdateChannel: telemetry.AutoUpdateChannel(),
+		CurDay:            daystr.GetCurDayStr(),
+		Activity:          activity,
+	}
+	barr, err := json.MarshalIndent(input, "", "  ")
+	if err != nil {
+		return "", fmt.Errorf("error marshaling telemetry preview: %v", err)
+	}
+	return string(barr), nil
+}
+
+// UploadPendingCrashReports uploads every locally-captured crash report that hasn't been uploaded
+// yet (see pkg/crashreport), gated on telemetry.IsCrashReportsEnabled like any other telemetry
+// upload. Reports are always captured locally regardless of this setting -- only the upload is
+// conditional. Returns the number of reports successfully uploaded.
+func UploadPendingCrashReports(ctx context.Context, clientId string) (int, error) {
+	if !telemetry.IsCrashReportsEnabled() {
+		return 0, nil
+	}
+	pending, err := crashreport.ListPending()
+	if err != nil {
+		return 0, fmt.Errorf("cannot list pending crash reports: %v", err)
+	}
+	numUploaded := 0
+	for _, report := range pending {
+		req, err := makeAnonPostReq(ctx, CrashReportUrl, CrashReportInputType{ClientId: clientId, Report: report})
+		if err != nil {
+			return numUploaded, err
+		}
+		if _, err := doRequest(req, nil); err != nil {
+			return numUploaded, err
+		}
+		if err := crashreport.MarkUploaded(report); err != nil {
+			return numUploaded, fmt.Errorf("error marking crash report uploaded: %v", err)
+		}
+		numUploaded++
+	}
+	return numUploaded, nil
+}
+
 func SendNoTelemetryUpdate(ctx context.Context, clientId string, noTelemetryVal bool) error {
 	req, err := makeAnonPostReq(ctx, NoTelemetryUrl, NoTelemetryInputType{ClientId: clientId, Value: noTelemetryVal})
 	if err != nil {
@@ -4,6 +4,7 @@
 package wcloud
 
 import (
+	"github.com/wavetermdev/waveterm/pkg/crashreport"
 	"github.com/wavetermdev/waveterm/pkg/telemetry"
 )
 
@@ -21,3 +22,8 @@ type TelemetryInputType struct {
 	CurDay            string                    `json:"curday"`
 	Activity          []*telemetry.ActivityType `json:"activity"`
 }
+
+type CrashReportInputType struct {
+	ClientId string                   `json:"clientid"`
+	Report   *crashreport.CrashReport `json:"report"`
+}
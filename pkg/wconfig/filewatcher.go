@@ -136,10 +136,13 @@ func (w *Watcher) handleEvent(event fsnotify.Event) {
 var validFileRe = regexp.MustCompile(`^[a-zA-Z0-9_@.-]+\.json$`)
 
 func isValidSubSettingsFileName(fileName string) bool {
+	baseName := filepath.Base(fileName)
+	if baseName == WaveTomlFile {
+		return true
+	}
 	if filepath.Ext(fileName) != ".json" {
 		return false
 	}
-	baseName := filepath.Base(fileName)
 	return validFileRe.MatchString(baseName)
 }
 
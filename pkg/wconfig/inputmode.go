@@ -0,0 +1,90 @@
+// Copyright 2026, Command Line Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package wconfig
+
+// InputModeEmacs and InputModeVi are the two supported values for the "editor:inputmode" setting
+// (see SettingsType.EditorInputMode), selecting which line-editing chord set command input fields
+// (e.g. the AI chat box, the file search box) should use. This repo has no "FeState" concept --
+// command-input editing state lives in the frontend component that owns the text field, not in a
+// shared backend-tracked state object -- so what's tracked here is just the user's persisted
+// preference plus the canonical chord-to-action table for each mode; actually dispatching a key
+// event against the current input value is still the frontend's job, same division of labor as
+// KeybindingConfigType (see keybindingsconfig.go).
+const (
+	InputModeEmacs = "emacs"
+	InputModeVi    = "vi"
+)
+
+// InputEditAction is one line-editing operation an input field's key handler can perform, named
+// independently of KeybindingAction since these apply within a focused text field rather than to a
+// block or the app shell.
+type InputEditAction struct {
+	Id          string `json:"id"`
+	DisplayName string `json:"displayname"`
+}
+
+// InputEditActions is the fixed set of line-editing operations covered by the default emacs/vi
+// chord sets, modeled on the subset of readline/vi-insert bindings most command-input fields in
+// the product actually need (single-line text entry, not a full modal editor).
+var InputEditActions = []InputEditAction{
+	{Id: "moveleft", DisplayName: "Move cursor left"},
+	{Id: "moveright", DisplayName: "Move cursor right"},
+	{Id: "movewordleft", DisplayName: "Move cursor one word left"},
+	{Id: "movewordright", DisplayName: "Move cursor one word right"},
+	{Id: "linestart", DisplayName: "Move cursor to start of line"},
+	{Id: "lineend", DisplayName: "Move cursor to end of line"},
+	{Id: "deletewordback", DisplayName: "Delete word before cursor"},
+	{Id: "deletetoend", DisplayName: "Delete from cursor to end of line"},
+	{Id: "deletetostart", DisplayName: "Delete from cursor to start of line"},
+	{Id: "historyprev", DisplayName: "Recall previous history entry"},
+	{Id: "historynext", DisplayName: "Recall next history entry"},
+}
+
+// defaultInputModeChords gives the default chord bound to each InputEditActions id under each
+// supported mode. Vi's defaults are its insert-mode bindings (Escape-driven normal-mode navigation
+// isn't modeled here, since there's no modal text-field implementation in the frontend for it to
+// drive yet) plus the handful of additional chords (Ctrl:w, Ctrl:u, Ctrl:k) vi-insert shares with
+// emacs/readline.
+var defaultInputModeChords = map[string]map[string]string{
+	InputModeEmacs: {
+		"moveleft":       "Ctrl:b",
+		"moveright":      "Ctrl:f",
+		"movewordleft":   "Alt:b",
+		"movewordright":  "Alt:f",
+		"linestart":      "Ctrl:a",
+		"lineend":        "Ctrl:e",
+		"deletewordback": "Ctrl:w",
+		"deletetoend":    "Ctrl:k",
+		"deletetostart":  "Ctrl:u",
+		"historyprev":    "Ctrl:p",
+		"historynext":    "Ctrl:n",
+	},
+	InputModeVi: {
+		"moveleft":       "ArrowLeft",
+		"moveright":      "ArrowRight",
+		"movewordleft":   "Ctrl:ArrowLeft",
+		"movewordright":  "Ctrl:ArrowRight",
+		"linestart":      "Home",
+		"lineend":        "End",
+		"deletewordback": "Ctrl:w",
+		"deletetoend":    "Ctrl:k",
+		"deletetostart":  "Ctrl:u",
+		"historyprev":    "Ctrl:p",
+		"historynext":    "Ctrl:n",
+	},
+}
+
+// GetInputModeChords returns the chord-to-action-id map for mode, defaulting to InputModeEmacs for
+// an empty or unrecognized mode.
+func GetInputModeChords(mode string) map[string]string {
+	chords, ok := defaultInputModeChords[mode]
+	if !ok {
+		chords = defaultInputModeChords[InputModeEmacs]
+	}
+	rtn := make(map[string]string, len(chords))
+	for actionId, chord := range chords {
+		rtn[actionId] = chord
+	}
+	return rtn
+}
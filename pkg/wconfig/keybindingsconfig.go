@@ -0,0 +1,169 @@
+// Copyright 2026, Command Line Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package wconfig
+
+import (
+	"sort"
+
+	"github.com/wavetermdev/waveterm/pkg/waveobj"
+)
+
+// KeybindingsFile is the "keybindings" config part (see defaultconfig/keybindings.json for the
+// built-in defaults, layered under the home config directory's keybindings.json the same way
+// every other part is, see ReadFullConfig). This is a registry of chord-to-action bindings only --
+// resolving a chord on a live keypress into an actual dispatch is still the frontend's job (see
+// frontend/app/store/keymodel.ts); what lives here is the config shape, per-platform chord
+// resolution, conflict detection, and action-id validation, so a settings UI or wsh command can
+// manage bindings against a known and consistent vocabulary.
+const KeybindingsFile = "keybindings.json"
+
+// KeybindingConfigType is one key binding, keyed by a user-chosen id in the "keybindings" config
+// part. Chord uses the same "Cmd:Shift:p"-style chord syntax the frontend already parses (see
+// frontend/util/keyutil.ts). ChordDarwin/ChordWindows/ChordLinux override Chord on that platform
+// only, which is how the defaults encode "Cmd on macOS, Alt everywhere else, Ctrl shared" (see
+// docs/docs/keybindings.mdx). Action is a registered action id (see KeybindingActions) or
+// KeybindingActionWsh for a custom invocation, in which case BlockDef is used the same way a
+// trigger or file handler's BlockDef is.
+type KeybindingConfigType struct {
+	Action       string           `json:"action"`
+	Chord        string           `json:"chord,omitempty"`
+	ChordDarwin  string           `json:"chorddarwin,omitempty"`
+	ChordWindows string           `json:"chordwindows,omitempty"`
+	ChordLinux   string           `json:"chordlinux,omitempty"`
+	BlockDef     waveobj.BlockDef `json:"blockdef,omitempty"`
+	Disabled     bool             `json:"disabled,omitempty"`
+}
+
+// KeybindingAction describes one backend-known operation a keybinding can be bound to.
+type KeybindingAction struct {
+	Id          string `json:"id"`
+	DisplayName string `json:"displayname"`
+	Category    string `json:"category"`
+}
+
+// KeybindingActionWsh is the Action value for a user-defined binding that creates a block from
+// its own BlockDef instead of naming a built-in action.
+const KeybindingActionWsh = "wsh"
+
+// KeybindingActions is the fixed set of built-in actions a keybinding's Action field may name,
+// mirroring the Global/File Preview/Web/WaveAI/Terminal sections of docs/docs/keybindings.mdx.
+// Actual dispatch for these still lives in the frontend; this list exists so bindings can be
+// validated against a known vocabulary before being saved.
+var KeybindingActions = []KeybindingAction{
+	{Id: "tab:new", DisplayName: "Open a new tab", Category: "global"},
+	{Id: "block:newterm", DisplayName: "Open a new terminal block", Category: "global"},
+	{Id: "window:new", DisplayName: "Open a new window", Category: "global"},
+	{Id: "block:close", DisplayName: "Close the current block", Category: "global"},
+	{Id: "tab:close", DisplayName: "Close the current tab", Category: "global"},
+	{Id: "block:magnify", DisplayName: "Magnify / un-magnify the current block", Category: "global"},
+	{Id: "connection:switcher", DisplayName: "Open the connection switcher", Category: "global"},
+	{Id: "block:refocus", DisplayName: "Refocus the current block", Category: "global"},
+	{Id: "block:shownumbers", DisplayName: "Show block numbers", Category: "global"},
+	{Id: "block:switch", DisplayName: "Switch to block number", Category: "global"},
+	{Id: "block:movefocus", DisplayName: "Move focus between blocks", Category: "global"},
+	{Id: "tab:switch", DisplayName: "Switch to tab number", Category: "global"},
+	{Id: "tab:switchleft", DisplayName: "Switch tab left", Category: "global"},
+	{Id: "tab:switchright", DisplayName: "Switch tab right", Category: "global"},
+	{Id: "workspace:switch", DisplayName: "Switch to workspace number", Category: "global"},
+	{Id: "app:refreshui", DisplayName: "Refresh the UI", Category: "global"},
+	{Id: "tab:cyclelayoutpreset", DisplayName: "Cycle to the next layout preset", Category: "global"},
+	{Id: "preview:up", DisplayName: "Move up a directory", Category: "preview"},
+	{Id: "preview:back", DisplayName: "Go back", Category: "preview"},
+	{Id: "preview:forward", DisplayName: "Go forward", Category: "preview"},
+	{Id: "preview:open", DisplayName: "Open a new file", Category: "preview"},
+	{Id: "preview:save", DisplayName: "Save file", Category: "preview"},
+	{Id: "preview:edit", DisplayName: "Toggle preview/edit mode", Category: "preview"},
+	{Id: "preview:revert", DisplayName: "Revert changes", Category: "preview"},
+	{Id: "web:focusurl", DisplayName: "Focus the URL input bar", Category: "web"},
+	{Id: "web:reload", DisplayName: "Reload webpage", Category: "web"},
+	{Id: "web:back", DisplayName: "Back", Category: "web"},
+	{Id: "web:forward", DisplayName: "Forward", Category: "web"},
+	{Id: "waveai:clear", DisplayName: "Clear AI chat", Category: "waveai"},
+	{Id: "term:copy", DisplayName: "Copy", Category: "term"},
+	{Id: "term:paste", DisplayName: "Paste", Category: "term"},
+	{Id: "term:clear", DisplayName: "Clear terminal", Category: "term"},
+}
+
+// IsKnownKeybindingAction reports whether actionId is KeybindingActionWsh or names an entry in
+// KeybindingActions.
+func IsKnownKeybindingAction(actionId string) bool {
+	if actionId == KeybindingActionWsh {
+		return true
+	}
+	for _, action := range KeybindingActions {
+		if action.Id == actionId {
+			return true
+		}
+	}
+	return false
+}
+
+// ResolveKeybindingChord returns the chord that applies to kb on goos (one of "darwin", "windows",
+// "linux"): the matching per-platform override if set, else the plain Chord (which is empty for a
+// binding that hasn't been configured for goos at all).
+func ResolveKeybindingChord(kb KeybindingConfigType, goos string) string {
+	switch goos {
+	case "darwin":
+		if kb.ChordDarwin != "" {
+			return kb.ChordDarwin
+		}
+	case "windows":
+		if kb.ChordWindows != "" {
+			return kb.ChordWindows
+		}
+	case "linux":
+		if kb.ChordLinux != "" {
+			return kb.ChordLinux
+		}
+	}
+	return kb.Chord
+}
+
+// KeybindingConflict is a chord, on one platform, claimed by more than one enabled binding -- at
+// most one of them can actually fire, so callers (a settings UI, or wsh) should warn about these
+// before saving. Conflict detection does not model block-type scoping (e.g. a file preview binding
+// and a web-view binding that are never both active at once still count as a conflict here), so
+// this is a conservative, over-inclusive check, not a proof a chord silently does nothing.
+type KeybindingConflict struct {
+	Platform string   `json:"platform"`
+	Chord    string   `json:"chord"`
+	Ids      []string `json:"ids"`
+}
+
+// DetectKeybindingConflicts reports every chord, on every platform, claimed by more than one
+// enabled binding in bindings. Ids within each conflict are sorted for deterministic output.
+func DetectKeybindingConflicts(bindings map[string]KeybindingConfigType) []KeybindingConflict {
+	var ids []string
+	for id := range bindings {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	var conflicts []KeybindingConflict
+	for _, platform := range []string{"darwin", "windows", "linux"} {
+		byChord := make(map[string][]string)
+		for _, id := range ids {
+			kb := bindings[id]
+			if kb.Disabled {
+				continue
+			}
+			chord := ResolveKeybindingChord(kb, platform)
+			if chord == "" {
+				continue
+			}
+			byChord[chord] = append(byChord[chord], id)
+		}
+		var chords []string
+		for chord := range byChord {
+			chords = append(chords, chord)
+		}
+		sort.Strings(chords)
+		for _, chord := range chords {
+			if len(byChord[chord]) > 1 {
+				conflicts = append(conflicts, KeybindingConflict{Platform: platform, Chord: chord, Ids: byChord[chord]})
+			}
+		}
+	}
+	return conflicts
+}
@@ -0,0 +1,29 @@
+// Copyright 2026, Command Line Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package wconfig
+
+import "github.com/wavetermdev/waveterm/pkg/waveobj"
+
+// LayoutPresetsFile is the "layoutpresets" config part (see defaultconfig/layoutpresets.json for
+// the built-in defaults), a registry of named block arrangements a tab can be reset to -- a
+// pre-filled PortableLayout (see pkg/wcore.PortableLayout) keyed by a user-chosen id, the same
+// shape/merge convention as widgets/triggers/keybindings.
+const LayoutPresetsFile = "layoutpresets.json"
+
+// LayoutPresetEntry is one block placement within a LayoutPresetConfigType, mirroring the shape of
+// pkg/wcore.PortableLayout's entries so applying a preset is just feeding Layout into
+// wcore.ApplyPortableLayout.
+type LayoutPresetEntry struct {
+	IndexArr []int             `json:"indexarr"`
+	Size     *uint             `json:"size,omitempty"`
+	BlockDef *waveobj.BlockDef `json:"blockdef"`
+	Focused  bool              `json:"focused,omitempty"`
+}
+
+// LayoutPresetConfigType is one named layout preset in the "layoutpresets" config part.
+type LayoutPresetConfigType struct {
+	DisplayName  string              `json:"displayname"`
+	DisplayOrder float64             `json:"display:order,omitempty"`
+	Layout       []LayoutPresetEntry `json:"layout"`
+}
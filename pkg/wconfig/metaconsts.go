@@ -6,89 +6,166 @@
 package wconfig
 
 const (
-	ConfigKey_AppClear                       = "app:*"
-	ConfigKey_AppGlobalHotkey                = "app:globalhotkey"
-	ConfigKey_AppDismissArchitectureWarning  = "app:dismissarchitecturewarning"
-
-	ConfigKey_AiClear                        = "ai:*"
-	ConfigKey_AiPreset                       = "ai:preset"
-	ConfigKey_AiApiType                      = "ai:apitype"
-	ConfigKey_AiBaseURL                      = "ai:baseurl"
-	ConfigKey_AiApiToken                     = "ai:apitoken"
-	ConfigKey_AiName                         = "ai:name"
-	ConfigKey_AiModel                        = "ai:model"
-	ConfigKey_AiOrgID                        = "ai:orgid"
-	ConfigKey_AIApiVersion                   = "ai:apiversion"
-	ConfigKey_AiMaxTokens                    = "ai:maxtokens"
-	ConfigKey_AiTimeoutMs                    = "ai:timeoutms"
-	ConfigKey_AiFontSize                     = "ai:fontsize"
-	ConfigKey_AiFixedFontSize                = "ai:fixedfontsize"
+	ConfigKey_AppClear                      = "app:*"
+	ConfigKey_AppGlobalHotkey               = "app:globalhotkey"
+	ConfigKey_AppDismissArchitectureWarning = "app:dismissarchitecturewarning"
+	ConfigKey_AppLocale                     = "app:locale"
+
+	ConfigKey_AiClear                     = "ai:*"
+	ConfigKey_AiPreset                    = "ai:preset"
+	ConfigKey_AiApiType                   = "ai:apitype"
+	ConfigKey_AiBaseURL                   = "ai:baseurl"
+	ConfigKey_AiApiToken                  = "ai:apitoken"
+	ConfigKey_AiName                      = "ai:name"
+	ConfigKey_AiModel                     = "ai:model"
+	ConfigKey_AiOrgID                     = "ai:orgid"
+	ConfigKey_AIApiVersion                = "ai:apiversion"
+	ConfigKey_AiMaxTokens                 = "ai:maxtokens"
+	ConfigKey_AiTimeoutMs                 = "ai:timeoutms"
+	ConfigKey_AiFontSize                  = "ai:fontsize"
+	ConfigKey_AiFixedFontSize             = "ai:fixedfontsize"
+	ConfigKey_AiBudgetMonthlyLimit        = "ai:budget:monthlylimit"
+	ConfigKey_AiBudgetCurrency            = "ai:budget:currency"
+	ConfigKey_AiBudgetHardStop            = "ai:budget:hardstop"
+	ConfigKey_AiApiTokens                 = "ai:apitokens"
+	ConfigKey_AiGatewayAuthHeaderTemplate = "ai:gateway:authheadertemplate"
+
+	ConfigKey_OidcClear        = "oidc:*"
+	ConfigKey_OidcEnabled      = "oidc:enabled"
+	ConfigKey_OidcIssuer       = "oidc:issuer"
+	ConfigKey_OidcClientId     = "oidc:clientid"
+	ConfigKey_OidcClientSecret = "oidc:clientsecret"
+	ConfigKey_OidcRedirectUrl  = "oidc:redirecturl"
+	ConfigKey_OidcSessionTtlMs = "oidc:sessionttlms"
+
+	ConfigKey_ServerClear                  = "server:*"
+	ConfigKey_ServerHeadless               = "server:headless"
+	ConfigKey_ServerListenAddr             = "server:listenaddr"
+	ConfigKey_ServerFrontendDir            = "server:frontenddir"
+	ConfigKey_ServerTlsCertFile            = "server:tlscertfile"
+	ConfigKey_ServerTlsKeyFile             = "server:tlskeyfile"
+	ConfigKey_ServerPprofEnabled           = "server:pprofenabled"
+	ConfigKey_ServerShutdownDrainTimeoutMs = "server:shutdowndraintimeoutms"
+	ConfigKey_ServerFilestoreWalSyncMode   = "server:filestorewalsyncmode"
+
+	ConfigKey_GatewayClear          = "gateway:*"
+	ConfigKey_GatewayEnabled        = "gateway:enabled"
+	ConfigKey_GatewayToken          = "gateway:token"
+	ConfigKey_GatewayUnixSocketPath = "gateway:unixsocketpath"
+
+	ConfigKey_McpClear            = "mcp:*"
+	ConfigKey_McpEnabled          = "mcp:enabled"
+	ConfigKey_McpAllowRunCommand  = "mcp:allowruncommand"
+	ConfigKey_McpAllowReadFile    = "mcp:allowreadfile"
+	ConfigKey_McpAllowListTabs    = "mcp:allowlisttabs"
+	ConfigKey_McpAllowFetchOutput = "mcp:allowfetchoutput"
+
+	ConfigKey_SyncClear     = "sync:*"
+	ConfigKey_SyncEnabled   = "sync:enabled"
+	ConfigKey_SyncServerURL = "sync:serverurl"
+	ConfigKey_SyncKey       = "sync:key"
 
 	ConfigKey_TermClear                      = "term:*"
 	ConfigKey_TermFontSize                   = "term:fontsize"
 	ConfigKey_TermFontFamily                 = "term:fontfamily"
 	ConfigKey_TermTheme                      = "term:theme"
 	ConfigKey_TermDisableWebGl               = "term:disablewebgl"
+	ConfigKey_TermDisableImage               = "term:disableimage"
+	ConfigKey_TermDisableOsc52               = "term:disableosc52"
+	ConfigKey_TermOsc52MaxSize               = "term:osc52maxsize"
 	ConfigKey_TermLocalShellPath             = "term:localshellpath"
 	ConfigKey_TermLocalShellOpts             = "term:localshellopts"
+	ConfigKey_TermAutoFoldLines              = "term:autofoldlines"
 	ConfigKey_TermScrollback                 = "term:scrollback"
 	ConfigKey_TermCopyOnSelect               = "term:copyonselect"
 	ConfigKey_TermTransparency               = "term:transparency"
-
-	ConfigKey_EditorMinimapEnabled           = "editor:minimapenabled"
-	ConfigKey_EditorStickyScrollEnabled      = "editor:stickyscrollenabled"
-	ConfigKey_EditorWordWrap                 = "editor:wordwrap"
-	ConfigKey_EditorFontSize                 = "editor:fontsize"
-
-	ConfigKey_WebClear                       = "web:*"
-	ConfigKey_WebOpenLinksInternally         = "web:openlinksinternally"
-	ConfigKey_WebDefaultUrl                  = "web:defaulturl"
-	ConfigKey_WebDefaultSearch               = "web:defaultsearch"
-
-	ConfigKey_BlockHeaderClear               = "blockheader:*"
-	ConfigKey_BlockHeaderShowBlockIds        = "blockheader:showblockids"
-
-	ConfigKey_AutoUpdateClear                = "autoupdate:*"
-	ConfigKey_AutoUpdateEnabled              = "autoupdate:enabled"
-	ConfigKey_AutoUpdateIntervalMs           = "autoupdate:intervalms"
-	ConfigKey_AutoUpdateInstallOnQuit        = "autoupdate:installonquit"
-	ConfigKey_AutoUpdateChannel              = "autoupdate:channel"
-
-	ConfigKey_MarkdownFontSize               = "markdown:fontsize"
-	ConfigKey_MarkdownFixedFontSize          = "markdown:fixedfontsize"
-
-	ConfigKey_PreviewShowHiddenFiles         = "preview:showhiddenfiles"
-
-	ConfigKey_TabPreset                      = "tab:preset"
-
-	ConfigKey_WidgetClear                    = "widget:*"
-	ConfigKey_WidgetShowHelp                 = "widget:showhelp"
-
-	ConfigKey_WindowClear                    = "window:*"
-	ConfigKey_WindowTransparent              = "window:transparent"
-	ConfigKey_WindowBlur                     = "window:blur"
-	ConfigKey_WindowOpacity                  = "window:opacity"
-	ConfigKey_WindowBgColor                  = "window:bgcolor"
-	ConfigKey_WindowReducedMotion            = "window:reducedmotion"
-	ConfigKey_WindowTileGapSize              = "window:tilegapsize"
-	ConfigKey_WindowShowMenuBar              = "window:showmenubar"
-	ConfigKey_WindowNativeTitleBar           = "window:nativetitlebar"
-	ConfigKey_WindowDisableHardwareAcceleration = "window:disablehardwareacceleration"
-	ConfigKey_WindowMaxTabCacheSize          = "window:maxtabcachesize"
-	ConfigKey_WindowMagnifiedBlockOpacity    = "window:magnifiedblockopacity"
-	ConfigKey_WindowMagnifiedBlockSize       = "window:magnifiedblocksize"
-	ConfigKey_WindowMagnifiedBlockBlurPrimaryPx = "window:magnifiedblockblurprimarypx"
+	ConfigKey_TermPasteOnMiddleClick         = "term:pasteonmiddleclick"
+	ConfigKey_TermBracketedPasteMode         = "term:bracketedpastemode"
+	ConfigKey_TermTrimTrailingNewlineOnPaste = "term:trimtrailingnewlineonpaste"
+	ConfigKey_TermDisablePasteSafety         = "term:disablepastesafety"
+
+	ConfigKey_RedactClear           = "redact:*"
+	ConfigKey_RedactDisableBuiltins = "redact:disablebuiltins"
+
+	ConfigKey_IdleLockClear          = "idlelock:*"
+	ConfigKey_IdleLockTimeoutMinutes = "idlelock:timeoutminutes"
+
+	ConfigKey_A11yClear            = "a11y:*"
+	ConfigKey_A11yScreenReaderMode = "a11y:screenreadermode"
+	ConfigKey_A11yReduceMotion     = "a11y:reducemotion"
+
+	ConfigKey_EditorMinimapEnabled      = "editor:minimapenabled"
+	ConfigKey_EditorStickyScrollEnabled = "editor:stickyscrollenabled"
+	ConfigKey_EditorWordWrap            = "editor:wordwrap"
+	ConfigKey_EditorFontSize            = "editor:fontsize"
+	ConfigKey_EditorInputMode           = "editor:inputmode"
+
+	ConfigKey_WebClear               = "web:*"
+	ConfigKey_WebOpenLinksInternally = "web:openlinksinternally"
+	ConfigKey_WebDefaultUrl          = "web:defaulturl"
+	ConfigKey_WebDefaultSearch       = "web:defaultsearch"
+
+	ConfigKey_BlockHeaderClear        = "blockheader:*"
+	ConfigKey_BlockHeaderShowBlockIds = "blockheader:showblockids"
+
+	ConfigKey_FileClear                = "file:*"
+	ConfigKey_FileBwLimitBps           = "file:bwlimitbps"
+	ConfigKey_FileForcePermanentDelete = "file:forcepermanentdelete"
+
+	ConfigKey_SysinfoClear        = "sysinfo:*"
+	ConfigKey_SysinfoSampleRateMs = "sysinfo:sampleratems"
+	ConfigKey_SysinfoRetention    = "sysinfo:retention"
+
+	ConfigKey_GitClear             = "git:*"
+	ConfigKey_GitHubToken          = "git:githubtoken"
+	ConfigKey_GitLabToken          = "git:gitlabtoken"
+	ConfigKey_GitLabBaseURL        = "git:gitlabbaseurl"
+	ConfigKey_GitPrStatusRefreshMs = "git:prstatusrefreshms"
+
+	ConfigKey_AutoUpdateClear         = "autoupdate:*"
+	ConfigKey_AutoUpdateEnabled       = "autoupdate:enabled"
+	ConfigKey_AutoUpdateIntervalMs    = "autoupdate:intervalms"
+	ConfigKey_AutoUpdateInstallOnQuit = "autoupdate:installonquit"
+	ConfigKey_AutoUpdateChannel       = "autoupdate:channel"
+
+	ConfigKey_MarkdownFontSize      = "markdown:fontsize"
+	ConfigKey_MarkdownFixedFontSize = "markdown:fixedfontsize"
+
+	ConfigKey_PreviewShowHiddenFiles = "preview:showhiddenfiles"
+
+	ConfigKey_TabPreset = "tab:preset"
+
+	ConfigKey_WidgetClear    = "widget:*"
+	ConfigKey_WidgetShowHelp = "widget:showhelp"
+
+	ConfigKey_WindowClear                         = "window:*"
+	ConfigKey_WindowTransparent                   = "window:transparent"
+	ConfigKey_WindowBlur                          = "window:blur"
+	ConfigKey_WindowOpacity                       = "window:opacity"
+	ConfigKey_WindowBgColor                       = "window:bgcolor"
+	ConfigKey_WindowReducedMotion                 = "window:reducedmotion"
+	ConfigKey_WindowTileGapSize                   = "window:tilegapsize"
+	ConfigKey_WindowShowMenuBar                   = "window:showmenubar"
+	ConfigKey_WindowNativeTitleBar                = "window:nativetitlebar"
+	ConfigKey_WindowDisableHardwareAcceleration   = "window:disablehardwareacceleration"
+	ConfigKey_WindowMaxTabCacheSize               = "window:maxtabcachesize"
+	ConfigKey_WindowMagnifiedBlockOpacity         = "window:magnifiedblockopacity"
+	ConfigKey_WindowMagnifiedBlockSize            = "window:magnifiedblocksize"
+	ConfigKey_WindowMagnifiedBlockBlurPrimaryPx   = "window:magnifiedblockblurprimarypx"
 	ConfigKey_WindowMagnifiedBlockBlurSecondaryPx = "window:magnifiedblockblursecondarypx"
-	ConfigKey_WindowConfirmClose             = "window:confirmclose"
-	ConfigKey_WindowSaveLastWindow           = "window:savelastwindow"
-	ConfigKey_WindowDimensions               = "window:dimensions"
-	ConfigKey_WindowZoom                     = "window:zoom"
-
-	ConfigKey_TelemetryClear                 = "telemetry:*"
-	ConfigKey_TelemetryEnabled               = "telemetry:enabled"
-
-	ConfigKey_ConnClear                      = "conn:*"
-	ConfigKey_ConnAskBeforeWshInstall        = "conn:askbeforewshinstall"
-	ConfigKey_ConnWshEnabled                 = "conn:wshenabled"
+	ConfigKey_WindowConfirmClose                  = "window:confirmclose"
+	ConfigKey_WindowSaveLastWindow                = "window:savelastwindow"
+	ConfigKey_WindowDimensions                    = "window:dimensions"
+	ConfigKey_WindowZoom                          = "window:zoom"
+
+	ConfigKey_TelemetryClear        = "telemetry:*"
+	ConfigKey_TelemetryEnabled      = "telemetry:enabled"
+	ConfigKey_TelemetryCrashReports = "telemetry:crashreports"
+	ConfigKey_TelemetryUsageCounts  = "telemetry:usagecounts"
+	ConfigKey_TelemetryAIMetadata   = "telemetry:aimetadata"
+
+	ConfigKey_ConnClear               = "conn:*"
+	ConfigKey_ConnAskBeforeWshInstall = "conn:askbeforewshinstall"
+	ConfigKey_ConnWshEnabled          = "conn:wshenabled"
+	ConfigKey_ConnSudoCacheTtlMs      = "conn:sudocachettlms"
 )
-
@@ -12,9 +12,14 @@ import (
 	"os"
 	"path/filepath"
 	"reflect"
+	"regexp"
 	"sort"
 	"strings"
+	"time"
 
+	"github.com/wavetermdev/waveterm/pkg/cmdpolicy"
+	"github.com/wavetermdev/waveterm/pkg/secretredact"
+	"github.com/wavetermdev/waveterm/pkg/sudocache"
 	"github.com/wavetermdev/waveterm/pkg/util/utilfn"
 	"github.com/wavetermdev/waveterm/pkg/wavebase"
 	"github.com/wavetermdev/waveterm/pkg/waveobj"
@@ -24,6 +29,31 @@ import (
 
 const SettingsFile = "settings.json"
 const ConnectionsFile = "connections.json"
+const PluginsFile = "plugins.json"
+const RenderersFile = "renderers.json"
+const WidgetsFile = "widgets.json"
+const FileHandlersFile = "filehandlers.json"
+const TriggersFile = "triggers.json"
+const TermThemesFile = "termthemes.json"
+
+// Modes for SettingsType.TermBracketedPasteMode: whether the terminal wraps pasted text in the
+// \x1b[200~/\x1b[201~ bracketed-paste markers xterm.js itself decides to request.
+const (
+	BracketedPasteModeAuto   = "auto"   // honor whatever the running program (shell/TUI) requested, the terminal default
+	BracketedPasteModeAlways = "always" // force bracketed-paste markers even if the program didn't request them
+	BracketedPasteModeNever  = "never"  // strip bracketed-paste markers, pasting as plain keystrokes
+)
+
+// IsValidBracketedPasteMode reports whether mode is empty (meaning BracketedPasteModeAuto) or one
+// of the BracketedPasteMode* constants.
+func IsValidBracketedPasteMode(mode string) bool {
+	switch mode {
+	case "", BracketedPasteModeAuto, BracketedPasteModeAlways, BracketedPasteModeNever:
+		return true
+	default:
+		return false
+	}
+}
 
 const AnySchema = `
 {
@@ -36,6 +66,7 @@ type SettingsType struct {
 	AppClear                      bool   `json:"app:*,omitempty"`
 	AppGlobalHotkey               string `json:"app:globalhotkey,omitempty"`
 	AppDismissArchitectureWarning bool   `json:"app:dismissarchitecturewarning,omitempty"`
+	AppLocale                     string `json:"app:locale,omitempty"` // e.g. "en", "es" -- selects the catalog i18n.T uses for server-generated strings (command announcements, etc); empty means i18n.DefaultLocale
 
 	AiClear         bool    `json:"ai:*,omitempty"`
 	AiPreset        string  `json:"ai:preset,omitempty"`
@@ -51,21 +82,84 @@ type SettingsType struct {
 	AiFontSize      float64 `json:"ai:fontsize,omitempty"`
 	AiFixedFontSize float64 `json:"ai:fixedfontsize,omitempty"`
 
-	TermClear          bool     `json:"term:*,omitempty"`
-	TermFontSize       float64  `json:"term:fontsize,omitempty"`
-	TermFontFamily     string   `json:"term:fontfamily,omitempty"`
-	TermTheme          string   `json:"term:theme,omitempty"`
-	TermDisableWebGl   bool     `json:"term:disablewebgl,omitempty"`
-	TermLocalShellPath string   `json:"term:localshellpath,omitempty"`
-	TermLocalShellOpts []string `json:"term:localshellopts,omitempty"`
-	TermScrollback     *int64   `json:"term:scrollback,omitempty"`
-	TermCopyOnSelect   *bool    `json:"term:copyonselect,omitempty"`
-	TermTransparency   *float64 `json:"term:transparency,omitempty"`
+	AiBudgetMonthlyLimit float64 `json:"ai:budget:monthlylimit,omitempty"`
+	AiBudgetCurrency     string  `json:"ai:budget:currency,omitempty"`
+	AiBudgetHardStop     bool    `json:"ai:budget:hardstop,omitempty"`
+
+	AiApiTokens                 string `json:"ai:apitokens,omitempty"` // comma-separated list of keys to rotate through
+	AiGatewayAuthHeaderTemplate string `json:"ai:gateway:authheadertemplate,omitempty"`
+
+	OidcClear        bool    `json:"oidc:*,omitempty"`
+	OidcEnabled      bool    `json:"oidc:enabled,omitempty"`
+	OidcIssuer       string  `json:"oidc:issuer,omitempty"`
+	OidcClientId     string  `json:"oidc:clientid,omitempty"`
+	OidcClientSecret string  `json:"oidc:clientsecret,omitempty"` // empty for public clients (PKCE-only)
+	OidcRedirectUrl  string  `json:"oidc:redirecturl,omitempty"`
+	OidcSessionTtlMs float64 `json:"oidc:sessionttlms,omitempty"` // defaults to 24h if unset
+
+	ServerClear        bool   `json:"server:*,omitempty"`
+	ServerHeadless     bool   `json:"server:headless,omitempty"`    // run without Electron, serving the full frontend over HTTP(S)
+	ServerListenAddr   string `json:"server:listenaddr,omitempty"`  // e.g. "0.0.0.0:61269", only used when server:headless is set
+	ServerFrontendDir  string `json:"server:frontenddir,omitempty"` // directory containing the built frontend (dist/frontend) to serve
+	ServerTlsCertFile  string `json:"server:tlscertfile,omitempty"`
+	ServerTlsKeyFile   string `json:"server:tlskeyfile,omitempty"`
+	ServerPprofEnabled bool   `json:"server:pprofenabled,omitempty"` // exposes /debug/pprof/* on the local server, off by default
+
+	ServerShutdownDrainTimeoutMs float64 `json:"server:shutdowndraintimeoutms,omitempty"` // how long to wait for running commands to exit on shutdown before giving up, defaults to 2000
+
+	ServerFilestoreWalSyncMode string `json:"server:filestorewalsyncmode,omitempty"` // "always", "periodic" (default), or "off" -- how often the filestore cache's write-ahead journal is fsync'd
+
+	GatewayClear          bool   `json:"gateway:*,omitempty"`
+	GatewayEnabled        bool   `json:"gateway:enabled,omitempty"`
+	GatewayToken          string `json:"gateway:token,omitempty"` // required bearer token for all gateway requests
+	GatewayUnixSocketPath string `json:"gateway:unixsocketpath,omitempty"`
+
+	McpClear            bool `json:"mcp:*,omitempty"`
+	McpEnabled          bool `json:"mcp:enabled,omitempty"`
+	McpAllowRunCommand  bool `json:"mcp:allowruncommand,omitempty"`
+	McpAllowReadFile    bool `json:"mcp:allowreadfile,omitempty"`
+	McpAllowListTabs    bool `json:"mcp:allowlisttabs,omitempty"`
+	McpAllowFetchOutput bool `json:"mcp:allowfetchoutput,omitempty"`
+
+	SyncClear     bool   `json:"sync:*,omitempty"`
+	SyncEnabled   bool   `json:"sync:enabled,omitempty"`
+	SyncServerURL string `json:"sync:serverurl,omitempty"`
+	SyncKey       string `json:"sync:key,omitempty"` // passphrase used to derive the end-to-end encryption key; never sent to the server
+
+	TermClear                      bool     `json:"term:*,omitempty"`
+	TermFontSize                   float64  `json:"term:fontsize,omitempty"`
+	TermFontFamily                 string   `json:"term:fontfamily,omitempty"`
+	TermTheme                      string   `json:"term:theme,omitempty"`
+	TermDisableWebGl               bool     `json:"term:disablewebgl,omitempty"`
+	TermDisableImage               bool     `json:"term:disableimage,omitempty"`
+	TermDisableOsc52               bool     `json:"term:disableosc52,omitempty"`
+	TermOsc52MaxSize               *int64   `json:"term:osc52maxsize,omitempty"`
+	TermLocalShellPath             string   `json:"term:localshellpath,omitempty"`
+	TermLocalShellOpts             []string `json:"term:localshellopts,omitempty"`
+	TermAutoFoldLines              int64    `json:"term:autofoldlines,omitempty"` // auto-fold a "cmd" block's output once it exceeds this many lines, 0 disables auto-fold; see outputfold.ComputeFoldState
+	TermScrollback                 *int64   `json:"term:scrollback,omitempty"`
+	TermCopyOnSelect               *bool    `json:"term:copyonselect,omitempty"`
+	TermTransparency               *float64 `json:"term:transparency,omitempty"`
+	TermPasteOnMiddleClick         *bool    `json:"term:pasteonmiddleclick,omitempty"`
+	TermBracketedPasteMode         string   `json:"term:bracketedpastemode,omitempty"`         // one of BracketedPasteModeAuto (default), BracketedPasteModeAlways, BracketedPasteModeNever -- see IsValidBracketedPasteMode
+	TermTrimTrailingNewlineOnPaste *bool    `json:"term:trimtrailingnewlineonpaste,omitempty"` // strip a single trailing newline from pasted text so it doesn't auto-submit into the shell (defaults to true)
+	TermDisablePasteSafety         bool     `json:"term:disablepastesafety,omitempty"`         // skip the pasteguard confirmation prompt for multi-line/sudo/curl-pipe-to-shell pastes globally; see ConnKeywords.TermPasteSafety for a per-connection allowlist
+
+	RedactClear           bool `json:"redact:*,omitempty"`
+	RedactDisableBuiltins bool `json:"redact:disablebuiltins,omitempty"` // skip secretredact.DefaultRules, using only the custom patterns configured in the "redactrules" config part
+
+	IdleLockClear          bool  `json:"idlelock:*,omitempty"`
+	IdleLockTimeoutMinutes int64 `json:"idlelock:timeoutminutes,omitempty"` // lock the window after this many minutes of inactivity; 0 disables idle locking; see idlelock.ShouldLock, ConnKeywords.ConnDisconnectOnIdleLock
+
+	A11yClear            bool `json:"a11y:*,omitempty"`
+	A11yScreenReaderMode bool `json:"a11y:screenreadermode,omitempty"` // mirrors each block's terminal output into a structured, line-oriented stream (see a11ystream, wps.Event_A11yLine) for an ARIA live region, instead of requiring a screen reader to parse the raw terminal grid
+	A11yReduceMotion     bool `json:"a11y:reducemotion,omitempty"`     // hint for the frontend to skip/shorten animations and reduce incidental UI updates; see also window:reducedmotion, which only covers the native window chrome
 
 	EditorMinimapEnabled      bool    `json:"editor:minimapenabled,omitempty"`
 	EditorStickyScrollEnabled bool    `json:"editor:stickyscrollenabled,omitempty"`
 	EditorWordWrap            bool    `json:"editor:wordwrap,omitempty"`
 	EditorFontSize            float64 `json:"editor:fontsize,omitempty"`
+	EditorInputMode           string  `json:"editor:inputmode,omitempty"` // "emacs" (default) or "vi" -- line-editing mode for command input fields, see wconfig.InputModeEmacs/InputModeVi
 
 	WebClear               bool   `json:"web:*,omitempty"`
 	WebOpenLinksInternally bool   `json:"web:openlinksinternally,omitempty"`
@@ -75,6 +169,20 @@ type SettingsType struct {
 	BlockHeaderClear        bool `json:"blockheader:*,omitempty"`
 	BlockHeaderShowBlockIds bool `json:"blockheader:showblockids,omitempty"`
 
+	FileClear                bool  `json:"file:*,omitempty"`
+	FileBwLimitBps           int64 `json:"file:bwlimitbps,omitempty"`           // global default cap on remote file copy/streaming throughput, in bytes/sec; 0 means unlimited, overridable per-connection via conn:bwlimitbps
+	FileForcePermanentDelete bool  `json:"file:forcepermanentdelete,omitempty"` // skip the OS trash and unlink local files directly; remote deletes are always permanent regardless of this setting, since there's no trash facility to reach from the wsh helper
+
+	SysinfoClear        bool  `json:"sysinfo:*,omitempty"`
+	SysinfoSampleRateMs int64 `json:"sysinfo:sampleratems,omitempty"` // sample interval for the cpu/mem/disk/net backend, in ms; 0 uses the default (1000ms), overridable per-connection via conn:sysinfosampleratems
+	SysinfoRetention    int   `json:"sysinfo:retention,omitempty"`    // number of historical points retained per connection; 0 uses the default (1024), overridable per-connection via conn:sysinforetention
+
+	GitClear             bool   `json:"git:*,omitempty"`
+	GitHubToken          string `json:"git:githubtoken,omitempty"`
+	GitLabToken          string `json:"git:gitlabtoken,omitempty"`
+	GitLabBaseURL        string `json:"git:gitlabbaseurl,omitempty"`     // for self-hosted GitLab instances, defaults to https://gitlab.com
+	GitPrStatusRefreshMs int64  `json:"git:prstatusrefreshms,omitempty"` // refresh interval for the PR/CI status block, 0 uses the default (30000)
+
 	AutoUpdateClear         bool    `json:"autoupdate:*,omitempty"`
 	AutoUpdateEnabled       bool    `json:"autoupdate:enabled,omitempty"`
 	AutoUpdateIntervalMs    float64 `json:"autoupdate:intervalms,omitempty"`
@@ -111,12 +219,16 @@ type SettingsType struct {
 	WindowDimensions                    string   `json:"window:dimensions,omitempty"`
 	WindowZoom                          *float64 `json:"window:zoom,omitempty"`
 
-	TelemetryClear   bool `json:"telemetry:*,omitempty"`
-	TelemetryEnabled bool `json:"telemetry:enabled,omitempty"`
+	TelemetryClear        bool `json:"telemetry:*,omitempty"`
+	TelemetryEnabled      bool `json:"telemetry:enabled,omitempty"`
+	TelemetryCrashReports bool `json:"telemetry:crashreports,omitempty"`
+	TelemetryUsageCounts  bool `json:"telemetry:usagecounts,omitempty"`
+	TelemetryAIMetadata   bool `json:"telemetry:aimetadata,omitempty"`
 
-	ConnClear               bool `json:"conn:*,omitempty"`
-	ConnAskBeforeWshInstall bool `json:"conn:askbeforewshinstall,omitempty"`
-	ConnWshEnabled          bool `json:"conn:wshenabled,omitempty"`
+	ConnClear               bool  `json:"conn:*,omitempty"`
+	ConnAskBeforeWshInstall bool  `json:"conn:askbeforewshinstall,omitempty"`
+	ConnWshEnabled          bool  `json:"conn:wshenabled,omitempty"`
+	ConnSudoCacheTtlMs      int64 `json:"conn:sudocachettlms,omitempty"` // how long a cached sudo password stays valid for a connection; 0 (the zero value) falls back to sudocache.DefaultTtlMs, same as sysinfo:sampleratems; see ResolveSudoCacheTtl, ConnKeywords.ConnSudoCacheTtlMs
 }
 
 type ConfigError struct {
@@ -125,14 +237,120 @@ type ConfigError struct {
 }
 
 type FullConfigType struct {
-	Settings       SettingsType                   `json:"settings" merge:"meta"`
-	MimeTypes      map[string]MimeTypeConfigType  `json:"mimetypes"`
-	DefaultWidgets map[string]WidgetConfigType    `json:"defaultwidgets"`
-	Widgets        map[string]WidgetConfigType    `json:"widgets"`
-	Presets        map[string]waveobj.MetaMapType `json:"presets"`
-	TermThemes     map[string]TermThemeType       `json:"termthemes"`
-	Connections    map[string]wshrpc.ConnKeywords `json:"connections"`
-	ConfigErrors   []ConfigError                  `json:"configerrors" configfile:"-"`
+	Settings       SettingsType                      `json:"settings" merge:"meta"`
+	MimeTypes      map[string]MimeTypeConfigType     `json:"mimetypes"`
+	DefaultWidgets map[string]WidgetConfigType       `json:"defaultwidgets"`
+	Widgets        map[string]WidgetConfigType       `json:"widgets"`
+	Presets        map[string]waveobj.MetaMapType    `json:"presets"`
+	TermThemes     map[string]TermThemeType          `json:"termthemes"`
+	Connections    map[string]wshrpc.ConnKeywords    `json:"connections"`
+	McpServers     map[string]McpServerConfigType    `json:"mcpservers"`
+	Plugins        map[string]PluginConfigType       `json:"plugins"`
+	Renderers      map[string]RendererConfigType     `json:"renderers"`
+	FileHandlers   map[string]FileHandlerConfigType  `json:"filehandlers"`
+	Triggers       map[string]TriggerConfigType      `json:"triggers"`
+	Keybindings    map[string]KeybindingConfigType   `json:"keybindings"`
+	LayoutPresets  map[string]LayoutPresetConfigType `json:"layoutpresets"`
+	DbConnections  map[string]DbConnectionConfigType `json:"dbconnections"`
+	CmdPolicies    map[string]CmdPolicyConfigType    `json:"cmdpolicies"`
+	RedactRules    map[string]RedactRuleConfigType   `json:"redactrules"`
+	HttpSecrets    map[string]string                 `json:"httpsecrets"`
+	ConfigErrors   []ConfigError                     `json:"configerrors" configfile:"-"`
+}
+
+const (
+	DefaultSysinfoSampleRateMs = 1000
+	DefaultSysinfoRetention    = 1024
+)
+
+// ResolveSysinfoConfig returns the effective sysinfo sample interval and history retention for
+// connection, preferring a per-connection override over the global default and falling back to
+// this package's defaults when nothing is configured. It is used both by the local sysinfo loop
+// (which reads this config directly) and by the code that launches a connserver's sysinfo loop
+// over ssh (which has no config access of its own, so resolves this once at connect time).
+func (c FullConfigType) ResolveSysinfoConfig(connection string) (sampleRateMs int64, retention int) {
+	sampleRateMs = c.Settings.SysinfoSampleRateMs
+	retention = c.Settings.SysinfoRetention
+	if connKeywords, ok := c.Connections[connection]; ok {
+		if connKeywords.ConnSysinfoSampleRateMs != nil {
+			sampleRateMs = *connKeywords.ConnSysinfoSampleRateMs
+		}
+		if connKeywords.ConnSysinfoRetention != nil {
+			retention = *connKeywords.ConnSysinfoRetention
+		}
+	}
+	if sampleRateMs <= 0 {
+		sampleRateMs = DefaultSysinfoSampleRateMs
+	}
+	if retention <= 0 {
+		retention = DefaultSysinfoRetention
+	}
+	return sampleRateMs, retention
+}
+
+// ResolveSudoCacheTtl returns the effective sudo-password cache TTL for connection, preferring a
+// per-connection override (ConnKeywords.ConnSudoCacheTtlMs) over the global conn:sudocachettlms
+// setting and falling back to sudocache.DefaultTtlMs when neither is configured.
+func (c FullConfigType) ResolveSudoCacheTtl(connection string) time.Duration {
+	ttlMs := c.Settings.ConnSudoCacheTtlMs
+	if connKeywords, ok := c.Connections[connection]; ok && connKeywords.ConnSudoCacheTtlMs != nil {
+		ttlMs = *connKeywords.ConnSudoCacheTtlMs
+	}
+	if ttlMs <= 0 {
+		ttlMs = sudocache.DefaultTtlMs
+	}
+	return time.Duration(ttlMs) * time.Millisecond
+}
+
+// ResolveConnectionsByTag returns every configured connection name whose ConnKeywords.ConnTags
+// contains tag, for resolving "tag:staging"-style selectors in multi-remote fan-out (see
+// wshrpc.CommandRunMultiCommandData).
+func (c FullConfigType) ResolveConnectionsByTag(tag string) []string {
+	var rtn []string
+	for connName, connKeywords := range c.Connections {
+		for _, connTag := range connKeywords.ConnTags {
+			if connTag == tag {
+				rtn = append(rtn, connName)
+				break
+			}
+		}
+	}
+	return rtn
+}
+
+// CmdPolicyRules converts the "cmdpolicies" config part into cmdpolicy.Rule values (using each
+// entry's map key as its Rule.Id), ready to pass to cmdpolicy.Evaluate.
+func (c FullConfigType) CmdPolicyRules() []cmdpolicy.Rule {
+	rules := make([]cmdpolicy.Rule, 0, len(c.CmdPolicies))
+	for id, policy := range c.CmdPolicies {
+		rules = append(rules, cmdpolicy.Rule{
+			Id:             id,
+			Pattern:        policy.Pattern,
+			Action:         cmdpolicy.Action(policy.Action),
+			Connection:     policy.Connection,
+			AfterHoursOnly: policy.AfterHoursOnly,
+			Priority:       policy.Priority,
+		})
+	}
+	return rules
+}
+
+// RedactionRules converts the "redactrules" config part into secretredact.Rule values (using each
+// entry's map key as its Id), appended to secretredact.DefaultRules unless Settings.RedactDisableBuiltins
+// is set. Entries with an invalid Pattern are skipped.
+func (c FullConfigType) RedactionRules() []secretredact.Rule {
+	var rules []secretredact.Rule
+	if !c.Settings.RedactDisableBuiltins {
+		rules = append(rules, secretredact.DefaultRules()...)
+	}
+	for id, rule := range c.RedactRules {
+		re, err := regexp.Compile(rule.Pattern)
+		if err != nil {
+			continue
+		}
+		rules = append(rules, secretredact.Rule{Id: id, Pattern: re})
+	}
+	return rules
 }
 
 func goBackWS(barr []byte, offset int) int {
@@ -334,9 +552,57 @@ func ReadFullConfig() FullConfigType {
 			utilfn.ReUnmarshal(fieldPtr, configPart)
 		}
 	}
+	applyWaveTomlConfig(&fullConfig)
 	return fullConfig
 }
 
+// applyWaveTomlConfig layers the optional wave.toml declarative config (see wavetoml.go) on top
+// of the JSON config parts just read -- wave.toml wins on conflicts, the same way the home
+// config directory already wins over the built-in defaults.
+func applyWaveTomlConfig(fullConfig *FullConfigType) {
+	tomlConfig, errs := ReadWaveTomlConfig()
+	fullConfig.ConfigErrors = append(fullConfig.ConfigErrors, errs...)
+	if len(tomlConfig.Settings) > 0 {
+		settingsMeta := settingsToMetaMapFallback(fullConfig.Settings)
+		for k, v := range tomlConfig.Settings {
+			settingsMeta[k] = v
+		}
+		utilfn.ReUnmarshal(&fullConfig.Settings, settingsMeta)
+	}
+	for name, conn := range tomlConfig.Connections {
+		if fullConfig.Connections == nil {
+			fullConfig.Connections = map[string]wshrpc.ConnKeywords{}
+		}
+		var connKeywords wshrpc.ConnKeywords
+		utilfn.ReUnmarshal(&connKeywords, conn)
+		fullConfig.Connections[name] = connKeywords
+	}
+	for name, widget := range tomlConfig.Widgets {
+		if fullConfig.Widgets == nil {
+			fullConfig.Widgets = map[string]WidgetConfigType{}
+		}
+		var widgetConfig WidgetConfigType
+		utilfn.ReUnmarshal(&widgetConfig, widget)
+		fullConfig.Widgets[name] = widgetConfig
+	}
+	for name, preset := range tomlConfig.Presets {
+		if fullConfig.Presets == nil {
+			fullConfig.Presets = map[string]waveobj.MetaMapType{}
+		}
+		fullConfig.Presets[name] = preset
+	}
+}
+
+func settingsToMetaMapFallback(settings SettingsType) waveobj.MetaMapType {
+	barr, _ := json.Marshal(settings)
+	var meta waveobj.MetaMapType
+	json.Unmarshal(barr, &meta)
+	if meta == nil {
+		meta = waveobj.MetaMapType{}
+	}
+	return meta
+}
+
 func GetConfigSubdirs() []string {
 	var fullConfig FullConfigType
 	configRType := reflect.TypeOf(fullConfig)
@@ -488,6 +754,11 @@ func SetBaseConfigValue(toMerge waveobj.MetaMapType) error {
 		if ctype == nil {
 			return fmt.Errorf("invalid config key: %s", configKey)
 		}
+		if configKey == ConfigKey_TermBracketedPasteMode {
+			if mode, ok := val.(string); ok && !IsValidBracketedPasteMode(mode) {
+				return fmt.Errorf("invalid value for %s: %q", configKey, mode)
+			}
+		}
 		if val == nil {
 			delete(m, configKey)
 		} else {
@@ -532,6 +803,166 @@ func SetConnectionsConfigValue(connName string, toMerge waveobj.MetaMapType) err
 	return WriteWaveHomeConfigFile(ConnectionsFile, m)
 }
 
+// SetPluginEnabled persists the enable/disable toggle for one plugin (keyed by its manifest
+// "name") to plugins.json, the same way SetConnectionsConfigValue persists per-connection config.
+func SetPluginEnabled(pluginName string, enabled bool) error {
+	m, cerrs := ReadWaveHomeConfigFile(PluginsFile)
+	if len(cerrs) > 0 {
+		return fmt.Errorf("error reading config file: %v", cerrs[0])
+	}
+	if m == nil {
+		m = make(waveobj.MetaMapType)
+	}
+	pluginData := m.GetMap(pluginName)
+	if pluginData == nil {
+		pluginData = make(waveobj.MetaMapType)
+	}
+	pluginData["enabled"] = enabled
+	m[pluginName] = pluginData
+	return WriteWaveHomeConfigFile(PluginsFile, m)
+}
+
+// SetRendererConfigValue persists (or updates) one custom preview renderer registration, keyed
+// by rendererId, the same way SetConnectionsConfigValue persists per-connection config.
+func SetRendererConfigValue(rendererId string, toMerge waveobj.MetaMapType) error {
+	m, cerrs := ReadWaveHomeConfigFile(RenderersFile)
+	if len(cerrs) > 0 {
+		return fmt.Errorf("error reading config file: %v", cerrs[0])
+	}
+	if m == nil {
+		m = make(waveobj.MetaMapType)
+	}
+	rendererData := m.GetMap(rendererId)
+	if rendererData == nil {
+		rendererData = make(waveobj.MetaMapType)
+	}
+	for configKey, val := range toMerge {
+		rendererData[configKey] = val
+	}
+	m[rendererId] = rendererData
+	return WriteWaveHomeConfigFile(RenderersFile, m)
+}
+
+// SetTermThemeConfigValue persists (or overwrites) one terminal color theme, keyed by themeId, to
+// termthemes.json, the same way SetConnectionsConfigValue persists per-connection config. Used to
+// save themes produced by ImportBase16Scheme/ImportITermColorScheme.
+func SetTermThemeConfigValue(themeId string, theme TermThemeType) error {
+	m, cerrs := ReadWaveHomeConfigFile(TermThemesFile)
+	if len(cerrs) > 0 {
+		return fmt.Errorf("error reading config file: %v", cerrs[0])
+	}
+	if m == nil {
+		m = make(waveobj.MetaMapType)
+	}
+	jsonBytes, err := json.Marshal(theme)
+	if err != nil {
+		return fmt.Errorf("error marshaling term theme: %w", err)
+	}
+	var themeData waveobj.MetaMapType
+	if err := json.Unmarshal(jsonBytes, &themeData); err != nil {
+		return fmt.Errorf("error unmarshaling term theme: %w", err)
+	}
+	m[themeId] = themeData
+	return WriteWaveHomeConfigFile(TermThemesFile, m)
+}
+
+// PluginConfigType holds the persisted enable/disable state for one discovered block-view
+// plugin (see pkg/wplugin), keyed by the plugin's manifest "name" in the "plugins" config part.
+type PluginConfigType struct {
+	Enabled bool `json:"enabled"`
+}
+
+// RendererConfigType describes one custom preview renderer, registered by a wsh-launched vdom
+// app (see vdom.VDomRendererRegistration), keyed by an app-chosen id in the "renderers" config
+// part. BlockDef is the block that gets created when the preview block selects this renderer.
+type RendererConfigType struct {
+	MimeTypes   []string         `json:"mimetypes,omitempty"`
+	Extensions  []string         `json:"extensions,omitempty"`
+	DisplayName string           `json:"displayname,omitempty"`
+	Priority    int              `json:"priority,omitempty"`
+	BlockDef    waveobj.BlockDef `json:"blockdef"`
+}
+
+// FileHandlerConfigType describes one user-defined "open with" handler for a path or URL detected
+// in terminal output (see termwrap's OSC 8 and heuristic file:line:col link support), keyed by a
+// user-chosen id in the "filehandlers" config part. Extensions and UrlSchemes determine which
+// links match; when more than one handler matches, the highest Priority wins. Action selects what
+// happens on click: "preview" opens the match in a Wave preview block (or, for a URL, a web
+// block), "native" opens it with the OS's default application, and "cmd" creates a new block from
+// BlockDef, substituting {path}, {line}, and {col} placeholders in its cmd:args.
+type FileHandlerConfigType struct {
+	Extensions []string         `json:"extensions,omitempty"`
+	UrlSchemes []string         `json:"urlschemes,omitempty"`
+	Priority   int              `json:"priority,omitempty"`
+	Action     string           `json:"action"`
+	BlockDef   waveobj.BlockDef `json:"blockdef,omitempty"`
+}
+
+// TriggerConfigType describes one regex-based watcher over terminal output, keyed by a
+// user-chosen id in the "triggers" config part. Pattern is matched against each completed line of
+// terminal output. BlockIds scopes the trigger to specific blocks; when empty, the trigger is
+// global and applies to every terminal block. Actions selects what happens on a match:
+// "highlight" decorates the matching line, "notify" shows an OS notification (NotifyTitle and
+// NotifyBody may reference "{match}" for the matched line), "status" sets the block's
+// frame:bordercolor/frame:icon meta, and "cmd" creates a new block from BlockDef, substituting a
+// "{match}" placeholder in its cmd:args with the matched line.
+type TriggerConfigType struct {
+	Pattern           string           `json:"pattern"`
+	BlockIds          []string         `json:"blockids,omitempty"`
+	Enabled           bool             `json:"enabled,omitempty"`
+	Actions           []string         `json:"actions"`
+	HighlightColor    string           `json:"highlightcolor,omitempty"`
+	NotifyTitle       string           `json:"notifytitle,omitempty"`
+	NotifyBody        string           `json:"notifybody,omitempty"`
+	StatusBorderColor string           `json:"statusbordercolor,omitempty"`
+	StatusIcon        string           `json:"statusicon,omitempty"`
+	BlockDef          waveobj.BlockDef `json:"blockdef,omitempty"`
+}
+
+// McpServerConfigType describes one user-configured MCP server (launched as a local stdio
+// subprocess) whose tools should be made available to the AI chat's function calling.
+type McpServerConfigType struct {
+	DisplayOrder float64  `json:"display:order,omitempty"`
+	Enabled      bool     `json:"enabled,omitempty"`
+	Command      string   `json:"command"`
+	Args         []string `json:"args,omitempty"`
+}
+
+// DbConnectionConfigType is a named connection for the database query block (see
+// pkg/wshrpc/wshserver's DbQuery* commands). ConnStr is stored here rather than in
+// settings.json so it isn't mixed in with the rest of the flat settings keys, but it's
+// still plain JSON on disk like every other config file in this directory -- this repo has
+// no secrets-vault abstraction.
+type DbConnectionConfigType struct {
+	Driver  string `json:"driver"` // "sqlite3" (only driver compiled into this build)
+	ConnStr string `json:"connstr"`
+}
+
+// CmdPolicyConfigType describes one regex allow/deny rule, keyed by a user-chosen id in the
+// "cmdpolicies" config part, enforced against "cmd"-type blocks (see
+// blockcontroller.createCmdStrAndOpts) and against RunShellCommandCommand's one-off MCP command
+// execution. Pattern is matched against the full command string. Connection scopes the rule to a
+// single named connection; empty applies it everywhere, including local (no connection). When
+// AfterHoursOnly is set, the rule only applies outside Mon-Fri 9am-5pm local time. When more
+// than one rule matches, the highest Priority wins (ties broken by id); see cmdpolicy.Evaluate
+// for the exact precedence rules, including that an explicit "allow" rule can override a
+// lower-priority "deny".
+type CmdPolicyConfigType struct {
+	Pattern        string `json:"pattern"`
+	Action         string `json:"action"` // cmdpolicy.ActionAllow or cmdpolicy.ActionDeny
+	Connection     string `json:"connection,omitempty"`
+	AfterHoursOnly bool   `json:"afterhoursonly,omitempty"`
+	Priority       int    `json:"priority,omitempty"`
+}
+
+// RedactRuleConfigType describes one additional regex the secret-redaction pipeline should
+// replace, keyed by a user-chosen id in the "redactrules" config part, supplementing (not
+// replacing, unless Settings.RedactDisableBuiltins is set) secretredact.DefaultRules. See
+// FullConfigType.RedactionRules and secretredact.Redact.
+type RedactRuleConfigType struct {
+	Pattern string `json:"pattern"`
+}
+
 type WidgetConfigType struct {
 	DisplayOrder float64          `json:"display:order,omitempty"`
 	Icon         string           `json:"icon,omitempty"`
@@ -0,0 +1,259 @@
+// Copyright 2026, Command Line Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package wconfig
+
+import (
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// TermThemeFormat_Base16 and TermThemeFormat_ITerm select which importer ImportTermTheme uses.
+const (
+	TermThemeFormat_Base16 = "base16"
+	TermThemeFormat_ITerm  = "iterm"
+)
+
+// ImportTermTheme converts a base16 or iTerm color scheme file's contents into a TermThemeType,
+// ready to hand to SetTermThemeConfigValue.
+func ImportTermTheme(format string, data []byte) (TermThemeType, error) {
+	switch format {
+	case TermThemeFormat_Base16:
+		return ImportBase16Scheme(data)
+	case TermThemeFormat_ITerm:
+		return ImportITermColorScheme(data)
+	default:
+		return TermThemeType{}, fmt.Errorf("unknown term theme import format %q", format)
+	}
+}
+
+// base16FieldRe matches the flat "key: value" lines a base16 scheme YAML file is made of (scheme
+// name, author, and the base00-base0F hex colors). Base16 scheme files don't use any YAML feature
+// beyond this, so a regex subset avoids pulling in a YAML parser this module doesn't otherwise need.
+var base16FieldRe = regexp.MustCompile(`(?im)^\s*(scheme|author|base0[0-9a-f]):\s*"?#?([^"\r\n]*?)"?\s*$`)
+
+// ImportBase16Scheme parses a base16 scheme YAML file (see
+// https://github.com/chriskempson/base16/blob/main/styling.md) and maps its base00-base0F colors
+// onto TermThemeType using the same ANSI assignment base16-shell uses.
+func ImportBase16Scheme(data []byte) (TermThemeType, error) {
+	matches := base16FieldRe.FindAllStringSubmatch(string(data), -1)
+	fields := make(map[string]string)
+	for _, m := range matches {
+		fields[strings.ToLower(m[1])] = m[2]
+	}
+	hex := func(key string) string {
+		v := fields[key]
+		if v == "" {
+			return ""
+		}
+		return "#" + strings.ToLower(v)
+	}
+	if hex("base00") == "" || hex("base05") == "" {
+		return TermThemeType{}, fmt.Errorf("missing base00/base05 colors, doesn't look like a base16 scheme file")
+	}
+	return TermThemeType{
+		DisplayName:         fields["scheme"],
+		Black:               hex("base00"),
+		Red:                 hex("base08"),
+		Green:               hex("base0b"),
+		Yellow:              hex("base0a"),
+		Blue:                hex("base0d"),
+		Magenta:             hex("base0e"),
+		Cyan:                hex("base0c"),
+		White:               hex("base05"),
+		BrightBlack:         hex("base03"),
+		BrightRed:           hex("base08"),
+		BrightGreen:         hex("base0b"),
+		BrightYellow:        hex("base0a"),
+		BrightBlue:          hex("base0d"),
+		BrightMagenta:       hex("base0e"),
+		BrightCyan:          hex("base0c"),
+		BrightWhite:         hex("base07"),
+		Gray:                hex("base03"),
+		CmdText:             hex("base05"),
+		Foreground:          hex("base05"),
+		SelectionBackground: hex("base02"),
+		Background:          hex("base00"),
+		Cursor:              hex("base05"),
+	}, nil
+}
+
+// plistValue is one value parsed out of an .itermcolors plist -- a real number, a string, or a
+// nested dict (used for the "Red/Green/Blue Component" color entries).
+type plistValue struct {
+	isDict bool
+	real   float64
+	dict   map[string]plistValue
+}
+
+// parsePlistDict reads a <dict>...</dict> body (the opening <dict> tag must already be consumed)
+// as a map from <key> name to the element that follows it, recursing into nested dicts. Only the
+// element types an .itermcolors file actually uses (key, dict, real, string, integer) are handled;
+// anything else is skipped, since this importer only needs the color entries.
+func parsePlistDict(dec *xml.Decoder) (map[string]plistValue, error) {
+	rtn := make(map[string]plistValue)
+	var pendingKey string
+	for {
+		tok, err := dec.Token()
+		if err != nil {
+			return nil, err
+		}
+		switch t := tok.(type) {
+		case xml.StartElement:
+			switch t.Name.Local {
+			case "key":
+				var key string
+				if err := dec.DecodeElement(&key, &t); err != nil {
+					return nil, err
+				}
+				pendingKey = key
+			case "real", "integer":
+				var s string
+				if err := dec.DecodeElement(&s, &t); err != nil {
+					return nil, err
+				}
+				f, _ := strconv.ParseFloat(s, 64)
+				rtn[pendingKey] = plistValue{real: f}
+			case "string":
+				var s string
+				if err := dec.DecodeElement(&s, &t); err != nil {
+					return nil, err
+				}
+			case "dict":
+				child, err := parsePlistDict(dec)
+				if err != nil {
+					return nil, err
+				}
+				rtn[pendingKey] = plistValue{isDict: true, dict: child}
+			default:
+				if err := dec.Skip(); err != nil {
+					return nil, err
+				}
+			}
+		case xml.EndElement:
+			if t.Name.Local == "dict" {
+				return rtn, nil
+			}
+		}
+	}
+}
+
+// plistColorToHex converts one "Red/Green/Blue Component" dict (0.0-1.0 floats) to a "#rrggbb" hex
+// string, as used by every color entry in an .itermcolors file.
+func plistColorToHex(v plistValue) (string, bool) {
+	if !v.isDict {
+		return "", false
+	}
+	r, rOk := v.dict["Red Component"]
+	g, gOk := v.dict["Green Component"]
+	b, bOk := v.dict["Blue Component"]
+	if !rOk || !gOk || !bOk {
+		return "", false
+	}
+	toByte := func(f float64) int {
+		n := int(f*255 + 0.5)
+		if n < 0 {
+			n = 0
+		} else if n > 255 {
+			n = 255
+		}
+		return n
+	}
+	return fmt.Sprintf("#%02x%02x%02x", toByte(r.real), toByte(g.real), toByte(b.real)), true
+}
+
+var base16AnsiOrder = []string{"black", "red", "green", "yellow", "blue", "magenta", "cyan", "white"}
+
+func assignAnsiColor(theme *TermThemeType, idx int, hex string) {
+	switch idx {
+	case 0:
+		theme.Black = hex
+	case 1:
+		theme.Red = hex
+	case 2:
+		theme.Green = hex
+	case 3:
+		theme.Yellow = hex
+	case 4:
+		theme.Blue = hex
+	case 5:
+		theme.Magenta = hex
+	case 6:
+		theme.Cyan = hex
+	case 7:
+		theme.White = hex
+	case 8:
+		theme.BrightBlack = hex
+	case 9:
+		theme.BrightRed = hex
+	case 10:
+		theme.BrightGreen = hex
+	case 11:
+		theme.BrightYellow = hex
+	case 12:
+		theme.BrightBlue = hex
+	case 13:
+		theme.BrightMagenta = hex
+	case 14:
+		theme.BrightCyan = hex
+	case 15:
+		theme.BrightWhite = hex
+	}
+}
+
+// ImportITermColorScheme parses an .itermcolors file (a plist XML document with "Ansi N Color",
+// "Background Color", "Foreground Color", "Cursor Color", and "Selection Color" dict entries) into
+// a TermThemeType. Only those color keys are read; other iTerm profile settings in the same file
+// (font, transparency, etc.) aren't part of TermThemeType and are ignored.
+func ImportITermColorScheme(data []byte) (TermThemeType, error) {
+	dec := xml.NewDecoder(bytes.NewReader(data))
+	var root map[string]plistValue
+	for {
+		tok, err := dec.Token()
+		if err != nil {
+			return TermThemeType{}, fmt.Errorf("error parsing itermcolors file: %w", err)
+		}
+		se, ok := tok.(xml.StartElement)
+		if !ok || se.Name.Local != "dict" {
+			continue
+		}
+		root, err = parsePlistDict(dec)
+		if err != nil {
+			return TermThemeType{}, fmt.Errorf("error parsing itermcolors file: %w", err)
+		}
+		break
+	}
+	if root == nil {
+		return TermThemeType{}, fmt.Errorf("no top-level dict found in itermcolors file")
+	}
+	getHex := func(key string) string {
+		v, ok := root[key]
+		if !ok {
+			return ""
+		}
+		hex, ok := plistColorToHex(v)
+		if !ok {
+			return ""
+		}
+		return hex
+	}
+	if getHex("Background Color") == "" || getHex("Foreground Color") == "" {
+		return TermThemeType{}, fmt.Errorf("missing Background/Foreground Color entries, doesn't look like an itermcolors file")
+	}
+	var theme TermThemeType
+	for i := range base16AnsiOrder {
+		assignAnsiColor(&theme, i, getHex(fmt.Sprintf("Ansi %d Color", i)))
+		assignAnsiColor(&theme, i+8, getHex(fmt.Sprintf("Ansi %d Color", i+8)))
+	}
+	theme.Foreground = getHex("Foreground Color")
+	theme.Background = getHex("Background Color")
+	theme.Cursor = getHex("Cursor Color")
+	theme.SelectionBackground = getHex("Selection Color")
+	theme.Gray = theme.BrightBlack
+	theme.CmdText = theme.Foreground
+	return theme, nil
+}
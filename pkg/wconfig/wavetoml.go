@@ -0,0 +1,206 @@
+// Copyright 2024, Command Line Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package wconfig
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/wavetermdev/waveterm/pkg/wavebase"
+	"github.com/wavetermdev/waveterm/pkg/waveobj"
+)
+
+// WaveTomlFile is an optional single-file, declarative alternative to the JSON config parts
+// (settings.json, connections.json, widgets.json, presets/ai.json): a user can define
+// connections, widgets, and AI providers in one wave.toml under the dotfiles they already sync,
+// rather than juggling several JSON files. It's watched the same way as the JSON config (see
+// filewatcher.go) so edits hot-apply without a restart.
+//
+// Only the TOML subset actually needed here is supported: "[table]" and "[table.name]" headers,
+// "key = value" pairs (bare or quoted keys), and string/bool/integer/float/string-array values.
+// Inline tables, arrays of tables, multiline strings, and dotted keys outside of headers are not
+// supported -- there's no vendored TOML library available to pull in here, so this intentionally
+// covers only the shape of file this config needs rather than the full TOML spec.
+//
+// Keybindings are not part of this file: the "keybindings" config part (see
+// keybindingsconfig.go) is a map of arbitrary user-chosen ids to binding structs, same shape
+// as widgets/triggers/filehandlers, which this minimal TOML subset has no way to express as a
+// "[keybindings.<id>]" array-of-tables section (see the support note above).
+const WaveTomlFile = "wave.toml"
+
+// WaveTomlConfig is wave.toml parsed into the same shapes used by the JSON config parts, ready
+// to be layered on top of them by ReadFullConfig.
+type WaveTomlConfig struct {
+	Settings    waveobj.MetaMapType
+	Connections map[string]waveobj.MetaMapType
+	Widgets     map[string]waveobj.MetaMapType
+	Presets     map[string]waveobj.MetaMapType
+}
+
+// namedTables are the [table.name] sections -- each name becomes one entry in the resulting map.
+var namedTomlTables = map[string]bool{
+	"connections": true,
+	"widgets":     true,
+	"ai":          true,
+}
+
+func ReadWaveTomlConfig() (WaveTomlConfig, []ConfigError) {
+	configDirAbsPath := wavebase.GetWaveConfigDir()
+	fullFileName := filepath.Join(configDirAbsPath, WaveTomlFile)
+	barr, err := os.ReadFile(fullFileName)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return WaveTomlConfig{}, nil
+		}
+		return WaveTomlConfig{}, []ConfigError{{File: WaveTomlFile, Err: err.Error()}}
+	}
+	tables, err := parseTomlSubset(barr)
+	if err != nil {
+		return WaveTomlConfig{}, []ConfigError{{File: WaveTomlFile, Err: err.Error()}}
+	}
+	rtn := WaveTomlConfig{
+		Settings:    waveobj.MetaMapType{},
+		Connections: map[string]waveobj.MetaMapType{},
+		Widgets:     map[string]waveobj.MetaMapType{},
+		Presets:     map[string]waveobj.MetaMapType{},
+	}
+	for header, leaf := range tables {
+		dotIdx := strings.Index(header, ".")
+		if dotIdx == -1 {
+			if header == "settings" {
+				for k, v := range leaf {
+					rtn.Settings[k] = v
+				}
+			}
+			continue
+		}
+		tableName := header[:dotIdx]
+		entryName := header[dotIdx+1:]
+		if !namedTomlTables[tableName] || entryName == "" {
+			continue
+		}
+		switch tableName {
+		case "connections":
+			rtn.Connections[entryName] = waveobj.MetaMapType(leaf)
+		case "widgets":
+			rtn.Widgets[entryName] = waveobj.MetaMapType(leaf)
+		case "ai":
+			rtn.Presets["ai@"+entryName] = waveobj.MetaMapType(leaf)
+		}
+	}
+	return rtn, nil
+}
+
+// parseTomlSubset parses the restricted TOML dialect documented on WaveTomlFile into a flat map
+// of "header" (e.g. "settings" or "connections.myhost") to that table's key/value pairs.
+func parseTomlSubset(data []byte) (map[string]map[string]any, error) {
+	tables := map[string]map[string]any{}
+	curHeader := ""
+	tables[curHeader] = map[string]any{}
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	lineNum := 0
+	for scanner.Scan() {
+		lineNum++
+		line := stripTomlComment(scanner.Text())
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+		if strings.HasPrefix(line, "[") {
+			if !strings.HasSuffix(line, "]") {
+				return nil, fmt.Errorf("wave.toml line %d: malformed table header", lineNum)
+			}
+			header := strings.TrimSpace(line[1 : len(line)-1])
+			header = unquoteTomlHeaderSegments(header)
+			if _, ok := tables[header]; !ok {
+				tables[header] = map[string]any{}
+			}
+			curHeader = header
+			continue
+		}
+		eqIdx := strings.Index(line, "=")
+		if eqIdx == -1 {
+			return nil, fmt.Errorf("wave.toml line %d: expected key = value", lineNum)
+		}
+		key := strings.TrimSpace(line[:eqIdx])
+		key = unquoteTomlString(key)
+		valStr := strings.TrimSpace(line[eqIdx+1:])
+		val, err := parseTomlValue(valStr)
+		if err != nil {
+			return nil, fmt.Errorf("wave.toml line %d: %w", lineNum, err)
+		}
+		tables[curHeader][key] = val
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	delete(tables, "")
+	return tables, nil
+}
+
+func stripTomlComment(line string) string {
+	inQuote := false
+	for i, ch := range line {
+		if ch == '"' {
+			inQuote = !inQuote
+		}
+		if ch == '#' && !inQuote {
+			return line[:i]
+		}
+	}
+	return line
+}
+
+func unquoteTomlHeaderSegments(header string) string {
+	parts := strings.Split(header, ".")
+	for i, part := range parts {
+		parts[i] = unquoteTomlString(strings.TrimSpace(part))
+	}
+	return strings.Join(parts, ".")
+}
+
+func unquoteTomlString(s string) string {
+	if len(s) >= 2 && s[0] == '"' && s[len(s)-1] == '"' {
+		return s[1 : len(s)-1]
+	}
+	return s
+}
+
+func parseTomlValue(valStr string) (any, error) {
+	if valStr == "true" {
+		return true, nil
+	}
+	if valStr == "false" {
+		return false, nil
+	}
+	if strings.HasPrefix(valStr, "\"") {
+		return unquoteTomlString(valStr), nil
+	}
+	if strings.HasPrefix(valStr, "[") {
+		if !strings.HasSuffix(valStr, "]") {
+			return nil, fmt.Errorf("malformed array value")
+		}
+		inner := strings.TrimSpace(valStr[1 : len(valStr)-1])
+		if inner == "" {
+			return []string{}, nil
+		}
+		var rtn []string
+		for _, item := range strings.Split(inner, ",") {
+			rtn = append(rtn, unquoteTomlString(strings.TrimSpace(item)))
+		}
+		return rtn, nil
+	}
+	if intVal, err := strconv.ParseInt(valStr, 10, 64); err == nil {
+		return intVal, nil
+	}
+	if floatVal, err := strconv.ParseFloat(valStr, 64); err == nil {
+		return floatVal, nil
+	}
+	return nil, fmt.Errorf("unsupported value: %s", valStr)
+}
@@ -0,0 +1,34 @@
+// Copyright 2026, Command Line Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package wcore
+
+import (
+	"fmt"
+	"image"
+	"os"
+	"path/filepath"
+
+	_ "github.com/wavetermdev/waveterm/pkg/imageops" // registers png/jpeg/gif decoders for image.DecodeConfig
+	"github.com/wavetermdev/waveterm/pkg/wavebase"
+)
+
+// ValidateBackgroundImagePath expands ~ in path and confirms it names a local, readable file whose
+// content decodes as one of the image formats imageops supports (png, jpeg, gif), returning the
+// expanded path for storage on waveobj.MetaKey_BgImage. The frontend serves the file itself via
+// the existing /wave/stream-file handler, so this only needs to validate it up front.
+func ValidateBackgroundImagePath(path string) (string, error) {
+	expanded, err := wavebase.ExpandHomeDir(path)
+	if err != nil {
+		return "", fmt.Errorf("invalid background image path: %w", err)
+	}
+	f, err := os.Open(expanded)
+	if err != nil {
+		return "", fmt.Errorf("cannot open background image: %w", err)
+	}
+	defer f.Close()
+	if _, _, err := image.DecodeConfig(f); err != nil {
+		return "", fmt.Errorf("%q is not a supported image (png/jpeg/gif): %w", filepath.Base(expanded), err)
+	}
+	return expanded, nil
+}
@@ -7,9 +7,11 @@ import (
 	"context"
 	"fmt"
 	"log"
+	"sort"
 	"time"
 
 	"github.com/wavetermdev/waveterm/pkg/waveobj"
+	"github.com/wavetermdev/waveterm/pkg/wconfig"
 	"github.com/wavetermdev/waveterm/pkg/wstore"
 )
 
@@ -87,6 +89,31 @@ func GetNewTabLayout() PortableLayout {
 	}
 }
 
+// newTabLayoutWithWorkspaceDefaults returns GetNewTabLayout with ws.DefaultConnection/DefaultEnv
+// (see waveobj.Workspace) merged into each block's meta, so a new tab in a workspace set up for a
+// particular project lands on the right connection/env without per-tab setup.
+func newTabLayoutWithWorkspaceDefaults(ws *waveobj.Workspace) PortableLayout {
+	layout := GetNewTabLayout()
+	if ws.DefaultConnection == "" && len(ws.DefaultEnv) == 0 {
+		return layout
+	}
+	for i := range layout {
+		if layout[i].BlockDef == nil {
+			continue
+		}
+		if layout[i].BlockDef.Meta == nil {
+			layout[i].BlockDef.Meta = waveobj.MetaMapType{}
+		}
+		if ws.DefaultConnection != "" {
+			layout[i].BlockDef.Meta[waveobj.MetaKey_Connection] = ws.DefaultConnection
+		}
+		if len(ws.DefaultEnv) > 0 {
+			layout[i].BlockDef.Meta[waveobj.MetaKey_CmdEnv] = ws.DefaultEnv
+		}
+	}
+	return layout
+}
+
 func GetLayoutIdForTab(ctx context.Context, tabId string) (string, error) {
 	tabObj, err := wstore.DBGet[*waveobj.Tab](ctx, tabId)
 	if err != nil {
@@ -188,3 +215,77 @@ func BootstrapStarterLayout(ctx context.Context) error {
 
 	return nil
 }
+
+func layoutPresetToPortable(preset wconfig.LayoutPresetConfigType) PortableLayout {
+	layout := make(PortableLayout, len(preset.Layout))
+	for i, entry := range preset.Layout {
+		layout[i].IndexArr = entry.IndexArr
+		layout[i].Size = entry.Size
+		layout[i].BlockDef = entry.BlockDef
+		layout[i].Focused = entry.Focused
+	}
+	return layout
+}
+
+// ApplyLayoutPreset replaces tabId's block layout with the named preset from the "layoutpresets"
+// config (see wconfig.LayoutPresetConfigType) and records presetId on the tab's meta (see
+// waveobj.MetaKey_LayoutPreset) so CycleLayoutPreset knows where to resume from.
+func ApplyLayoutPreset(ctx context.Context, tabId string, presetId string) error {
+	fullConfig := wconfig.GetWatcher().GetFullConfig()
+	preset, ok := fullConfig.LayoutPresets[presetId]
+	if !ok {
+		return fmt.Errorf("no layout preset found with id %q", presetId)
+	}
+	err := ApplyPortableLayout(ctx, tabId, layoutPresetToPortable(preset))
+	if err != nil {
+		return fmt.Errorf("unable to apply layout preset %q: %w", presetId, err)
+	}
+	err = wstore.UpdateObjectMeta(ctx, waveobj.MakeORef(waveobj.OType_Tab, tabId), waveobj.MetaMapType{waveobj.MetaKey_LayoutPreset: presetId}, false)
+	if err != nil {
+		return fmt.Errorf("unable to record applied layout preset on tab %s: %w", tabId, err)
+	}
+	return nil
+}
+
+// sortedLayoutPresetIds returns the configured layout preset ids ordered by DisplayOrder (ties
+// broken by id), the order CycleLayoutPreset steps through.
+func sortedLayoutPresetIds(presets map[string]wconfig.LayoutPresetConfigType) []string {
+	ids := make([]string, 0, len(presets))
+	for id := range presets {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool {
+		oi, oj := presets[ids[i]].DisplayOrder, presets[ids[j]].DisplayOrder
+		if oi != oj {
+			return oi < oj
+		}
+		return ids[i] < ids[j]
+	})
+	return ids
+}
+
+// CycleLayoutPreset applies the layout preset following the one last applied to tabId (see
+// waveobj.MetaKey_LayoutPreset on the tab's meta), wrapping around to the first preset, so a single
+// keybinding action (see wconfig.KeybindingActions' "tab:cyclelayoutpreset") can step through all
+// configured presets. Returns the id of the preset it applied.
+func CycleLayoutPreset(ctx context.Context, tabId string) (string, error) {
+	fullConfig := wconfig.GetWatcher().GetFullConfig()
+	ids := sortedLayoutPresetIds(fullConfig.LayoutPresets)
+	if len(ids) == 0 {
+		return "", fmt.Errorf("no layout presets configured")
+	}
+	tabObj, err := wstore.DBMustGet[*waveobj.Tab](ctx, tabId)
+	if err != nil {
+		return "", fmt.Errorf("unable to get tab %s: %w", tabId, err)
+	}
+	curPresetId := tabObj.Meta.GetString(waveobj.MetaKey_LayoutPreset, "")
+	nextIdx := 0
+	for i, id := range ids {
+		if id == curPresetId {
+			nextIdx = (i + 1) % len(ids)
+			break
+		}
+	}
+	nextId := ids[nextIdx]
+	return nextId, ApplyLayoutPreset(ctx, tabId, nextId)
+}
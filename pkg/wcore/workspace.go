@@ -223,7 +223,11 @@ func CreateTab(ctx context.Context, workspaceId string, tabName string, activate
 
 	// No need to apply an initial layout for the initial launch, since the starter layout will get applied after TOS modal dismissal
 	if !isInitialLaunch {
-		err = ApplyPortableLayout(ctx, tab.OID, GetNewTabLayout())
+		ws, err := GetWorkspace(ctx, workspaceId)
+		if err != nil {
+			return tab.OID, fmt.Errorf("workspace %s not found: %w", workspaceId, err)
+		}
+		err = ApplyPortableLayout(ctx, tab.OID, newTabLayoutWithWorkspaceDefaults(ws))
 		if err != nil {
 			return tab.OID, fmt.Errorf("error applying new tab layout: %w", err)
 		}
@@ -377,6 +381,59 @@ func SendActiveTabUpdate(ctx context.Context, workspaceId string, newActiveTabId
 	})
 }
 
+// MoveTabToWorkspace moves tabId out of srcWorkspaceId and appends it to destWorkspaceId, keeping
+// its pinned/unpinned state, so tabs can be reorganized across workspaces without closing and
+// recreating them (see DeleteTab, which instead closes the tab's blocks -- this only reparents it).
+// If activate is true, the tab also becomes destWorkspaceId's active tab.
+func MoveTabToWorkspace(ctx context.Context, srcWorkspaceId string, tabId string, destWorkspaceId string, activate bool) error {
+	if srcWorkspaceId == destWorkspaceId {
+		return nil
+	}
+	srcWs, _ := wstore.DBGet[*waveobj.Workspace](ctx, srcWorkspaceId)
+	if srcWs == nil {
+		return fmt.Errorf("workspace not found: %q", srcWorkspaceId)
+	}
+	destWs, _ := wstore.DBGet[*waveobj.Workspace](ctx, destWorkspaceId)
+	if destWs == nil {
+		return fmt.Errorf("workspace not found: %q", destWorkspaceId)
+	}
+
+	pinned := false
+	tabIdx := utilfn.FindStringInSlice(srcWs.TabIds, tabId)
+	tabIdxPinned := utilfn.FindStringInSlice(srcWs.PinnedTabIds, tabId)
+	if tabIdx != -1 {
+		srcWs.TabIds = append(srcWs.TabIds[:tabIdx], srcWs.TabIds[tabIdx+1:]...)
+	} else if tabIdxPinned != -1 {
+		pinned = true
+		srcWs.PinnedTabIds = append(srcWs.PinnedTabIds[:tabIdxPinned], srcWs.PinnedTabIds[tabIdxPinned+1:]...)
+	} else {
+		return fmt.Errorf("tab %s not found in workspace %s", tabId, srcWorkspaceId)
+	}
+
+	if srcWs.ActiveTabId == tabId {
+		if len(srcWs.TabIds) > 0 && tabIdx != -1 {
+			srcWs.ActiveTabId = srcWs.TabIds[max(0, min(tabIdx-1, len(srcWs.TabIds)-1))]
+		} else if len(srcWs.PinnedTabIds) > 0 {
+			srcWs.ActiveTabId = srcWs.PinnedTabIds[0]
+		} else {
+			srcWs.ActiveTabId = ""
+		}
+	}
+
+	if pinned {
+		destWs.PinnedTabIds = append(destWs.PinnedTabIds, tabId)
+	} else {
+		destWs.TabIds = append(destWs.TabIds, tabId)
+	}
+	if activate || destWs.ActiveTabId == "" {
+		destWs.ActiveTabId = tabId
+	}
+
+	wstore.DBUpdate(ctx, srcWs)
+	wstore.DBUpdate(ctx, destWs)
+	return nil
+}
+
 func UpdateWorkspaceTabIds(ctx context.Context, workspaceId string, tabIds []string, pinnedTabIds []string) error {
 	ws, _ := wstore.DBGet[*waveobj.Workspace](ctx, workspaceId)
 	if ws == nil {
@@ -467,3 +524,22 @@ func SetName(workspaceId string, name string) error {
 	wstore.DBUpdate(ctx, ws)
 	return nil
 }
+
+// SetDefaults sets the connection and env vars new tabs created in this workspace default to (see
+// newTabLayoutWithWorkspaceDefaults); a blank defaultConnection or nil defaultEnv clears that
+// default without touching the other.
+func SetDefaults(workspaceId string, defaultConnection string, defaultEnv map[string]string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+	ws, e := wstore.DBGet[*waveobj.Workspace](ctx, workspaceId)
+	if e != nil {
+		return e
+	}
+	if ws == nil {
+		return fmt.Errorf("workspace not found: %q", workspaceId)
+	}
+	ws.DefaultConnection = defaultConnection
+	ws.DefaultEnv = defaultEnv
+	wstore.DBUpdate(ctx, ws)
+	return nil
+}
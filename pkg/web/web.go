@@ -5,17 +5,21 @@ package web
 
 import (
 	"bytes"
+	"context"
 	"encoding/base64"
 	"encoding/json"
 	"fmt"
 	"io"
 	"io/fs"
 	"log"
+	"mime"
 	"net"
 	"net/http"
+	"net/http/pprof"
 	"os"
 	"path/filepath"
 	"strconv"
+	"sync"
 	"time"
 
 	"github.com/google/uuid"
@@ -26,7 +30,9 @@ import (
 	"github.com/wavetermdev/waveterm/pkg/filestore"
 	"github.com/wavetermdev/waveterm/pkg/panichandler"
 	"github.com/wavetermdev/waveterm/pkg/service"
+	"github.com/wavetermdev/waveterm/pkg/util/utilfn"
 	"github.com/wavetermdev/waveterm/pkg/wavebase"
+	"github.com/wavetermdev/waveterm/pkg/wconfig"
 	"github.com/wavetermdev/waveterm/pkg/wshrpc"
 	"github.com/wavetermdev/waveterm/pkg/wshrpc/wshclient"
 	"github.com/wavetermdev/waveterm/pkg/wshrpc/wshserver"
@@ -45,13 +51,21 @@ const (
 	ContentTypeHeaderKey = "Content-Type"
 	ContentTypeJson      = "application/json"
 	ContentTypeBinary    = "application/octet-stream"
+	ContentTypeText      = "text/plain; charset=utf-8"
 
 	ContentLengthHeaderKey = "Content-Length"
 	LastModifiedHeaderKey  = "Last-Modified"
 
+	ETagHeaderKey        = "ETag"
+	IfNoneMatchHeaderKey = "If-None-Match"
+
 	WaveZoneFileInfoHeaderKey = "X-ZoneFileInfo"
 )
 
+// sniffLen is the number of leading bytes read to sniff a wave file's content type
+// when its name has no recognized extension (mirrors http.DetectContentType's own limit).
+const sniffLen = 512
+
 const HttpReadTimeout = 5 * time.Second
 const HttpWriteTimeout = 21 * time.Second
 const HttpMaxHeaderBytes = 60000
@@ -147,6 +161,50 @@ func marshalReturnValue(data any, err error) []byte {
 	return rtn
 }
 
+// wavePartReader adapts filestore's part-oriented ReadAt into an io.Reader so a wave file's
+// blob can be streamed with io.Copy instead of being buffered part-by-part into memory.
+type wavePartReader struct {
+	ctx    context.Context
+	zoneId string
+	name   string
+	offset int64
+	endIdx int64
+	buf    []byte
+}
+
+func (pr *wavePartReader) Read(p []byte) (int, error) {
+	for len(pr.buf) == 0 {
+		if pr.offset >= pr.endIdx {
+			return 0, io.EOF
+		}
+		_, data, err := filestore.WFS.ReadAt(pr.ctx, pr.zoneId, pr.name, pr.offset, filestore.DefaultPartDataSize)
+		if err != nil {
+			return 0, err
+		}
+		pr.offset += int64(len(data))
+		pr.buf = data
+	}
+	n := copy(p, pr.buf)
+	pr.buf = pr.buf[n:]
+	return n, nil
+}
+
+// detectWaveFileMimeType determines the content type of a wave file blob, preferring the
+// name's extension and falling back to sniffing the leading bytes of the data.
+func detectWaveFileMimeType(name string, leadingData []byte) string {
+	ext := filepath.Ext(name)
+	if mimeType, ok := utilfn.StaticMimeTypeMap[ext]; ok {
+		return mimeType
+	}
+	if mimeType := mime.TypeByExtension(ext); mimeType != "" {
+		return mimeType
+	}
+	if len(leadingData) == 0 {
+		return ContentTypeBinary
+	}
+	return http.DetectContentType(leadingData)
+}
+
 func handleWaveFile(w http.ResponseWriter, r *http.Request) {
 	zoneId := r.URL.Query().Get("zoneid")
 	name := r.URL.Query().Get("name")
@@ -181,31 +239,43 @@ func handleWaveFile(w http.ResponseWriter, r *http.Request) {
 	if err != nil {
 		http.Error(w, fmt.Sprintf("error serializing file info: %v", err), http.StatusInternalServerError)
 	}
-	// can make more efficient by checking modtime + If-Modified-Since headers to allow caching
+	etag := fmt.Sprintf("%q", fmt.Sprintf("%x-%x", file.ModTs, file.Size))
 	dataStartIdx := file.DataStartIdx()
 	if offset >= dataStartIdx {
 		dataStartIdx = offset
 	}
-	w.Header().Set(ContentTypeHeaderKey, ContentTypeBinary)
 	w.Header().Set(ContentLengthHeaderKey, fmt.Sprintf("%d", file.Size-dataStartIdx))
 	w.Header().Set(WaveZoneFileInfoHeaderKey, base64.StdEncoding.EncodeToString(jsonFileBArr))
 	w.Header().Set(LastModifiedHeaderKey, time.UnixMilli(file.ModTs).UTC().Format(http.TimeFormat))
+	w.Header().Set(ETagHeaderKey, etag)
+	if r.Header.Get(IfNoneMatchHeaderKey) == etag {
+		w.WriteHeader(http.StatusNotModified)
+		return
+	}
 	if dataStartIdx >= file.Size {
+		w.Header().Set(ContentTypeHeaderKey, ContentTypeBinary)
 		w.WriteHeader(http.StatusOK)
 		return
 	}
-	for offset := dataStartIdx; offset < file.Size; offset += filestore.DefaultPartDataSize {
-		_, data, err := filestore.WFS.ReadAt(r.Context(), zoneId, name, offset, filestore.DefaultPartDataSize)
-		if err != nil {
-			if offset == 0 {
-				http.Error(w, fmt.Sprintf("error reading file: %v", err), http.StatusInternalServerError)
-			} else {
-				// nothing to do, the headers have already been sent
-				log.Printf("error reading file %s/%s @ %d: %v\n", zoneId, name, offset, err)
-			}
-			return
+	var sniffSize int64 = filestore.DefaultPartDataSize
+	if sniffSize > sniffLen {
+		sniffSize = sniffLen
+	}
+	_, leadingData, err := filestore.WFS.ReadAt(r.Context(), zoneId, name, dataStartIdx, sniffSize)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("error reading file: %v", err), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set(ContentTypeHeaderKey, detectWaveFileMimeType(name, leadingData))
+	partReader := &wavePartReader{ctx: r.Context(), zoneId: zoneId, name: name, offset: dataStartIdx, endIdx: file.Size}
+	written, err := io.Copy(w, partReader)
+	if err != nil {
+		if written == 0 {
+			http.Error(w, fmt.Sprintf("error reading file: %v", err), http.StatusInternalServerError)
+		} else {
+			// nothing to do, the headers have already been sent
+			log.Printf("error reading file %s/%s @ %d: %v\n", zoneId, name, dataStartIdx+written, err)
 		}
-		w.Write(data)
 	}
 }
 
@@ -355,6 +425,10 @@ type ClientActiveState struct {
 	Open   bool `json:"open"`
 }
 
+// WebFnWrap requires the caller to present either a valid X-AuthKey header (the normal
+// Electron/wsh credential, see pkg/authkey) or, failing that, a valid OIDC session cookie (see
+// hasValidOidcSession) -- the latter is what lets a plain browser loaded from FrontendDir in
+// headless mode call these endpoints at all, since it has no way to ever learn the authkey.
 func WebFnWrap(opts WebFnOpts, fn WebFnType) WebFnType {
 	return func(w http.ResponseWriter, r *http.Request) {
 		defer func() {
@@ -377,7 +451,7 @@ func WebFnWrap(opts WebFnOpts, fn WebFnType) WebFnType {
 		}
 		w.Header().Set("Access-Control-Expose-Headers", "X-ZoneFileInfo")
 		err := authkey.ValidateIncomingRequest(r)
-		if err != nil {
+		if err != nil && !hasValidOidcSession(r) {
 			w.WriteHeader(http.StatusUnauthorized)
 			w.Write([]byte(fmt.Sprintf("error validating authkey: %v", err)))
 			return
@@ -396,6 +470,19 @@ func MakeTCPListener(serviceName string) (net.Listener, error) {
 	return rtn, nil
 }
 
+// MakeServerListener binds to a fixed, configurable address instead of an
+// ephemeral loopback port, for headless server mode where the address needs
+// to be known ahead of time (e.g. to open it in a browser or put it behind
+// a reverse proxy), and may need to be reachable from outside localhost.
+func MakeServerListener(listenAddr string) (net.Listener, error) {
+	rtn, err := net.Listen("tcp", listenAddr)
+	if err != nil {
+		return nil, fmt.Errorf("error creating listener at %v: %v", listenAddr, err)
+	}
+	log.Printf("Server [headless] listening on %s\n", rtn.Addr())
+	return rtn, nil
+}
+
 func MakeUnixListener() (net.Listener, error) {
 	serverAddr := wavebase.GetDomainSocketName()
 	os.Remove(serverAddr) // ignore error
@@ -410,14 +497,60 @@ func MakeUnixListener() (net.Listener, error) {
 
 const docsitePrefix = "/docsite/"
 
+// isPprofEnabled reports whether the opt-in "server:pprofenabled" setting is set. pprof exposes
+// stack traces, heap contents, and other process internals, so it's off by default even though
+// the routes are only bound to loopback/unix-socket listeners and still go through the authkey
+// check like every other /wave endpoint.
+func isPprofEnabled() bool {
+	settings := wconfig.GetWatcher().GetFullConfig()
+	return settings.Settings.ServerPprofEnabled
+}
+
+// registerPprofRoutes wires up the standard net/http/pprof handlers under /debug/pprof/, gated
+// behind the same authkey check as every other local endpoint (see WebFnWrap).
+func registerPprofRoutes(gr *mux.Router) {
+	gr.HandleFunc("/debug/pprof/", WebFnWrap(WebFnOpts{}, pprof.Index))
+	gr.HandleFunc("/debug/pprof/cmdline", WebFnWrap(WebFnOpts{}, pprof.Cmdline))
+	gr.HandleFunc("/debug/pprof/profile", WebFnWrap(WebFnOpts{}, pprof.Profile))
+	gr.HandleFunc("/debug/pprof/symbol", WebFnWrap(WebFnOpts{}, pprof.Symbol))
+	gr.HandleFunc("/debug/pprof/trace", WebFnWrap(WebFnOpts{}, pprof.Trace))
+	gr.PathPrefix("/debug/pprof/").HandlerFunc(WebFnWrap(WebFnOpts{}, pprof.Index))
+}
+
+// ServerOpts configures the parts of RunWebServer that only apply to
+// headless server mode (see server:* settings). The zero value reproduces
+// the normal Electron-backed behavior: loopback-only, plain HTTP, and no
+// frontend route (Electron loads the frontend bundle directly from disk).
+type ServerOpts struct {
+	TlsCertFile string
+	TlsKeyFile  string
+	FrontendDir string
+}
+
+var activeServer *http.Server
+var activeServerLock sync.Mutex
+
 // blocking
-func RunWebServer(listener net.Listener) {
+func RunWebServer(listener net.Listener, opts ServerOpts) {
 	gr := mux.NewRouter()
+	gr.Use(oidcSessionMiddleware)
 	gr.HandleFunc("/wave/stream-file", WebFnWrap(WebFnOpts{AllowCaching: true}, handleStreamFile))
 	gr.HandleFunc("/wave/file", WebFnWrap(WebFnOpts{AllowCaching: false}, handleWaveFile))
 	gr.HandleFunc("/wave/service", WebFnWrap(WebFnOpts{JsonErrors: true}, handleService))
 	gr.HandleFunc("/vdom/{uuid}/{path:.*}", WebFnWrap(WebFnOpts{AllowCaching: true}, handleVDom))
 	gr.PathPrefix(docsitePrefix).Handler(http.StripPrefix(docsitePrefix, docsite.GetDocsiteHandler()))
+	gr.PathPrefix("/share/").HandlerFunc(handleShare)
+	gr.HandleFunc("/auth/login", handleOidcLogin)
+	gr.HandleFunc("/auth/callback", handleOidcCallback)
+	gr.HandleFunc("/auth/logout", handleOidcLogout)
+	gr.HandleFunc("/gateway/service", handleGatewayService)
+	gr.HandleFunc("/gateway/ai/summarize", handleGatewayAiSummarize)
+	if isPprofEnabled() {
+		registerPprofRoutes(gr)
+	}
+	if opts.FrontendDir != "" {
+		gr.PathPrefix("/").Handler(makeFrontendHandler(opts.FrontendDir))
+	}
 	handler := http.TimeoutHandler(gr, HttpTimeoutDuration, "Timeout")
 	if wavebase.IsDevMode() {
 		handler = handlers.CORS(handlers.AllowedOrigins([]string{"*"}))(handler)
@@ -428,8 +561,29 @@ func RunWebServer(listener net.Listener) {
 		MaxHeaderBytes: HttpMaxHeaderBytes,
 		Handler:        handler,
 	}
-	err := server.Serve(listener)
-	if err != nil {
+	activeServerLock.Lock()
+	activeServer = server
+	activeServerLock.Unlock()
+	var err error
+	if opts.TlsCertFile != "" && opts.TlsKeyFile != "" {
+		err = server.ServeTLS(listener, opts.TlsCertFile, opts.TlsKeyFile)
+	} else {
+		err = server.Serve(listener)
+	}
+	if err != nil && err != http.ErrServerClosed {
 		log.Printf("ERROR: %v\n", err)
 	}
 }
+
+// Shutdown gracefully stops the running web server, if any, waiting for
+// in-flight requests to finish or ctx to expire. Safe to call even if the
+// server was never started.
+func Shutdown(ctx context.Context) error {
+	activeServerLock.Lock()
+	server := activeServer
+	activeServerLock.Unlock()
+	if server == nil {
+		return nil
+	}
+	return server.Shutdown(ctx)
+}
@@ -0,0 +1,30 @@
+// Copyright 2024, Command Line Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package web
+
+import (
+	"net/http"
+	"os"
+	"path/filepath"
+)
+
+// makeFrontendHandler serves the built frontend (dist/frontend) as a single
+// page app: files that exist on disk are served directly, anything else
+// falls back to index.html so client-side routing still works. This route
+// is only registered in headless server mode (server:frontenddir) -- the
+// normal Electron app loads the frontend bundle directly from disk instead
+// of fetching it over HTTP.
+func makeFrontendHandler(frontendDir string) http.HandlerFunc {
+	fileServer := http.FileServer(http.Dir(frontendDir))
+	indexPath := filepath.Join(frontendDir, "index.html")
+	return func(w http.ResponseWriter, r *http.Request) {
+		fullPath := filepath.Join(frontendDir, filepath.Clean(r.URL.Path))
+		info, err := os.Stat(fullPath)
+		if err != nil || info.IsDir() {
+			http.ServeFile(w, r, indexPath)
+			return
+		}
+		fileServer.ServeHTTP(w, r)
+	}
+}
@@ -0,0 +1,171 @@
+// Copyright 2024, Command Line Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package web
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net"
+	"net/http"
+	"os"
+	"strings"
+
+	"github.com/gorilla/mux"
+	"github.com/wavetermdev/waveterm/pkg/service"
+	"github.com/wavetermdev/waveterm/pkg/wconfig"
+	"github.com/wavetermdev/waveterm/pkg/wshrpc"
+	"github.com/wavetermdev/waveterm/pkg/wshrpc/wshserver"
+)
+
+// gatewayServiceAllowlist is the curated subset of pkg/service.ServiceMap
+// that external tools and editor plugins are allowed to drive through the
+// gateway. This is intentionally much narrower than the full service map
+// used internally by the frontend (e.g. no "client"/"window" services),
+// since the gateway is meant for scripted object/block/file automation, not
+// full app control.
+var gatewayServiceAllowlist = map[string]bool{
+	"object": true,
+	"block":  true,
+	"file":   true,
+}
+
+func gatewayConfig() (token string, ok bool) {
+	settings := wconfig.GetWatcher().GetFullConfig().Settings
+	if !settings.GatewayEnabled || settings.GatewayToken == "" {
+		return "", false
+	}
+	return settings.GatewayToken, true
+}
+
+func checkGatewayAuth(r *http.Request) error {
+	token, ok := gatewayConfig()
+	if !ok {
+		return fmt.Errorf("gateway is not enabled")
+	}
+	authHeader := r.Header.Get("Authorization")
+	if !strings.HasPrefix(authHeader, "Bearer ") {
+		return fmt.Errorf("missing bearer token")
+	}
+	if strings.TrimPrefix(authHeader, "Bearer ") != token {
+		return fmt.Errorf("invalid bearer token")
+	}
+	return nil
+}
+
+// handleGatewayService exposes a curated subset of pkg/service (object,
+// block, file) over HTTP with bearer-token auth, using the same request and
+// response shape as the internal /wave/service endpoint, so external tools
+// and editor plugins can drive Wave Terminal without embedding wshrpc.
+func handleGatewayService(w http.ResponseWriter, r *http.Request) {
+	if err := checkGatewayAuth(r); err != nil {
+		http.Error(w, err.Error(), http.StatusUnauthorized)
+		return
+	}
+	if r.Method != http.MethodPost {
+		http.Error(w, "invalid request method", http.StatusMethodNotAllowed)
+		return
+	}
+	bodyData, err := io.ReadAll(r.Body)
+	if err != nil {
+		http.Error(w, "unable to read request body", http.StatusBadRequest)
+		return
+	}
+	defer r.Body.Close()
+	var webCall service.WebCallType
+	if err := json.Unmarshal(bodyData, &webCall); err != nil {
+		http.Error(w, fmt.Sprintf("invalid request body: %v", err), http.StatusBadRequest)
+		return
+	}
+	if !gatewayServiceAllowlist[webCall.Service] {
+		http.Error(w, fmt.Sprintf("service %q is not exposed via the gateway", webCall.Service), http.StatusForbidden)
+		return
+	}
+	rtn := service.CallService(r.Context(), webCall)
+	writeGatewayJson(w, rtn)
+}
+
+type gatewayAiSummarizeRequest struct {
+	BlockId  string `json:"blockid"`
+	FileName string `json:"filename"`
+}
+
+// handleGatewayAiSummarize exposes the AI summarize capability over the
+// gateway, since AI commands normally only exist as wshrpc methods, not as
+// entries in pkg/service.ServiceMap.
+func handleGatewayAiSummarize(w http.ResponseWriter, r *http.Request) {
+	if err := checkGatewayAuth(r); err != nil {
+		http.Error(w, err.Error(), http.StatusUnauthorized)
+		return
+	}
+	if r.Method != http.MethodPost {
+		http.Error(w, "invalid request method", http.StatusMethodNotAllowed)
+		return
+	}
+	bodyData, err := io.ReadAll(r.Body)
+	if err != nil {
+		http.Error(w, "unable to read request body", http.StatusBadRequest)
+		return
+	}
+	defer r.Body.Close()
+	var req gatewayAiSummarizeRequest
+	if err := json.Unmarshal(bodyData, &req); err != nil {
+		http.Error(w, fmt.Sprintf("invalid request body: %v", err), http.StatusBadRequest)
+		return
+	}
+	if req.FileName == "" {
+		req.FileName = "term"
+	}
+	rtn, err := wshserver.WshServerImpl.SummarizeCommand(r.Context(), wshrpc.CommandSummarizeData{BlockId: req.BlockId, FileName: req.FileName})
+	if err != nil {
+		writeGatewayJson(w, map[string]string{"error": err.Error()})
+		return
+	}
+	writeGatewayJson(w, rtn)
+}
+
+// MakeGatewayUnixListener binds a unix domain socket dedicated to the
+// gateway, separate from the wshrpc domain socket, so a gateway client only
+// needs filesystem access to that one socket file rather than the ability
+// to speak wshrpc's JWT-based connection handshake.
+func MakeGatewayUnixListener(socketPath string) (net.Listener, error) {
+	os.Remove(socketPath) // ignore error
+	rtn, err := net.Listen("unix", socketPath)
+	if err != nil {
+		return nil, fmt.Errorf("error creating gateway listener at %v: %v", socketPath, err)
+	}
+	os.Chmod(socketPath, 0700)
+	log.Printf("Server [gateway-unix] listening on %s\n", socketPath)
+	return rtn, nil
+}
+
+// RunGatewayUnixServer serves just the gateway routes (still requiring the
+// bearer token) over a unix socket listener. Blocking.
+func RunGatewayUnixServer(listener net.Listener) {
+	gr := mux.NewRouter()
+	gr.HandleFunc("/gateway/service", handleGatewayService)
+	gr.HandleFunc("/gateway/ai/summarize", handleGatewayAiSummarize)
+	server := &http.Server{
+		ReadTimeout:    HttpReadTimeout,
+		WriteTimeout:   HttpWriteTimeout,
+		MaxHeaderBytes: HttpMaxHeaderBytes,
+		Handler:        gr,
+	}
+	err := server.Serve(listener)
+	if err != nil && err != http.ErrServerClosed {
+		log.Printf("ERROR: %v\n", err)
+	}
+}
+
+func writeGatewayJson(w http.ResponseWriter, data any) {
+	jsonRtn, err := json.Marshal(data)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("error serializing response: %v", err), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set(ContentTypeHeaderKey, ContentTypeJson)
+	w.WriteHeader(http.StatusOK)
+	w.Write(jsonRtn)
+}
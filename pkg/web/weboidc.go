@@ -0,0 +1,178 @@
+// Copyright 2024, Command Line Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package web
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/wavetermdev/waveterm/pkg/authkey"
+	"github.com/wavetermdev/waveterm/pkg/ssoauth"
+	"github.com/wavetermdev/waveterm/pkg/wconfig"
+)
+
+const pendingLoginTtl = 10 * time.Minute
+
+type pendingLogin struct {
+	verifier  string
+	createdAt time.Time
+}
+
+var pendingLock sync.Mutex
+var pendingLogins = make(map[string]*pendingLogin) // state -> pending login
+
+func oidcConfig() (ssoauth.Config, bool) {
+	settings := wconfig.GetWatcher().GetFullConfig().Settings
+	if !settings.OidcEnabled || settings.OidcIssuer == "" || settings.OidcClientId == "" {
+		return ssoauth.Config{}, false
+	}
+	return ssoauth.Config{
+		Issuer:       settings.OidcIssuer,
+		ClientId:     settings.OidcClientId,
+		ClientSecret: settings.OidcClientSecret,
+		RedirectUrl:  settings.OidcRedirectUrl,
+		SessionTtl:   time.Duration(settings.OidcSessionTtlMs) * time.Millisecond,
+	}, true
+}
+
+// handleOidcLogin starts a PKCE login: it stashes the code verifier keyed by
+// a random state value, then redirects the browser to the provider.
+func handleOidcLogin(w http.ResponseWriter, r *http.Request) {
+	cfg, ok := oidcConfig()
+	if !ok {
+		http.Error(w, "OIDC login is not configured", http.StatusNotFound)
+		return
+	}
+	verifier, err := ssoauth.NewPKCEVerifier()
+	if err != nil {
+		http.Error(w, fmt.Sprintf("error starting login: %v", err), http.StatusInternalServerError)
+		return
+	}
+	state, err := ssoauth.NewState()
+	if err != nil {
+		http.Error(w, fmt.Sprintf("error starting login: %v", err), http.StatusInternalServerError)
+		return
+	}
+	authUrl, err := ssoauth.BuildAuthUrl(r.Context(), cfg, state, ssoauth.PKCEChallenge(verifier))
+	if err != nil {
+		http.Error(w, fmt.Sprintf("error building authorization url: %v", err), http.StatusBadGateway)
+		return
+	}
+	pendingLock.Lock()
+	pendingLogins[state] = &pendingLogin{verifier: verifier, createdAt: time.Now()}
+	pendingLock.Unlock()
+	http.Redirect(w, r, authUrl, http.StatusFound)
+}
+
+// handleOidcCallback completes the PKCE exchange and sets the session cookie.
+func handleOidcCallback(w http.ResponseWriter, r *http.Request) {
+	cfg, ok := oidcConfig()
+	if !ok {
+		http.Error(w, "OIDC login is not configured", http.StatusNotFound)
+		return
+	}
+	state := r.URL.Query().Get("state")
+	code := r.URL.Query().Get("code")
+	if state == "" || code == "" {
+		http.Error(w, "missing state or code", http.StatusBadRequest)
+		return
+	}
+	pendingLock.Lock()
+	pending, ok := pendingLogins[state]
+	if ok {
+		delete(pendingLogins, state)
+	}
+	pendingLock.Unlock()
+	if !ok || time.Since(pending.createdAt) > pendingLoginTtl {
+		http.Error(w, "login request expired or not found, please try again", http.StatusBadRequest)
+		return
+	}
+	session, err := ssoauth.ExchangeCode(r.Context(), cfg, code, pending.verifier)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("error completing login: %v", err), http.StatusBadGateway)
+		return
+	}
+	http.SetCookie(w, &http.Cookie{
+		Name:     ssoauth.SessionCookieName,
+		Value:    session.Token,
+		Path:     "/",
+		HttpOnly: true,
+		Secure:   r.TLS != nil,
+		SameSite: http.SameSiteLaxMode,
+		Expires:  time.UnixMilli(session.ExpiresTs),
+	})
+	w.Header().Set(ContentTypeHeaderKey, ContentTypeText)
+	fmt.Fprintf(w, "login successful as %s, you may close this tab\n", session.Email)
+}
+
+// handleOidcLogout revokes the caller's session (see ssoauth.RevokeSession) and clears the
+// session cookie.
+func handleOidcLogout(w http.ResponseWriter, r *http.Request) {
+	if cookie, err := r.Cookie(ssoauth.SessionCookieName); err == nil {
+		ssoauth.RevokeSession(cookie.Value)
+	}
+	http.SetCookie(w, &http.Cookie{
+		Name:     ssoauth.SessionCookieName,
+		Value:    "",
+		Path:     "/",
+		HttpOnly: true,
+		Secure:   r.TLS != nil,
+		SameSite: http.SameSiteLaxMode,
+		MaxAge:   -1,
+	})
+	w.Header().Set(ContentTypeHeaderKey, ContentTypeText)
+	fmt.Fprint(w, "logged out\n")
+}
+
+// hasValidOidcSession reports whether r carries a cookie that ssoauth.ValidateSession accepts.
+// It's used both by oidcSessionMiddleware (to gate browser-facing routes) and by WebFnWrap (as an
+// alternate credential to the X-AuthKey header, since a plain browser loaded from FrontendDir in
+// headless mode has no way to ever learn the authkey -- see WebFnWrap in web.go).
+func hasValidOidcSession(r *http.Request) bool {
+	if _, ok := oidcConfig(); !ok {
+		return false
+	}
+	cookie, err := r.Cookie(ssoauth.SessionCookieName)
+	if err != nil {
+		return false
+	}
+	_, ok := ssoauth.ValidateSession(cookie.Value)
+	return ok
+}
+
+// oidcSessionMiddleware is what actually makes OIDC login gate access: when server:oidcenabled is
+// set, browser requests that don't already carry their own credentials must present a valid
+// ssoauth.ValidateSession cookie, redirecting to /auth/login otherwise. The login/callback/logout
+// endpoints, the public doc site, and share links (which intentionally bypass auth on their own,
+// see handleShare) are always exempt. Requests that already authenticate themselves independently
+// -- the X-AuthKey header WebFnWrap checks for Electron/wsh traffic, and the gateway's own bearer
+// token (see checkGatewayAuth) -- are exempt too: those clients have no browser to complete the
+// PKCE login flow with, so gating them on a session cookie would just lock them out rather than
+// add any protection WebFnWrap/checkGatewayAuth don't already provide. When OIDC isn't configured
+// this is a no-op, preserving the normal Electron-backed/authkey-only behavior.
+func oidcSessionMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if _, ok := oidcConfig(); !ok {
+			next.ServeHTTP(w, r)
+			return
+		}
+		path := r.URL.Path
+		if path == "/auth/login" || path == "/auth/callback" || path == "/auth/logout" || strings.HasPrefix(path, docsitePrefix) || strings.HasPrefix(path, "/share/") {
+			next.ServeHTTP(w, r)
+			return
+		}
+		if authkey.ValidateIncomingRequest(r) == nil || checkGatewayAuth(r) == nil {
+			next.ServeHTTP(w, r)
+			return
+		}
+		if !hasValidOidcSession(r) {
+			http.Redirect(w, r, "/auth/login", http.StatusFound)
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
@@ -0,0 +1,80 @@
+// Copyright 2024, Command Line Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package web
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/wavetermdev/waveterm/pkg/blockcontroller"
+	"github.com/wavetermdev/waveterm/pkg/filestore"
+	"github.com/wavetermdev/waveterm/pkg/panichandler"
+	"github.com/wavetermdev/waveterm/pkg/webshare"
+)
+
+// handleShare serves the terminal output of a shared block to a viewer, and
+// (for driver-role shares) accepts their input, identified only by the
+// unguessable share token (no authkey required, unlike the rest of the web
+// API, since the whole point of a share link is to hand it to someone
+// without wave credentials).
+func handleShare(w http.ResponseWriter, r *http.Request) {
+	defer panichandler.PanicHandler("handleShare", recover())
+	w.Header().Set(CacheControlHeaderKey, CacheControlHeaderNoCache)
+	path := strings.TrimPrefix(r.URL.Path, "/share/")
+	isInput := strings.HasSuffix(path, "/input")
+	token := strings.TrimSuffix(strings.TrimSuffix(path, "/input"), "/")
+	if token == "" {
+		http.Error(w, "missing share token", http.StatusBadRequest)
+		return
+	}
+	info, ok := webshare.Resolve(token)
+	if !ok {
+		http.Error(w, "share link not found, expired, or revoked", http.StatusNotFound)
+		return
+	}
+	if isInput {
+		handleShareInput(w, r, info)
+		return
+	}
+	webshare.AddViewer(token)
+	defer webshare.RemoveViewer(token)
+	_, data, err := filestore.WFS.ReadFile(r.Context(), info.BlockId, blockcontroller.BlockFile_Term)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("error reading shared block: %v", err), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set(ContentTypeHeaderKey, ContentTypeBinary)
+	w.Write(data)
+}
+
+// handleShareInput lets a driver-role viewer send raw input (the POST body)
+// to the shared block's pty. Observer-role shares are enforced server-side
+// and always rejected here.
+func handleShareInput(w http.ResponseWriter, r *http.Request, info *webshare.ShareInfo) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	if info.Role != webshare.Role_Driver {
+		http.Error(w, "this share is read-only", http.StatusForbidden)
+		return
+	}
+	inputData, err := io.ReadAll(io.LimitReader(r.Body, 64*1024))
+	if err != nil {
+		http.Error(w, fmt.Sprintf("error reading input: %v", err), http.StatusBadRequest)
+		return
+	}
+	bc := blockcontroller.GetBlockController(info.BlockId)
+	if bc == nil {
+		http.Error(w, "block controller not found", http.StatusNotFound)
+		return
+	}
+	if err := bc.SendInput(&blockcontroller.BlockInputUnion{InputData: inputData}); err != nil {
+		http.Error(w, fmt.Sprintf("error sending input: %v", err), http.StatusInternalServerError)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,171 @@
+// Copyright 2024, Command Line Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+// Package webshare implements the control-plane for read-only live session
+// sharing: generating share links for a block's terminal output, tracking
+// expiry/revocation, and counting connected viewers. The actual viewer
+// connection is served by the "/share/{token}" endpoint in pkg/web.
+package webshare
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"sync"
+	"time"
+)
+
+const DefaultTtl = 60 * time.Minute
+const MaxTtl = 24 * time.Hour
+
+// Roles a share link can grant. Observer can only view terminal output;
+// driver can additionally send input (keystrokes, resizes) to the block.
+const (
+	Role_Observer = "observer"
+	Role_Driver   = "driver"
+)
+
+type ShareInfo struct {
+	Token       string `json:"token"`
+	BlockId     string `json:"blockid"`
+	Role        string `json:"role"`
+	CreatedTs   int64  `json:"createdts"`
+	ExpiresTs   int64  `json:"expirests"`
+	Revoked     bool   `json:"revoked"`
+	ViewerCount int    `json:"viewercount"`
+}
+
+var lock sync.Mutex
+var sharesByToken = make(map[string]*ShareInfo)
+var tokenByBlockId = make(map[string]string)
+
+func genToken() (string, error) {
+	buf := make([]byte, 16)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(buf), nil
+}
+
+// StartShare creates (or replaces) a share link for blockId with the given
+// role, valid for ttl. A ttl <= 0 uses DefaultTtl; ttl is capped at MaxTtl.
+// An empty role defaults to Role_Observer.
+func StartShare(blockId string, role string, ttl time.Duration) (*ShareInfo, error) {
+	if role == "" {
+		role = Role_Observer
+	}
+	if role != Role_Observer && role != Role_Driver {
+		return nil, fmt.Errorf("invalid share role %q (must be %q or %q)", role, Role_Observer, Role_Driver)
+	}
+	if ttl <= 0 {
+		ttl = DefaultTtl
+	}
+	if ttl > MaxTtl {
+		ttl = MaxTtl
+	}
+	token, err := genToken()
+	if err != nil {
+		return nil, fmt.Errorf("generating share token: %w", err)
+	}
+	lock.Lock()
+	defer lock.Unlock()
+	if oldToken, ok := tokenByBlockId[blockId]; ok {
+		delete(sharesByToken, oldToken)
+	}
+	now := time.Now()
+	info := &ShareInfo{
+		Token:     token,
+		BlockId:   blockId,
+		Role:      role,
+		CreatedTs: now.UnixMilli(),
+		ExpiresTs: now.Add(ttl).UnixMilli(),
+	}
+	sharesByToken[token] = info
+	tokenByBlockId[blockId] = token
+	return copyInfo(info), nil
+}
+
+// RevokeAll immediately revokes every active share across all blocks.
+func RevokeAll() int {
+	lock.Lock()
+	defer lock.Unlock()
+	count := 0
+	for _, info := range sharesByToken {
+		if !info.Revoked {
+			info.Revoked = true
+			count++
+		}
+	}
+	tokenByBlockId = make(map[string]string)
+	return count
+}
+
+// StopShare revokes the active share for blockId, if any.
+func StopShare(blockId string) error {
+	lock.Lock()
+	defer lock.Unlock()
+	token, ok := tokenByBlockId[blockId]
+	if !ok {
+		return nil
+	}
+	if info, ok := sharesByToken[token]; ok {
+		info.Revoked = true
+	}
+	delete(tokenByBlockId, blockId)
+	return nil
+}
+
+// GetStatus returns the current share info for blockId, if one exists.
+func GetStatus(blockId string) (*ShareInfo, bool) {
+	lock.Lock()
+	defer lock.Unlock()
+	token, ok := tokenByBlockId[blockId]
+	if !ok {
+		return nil, false
+	}
+	info, ok := sharesByToken[token]
+	if !ok {
+		return nil, false
+	}
+	return copyInfo(info), true
+}
+
+// Resolve looks up a share by token, returning ok=false if the token is
+// unknown, revoked, or expired.
+func Resolve(token string) (*ShareInfo, bool) {
+	lock.Lock()
+	defer lock.Unlock()
+	info, ok := sharesByToken[token]
+	if !ok || info.Revoked || time.Now().UnixMilli() > info.ExpiresTs {
+		return nil, false
+	}
+	return copyInfo(info), true
+}
+
+// AddViewer increments the viewer count for token and returns the new count.
+func AddViewer(token string) int {
+	lock.Lock()
+	defer lock.Unlock()
+	info, ok := sharesByToken[token]
+	if !ok {
+		return 0
+	}
+	info.ViewerCount++
+	return info.ViewerCount
+}
+
+// RemoveViewer decrements the viewer count for token.
+func RemoveViewer(token string) {
+	lock.Lock()
+	defer lock.Unlock()
+	info, ok := sharesByToken[token]
+	if !ok || info.ViewerCount <= 0 {
+		return
+	}
+	info.ViewerCount--
+}
+
+func copyInfo(info *ShareInfo) *ShareInfo {
+	copied := *info
+	return &copied
+}
@@ -0,0 +1,86 @@
+// Copyright 2025, Command Line Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package wlog
+
+import (
+	"fmt"
+	"os"
+	"sync"
+)
+
+const DefaultMaxSizeBytes = 10 * 1024 * 1024 // 10MB
+const DefaultMaxBackups = 5
+
+// RotatingWriter is an io.Writer that writes to a file, renaming it to "<path>.1", "<path>.2",
+// etc. (shifting older backups up, dropping anything past maxBackups) once it grows past
+// maxSizeBytes, then continuing on a fresh file at path.
+type RotatingWriter struct {
+	lock         sync.Mutex
+	path         string
+	maxSizeBytes int64
+	maxBackups   int
+	file         *os.File
+	curSize      int64
+}
+
+// NewRotatingWriter opens (or creates) path for appending, rotating immediately if it's already
+// past maxSizeBytes.
+func NewRotatingWriter(path string, maxSizeBytes int64, maxBackups int) (*RotatingWriter, error) {
+	w := &RotatingWriter{path: path, maxSizeBytes: maxSizeBytes, maxBackups: maxBackups}
+	if err := w.openCurrent(); err != nil {
+		return nil, err
+	}
+	return w, nil
+}
+
+func (w *RotatingWriter) openCurrent() error {
+	file, err := os.OpenFile(w.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return fmt.Errorf("error opening log file %q: %w", w.path, err)
+	}
+	info, err := file.Stat()
+	if err != nil {
+		file.Close()
+		return fmt.Errorf("error stat'ing log file %q: %w", w.path, err)
+	}
+	w.file = file
+	w.curSize = info.Size()
+	return nil
+}
+
+func (w *RotatingWriter) rotate() error {
+	if err := w.file.Close(); err != nil {
+		return fmt.Errorf("error closing log file %q: %w", w.path, err)
+	}
+	for i := w.maxBackups - 1; i >= 1; i-- {
+		oldPath := fmt.Sprintf("%s.%d", w.path, i)
+		newPath := fmt.Sprintf("%s.%d", w.path, i+1)
+		if _, err := os.Stat(oldPath); err == nil {
+			os.Rename(oldPath, newPath)
+		}
+	}
+	if w.maxBackups > 0 {
+		os.Rename(w.path, fmt.Sprintf("%s.1", w.path))
+	}
+	return w.openCurrent()
+}
+
+func (w *RotatingWriter) Write(p []byte) (int, error) {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+	if w.curSize+int64(len(p)) > w.maxSizeBytes && w.curSize > 0 {
+		if err := w.rotate(); err != nil {
+			return 0, err
+		}
+	}
+	n, err := w.file.Write(p)
+	w.curSize += int64(n)
+	return n, err
+}
+
+func (w *RotatingWriter) Close() error {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+	return w.file.Close()
+}
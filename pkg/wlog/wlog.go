@@ -0,0 +1,124 @@
+// Copyright 2025, Command Line Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+// Package wlog provides a leveled, structured logger (built on log/slog) with per-subsystem
+// level overrides that can be changed at runtime via the "wsh debug loglevel" command, plus
+// size-based log file rotation (see rotate.go).
+//
+// This replaces the ad-hoc log.Printf calls in cmd/server/main-server.go and pkg/remote, the two
+// places named by the request that exist in this tree -- there is no "cmdrunner" package in this
+// codebase (that name belongs to an older, unrelated terminal project), so it's left alone. The
+// rest of the tree still logs via the standard "log" package; converting every call site
+// repo-wide is a much larger, separate effort than this request scopes to.
+package wlog
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"sync"
+	"time"
+)
+
+// Re-export slog's levels so callers of this package don't need to import log/slog directly.
+const (
+	LevelDebug = slog.LevelDebug
+	LevelInfo  = slog.LevelInfo
+	LevelWarn  = slog.LevelWarn
+	LevelError = slog.LevelError
+)
+
+// DefaultSubsystem is the key used for SetLevel/GetLevel to change the fallback level applied to
+// subsystems that don't have their own override.
+const DefaultSubsystem = ""
+
+var (
+	regLock      sync.RWMutex
+	levelByName               = map[string]slog.Level{}
+	defaultLevel              = LevelInfo
+	baseHandler  slog.Handler = slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: slog.LevelDebug})
+)
+
+// SetOutput redirects all future log output to w (e.g. a *RotatingWriter). Must be called before
+// any Logger is used for it to take effect everywhere.
+func SetOutput(w io.Writer) {
+	regLock.Lock()
+	defer regLock.Unlock()
+	baseHandler = slog.NewTextHandler(w, &slog.HandlerOptions{Level: slog.LevelDebug})
+}
+
+// SetLevel sets the minimum level logged for a subsystem. Pass DefaultSubsystem to change the
+// fallback level used by subsystems without their own override.
+func SetLevel(subsystem string, level slog.Level) {
+	regLock.Lock()
+	defer regLock.Unlock()
+	if subsystem == DefaultSubsystem {
+		defaultLevel = level
+		return
+	}
+	levelByName[subsystem] = level
+}
+
+// ClearLevel removes a subsystem's override, falling back to the default level.
+func ClearLevel(subsystem string) {
+	regLock.Lock()
+	defer regLock.Unlock()
+	delete(levelByName, subsystem)
+}
+
+// GetLevel returns the effective level for a subsystem (its override, or the default).
+func GetLevel(subsystem string) slog.Level {
+	regLock.RLock()
+	defer regLock.RUnlock()
+	if lvl, ok := levelByName[subsystem]; ok {
+		return lvl
+	}
+	return defaultLevel
+}
+
+// GetLevels returns a snapshot of every subsystem with an explicit override, plus the default
+// level under DefaultSubsystem.
+func GetLevels() map[string]slog.Level {
+	regLock.RLock()
+	defer regLock.RUnlock()
+	rtn := make(map[string]slog.Level, len(levelByName)+1)
+	rtn[DefaultSubsystem] = defaultLevel
+	for name, lvl := range levelByName {
+		rtn[name] = lvl
+	}
+	return rtn
+}
+
+// Logger logs for a single subsystem, checking that subsystem's effective level (see SetLevel)
+// before formatting or emitting each record.
+type Logger struct {
+	subsystem string
+}
+
+// New returns a Logger for the given subsystem name (e.g. "remote", "main").
+func New(subsystem string) *Logger {
+	return &Logger{subsystem: subsystem}
+}
+
+func (l *Logger) log(level slog.Level, format string, args ...any) {
+	if level < GetLevel(l.subsystem) {
+		return
+	}
+	regLock.RLock()
+	handler := baseHandler
+	regLock.RUnlock()
+	msg := format
+	if len(args) > 0 {
+		msg = fmt.Sprintf(format, args...)
+	}
+	rec := slog.NewRecord(time.Now(), level, msg, 0)
+	rec.AddAttrs(slog.String("subsystem", l.subsystem))
+	_ = handler.Handle(context.Background(), rec)
+}
+
+func (l *Logger) Debugf(format string, args ...any) { l.log(LevelDebug, format, args...) }
+func (l *Logger) Infof(format string, args ...any)  { l.log(LevelInfo, format, args...) }
+func (l *Logger) Warnf(format string, args ...any)  { l.log(LevelWarn, format, args...) }
+func (l *Logger) Errorf(format string, args ...any) { l.log(LevelError, format, args...) }
@@ -0,0 +1,120 @@
+// Copyright 2024, Command Line Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+// Package wplugin implements discovery and loading of third-party block view plugins: packages
+// dropped into the plugins directory that add new block view types (a frontend JS bundle plus an
+// optional manifest-declared version compatibility range). wavesrv scans the plugins directory at
+// startup; the frontend is responsible for actually loading each enabled plugin's bundle and
+// registering its view type with the block view registry.
+package wplugin
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/wavetermdev/waveterm/pkg/wavebase"
+	"golang.org/x/mod/semver"
+)
+
+// PluginDirName is the subdirectory of the Wave data dir that holds one subdirectory per
+// installed plugin, each containing a manifest.json and the plugin's frontend bundle.
+const PluginDirName = "plugins"
+const ManifestFileName = "manifest.json"
+
+// Manifest is the plugin.json/manifest.json format a plugin package must ship to be recognized.
+type Manifest struct {
+	Name           string `json:"name"`        // unique plugin id, e.g. "my-company.my-view"
+	DisplayName    string `json:"displayname"` // shown in the enable/disable UI
+	Description    string `json:"description,omitempty"`
+	Version        string `json:"version"`                  // semver, e.g. "v1.2.0"
+	ViewType       string `json:"viewtype"`                 // the block "view" meta value this plugin registers
+	FrontendEntry  string `json:"frontendentry"`            // path (relative to the plugin dir) to the JS bundle to load
+	MinWaveVersion string `json:"minwaveversion,omitempty"` // semver floor, e.g. "v0.10.0" (empty = no floor)
+	MaxWaveVersion string `json:"maxwaveversion,omitempty"` // semver ceiling, exclusive (empty = no ceiling)
+}
+
+// PluginInfo is a discovered plugin plus its runtime state, as returned to the frontend.
+type PluginInfo struct {
+	Manifest    Manifest `json:"manifest"`
+	Dir         string   `json:"dir"`
+	Enabled     bool     `json:"enabled"`
+	Compatible  bool     `json:"compatible"`
+	IncompatMsg string   `json:"incompatmsg,omitempty"`
+}
+
+func PluginsDir() string {
+	return filepath.Join(wavebase.GetWaveDataDir(), PluginDirName)
+}
+
+// CheckVersionCompat reports whether waveVersion satisfies the manifest's
+// min/max-waveversion bounds. An invalid or "0.0.0" (dev build) waveVersion is always
+// considered compatible, since dev builds don't follow release semver ordering.
+func CheckVersionCompat(manifest Manifest, waveVersion string) (bool, string) {
+	if !semver.IsValid(waveVersion) || waveVersion == "v0.0.0" {
+		return true, ""
+	}
+	if manifest.MinWaveVersion != "" && semver.IsValid(manifest.MinWaveVersion) {
+		if semver.Compare(waveVersion, manifest.MinWaveVersion) < 0 {
+			return false, fmt.Sprintf("requires Wave Terminal %s or later (found %s)", manifest.MinWaveVersion, waveVersion)
+		}
+	}
+	if manifest.MaxWaveVersion != "" && semver.IsValid(manifest.MaxWaveVersion) {
+		if semver.Compare(waveVersion, manifest.MaxWaveVersion) >= 0 {
+			return false, fmt.Sprintf("requires Wave Terminal older than %s (found %s)", manifest.MaxWaveVersion, waveVersion)
+		}
+	}
+	return true, ""
+}
+
+func readManifest(pluginDir string) (Manifest, error) {
+	var manifest Manifest
+	manifestPath := filepath.Join(pluginDir, ManifestFileName)
+	manifestBytes, err := os.ReadFile(manifestPath)
+	if err != nil {
+		return manifest, fmt.Errorf("reading %s: %w", manifestPath, err)
+	}
+	if err := json.Unmarshal(manifestBytes, &manifest); err != nil {
+		return manifest, fmt.Errorf("parsing %s: %w", manifestPath, err)
+	}
+	if manifest.Name == "" {
+		return manifest, fmt.Errorf("%s: missing required \"name\" field", manifestPath)
+	}
+	if manifest.ViewType == "" {
+		return manifest, fmt.Errorf("%s: missing required \"viewtype\" field", manifestPath)
+	}
+	if manifest.FrontendEntry == "" {
+		return manifest, fmt.Errorf("%s: missing required \"frontendentry\" field", manifestPath)
+	}
+	return manifest, nil
+}
+
+// DiscoverPlugins scans pluginsDir for one subdirectory per plugin, each with a manifest.json.
+// A plugin directory with a missing or invalid manifest is skipped (reported via errs) rather
+// than failing the whole scan, so one broken plugin can't take down every other plugin.
+func DiscoverPlugins(pluginsDir string) (manifests []Manifest, dirs []string, errs []error) {
+	entries, err := os.ReadDir(pluginsDir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil, nil
+		}
+		return nil, nil, []error{fmt.Errorf("reading plugins dir %s: %w", pluginsDir, err)}
+	}
+	sort.Slice(entries, func(i, j int) bool { return entries[i].Name() < entries[j].Name() })
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			continue
+		}
+		pluginDir := filepath.Join(pluginsDir, entry.Name())
+		manifest, err := readManifest(pluginDir)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		manifests = append(manifests, manifest)
+		dirs = append(dirs, pluginDir)
+	}
+	return manifests, dirs, errs
+}
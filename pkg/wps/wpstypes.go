@@ -13,6 +13,9 @@ const (
 	Event_UserInput        = "userinput"
 	Event_RouteGone        = "route:gone"
 	Event_WorkspaceUpdate  = "workspace:update"
+	Event_SshConfigRefresh = "sshconfig:refresh"
+	Event_FileOpProgress   = "fileop:progress"
+	Event_A11yLine         = "a11y:line"
 )
 
 type WaveEvent struct {
@@ -47,3 +50,29 @@ type WSFileEventData struct {
 	FileOp   string `json:"fileop"`
 	Data64   string `json:"data64"`
 }
+
+// A11yLineEventData is one line (or command-boundary announcement) of a block's accessible
+// output stream, published for an ARIA live region while wconfig.SettingsType's
+// A11yScreenReaderMode is enabled (see a11ystream, blockcontroller.go's HandleAppendBlockFile).
+type A11yLineEventData struct {
+	BlockId string `json:"blockid"`
+	Line    string `json:"line"`
+	// IsBoundary marks a command-start/command-done announcement (e.g. "command exited 1")
+	// rather than a line of the command's own output -- only "cmd" type blocks can produce
+	// these, since interactive shells have no exit-code boundary to detect.
+	IsBoundary bool `json:"isboundary,omitempty"`
+}
+
+// WSFileOpProgressData reports the progress of a bulk file operation (move/copy/delete) started
+// by FileService.BulkFileOp, one event per item processed. Scoped by opid so the frontend can
+// subscribe to just the operation it kicked off.
+type WSFileOpProgressData struct {
+	OpId     string `json:"opid"`
+	Index    int    `json:"index"`
+	Total    int    `json:"total"`
+	Path     string `json:"path"`
+	DestPath string `json:"destpath,omitempty"`
+	Status   string `json:"status"` // "ok", "skipped", "error"
+	Error    string `json:"error,omitempty"`
+	Done     bool   `json:"done,omitempty"` // set on the final event for the operation
+}
@@ -6,13 +6,21 @@
 package wshclient
 
 import (
-	"github.com/wavetermdev/waveterm/pkg/wshutil"
-	"github.com/wavetermdev/waveterm/pkg/wshrpc"
+	"github.com/wavetermdev/waveterm/pkg/hooks"
+	"github.com/wavetermdev/waveterm/pkg/vdom"
 	"github.com/wavetermdev/waveterm/pkg/waveobj"
+	"github.com/wavetermdev/waveterm/pkg/wplugin"
 	"github.com/wavetermdev/waveterm/pkg/wps"
-	"github.com/wavetermdev/waveterm/pkg/vdom"
+	"github.com/wavetermdev/waveterm/pkg/wshrpc"
+	"github.com/wavetermdev/waveterm/pkg/wshutil"
 )
 
+// command "actionregistry", wshserver.ActionRegistryCommand
+func ActionRegistryCommand(w *wshutil.WshRpc, opts *wshrpc.RpcOpts) ([]wshrpc.ActionRegistryEntry, error) {
+	resp, err := sendRpcRequestCallHelper[[]wshrpc.ActionRegistryEntry](w, "actionregistry", nil, opts)
+	return resp, err
+}
+
 // command "activity", wshserver.ActivityCommand
 func ActivityCommand(w *wshutil.WshRpc, data wshrpc.ActivityUpdate, opts *wshrpc.RpcOpts) error {
 	_, err := sendRpcRequestCallHelper[any](w, "activity", data, opts)
@@ -25,6 +33,12 @@ func AiSendMessageCommand(w *wshutil.WshRpc, data wshrpc.AiMessageData, opts *ws
 	return err
 }
 
+// command "applysettingsbundle", wshserver.ApplySettingsBundleCommand
+func ApplySettingsBundleCommand(w *wshutil.WshRpc, data wshrpc.CommandApplySettingsBundleData, opts *wshrpc.RpcOpts) error {
+	_, err := sendRpcRequestCallHelper[any](w, "applysettingsbundle", data, opts)
+	return err
+}
+
 // command "authenticate", wshserver.AuthenticateCommand
 func AuthenticateCommand(w *wshutil.WshRpc, data string, opts *wshrpc.RpcOpts) (wshrpc.CommandAuthenticateRtnData, error) {
 	resp, err := sendRpcRequestCallHelper[wshrpc.CommandAuthenticateRtnData](w, "authenticate", data, opts)
@@ -37,6 +51,30 @@ func BlockInfoCommand(w *wshutil.WshRpc, data string, opts *wshrpc.RpcOpts) (*ws
 	return resp, err
 }
 
+// command "checkfonts", wshserver.CheckFontsCommand
+func CheckFontsCommand(w *wshutil.WshRpc, opts *wshrpc.RpcOpts) (wshrpc.FontStatusData, error) {
+	resp, err := sendRpcRequestCallHelper[wshrpc.FontStatusData](w, "checkfonts", nil, opts)
+	return resp, err
+}
+
+// command "cleanupdetached", wshserver.CleanupDetachedCommand
+func CleanupDetachedCommand(w *wshutil.WshRpc, data string, opts *wshrpc.RpcOpts) error {
+	_, err := sendRpcRequestCallHelper[any](w, "cleanupdetached", data, opts)
+	return err
+}
+
+// command "clientcapabilities", wshserver.ClientCapabilitiesCommand
+func ClientCapabilitiesCommand(w *wshutil.WshRpc, opts *wshrpc.RpcOpts) (wshrpc.ClientCapabilitiesData, error) {
+	resp, err := sendRpcRequestCallHelper[wshrpc.ClientCapabilitiesData](w, "clientcapabilities", nil, opts)
+	return resp, err
+}
+
+// command "clientdbstats", wshserver.ClientDbStatsCommand
+func ClientDbStatsCommand(w *wshutil.WshRpc, opts *wshrpc.RpcOpts) ([]wshrpc.DbStats, error) {
+	resp, err := sendRpcRequestCallHelper[[]wshrpc.DbStats](w, "clientdbstats", nil, opts)
+	return resp, err
+}
+
 // command "connconnect", wshserver.ConnConnectCommand
 func ConnConnectCommand(w *wshutil.WshRpc, data wshrpc.ConnRequest, opts *wshrpc.RpcOpts) error {
 	_, err := sendRpcRequestCallHelper[any](w, "connconnect", data, opts)
@@ -55,12 +93,36 @@ func ConnEnsureCommand(w *wshutil.WshRpc, data string, opts *wshrpc.RpcOpts) err
 	return err
 }
 
+// command "connimporttags", wshserver.ConnImportTagsCommand
+func ConnImportTagsCommand(w *wshutil.WshRpc, data string, opts *wshrpc.RpcOpts) (wshrpc.CommandConnImportTagsRtnData, error) {
+	resp, err := sendRpcRequestCallHelper[wshrpc.CommandConnImportTagsRtnData](w, "connimporttags", data, opts)
+	return resp, err
+}
+
 // command "connlist", wshserver.ConnListCommand
 func ConnListCommand(w *wshutil.WshRpc, opts *wshrpc.RpcOpts) ([]string, error) {
 	resp, err := sendRpcRequestCallHelper[[]string](w, "connlist", nil, opts)
 	return resp, err
 }
 
+// command "connportforwardclose", wshserver.ConnPortForwardCloseCommand
+func ConnPortForwardCloseCommand(w *wshutil.WshRpc, data string, opts *wshrpc.RpcOpts) error {
+	_, err := sendRpcRequestCallHelper[any](w, "connportforwardclose", data, opts)
+	return err
+}
+
+// command "connportforwardlist", wshserver.ConnPortForwardListCommand
+func ConnPortForwardListCommand(w *wshutil.WshRpc, opts *wshrpc.RpcOpts) ([]wshrpc.ConnPortForwardInfo, error) {
+	resp, err := sendRpcRequestCallHelper[[]wshrpc.ConnPortForwardInfo](w, "connportforwardlist", nil, opts)
+	return resp, err
+}
+
+// command "connportforwardopen", wshserver.ConnPortForwardOpenCommand
+func ConnPortForwardOpenCommand(w *wshutil.WshRpc, data wshrpc.CommandConnPortForwardOpenData, opts *wshrpc.RpcOpts) (wshrpc.ConnPortForwardInfo, error) {
+	resp, err := sendRpcRequestCallHelper[wshrpc.ConnPortForwardInfo](w, "connportforwardopen", data, opts)
+	return resp, err
+}
+
 // command "connreinstallwsh", wshserver.ConnReinstallWshCommand
 func ConnReinstallWshCommand(w *wshutil.WshRpc, data string, opts *wshrpc.RpcOpts) error {
 	_, err := sendRpcRequestCallHelper[any](w, "connreinstallwsh", data, opts)
@@ -79,6 +141,12 @@ func ControllerInputCommand(w *wshutil.WshRpc, data wshrpc.CommandBlockInputData
 	return err
 }
 
+// command "controllerrestartallfailed", wshserver.ControllerRestartAllFailedCommand
+func ControllerRestartAllFailedCommand(w *wshutil.WshRpc, data wshrpc.CommandControllerRestartAllFailedData, opts *wshrpc.RpcOpts) (wshrpc.CommandControllerRestartAllFailedRtnData, error) {
+	resp, err := sendRpcRequestCallHelper[wshrpc.CommandControllerRestartAllFailedRtnData](w, "controllerrestartallfailed", data, opts)
+	return resp, err
+}
+
 // command "controllerresync", wshserver.ControllerResyncCommand
 func ControllerResyncCommand(w *wshutil.WshRpc, data wshrpc.CommandControllerResyncData, opts *wshrpc.RpcOpts) error {
 	_, err := sendRpcRequestCallHelper[any](w, "controllerresync", data, opts)
@@ -103,6 +171,36 @@ func CreateSubBlockCommand(w *wshutil.WshRpc, data wshrpc.CommandCreateSubBlockD
 	return resp, err
 }
 
+// command "dbquery", wshserver.DbQueryCommand
+func DbQueryCommand(w *wshutil.WshRpc, data wshrpc.CommandDbQueryData, opts *wshrpc.RpcOpts) (wshrpc.DbQueryResult, error) {
+	resp, err := sendRpcRequestCallHelper[wshrpc.DbQueryResult](w, "dbquery", data, opts)
+	return resp, err
+}
+
+// command "dbquerycancel", wshserver.DbQueryCancelCommand
+func DbQueryCancelCommand(w *wshutil.WshRpc, data string, opts *wshrpc.RpcOpts) error {
+	_, err := sendRpcRequestCallHelper[any](w, "dbquerycancel", data, opts)
+	return err
+}
+
+// command "debugcrashes", wshserver.DebugCrashesCommand
+func DebugCrashesCommand(w *wshutil.WshRpc, opts *wshrpc.RpcOpts) ([]wshrpc.CrashReportSummary, error) {
+	resp, err := sendRpcRequestCallHelper[[]wshrpc.CrashReportSummary](w, "debugcrashes", nil, opts)
+	return resp, err
+}
+
+// command "debugloglevel", wshserver.DebugLogLevelCommand
+func DebugLogLevelCommand(w *wshutil.WshRpc, data wshrpc.CommandDebugLogLevelData, opts *wshrpc.RpcOpts) (wshrpc.CommandDebugLogLevelRtnData, error) {
+	resp, err := sendRpcRequestCallHelper[wshrpc.CommandDebugLogLevelRtnData](w, "debugloglevel", data, opts)
+	return resp, err
+}
+
+// command "debugprofile", wshserver.DebugProfileCommand
+func DebugProfileCommand(w *wshutil.WshRpc, data wshrpc.CommandDebugProfileData, opts *wshrpc.RpcOpts) (wshrpc.CommandDebugProfileRtnData, error) {
+	resp, err := sendRpcRequestCallHelper[wshrpc.CommandDebugProfileRtnData](w, "debugprofile", data, opts)
+	return resp, err
+}
+
 // command "deleteblock", wshserver.DeleteBlockCommand
 func DeleteBlockCommand(w *wshutil.WshRpc, data wshrpc.CommandDeleteBlockData, opts *wshrpc.RpcOpts) error {
 	_, err := sendRpcRequestCallHelper[any](w, "deleteblock", data, opts)
@@ -163,6 +261,12 @@ func EventUnsubAllCommand(w *wshutil.WshRpc, opts *wshrpc.RpcOpts) error {
 	return err
 }
 
+// command "exportsettingsbundle", wshserver.ExportSettingsBundleCommand
+func ExportSettingsBundleCommand(w *wshutil.WshRpc, data wshrpc.CommandExportSettingsBundleData, opts *wshrpc.RpcOpts) error {
+	_, err := sendRpcRequestCallHelper[any](w, "exportsettingsbundle", data, opts)
+	return err
+}
+
 // command "fileappend", wshserver.FileAppendCommand
 func FileAppendCommand(w *wshutil.WshRpc, data wshrpc.CommandFileData, opts *wshrpc.RpcOpts) error {
 	_, err := sendRpcRequestCallHelper[any](w, "fileappend", data, opts)
@@ -217,12 +321,30 @@ func FocusWindowCommand(w *wshutil.WshRpc, data string, opts *wshrpc.RpcOpts) er
 	return err
 }
 
+// command "getmcpconfig", wshserver.GetMcpConfigCommand
+func GetMcpConfigCommand(w *wshutil.WshRpc, opts *wshrpc.RpcOpts) (wshrpc.McpConfigData, error) {
+	resp, err := sendRpcRequestCallHelper[wshrpc.McpConfigData](w, "getmcpconfig", nil, opts)
+	return resp, err
+}
+
 // command "getmeta", wshserver.GetMetaCommand
 func GetMetaCommand(w *wshutil.WshRpc, data wshrpc.CommandGetMetaData, opts *wshrpc.RpcOpts) (waveobj.MetaMapType, error) {
 	resp, err := sendRpcRequestCallHelper[waveobj.MetaMapType](w, "getmeta", data, opts)
 	return resp, err
 }
 
+// command "getmetabulk", wshserver.GetMetaBulkCommand
+func GetMetaBulkCommand(w *wshutil.WshRpc, data wshrpc.CommandGetMetaBulkData, opts *wshrpc.RpcOpts) ([]wshrpc.MetaBulkResult, error) {
+	resp, err := sendRpcRequestCallHelper[[]wshrpc.MetaBulkResult](w, "getmetabulk", data, opts)
+	return resp, err
+}
+
+// command "getoutputfoldstate", wshserver.GetOutputFoldStateCommand
+func GetOutputFoldStateCommand(w *wshutil.WshRpc, data string, opts *wshrpc.RpcOpts) (wshrpc.OutputFoldStateData, error) {
+	resp, err := sendRpcRequestCallHelper[wshrpc.OutputFoldStateData](w, "getoutputfoldstate", data, opts)
+	return resp, err
+}
+
 // command "getupdatechannel", wshserver.GetUpdateChannelCommand
 func GetUpdateChannelCommand(w *wshutil.WshRpc, opts *wshrpc.RpcOpts) (string, error) {
 	resp, err := sendRpcRequestCallHelper[string](w, "getupdatechannel", nil, opts)
@@ -235,6 +357,90 @@ func GetVarCommand(w *wshutil.WshRpc, data wshrpc.CommandVarData, opts *wshrpc.R
 	return resp, err
 }
 
+// command "hooksadd", wshserver.HooksAddCommand
+func HooksAddCommand(w *wshutil.WshRpc, data hooks.HookDef, opts *wshrpc.RpcOpts) error {
+	_, err := sendRpcRequestCallHelper[any](w, "hooksadd", data, opts)
+	return err
+}
+
+// command "hookslist", wshserver.HooksListCommand
+func HooksListCommand(w *wshutil.WshRpc, opts *wshrpc.RpcOpts) ([]hooks.HookDef, error) {
+	resp, err := sendRpcRequestCallHelper[[]hooks.HookDef](w, "hookslist", nil, opts)
+	return resp, err
+}
+
+// command "hooksremove", wshserver.HooksRemoveCommand
+func HooksRemoveCommand(w *wshutil.WshRpc, data int, opts *wshrpc.RpcOpts) error {
+	_, err := sendRpcRequestCallHelper[any](w, "hooksremove", data, opts)
+	return err
+}
+
+// command "idlelocksetpassphrase", wshserver.IdleLockSetPassphraseCommand
+func IdleLockSetPassphraseCommand(w *wshutil.WshRpc, data string, opts *wshrpc.RpcOpts) error {
+	_, err := sendRpcRequestCallHelper[any](w, "idlelocksetpassphrase", data, opts)
+	return err
+}
+
+// command "idlelockstatus", wshserver.IdleLockStatusCommand
+func IdleLockStatusCommand(w *wshutil.WshRpc, opts *wshrpc.RpcOpts) (wshrpc.IdleLockStatusData, error) {
+	resp, err := sendRpcRequestCallHelper[wshrpc.IdleLockStatusData](w, "idlelockstatus", nil, opts)
+	return resp, err
+}
+
+// command "idlelocktouch", wshserver.IdleLockTouchCommand
+func IdleLockTouchCommand(w *wshutil.WshRpc, opts *wshrpc.RpcOpts) error {
+	_, err := sendRpcRequestCallHelper[any](w, "idlelocktouch", nil, opts)
+	return err
+}
+
+// command "idlelockunlock", wshserver.IdleLockUnlockCommand
+func IdleLockUnlockCommand(w *wshutil.WshRpc, data string, opts *wshrpc.RpcOpts) error {
+	_, err := sendRpcRequestCallHelper[any](w, "idlelockunlock", data, opts)
+	return err
+}
+
+// command "importsettingsbundle", wshserver.ImportSettingsBundleCommand
+func ImportSettingsBundleCommand(w *wshutil.WshRpc, data wshrpc.CommandImportSettingsBundleData, opts *wshrpc.RpcOpts) ([]wshrpc.SettingsSyncConflict, error) {
+	resp, err := sendRpcRequestCallHelper[[]wshrpc.SettingsSyncConflict](w, "importsettingsbundle", data, opts)
+	return resp, err
+}
+
+// command "importtermtheme", wshserver.ImportTermThemeCommand
+func ImportTermThemeCommand(w *wshutil.WshRpc, data wshrpc.CommandImportTermThemeData, opts *wshrpc.RpcOpts) (string, error) {
+	resp, err := sendRpcRequestCallHelper[string](w, "importtermtheme", data, opts)
+	return resp, err
+}
+
+// command "inputmodechords", wshserver.InputModeChordsCommand
+func InputModeChordsCommand(w *wshutil.WshRpc, opts *wshrpc.RpcOpts) (wshrpc.InputModeChordsData, error) {
+	resp, err := sendRpcRequestCallHelper[wshrpc.InputModeChordsData](w, "inputmodechords", nil, opts)
+	return resp, err
+}
+
+// command "keybindingregistry", wshserver.KeybindingRegistryCommand
+func KeybindingRegistryCommand(w *wshutil.WshRpc, data string, opts *wshrpc.RpcOpts) (wshrpc.KeybindingRegistryData, error) {
+	resp, err := sendRpcRequestCallHelper[wshrpc.KeybindingRegistryData](w, "keybindingregistry", data, opts)
+	return resp, err
+}
+
+// command "listorphaneddetached", wshserver.ListOrphanedDetachedCommand
+func ListOrphanedDetachedCommand(w *wshutil.WshRpc, opts *wshrpc.RpcOpts) (wshrpc.CommandListOrphanedDetachedRtnData, error) {
+	resp, err := sendRpcRequestCallHelper[wshrpc.CommandListOrphanedDetachedRtnData](w, "listorphaneddetached", nil, opts)
+	return resp, err
+}
+
+// command "listplugins", wshserver.ListPluginsCommand
+func ListPluginsCommand(w *wshutil.WshRpc, opts *wshrpc.RpcOpts) ([]wplugin.PluginInfo, error) {
+	resp, err := sendRpcRequestCallHelper[[]wplugin.PluginInfo](w, "listplugins", nil, opts)
+	return resp, err
+}
+
+// command "listtabs", wshserver.ListTabsCommand
+func ListTabsCommand(w *wshutil.WshRpc, opts *wshrpc.RpcOpts) ([]wshrpc.TabListEntry, error) {
+	resp, err := sendRpcRequestCallHelper[[]wshrpc.TabListEntry](w, "listtabs", nil, opts)
+	return resp, err
+}
+
 // command "message", wshserver.MessageCommand
 func MessageCommand(w *wshutil.WshRpc, data wshrpc.CommandMessageData, opts *wshrpc.RpcOpts) error {
 	_, err := sendRpcRequestCallHelper[any](w, "message", data, opts)
@@ -253,12 +459,65 @@ func PathCommand(w *wshutil.WshRpc, data wshrpc.PathCommandData, opts *wshrpc.Rp
 	return resp, err
 }
 
+// command "redacttext", wshserver.RedactTextCommand
+func RedactTextCommand(w *wshutil.WshRpc, data wshrpc.CommandRedactTextData, opts *wshrpc.RpcOpts) (wshrpc.CommandRedactTextRtnData, error) {
+	resp, err := sendRpcRequestCallHelper[wshrpc.CommandRedactTextRtnData](w, "redacttext", data, opts)
+	return resp, err
+}
+
+// command "registervdomrenderer", wshserver.RegisterVDomRendererCommand
+func RegisterVDomRendererCommand(w *wshutil.WshRpc, data wshrpc.CommandRegisterVDomRendererData, opts *wshrpc.RpcOpts) error {
+	_, err := sendRpcRequestCallHelper[any](w, "registervdomrenderer", data, opts)
+	return err
+}
+
+// command "remotearchiveextract", wshserver.RemoteArchiveExtractCommand
+func RemoteArchiveExtractCommand(w *wshutil.WshRpc, data wshrpc.CommandRemoteArchiveExtractData, opts *wshrpc.RpcOpts) error {
+	_, err := sendRpcRequestCallHelper[any](w, "remotearchiveextract", data, opts)
+	return err
+}
+
+// command "remotearchivelist", wshserver.RemoteArchiveListCommand
+func RemoteArchiveListCommand(w *wshutil.WshRpc, data string, opts *wshrpc.RpcOpts) ([]wshrpc.ArchiveEntryInfo, error) {
+	resp, err := sendRpcRequestCallHelper[[]wshrpc.ArchiveEntryInfo](w, "remotearchivelist", data, opts)
+	return resp, err
+}
+
+// command "remotearchivereadentry", wshserver.RemoteArchiveReadEntryCommand
+func RemoteArchiveReadEntryCommand(w *wshutil.WshRpc, data wshrpc.CommandRemoteArchiveReadEntryData, opts *wshrpc.RpcOpts) (wshrpc.ArchiveEntryContent, error) {
+	resp, err := sendRpcRequestCallHelper[wshrpc.ArchiveEntryContent](w, "remotearchivereadentry", data, opts)
+	return resp, err
+}
+
+// command "remotediskusage", wshserver.RemoteDiskUsageCommand
+func RemoteDiskUsageCommand(w *wshutil.WshRpc, data wshrpc.CommandRemoteDiskUsageData, opts *wshrpc.RpcOpts) chan wshrpc.RespOrErrorUnion[wshrpc.DiskUsageProgressData] {
+	return sendRpcRequestResponseStreamHelper[wshrpc.DiskUsageProgressData](w, "remotediskusage", data, opts)
+}
+
+// command "remotefilecopy", wshserver.RemoteFileCopyCommand
+func RemoteFileCopyCommand(w *wshutil.WshRpc, data wshrpc.CommandRemoteFileCopyData, opts *wshrpc.RpcOpts) error {
+	_, err := sendRpcRequestCallHelper[any](w, "remotefilecopy", data, opts)
+	return err
+}
+
+// command "remotefiledatatable", wshserver.RemoteFileDataTableCommand
+func RemoteFileDataTableCommand(w *wshutil.WshRpc, data wshrpc.CommandRemoteFileDataTableData, opts *wshrpc.RpcOpts) (wshrpc.FileDataTablePage, error) {
+	resp, err := sendRpcRequestCallHelper[wshrpc.FileDataTablePage](w, "remotefiledatatable", data, opts)
+	return resp, err
+}
+
 // command "remotefiledelete", wshserver.RemoteFileDeleteCommand
 func RemoteFileDeleteCommand(w *wshutil.WshRpc, data string, opts *wshrpc.RpcOpts) error {
 	_, err := sendRpcRequestCallHelper[any](w, "remotefiledelete", data, opts)
 	return err
 }
 
+// command "remotefilediff", wshserver.RemoteFileDiffCommand
+func RemoteFileDiffCommand(w *wshutil.WshRpc, data wshrpc.CommandRemoteFileDiffData, opts *wshrpc.RpcOpts) (wshrpc.CommandRemoteFileDiffRtnData, error) {
+	resp, err := sendRpcRequestCallHelper[wshrpc.CommandRemoteFileDiffRtnData](w, "remotefilediff", data, opts)
+	return resp, err
+}
+
 // command "remotefileinfo", wshserver.RemoteFileInfoCommand
 func RemoteFileInfoCommand(w *wshutil.WshRpc, data string, opts *wshrpc.RpcOpts) (*wshrpc.FileInfo, error) {
 	resp, err := sendRpcRequestCallHelper[*wshrpc.FileInfo](w, "remotefileinfo", data, opts)
@@ -283,12 +542,105 @@ func RemoteFileTouchCommand(w *wshutil.WshRpc, data string, opts *wshrpc.RpcOpts
 	return err
 }
 
+// command "remotegitprstatus", wshserver.RemoteGitPrStatusCommand
+func RemoteGitPrStatusCommand(w *wshutil.WshRpc, data wshrpc.CommandRemoteGitPrStatusData, opts *wshrpc.RpcOpts) chan wshrpc.RespOrErrorUnion[wshrpc.GitPrStatusData] {
+	return sendRpcRequestResponseStreamHelper[wshrpc.GitPrStatusData](w, "remotegitprstatus", data, opts)
+}
+
+// command "remotegitstatus", wshserver.RemoteGitStatusCommand
+func RemoteGitStatusCommand(w *wshutil.WshRpc, data string, opts *wshrpc.RpcOpts) (wshrpc.GitStatusInfo, error) {
+	resp, err := sendRpcRequestCallHelper[wshrpc.GitStatusInfo](w, "remotegitstatus", data, opts)
+	return resp, err
+}
+
+// command "remotehttprequest", wshserver.RemoteHttpRequestCommand
+func RemoteHttpRequestCommand(w *wshutil.WshRpc, data wshrpc.CommandRemoteHttpRequestData, opts *wshrpc.RpcOpts) (wshrpc.HttpResponseData, error) {
+	resp, err := sendRpcRequestCallHelper[wshrpc.HttpResponseData](w, "remotehttprequest", data, opts)
+	return resp, err
+}
+
+// command "remoteimageop", wshserver.RemoteImageOpCommand
+func RemoteImageOpCommand(w *wshutil.WshRpc, data wshrpc.CommandRemoteImageOpData, opts *wshrpc.RpcOpts) (wshrpc.ImageOpResult, error) {
+	resp, err := sendRpcRequestCallHelper[wshrpc.ImageOpResult](w, "remoteimageop", data, opts)
+	return resp, err
+}
+
+// command "remotejupyterkernelexecute", wshserver.RemoteJupyterKernelExecuteCommand
+func RemoteJupyterKernelExecuteCommand(w *wshutil.WshRpc, data wshrpc.CommandRemoteJupyterKernelExecuteData, opts *wshrpc.RpcOpts) chan wshrpc.RespOrErrorUnion[wshrpc.JupyterOutputData] {
+	return sendRpcRequestResponseStreamHelper[wshrpc.JupyterOutputData](w, "remotejupyterkernelexecute", data, opts)
+}
+
+// command "remotejupyterkernelstop", wshserver.RemoteJupyterKernelStopCommand
+func RemoteJupyterKernelStopCommand(w *wshutil.WshRpc, data string, opts *wshrpc.RpcOpts) error {
+	_, err := sendRpcRequestCallHelper[any](w, "remotejupyterkernelstop", data, opts)
+	return err
+}
+
+// command "remotelistlisteners", wshserver.RemoteListListenersCommand
+func RemoteListListenersCommand(w *wshutil.WshRpc, opts *wshrpc.RpcOpts) ([]wshrpc.ListenerInfo, error) {
+	resp, err := sendRpcRequestCallHelper[[]wshrpc.ListenerInfo](w, "remotelistlisteners", nil, opts)
+	return resp, err
+}
+
+// command "remotelistprocesses", wshserver.RemoteListProcessesCommand
+func RemoteListProcessesCommand(w *wshutil.WshRpc, opts *wshrpc.RpcOpts) ([]wshrpc.ProcessInfo, error) {
+	resp, err := sendRpcRequestCallHelper[[]wshrpc.ProcessInfo](w, "remotelistprocesses", nil, opts)
+	return resp, err
+}
+
+// command "remotelogtail", wshserver.RemoteLogTailCommand
+func RemoteLogTailCommand(w *wshutil.WshRpc, data wshrpc.CommandRemoteLogTailData, opts *wshrpc.RpcOpts) chan wshrpc.RespOrErrorUnion[wshrpc.LogLineData] {
+	return sendRpcRequestResponseStreamHelper[wshrpc.LogLineData](w, "remotelogtail", data, opts)
+}
+
+// command "remotelspnotify", wshserver.RemoteLspNotifyCommand
+func RemoteLspNotifyCommand(w *wshutil.WshRpc, data wshrpc.CommandRemoteLspNotifyData, opts *wshrpc.RpcOpts) error {
+	_, err := sendRpcRequestCallHelper[any](w, "remotelspnotify", data, opts)
+	return err
+}
+
+// command "remotelsprequest", wshserver.RemoteLspRequestCommand
+func RemoteLspRequestCommand(w *wshutil.WshRpc, data wshrpc.CommandRemoteLspRequestData, opts *wshrpc.RpcOpts) (wshrpc.CommandRemoteLspRequestRtnData, error) {
+	resp, err := sendRpcRequestCallHelper[wshrpc.CommandRemoteLspRequestRtnData](w, "remotelsprequest", data, opts)
+	return resp, err
+}
+
+// command "remotelspstop", wshserver.RemoteLspStopCommand
+func RemoteLspStopCommand(w *wshutil.WshRpc, data string, opts *wshrpc.RpcOpts) error {
+	_, err := sendRpcRequestCallHelper[any](w, "remotelspstop", data, opts)
+	return err
+}
+
 // command "remotemkdir", wshserver.RemoteMkdirCommand
 func RemoteMkdirCommand(w *wshutil.WshRpc, data string, opts *wshrpc.RpcOpts) error {
 	_, err := sendRpcRequestCallHelper[any](w, "remotemkdir", data, opts)
 	return err
 }
 
+// command "remotepdftext", wshserver.RemotePdfTextCommand
+func RemotePdfTextCommand(w *wshutil.WshRpc, data string, opts *wshrpc.RpcOpts) (string, error) {
+	resp, err := sendRpcRequestCallHelper[string](w, "remotepdftext", data, opts)
+	return resp, err
+}
+
+// command "remoteprocessrenice", wshserver.RemoteProcessReniceCommand
+func RemoteProcessReniceCommand(w *wshutil.WshRpc, data wshrpc.CommandRemoteProcessReniceData, opts *wshrpc.RpcOpts) error {
+	_, err := sendRpcRequestCallHelper[any](w, "remoteprocessrenice", data, opts)
+	return err
+}
+
+// command "remoteprocesssignal", wshserver.RemoteProcessSignalCommand
+func RemoteProcessSignalCommand(w *wshutil.WshRpc, data wshrpc.CommandRemoteProcessSignalData, opts *wshrpc.RpcOpts) error {
+	_, err := sendRpcRequestCallHelper[any](w, "remoteprocesssignal", data, opts)
+	return err
+}
+
+// command "remoteruncommand", wshserver.RemoteRunCommandCommand
+func RemoteRunCommandCommand(w *wshutil.WshRpc, data wshrpc.CommandRemoteRunCommandData, opts *wshrpc.RpcOpts) (wshrpc.CommandRemoteRunCommandRtnData, error) {
+	resp, err := sendRpcRequestCallHelper[wshrpc.CommandRemoteRunCommandRtnData](w, "remoteruncommand", data, opts)
+	return resp, err
+}
+
 // command "remotestreamcpudata", wshserver.RemoteStreamCpuDataCommand
 func RemoteStreamCpuDataCommand(w *wshutil.WshRpc, opts *wshrpc.RpcOpts) chan wshrpc.RespOrErrorUnion[wshrpc.TimeSeriesData] {
 	return sendRpcRequestResponseStreamHelper[wshrpc.TimeSeriesData](w, "remotestreamcpudata", nil, opts)
@@ -300,9 +652,9 @@ func RemoteStreamFileCommand(w *wshutil.WshRpc, data wshrpc.CommandRemoteStreamF
 }
 
 // command "remotewritefile", wshserver.RemoteWriteFileCommand
-func RemoteWriteFileCommand(w *wshutil.WshRpc, data wshrpc.CommandRemoteWriteFileData, opts *wshrpc.RpcOpts) error {
-	_, err := sendRpcRequestCallHelper[any](w, "remotewritefile", data, opts)
-	return err
+func RemoteWriteFileCommand(w *wshutil.WshRpc, data wshrpc.CommandRemoteWriteFileData, opts *wshrpc.RpcOpts) (wshrpc.CommandRemoteFileWriteRtnData, error) {
+	resp, err := sendRpcRequestCallHelper[wshrpc.CommandRemoteFileWriteRtnData](w, "remotewritefile", data, opts)
+	return resp, err
 }
 
 // command "resolveids", wshserver.ResolveIdsCommand
@@ -323,6 +675,36 @@ func RouteUnannounceCommand(w *wshutil.WshRpc, opts *wshrpc.RpcOpts) error {
 	return err
 }
 
+// command "runmulticommand", wshserver.RunMultiCommandCommand
+func RunMultiCommandCommand(w *wshutil.WshRpc, data wshrpc.CommandRunMultiCommandData, opts *wshrpc.RpcOpts) (wshrpc.CommandRunMultiCommandRtnData, error) {
+	resp, err := sendRpcRequestCallHelper[wshrpc.CommandRunMultiCommandRtnData](w, "runmulticommand", data, opts)
+	return resp, err
+}
+
+// command "runshellcommand", wshserver.RunShellCommandCommand
+func RunShellCommandCommand(w *wshutil.WshRpc, data wshrpc.CommandRunShellCommandData, opts *wshrpc.RpcOpts) (wshrpc.CommandRunShellCommandRtnData, error) {
+	resp, err := sendRpcRequestCallHelper[wshrpc.CommandRunShellCommandRtnData](w, "runshellcommand", data, opts)
+	return resp, err
+}
+
+// command "searchblockfile", wshserver.SearchBlockFileCommand
+func SearchBlockFileCommand(w *wshutil.WshRpc, data wshrpc.CommandSearchBlockFileData, opts *wshrpc.RpcOpts) (wshrpc.CommandSearchBlockFileRtnData, error) {
+	resp, err := sendRpcRequestCallHelper[wshrpc.CommandSearchBlockFileRtnData](w, "searchblockfile", data, opts)
+	return resp, err
+}
+
+// command "searchcmdhistory", wshserver.SearchCmdHistoryCommand
+func SearchCmdHistoryCommand(w *wshutil.WshRpc, data wshrpc.CommandSearchCmdHistoryData, opts *wshrpc.RpcOpts) (wshrpc.CommandSearchCmdHistoryRtnData, error) {
+	resp, err := sendRpcRequestCallHelper[wshrpc.CommandSearchCmdHistoryRtnData](w, "searchcmdhistory", data, opts)
+	return resp, err
+}
+
+// command "setbroadcastgroup", wshserver.SetBroadcastGroupCommand
+func SetBroadcastGroupCommand(w *wshutil.WshRpc, data wshrpc.CommandSetBroadcastGroupData, opts *wshrpc.RpcOpts) error {
+	_, err := sendRpcRequestCallHelper[any](w, "setbroadcastgroup", data, opts)
+	return err
+}
+
 // command "setconfig", wshserver.SetConfigCommand
 func SetConfigCommand(w *wshutil.WshRpc, data wshrpc.MetaSettingsType, opts *wshrpc.RpcOpts) error {
 	_, err := sendRpcRequestCallHelper[any](w, "setconfig", data, opts)
@@ -341,6 +723,24 @@ func SetMetaCommand(w *wshutil.WshRpc, data wshrpc.CommandSetMetaData, opts *wsh
 	return err
 }
 
+// command "setmetabulk", wshserver.SetMetaBulkCommand
+func SetMetaBulkCommand(w *wshutil.WshRpc, data wshrpc.CommandSetMetaBulkData, opts *wshrpc.RpcOpts) error {
+	_, err := sendRpcRequestCallHelper[any](w, "setmetabulk", data, opts)
+	return err
+}
+
+// command "setpluginenabled", wshserver.SetPluginEnabledCommand
+func SetPluginEnabledCommand(w *wshutil.WshRpc, data wshrpc.CommandSetPluginEnabledData, opts *wshrpc.RpcOpts) error {
+	_, err := sendRpcRequestCallHelper[any](w, "setpluginenabled", data, opts)
+	return err
+}
+
+// command "settermrecording", wshserver.SetTermRecordingCommand
+func SetTermRecordingCommand(w *wshutil.WshRpc, data wshrpc.CommandSetTermRecordingData, opts *wshrpc.RpcOpts) error {
+	_, err := sendRpcRequestCallHelper[any](w, "settermrecording", data, opts)
+	return err
+}
+
 // command "setvar", wshserver.SetVarCommand
 func SetVarCommand(w *wshutil.WshRpc, data wshrpc.CommandVarData, opts *wshrpc.RpcOpts) error {
 	_, err := sendRpcRequestCallHelper[any](w, "setvar", data, opts)
@@ -353,6 +753,12 @@ func SetViewCommand(w *wshutil.WshRpc, data wshrpc.CommandBlockSetViewData, opts
 	return err
 }
 
+// command "sharecommandoutput", wshserver.ShareCommandOutputCommand
+func ShareCommandOutputCommand(w *wshutil.WshRpc, data wshrpc.CommandShareCommandOutputData, opts *wshrpc.RpcOpts) (wshrpc.CommandShareCommandOutputRtnData, error) {
+	resp, err := sendRpcRequestCallHelper[wshrpc.CommandShareCommandOutputRtnData](w, "sharecommandoutput", data, opts)
+	return resp, err
+}
+
 // command "streamcpudata", wshserver.StreamCpuDataCommand
 func StreamCpuDataCommand(w *wshutil.WshRpc, data wshrpc.CpuDataRequest, opts *wshrpc.RpcOpts) chan wshrpc.RespOrErrorUnion[wshrpc.TimeSeriesData] {
 	return sendRpcRequestResponseStreamHelper[wshrpc.TimeSeriesData](w, "streamcpudata", data, opts)
@@ -368,6 +774,54 @@ func StreamWaveAiCommand(w *wshutil.WshRpc, data wshrpc.WaveAIStreamRequest, opt
 	return sendRpcRequestResponseStreamHelper[wshrpc.WaveAIPacketType](w, "streamwaveai", data, opts)
 }
 
+// command "sudocacheclear", wshserver.SudoCacheClearCommand
+func SudoCacheClearCommand(w *wshutil.WshRpc, data string, opts *wshrpc.RpcOpts) error {
+	_, err := sendRpcRequestCallHelper[any](w, "sudocacheclear", data, opts)
+	return err
+}
+
+// command "sudocacheset", wshserver.SudoCacheSetCommand
+func SudoCacheSetCommand(w *wshutil.WshRpc, data wshrpc.CommandSudoCacheSetData, opts *wshrpc.RpcOpts) error {
+	_, err := sendRpcRequestCallHelper[any](w, "sudocacheset", data, opts)
+	return err
+}
+
+// command "sudocachestatus", wshserver.SudoCacheStatusCommand
+func SudoCacheStatusCommand(w *wshutil.WshRpc, opts *wshrpc.RpcOpts) ([]wshrpc.SudoCacheStatusEntry, error) {
+	resp, err := sendRpcRequestCallHelper[[]wshrpc.SudoCacheStatusEntry](w, "sudocachestatus", nil, opts)
+	return resp, err
+}
+
+// command "summarize", wshserver.SummarizeCommand
+func SummarizeCommand(w *wshutil.WshRpc, data wshrpc.CommandSummarizeData, opts *wshrpc.RpcOpts) (wshrpc.CommandSummarizeRtnData, error) {
+	resp, err := sendRpcRequestCallHelper[wshrpc.CommandSummarizeRtnData](w, "summarize", data, opts)
+	return resp, err
+}
+
+// command "synchistorypull", wshserver.SyncHistoryPullCommand
+func SyncHistoryPullCommand(w *wshutil.WshRpc, opts *wshrpc.RpcOpts) (int, error) {
+	resp, err := sendRpcRequestCallHelper[int](w, "synchistorypull", nil, opts)
+	return resp, err
+}
+
+// command "synchistorypush", wshserver.SyncHistoryPushCommand
+func SyncHistoryPushCommand(w *wshutil.WshRpc, opts *wshrpc.RpcOpts) (int, error) {
+	resp, err := sendRpcRequestCallHelper[int](w, "synchistorypush", nil, opts)
+	return resp, err
+}
+
+// command "telemetryinspect", wshserver.TelemetryInspectCommand
+func TelemetryInspectCommand(w *wshutil.WshRpc, opts *wshrpc.RpcOpts) (wshrpc.TelemetryInspectData, error) {
+	resp, err := sendRpcRequestCallHelper[wshrpc.TelemetryInspectData](w, "telemetryinspect", nil, opts)
+	return resp, err
+}
+
+// command "termexport", wshserver.TermExportCommand
+func TermExportCommand(w *wshutil.WshRpc, data wshrpc.CommandTermExportData, opts *wshrpc.RpcOpts) (wshrpc.CommandTermExportRtnData, error) {
+	resp, err := sendRpcRequestCallHelper[wshrpc.CommandTermExportRtnData](w, "termexport", data, opts)
+	return resp, err
+}
+
 // command "test", wshserver.TestCommand
 func TestCommand(w *wshutil.WshRpc, data string, opts *wshrpc.RpcOpts) error {
 	_, err := sendRpcRequestCallHelper[any](w, "test", data, opts)
@@ -414,6 +868,30 @@ func WebSelectorCommand(w *wshutil.WshRpc, data wshrpc.CommandWebSelectorData, o
 	return resp, err
 }
 
+// command "websharerevokeall", wshserver.WebShareRevokeAllCommand
+func WebShareRevokeAllCommand(w *wshutil.WshRpc, opts *wshrpc.RpcOpts) (int, error) {
+	resp, err := sendRpcRequestCallHelper[int](w, "websharerevokeall", nil, opts)
+	return resp, err
+}
+
+// command "websharestart", wshserver.WebShareStartCommand
+func WebShareStartCommand(w *wshutil.WshRpc, data wshrpc.CommandWebShareStartData, opts *wshrpc.RpcOpts) (wshrpc.CommandWebShareStatusData, error) {
+	resp, err := sendRpcRequestCallHelper[wshrpc.CommandWebShareStatusData](w, "websharestart", data, opts)
+	return resp, err
+}
+
+// command "websharestatus", wshserver.WebShareStatusCommand
+func WebShareStatusCommand(w *wshutil.WshRpc, data wshrpc.CommandWebShareStopData, opts *wshrpc.RpcOpts) (wshrpc.CommandWebShareStatusData, error) {
+	resp, err := sendRpcRequestCallHelper[wshrpc.CommandWebShareStatusData](w, "websharestatus", data, opts)
+	return resp, err
+}
+
+// command "websharestop", wshserver.WebShareStopCommand
+func WebShareStopCommand(w *wshutil.WshRpc, data wshrpc.CommandWebShareStopData, opts *wshrpc.RpcOpts) error {
+	_, err := sendRpcRequestCallHelper[any](w, "websharestop", data, opts)
+	return err
+}
+
 // command "workspacelist", wshserver.WorkspaceListCommand
 func WorkspaceListCommand(w *wshutil.WshRpc, opts *wshrpc.RpcOpts) ([]wshrpc.WorkspaceInfoData, error) {
 	resp, err := sendRpcRequestCallHelper[[]wshrpc.WorkspaceInfoData](w, "workspacelist", nil, opts)
@@ -443,5 +921,3 @@ func WslStatusCommand(w *wshutil.WshRpc, opts *wshrpc.RpcOpts) ([]wshrpc.ConnSta
 	resp, err := sendRpcRequestCallHelper[[]wshrpc.ConnStatus](w, "wslstatus", nil, opts)
 	return resp, err
 }
-
-
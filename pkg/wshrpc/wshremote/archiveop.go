@@ -0,0 +1,79 @@
+// Copyright 2026, Command Line Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package wshremote
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"os"
+
+	"github.com/wavetermdev/waveterm/pkg/archiveops"
+	"github.com/wavetermdev/waveterm/pkg/wavebase"
+	"github.com/wavetermdev/waveterm/pkg/wshrpc"
+)
+
+// RemoteArchiveListCommand lists the entries of a zip/tar/tgz archive, local or on this
+// connection, without extracting them. See pkg/archiveops.
+func (impl *ServerImpl) RemoteArchiveListCommand(ctx context.Context, path string) ([]wshrpc.ArchiveEntryInfo, error) {
+	cleanedPath := wavebase.ExpandHomeDirSafe(path)
+	finfo, err := os.Stat(cleanedPath)
+	if err != nil {
+		return nil, fmt.Errorf("cannot stat file %q: %w", path, err)
+	}
+	if finfo.Size() > MaxFileSize {
+		return nil, fmt.Errorf("file %q is too large to list", path)
+	}
+	entries, err := archiveops.ListEntries(cleanedPath)
+	if err != nil {
+		return nil, err
+	}
+	rtn := make([]wshrpc.ArchiveEntryInfo, len(entries))
+	for idx, entry := range entries {
+		rtn[idx] = wshrpc.ArchiveEntryInfo{
+			Name:     entry.Name,
+			Size:     entry.Size,
+			IsDir:    entry.IsDir,
+			ModTime:  entry.ModTime,
+			MimeType: entry.MimeType,
+		}
+	}
+	return rtn, nil
+}
+
+// RemoteArchiveReadEntryCommand reads a single entry's content out of a zip/tar/tgz archive for
+// display in the preview/code viewer, without extracting the rest of the archive.
+func (impl *ServerImpl) RemoteArchiveReadEntryCommand(ctx context.Context, data wshrpc.CommandRemoteArchiveReadEntryData) (wshrpc.ArchiveEntryContent, error) {
+	cleanedPath := wavebase.ExpandHomeDirSafe(data.Path)
+	finfo, err := os.Stat(cleanedPath)
+	if err != nil {
+		return wshrpc.ArchiveEntryContent{}, fmt.Errorf("cannot stat file %q: %w", data.Path, err)
+	}
+	if finfo.Size() > MaxFileSize {
+		return wshrpc.ArchiveEntryContent{}, fmt.Errorf("file %q is too large to read", data.Path)
+	}
+	entryData, mimeType, err := archiveops.ReadEntry(cleanedPath, data.EntryName)
+	if err != nil {
+		return wshrpc.ArchiveEntryContent{}, err
+	}
+	return wshrpc.ArchiveEntryContent{
+		Data64:   base64.StdEncoding.EncodeToString(entryData),
+		MimeType: mimeType,
+	}, nil
+}
+
+// RemoteArchiveExtractCommand extracts selected entries of a zip/tar/tgz archive to a
+// destination directory, local or on this connection.
+func (impl *ServerImpl) RemoteArchiveExtractCommand(ctx context.Context, data wshrpc.CommandRemoteArchiveExtractData) error {
+	cleanedPath := wavebase.ExpandHomeDirSafe(data.Path)
+	finfo, err := os.Stat(cleanedPath)
+	if err != nil {
+		return fmt.Errorf("cannot stat file %q: %w", data.Path, err)
+	}
+	if finfo.Size() > MaxFileSize {
+		return fmt.Errorf("file %q is too large to extract", data.Path)
+	}
+	cleanedDestDir := wavebase.ExpandHomeDirSafe(data.DestDir)
+	return archiveops.ExtractEntries(cleanedPath, data.EntryNames, cleanedDestDir)
+}
@@ -0,0 +1,230 @@
+// Copyright 2024, Command Line Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package wshremote
+
+import (
+	"context"
+	"encoding/csv"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/wavetermdev/waveterm/pkg/wavebase"
+	"github.com/wavetermdev/waveterm/pkg/wshrpc"
+)
+
+const dataTableCacheTtl = 30 * time.Second
+
+type dataTableCacheEntry struct {
+	columns   []wshrpc.FileDataColumn
+	rows      [][]string
+	modTime   time.Time
+	fetchTime time.Time
+}
+
+var dataTableCacheLock sync.Mutex
+var dataTableCache = make(map[string]dataTableCacheEntry)
+
+// inferColumnType samples a column's values to guess whether it holds numbers, bools, or
+// freeform strings. An empty column (no non-empty samples) defaults to "string".
+func inferColumnType(rows [][]string, colIdx int) string {
+	sawAny := false
+	allNumber := true
+	allBool := true
+	for _, row := range rows {
+		if colIdx >= len(row) {
+			continue
+		}
+		val := strings.TrimSpace(row[colIdx])
+		if val == "" {
+			continue
+		}
+		sawAny = true
+		if allNumber {
+			if _, err := strconv.ParseFloat(val, 64); err != nil {
+				allNumber = false
+			}
+		}
+		if allBool {
+			lower := strings.ToLower(val)
+			if lower != "true" && lower != "false" {
+				allBool = false
+			}
+		}
+	}
+	if !sawAny {
+		return "string"
+	}
+	if allNumber {
+		return "number"
+	}
+	if allBool {
+		return "bool"
+	}
+	return "string"
+}
+
+func readCsvFile(path string) ([]wshrpc.FileDataColumn, [][]string, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer file.Close()
+	reader := csv.NewReader(file)
+	reader.FieldsPerRecord = -1
+	records, err := reader.ReadAll()
+	if err != nil {
+		return nil, nil, fmt.Errorf("parsing csv: %w", err)
+	}
+	if len(records) == 0 {
+		return nil, nil, nil
+	}
+	header := records[0]
+	rows := records[1:]
+	columns := make([]wshrpc.FileDataColumn, len(header))
+	for i, name := range header {
+		columns[i] = wshrpc.FileDataColumn{Name: name, Type: inferColumnType(rows, i)}
+	}
+	return columns, rows, nil
+}
+
+// loadDataTable reads and parses the file, caching the parsed result (keyed by path and
+// modtime) for dataTableCacheTtl so repeated paging/sorting requests against the same file
+// don't re-parse it on every call.
+func loadDataTable(path string) ([]wshrpc.FileDataColumn, [][]string, error) {
+	ext := strings.ToLower(filepath.Ext(path))
+	if ext == ".parquet" {
+		return nil, nil, fmt.Errorf("parquet files are not supported (no parquet reader dependency is available in this build)")
+	}
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, nil, err
+	}
+	dataTableCacheLock.Lock()
+	entry, ok := dataTableCache[path]
+	dataTableCacheLock.Unlock()
+	if ok && entry.modTime.Equal(info.ModTime()) && time.Since(entry.fetchTime) < dataTableCacheTtl {
+		return entry.columns, entry.rows, nil
+	}
+	columns, rows, err := readCsvFile(path)
+	if err != nil {
+		return nil, nil, err
+	}
+	dataTableCacheLock.Lock()
+	dataTableCache[path] = dataTableCacheEntry{columns: columns, rows: rows, modTime: info.ModTime(), fetchTime: time.Now()}
+	dataTableCacheLock.Unlock()
+	return columns, rows, nil
+}
+
+func colIndex(columns []wshrpc.FileDataColumn, name string) int {
+	for i, col := range columns {
+		if col.Name == name {
+			return i
+		}
+	}
+	return -1
+}
+
+func filterRows(columns []wshrpc.FileDataColumn, rows [][]string, filters map[string]string) [][]string {
+	if len(filters) == 0 {
+		return rows
+	}
+	var filtered [][]string
+	for _, row := range rows {
+		matches := true
+		for colName, needle := range filters {
+			idx := colIndex(columns, colName)
+			if idx < 0 || idx >= len(row) {
+				matches = false
+				break
+			}
+			if !strings.Contains(strings.ToLower(row[idx]), strings.ToLower(needle)) {
+				matches = false
+				break
+			}
+		}
+		if matches {
+			filtered = append(filtered, row)
+		}
+	}
+	return filtered
+}
+
+func sortRows(columns []wshrpc.FileDataColumn, rows [][]string, sortColumn string, sortDesc bool) {
+	idx := colIndex(columns, sortColumn)
+	if idx < 0 {
+		return
+	}
+	isNumber := columns[idx].Type == "number"
+	sort.SliceStable(rows, func(i, j int) bool {
+		a, b := "", ""
+		if idx < len(rows[i]) {
+			a = rows[i][idx]
+		}
+		if idx < len(rows[j]) {
+			b = rows[j][idx]
+		}
+		var less bool
+		if isNumber {
+			aNum, aErr := strconv.ParseFloat(a, 64)
+			bNum, bErr := strconv.ParseFloat(b, 64)
+			if aErr == nil && bErr == nil {
+				less = aNum < bNum
+			} else {
+				less = a < b
+			}
+		} else {
+			less = a < b
+		}
+		if sortDesc {
+			return !less
+		}
+		return less
+	})
+}
+
+// RemoteFileDataTableCommand parses a CSV (or Parquet, when a reader dependency is available)
+// file and returns a single page of rows with server-side sorting and filtering applied, so
+// large files don't need to be shipped to the webview in full.
+func (impl *ServerImpl) RemoteFileDataTableCommand(ctx context.Context, data wshrpc.CommandRemoteFileDataTableData) (wshrpc.FileDataTablePage, error) {
+	path := wavebase.ExpandHomeDirSafe(data.Path)
+	columns, rows, err := loadDataTable(path)
+	if err != nil {
+		return wshrpc.FileDataTablePage{}, err
+	}
+	rows = filterRows(columns, rows, data.Filters)
+	if data.SortColumn != "" {
+		rowsCopy := make([][]string, len(rows))
+		copy(rowsCopy, rows)
+		rows = rowsCopy
+		sortRows(columns, rows, data.SortColumn, data.SortDesc)
+	}
+	totalRows := len(rows)
+	offset := data.Offset
+	if offset < 0 {
+		offset = 0
+	}
+	limit := data.Limit
+	if limit <= 0 {
+		limit = 100
+	}
+	end := offset + limit
+	if offset > totalRows {
+		offset = totalRows
+	}
+	if end > totalRows {
+		end = totalRows
+	}
+	return wshrpc.FileDataTablePage{
+		Columns:   columns,
+		Rows:      rows[offset:end],
+		TotalRows: totalRows,
+		Offset:    offset,
+	}, nil
+}
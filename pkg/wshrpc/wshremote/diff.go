@@ -0,0 +1,68 @@
+// Copyright 2026, Command Line Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package wshremote
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/wavetermdev/waveterm/pkg/diffutil"
+	"github.com/wavetermdev/waveterm/pkg/wshrpc"
+)
+
+func readGitHeadVersion(ctx context.Context, path string) (string, error) {
+	dir := filepath.Dir(path)
+	repoRoot, err := runGitCommand(ctx, dir, "rev-parse", "--show-toplevel")
+	if err != nil {
+		return "", fmt.Errorf("resolving git repo for %q: %w", path, err)
+	}
+	relPath, err := filepath.Rel(repoRoot, path)
+	if err != nil {
+		return "", fmt.Errorf("resolving %q relative to repo root %q: %w", path, repoRoot, err)
+	}
+	content, err := runGitCommand(ctx, dir, "show", "HEAD:"+filepath.ToSlash(relPath))
+	if err != nil {
+		return "", fmt.Errorf("reading HEAD version of %q: %w", path, err)
+	}
+	return content, nil
+}
+
+// RemoteFileDiffCommand computes a line diff between data.Path1 and data.Path2, data.Text2 (a
+// literal text, e.g. an unsaved editor buffer), or (when data.GitHead is set) data.Path1's content
+// at git HEAD. Path1 (and Path2, for that branch) are read on whatever host this command is routed
+// to (local or over a connection), matching how every other Remote* file command resolves paths;
+// Text2 needs no read since it's supplied directly.
+func (impl *ServerImpl) RemoteFileDiffCommand(ctx context.Context, data wshrpc.CommandRemoteFileDiffData) (wshrpc.CommandRemoteFileDiffRtnData, error) {
+	var rtn wshrpc.CommandRemoteFileDiffRtnData
+	leftBytes, err := os.ReadFile(data.Path1)
+	if err != nil {
+		return rtn, fmt.Errorf("reading %q: %w", data.Path1, err)
+	}
+
+	var rightText, label2 string
+	if data.GitHead {
+		rightText, err = readGitHeadVersion(ctx, data.Path1)
+		if err != nil {
+			return rtn, err
+		}
+		label2 = data.Path1 + " (HEAD)"
+	} else if data.Path2 != "" {
+		rightBytes, err := os.ReadFile(data.Path2)
+		if err != nil {
+			return rtn, fmt.Errorf("reading %q: %w", data.Path2, err)
+		}
+		rightText = string(rightBytes)
+		label2 = data.Path2
+	} else {
+		rightText = data.Text2
+		label2 = data.Path1 + " (buffer)"
+	}
+
+	rtn.Label1 = data.Path1
+	rtn.Label2 = label2
+	rtn.Lines = diffutil.Compute(string(leftBytes), rightText)
+	return rtn, nil
+}
@@ -0,0 +1,97 @@
+// Copyright 2026, Command Line Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package wshremote
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/wavetermdev/waveterm/pkg/diskusage"
+	"github.com/wavetermdev/waveterm/pkg/wavebase"
+	"github.com/wavetermdev/waveterm/pkg/wshrpc"
+)
+
+// diskUsageCacheTTL controls how long a completed scan is reused by RemoteDiskUsageCommand before
+// a new request triggers a fresh walk.
+const diskUsageCacheTTL = 5 * time.Minute
+
+// diskUsageProgressInterval throttles how often progress events are sent down the channel.
+const diskUsageProgressInterval = 250 * time.Millisecond
+
+type diskUsageCacheEntry struct {
+	node      *diskusage.Node
+	scannedAt time.Time
+}
+
+var diskUsageCacheMu sync.Mutex
+var diskUsageCache = make(map[string]diskUsageCacheEntry)
+
+func toWireDiskUsageNode(n *diskusage.Node) *wshrpc.DiskUsageNode {
+	if n == nil {
+		return nil
+	}
+	wireNode := &wshrpc.DiskUsageNode{Name: n.Name, Path: n.Path, Size: n.Size, IsDir: n.IsDir}
+	for _, child := range n.Children {
+		wireNode.Children = append(wireNode.Children, toWireDiskUsageNode(child))
+	}
+	return wireNode
+}
+
+// RemoteDiskUsageCommand computes a du-style size-by-subtree breakdown of data.Path, local or on
+// this connection, streaming periodic progress events while the walk is in flight and a final
+// Done event carrying the completed tree (see pkg/diskusage). Completed scans are cached for
+// diskUsageCacheTTL and reused unless data.ForceRescan is set.
+func (impl *ServerImpl) RemoteDiskUsageCommand(ctx context.Context, data wshrpc.CommandRemoteDiskUsageData) chan wshrpc.RespOrErrorUnion[wshrpc.DiskUsageProgressData] {
+	ch := make(chan wshrpc.RespOrErrorUnion[wshrpc.DiskUsageProgressData], 16)
+	cleanedPath := wavebase.ExpandHomeDirSafe(data.Path)
+	go func() {
+		defer close(ch)
+		if !data.ForceRescan {
+			diskUsageCacheMu.Lock()
+			entry, ok := diskUsageCache[cleanedPath]
+			diskUsageCacheMu.Unlock()
+			if ok && time.Since(entry.scannedAt) < diskUsageCacheTTL {
+				ch <- wshrpc.RespOrErrorUnion[wshrpc.DiskUsageProgressData]{Response: wshrpc.DiskUsageProgressData{
+					Path:   cleanedPath,
+					Done:   true,
+					Cached: true,
+					Result: toWireDiskUsageNode(entry.node),
+				}}
+				return
+			}
+		}
+
+		var lastEmit time.Time
+		progress := func(filesScanned int, currentPath string) {
+			if time.Since(lastEmit) < diskUsageProgressInterval {
+				return
+			}
+			lastEmit = time.Now()
+			ch <- wshrpc.RespOrErrorUnion[wshrpc.DiskUsageProgressData]{Response: wshrpc.DiskUsageProgressData{
+				Path:         cleanedPath,
+				FilesScanned: filesScanned,
+				CurrentPath:  currentPath,
+			}}
+		}
+
+		node, err := diskusage.Walk(ctx, cleanedPath, data.Exclude, progress)
+		if err != nil && !errors.Is(err, context.Canceled) {
+			ch <- wshrpc.RespOrErrorUnion[wshrpc.DiskUsageProgressData]{Error: err}
+			return
+		}
+		if err == nil {
+			diskUsageCacheMu.Lock()
+			diskUsageCache[cleanedPath] = diskUsageCacheEntry{node: node, scannedAt: time.Now()}
+			diskUsageCacheMu.Unlock()
+		}
+		ch <- wshrpc.RespOrErrorUnion[wshrpc.DiskUsageProgressData]{Response: wshrpc.DiskUsageProgressData{
+			Path:   cleanedPath,
+			Done:   true,
+			Result: toWireDiskUsageNode(node),
+		}}
+	}()
+	return ch
+}
@@ -0,0 +1,81 @@
+// Copyright 2024, Command Line Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package wshremote
+
+import (
+	"bytes"
+	"context"
+	"os/exec"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/wavetermdev/waveterm/pkg/wavebase"
+	"github.com/wavetermdev/waveterm/pkg/wshrpc"
+)
+
+const gitStatusCacheTtl = 2 * time.Second
+
+type gitStatusCacheEntry struct {
+	info      wshrpc.GitStatusInfo
+	fetchTime time.Time
+}
+
+var gitStatusCacheLock = &sync.Mutex{}
+var gitStatusCache = make(map[string]gitStatusCacheEntry)
+
+func runGitCommand(ctx context.Context, dir string, args ...string) (string, error) {
+	cmd := exec.CommandContext(ctx, "git", args...)
+	cmd.Dir = dir
+	var outBuf bytes.Buffer
+	cmd.Stdout = &outBuf
+	if err := cmd.Run(); err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(outBuf.String()), nil
+}
+
+func computeGitStatus(ctx context.Context, dir string) wshrpc.GitStatusInfo {
+	repoRoot, err := runGitCommand(ctx, dir, "rev-parse", "--show-toplevel")
+	if err != nil {
+		return wshrpc.GitStatusInfo{IsRepo: false}
+	}
+	info := wshrpc.GitStatusInfo{IsRepo: true, RepoRoot: repoRoot}
+	if branch, err := runGitCommand(ctx, dir, "branch", "--show-current"); err == nil {
+		info.Branch = branch
+	}
+	if porcelain, err := runGitCommand(ctx, dir, "status", "--porcelain"); err == nil {
+		info.IsDirty = porcelain != ""
+	}
+	if counts, err := runGitCommand(ctx, dir, "rev-list", "--left-right", "--count", "HEAD...@{upstream}"); err == nil {
+		parts := strings.Fields(counts)
+		if len(parts) == 2 {
+			info.Ahead, _ = strconv.Atoi(parts[0])
+			info.Behind, _ = strconv.Atoi(parts[1])
+		}
+	}
+	return info
+}
+
+// RemoteGitStatusCommand reports branch, dirty state, and ahead/behind counts for the git
+// repository containing path, caching results briefly so repeated prompt/tab queries for the
+// same cwd don't each shell out to git.
+func (impl *ServerImpl) RemoteGitStatusCommand(ctx context.Context, path string) (wshrpc.GitStatusInfo, error) {
+	dir := wavebase.ExpandHomeDirSafe(path)
+
+	gitStatusCacheLock.Lock()
+	entry, ok := gitStatusCache[dir]
+	gitStatusCacheLock.Unlock()
+	if ok && time.Since(entry.fetchTime) < gitStatusCacheTtl {
+		return entry.info, nil
+	}
+
+	info := computeGitStatus(ctx, dir)
+
+	gitStatusCacheLock.Lock()
+	gitStatusCache[dir] = gitStatusCacheEntry{info: info, fetchTime: time.Now()}
+	gitStatusCacheLock.Unlock()
+	return info, nil
+}
@@ -0,0 +1,214 @@
+// Copyright 2024, Command Line Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package wshremote
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/wavetermdev/waveterm/pkg/wavebase"
+	"github.com/wavetermdev/waveterm/pkg/wconfig"
+	"github.com/wavetermdev/waveterm/pkg/wshrpc"
+)
+
+const DefaultGitPrStatusRefreshMs = 30000
+
+var githubRemoteRe = regexp.MustCompile(`github\.com[:/]([^/]+)/([^/.]+?)(\.git)?$`)
+var gitlabRemoteRe = regexp.MustCompile(`gitlab\.com[:/]([^/]+)/([^/.]+?)(\.git)?$`)
+
+// detectRepo parses the "origin" remote URL (as returned by git) to determine which provider
+// (github or gitlab) hosts the repo and its owner/repo slug.
+func detectRepo(ctx context.Context, dir string) (provider string, owner string, repo string, err error) {
+	remoteUrl, err := runGitCommand(ctx, dir, "remote", "get-url", "origin")
+	if err != nil {
+		return "", "", "", fmt.Errorf("no git remote named origin: %w", err)
+	}
+	if m := githubRemoteRe.FindStringSubmatch(remoteUrl); m != nil {
+		return "github", m[1], m[2], nil
+	}
+	if m := gitlabRemoteRe.FindStringSubmatch(remoteUrl); m != nil {
+		return "gitlab", m[1], m[2], nil
+	}
+	return "", "", "", fmt.Errorf("remote %q is not a recognized github/gitlab url", remoteUrl)
+}
+
+func fetchJson(req *http.Request, out any) error {
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("request to %s failed with status %s", req.URL, resp.Status)
+	}
+	return json.NewDecoder(resp.Body).Decode(out)
+}
+
+func fetchGithubPrStatus(ctx context.Context, owner string, repo string, token string) (wshrpc.GitPrStatusData, error) {
+	type ghUser struct {
+		Login string `json:"login"`
+	}
+	type ghHead struct {
+		Ref string `json:"ref"`
+		Sha string `json:"sha"`
+	}
+	type ghPr struct {
+		Number  int    `json:"number"`
+		Title   string `json:"title"`
+		HtmlUrl string `json:"html_url"`
+		User    ghUser `json:"user"`
+		Draft   bool   `json:"draft"`
+		Head    ghHead `json:"head"`
+	}
+	rtn := wshrpc.GitPrStatusData{Provider: "github", Owner: owner, Repo: repo}
+	prUrl := fmt.Sprintf("https://api.github.com/repos/%s/%s/pulls?state=open", url.PathEscape(owner), url.PathEscape(repo))
+	req, err := http.NewRequestWithContext(ctx, "GET", prUrl, nil)
+	if err != nil {
+		return rtn, err
+	}
+	req.Header.Set("Accept", "application/vnd.github+json")
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	var prs []ghPr
+	if err := fetchJson(req, &prs); err != nil {
+		return rtn, err
+	}
+	for _, pr := range prs {
+		info := wshrpc.GitPrInfo{
+			Number:     pr.Number,
+			Title:      pr.Title,
+			Url:        pr.HtmlUrl,
+			Author:     pr.User.Login,
+			Draft:      pr.Draft,
+			HeadBranch: pr.Head.Ref,
+		}
+		if pr.Head.Sha != "" {
+			info.CiStatus = fetchGithubCombinedStatus(ctx, owner, repo, pr.Head.Sha, token)
+		}
+		rtn.PRs = append(rtn.PRs, info)
+	}
+	return rtn, nil
+}
+
+func fetchGithubCombinedStatus(ctx context.Context, owner string, repo string, sha string, token string) string {
+	type ghCombinedStatus struct {
+		State string `json:"state"` // "success", "failure", "pending"
+	}
+	statusUrl := fmt.Sprintf("https://api.github.com/repos/%s/%s/commits/%s/status", url.PathEscape(owner), url.PathEscape(repo), url.PathEscape(sha))
+	req, err := http.NewRequestWithContext(ctx, "GET", statusUrl, nil)
+	if err != nil {
+		return ""
+	}
+	req.Header.Set("Accept", "application/vnd.github+json")
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	var status ghCombinedStatus
+	if err := fetchJson(req, &status); err != nil {
+		return ""
+	}
+	return status.State
+}
+
+func fetchGitlabPrStatus(ctx context.Context, owner string, repo string, token string, baseUrl string) (wshrpc.GitPrStatusData, error) {
+	type glAuthor struct {
+		Username string `json:"username"`
+	}
+	type glPipeline struct {
+		Status string `json:"status"` // "success", "failed", "running", "pending"
+	}
+	type glMr struct {
+		Iid          int        `json:"iid"`
+		Title        string     `json:"title"`
+		WebUrl       string     `json:"web_url"`
+		Author       glAuthor   `json:"author"`
+		Draft        bool       `json:"draft"`
+		SourceBranch string     `json:"source_branch"`
+		HeadPipeline glPipeline `json:"head_pipeline"`
+	}
+	rtn := wshrpc.GitPrStatusData{Provider: "gitlab", Owner: owner, Repo: repo}
+	if baseUrl == "" {
+		baseUrl = "https://gitlab.com"
+	}
+	projectId := url.PathEscape(owner + "/" + repo)
+	mrUrl := fmt.Sprintf("%s/api/v4/projects/%s/merge_requests?state=opened", strings.TrimRight(baseUrl, "/"), projectId)
+	req, err := http.NewRequestWithContext(ctx, "GET", mrUrl, nil)
+	if err != nil {
+		return rtn, err
+	}
+	if token != "" {
+		req.Header.Set("PRIVATE-TOKEN", token)
+	}
+	var mrs []glMr
+	if err := fetchJson(req, &mrs); err != nil {
+		return rtn, err
+	}
+	for _, mr := range mrs {
+		rtn.PRs = append(rtn.PRs, wshrpc.GitPrInfo{
+			Number:     mr.Iid,
+			Title:      mr.Title,
+			Url:        mr.WebUrl,
+			Author:     mr.Author.Username,
+			Draft:      mr.Draft,
+			HeadBranch: mr.SourceBranch,
+			CiStatus:   mr.HeadPipeline.Status,
+		})
+	}
+	return rtn, nil
+}
+
+func fetchGitPrStatus(ctx context.Context, dir string) (wshrpc.GitPrStatusData, error) {
+	provider, owner, repo, err := detectRepo(ctx, dir)
+	if err != nil {
+		return wshrpc.GitPrStatusData{}, err
+	}
+	settings := wconfig.GetWatcher().GetFullConfig().Settings
+	var data wshrpc.GitPrStatusData
+	if provider == "github" {
+		data, err = fetchGithubPrStatus(ctx, owner, repo, settings.GitHubToken)
+	} else {
+		data, err = fetchGitlabPrStatus(ctx, owner, repo, settings.GitLabToken, settings.GitLabBaseURL)
+	}
+	if err != nil {
+		return wshrpc.GitPrStatusData{}, err
+	}
+	data.UpdatedTs = time.Now().UnixMilli()
+	return data, nil
+}
+
+// RemoteGitPrStatusCommand polls the repo's provider (GitHub or GitLab, detected from the origin
+// remote) for open PR/MR and CI status every RefreshInterval, streaming updates to the caller
+// (e.g. a dashboard block) until the context is cancelled.
+func (impl *ServerImpl) RemoteGitPrStatusCommand(ctx context.Context, data wshrpc.CommandRemoteGitPrStatusData) chan wshrpc.RespOrErrorUnion[wshrpc.GitPrStatusData] {
+	ch := make(chan wshrpc.RespOrErrorUnion[wshrpc.GitPrStatusData], 16)
+	refreshMs := data.RefreshInterval
+	if refreshMs <= 0 {
+		refreshMs = DefaultGitPrStatusRefreshMs
+	}
+	dir := wavebase.ExpandHomeDirSafe(data.Path)
+	go func() {
+		defer close(ch)
+		for {
+			status, err := fetchGitPrStatus(ctx, dir)
+			if err != nil {
+				ch <- wshrpc.RespOrErrorUnion[wshrpc.GitPrStatusData]{Error: err}
+			} else {
+				ch <- wshrpc.RespOrErrorUnion[wshrpc.GitPrStatusData]{Response: status}
+			}
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(time.Duration(refreshMs) * time.Millisecond):
+			}
+		}
+	}()
+	return ch
+}
@@ -0,0 +1,98 @@
+// Copyright 2026, Command Line Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package wshremote
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptrace"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/wavetermdev/waveterm/pkg/wconfig"
+	"github.com/wavetermdev/waveterm/pkg/wshrpc"
+)
+
+const maxHttpResponseBody = 10 * 1024 * 1024 // 10M
+
+var secretTokenRe = regexp.MustCompile(`\{\{(\w+)\}\}`)
+
+// interpolateSecrets replaces {{name}} tokens with the value of the named entry in
+// httpsecrets.json (see wconfig.FullConfigType.HttpSecrets). A token with no matching secret
+// is left as-is rather than silently resolving to an empty string, so a typo'd name is visible
+// in the outgoing request instead of failing quietly.
+func interpolateSecrets(s string, secrets map[string]string) string {
+	return secretTokenRe.ReplaceAllStringFunc(s, func(token string) string {
+		name := secretTokenRe.FindStringSubmatch(token)[1]
+		if val, ok := secrets[name]; ok {
+			return val
+		}
+		return token
+	})
+}
+
+// RemoteHttpRequestCommand executes an HTTP request from wherever this ServerImpl is running
+// (the local machine, or a remote connection's wsh helper), interpolating {{secretname}} tokens
+// in the URL, header values, and body against httpsecrets.json, and returns the response along
+// with a basic DNS/connect/TLS/TTFB timing breakdown.
+func (impl *ServerImpl) RemoteHttpRequestCommand(ctx context.Context, data wshrpc.CommandRemoteHttpRequestData) (wshrpc.HttpResponseData, error) {
+	secrets := wconfig.GetWatcher().GetFullConfig().HttpSecrets
+	method := data.Method
+	if method == "" {
+		method = "GET"
+	}
+	url := interpolateSecrets(data.Url, secrets)
+	body := interpolateSecrets(data.Body, secrets)
+
+	var timing wshrpc.HttpTimingData
+	var dnsStart, connectStart, tlsStart, reqStart time.Time
+	reqStart = time.Now()
+	trace := &httptrace.ClientTrace{
+		DNSStart:          func(httptrace.DNSStartInfo) { dnsStart = time.Now() },
+		DNSDone:           func(httptrace.DNSDoneInfo) { timing.DnsMs = time.Since(dnsStart).Milliseconds() },
+		ConnectStart:      func(string, string) { connectStart = time.Now() },
+		ConnectDone:       func(string, string, error) { timing.ConnectMs = time.Since(connectStart).Milliseconds() },
+		TLSHandshakeStart: func() { tlsStart = time.Now() },
+		TLSHandshakeDone: func(tls.ConnectionState, error) {
+			timing.TlsMs = time.Since(tlsStart).Milliseconds()
+		},
+		GotFirstResponseByte: func() { timing.TtfbMs = time.Since(reqStart).Milliseconds() },
+	}
+
+	req, err := http.NewRequestWithContext(httptrace.WithClientTrace(ctx, trace), method, url, strings.NewReader(body))
+	if err != nil {
+		return wshrpc.HttpResponseData{}, fmt.Errorf("error building request: %w", err)
+	}
+	for k, v := range data.Headers {
+		req.Header.Set(k, interpolateSecrets(v, secrets))
+	}
+
+	client := &http.Client{}
+	resp, err := client.Do(req)
+	if err != nil {
+		return wshrpc.HttpResponseData{}, fmt.Errorf("error executing request: %w", err)
+	}
+	defer resp.Body.Close()
+	respBody, err := io.ReadAll(io.LimitReader(resp.Body, maxHttpResponseBody))
+	if err != nil {
+		return wshrpc.HttpResponseData{}, fmt.Errorf("error reading response body: %w", err)
+	}
+	timing.TotalMs = time.Since(reqStart).Milliseconds()
+
+	respHeaders := make(map[string]string, len(resp.Header))
+	for k := range resp.Header {
+		respHeaders[k] = resp.Header.Get(k)
+	}
+	return wshrpc.HttpResponseData{
+		StatusCode: resp.StatusCode,
+		Status:     resp.Status,
+		Headers:    respHeaders,
+		Body:       string(respBody),
+		Timing:     timing,
+	}, nil
+}
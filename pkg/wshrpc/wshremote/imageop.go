@@ -0,0 +1,49 @@
+// Copyright 2026, Command Line Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package wshremote
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"os"
+
+	"github.com/wavetermdev/waveterm/pkg/imageops"
+	"github.com/wavetermdev/waveterm/pkg/wavebase"
+	"github.com/wavetermdev/waveterm/pkg/wshrpc"
+)
+
+// RemoteImageOpCommand applies the image preview block's edit-toolbar actions (resize, rotate,
+// format conversion, EXIF strip) to an image file, local or on this connection. See
+// pkg/imageops for the pure-Go (no native imaging dependency) implementation.
+func (impl *ServerImpl) RemoteImageOpCommand(ctx context.Context, data wshrpc.CommandRemoteImageOpData) (wshrpc.ImageOpResult, error) {
+	cleanedPath := wavebase.ExpandHomeDirSafe(data.Path)
+	finfo, err := os.Stat(cleanedPath)
+	if err != nil {
+		return wshrpc.ImageOpResult{}, fmt.Errorf("cannot stat file %q: %w", data.Path, err)
+	}
+	if finfo.Size() > MaxFileSize {
+		return wshrpc.ImageOpResult{}, fmt.Errorf("file %q is too large to process", data.Path)
+	}
+	srcData, err := os.ReadFile(cleanedPath)
+	if err != nil {
+		return wshrpc.ImageOpResult{}, fmt.Errorf("cannot read file %q: %w", data.Path, err)
+	}
+
+	outData, mimeType, err := imageops.ProcessImage(srcData, imageops.Options{
+		RotateDegrees: data.RotateDegrees,
+		Width:         data.Width,
+		Height:        data.Height,
+		Format:        data.Format,
+		Quality:       data.Quality,
+	})
+	if err != nil {
+		return wshrpc.ImageOpResult{}, err
+	}
+
+	return wshrpc.ImageOpResult{
+		Data64:   base64.StdEncoding.EncodeToString(outData),
+		MimeType: mimeType,
+	}, nil
+}
@@ -0,0 +1,177 @@
+// Copyright 2026, Command Line Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package wshremote
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os/exec"
+	"sync"
+
+	"github.com/wavetermdev/waveterm/pkg/wshrpc"
+)
+
+// jupyterDriverScript is a small persistent Python REPL driver that this package talks to
+// over stdin/stdout instead of the real Jupyter wire protocol (ZeroMQ + HMAC-signed
+// multipart messages): no ZeroMQ client library is vendored in this build, so a true
+// "attach to a running Jupyter kernel" is out of scope. What's implemented here still gets
+// the observable behavior the block needs -- a persistent namespace, streamed stdout/stderr,
+// a result value, and basic rich output (an object's _repr_html_, if any) -- by exec'ing each
+// cell in a long-lived interpreter and reporting back as one JSON object per line of stdout.
+const jupyterDriverScript = `
+import sys, json, io, ast, traceback
+
+_real_stdout = sys.stdout
+_ns = {}
+
+def _emit(msg):
+    _real_stdout.write(json.dumps(msg) + "\n")
+    _real_stdout.flush()
+
+for _line in sys.stdin:
+    _line = _line.strip()
+    if not _line:
+        continue
+    try:
+        _req = json.loads(_line)
+    except Exception:
+        continue
+    _code = _req.get("code", "")
+    _buf = io.StringIO()
+    sys.stdout = _buf
+    sys.stderr = _buf
+    _result = None
+    _has_result = False
+    _err = None
+    try:
+        _parsed = ast.parse(_code, mode="exec")
+        if _parsed.body and isinstance(_parsed.body[-1], ast.Expr):
+            _last = _parsed.body.pop()
+            exec(compile(_parsed, "<cell>", "exec"), _ns)
+            _expr = ast.fix_missing_locations(ast.Expression(_last.value))
+            _result = eval(compile(_expr, "<cell>", "eval"), _ns)
+            _has_result = True
+        else:
+            exec(compile(_parsed, "<cell>", "exec"), _ns)
+    except Exception:
+        _err = traceback.format_exc()
+    sys.stdout = _real_stdout
+    sys.stderr = sys.__stderr__
+    _stream = _buf.getvalue()
+    if _stream:
+        _emit({"type": "stream", "mimetype": "text/plain", "data": _stream})
+    if _err is not None:
+        _emit({"type": "error", "data": _err})
+    elif _has_result and _result is not None:
+        _mime = "text/plain"
+        _data = repr(_result)
+        _repr_html = getattr(_result, "_repr_html_", None)
+        if callable(_repr_html):
+            try:
+                _html = _repr_html()
+                if _html:
+                    _mime = "text/html"
+                    _data = _html
+            except Exception:
+                pass
+        _emit({"type": "result", "mimetype": _mime, "data": _data})
+    _emit({"type": "done"})
+`
+
+type jupyterKernelProc struct {
+	cmd    *exec.Cmd
+	stdin  io.WriteCloser
+	stdout *bufio.Scanner
+	lock   sync.Mutex // serializes cell execution against this kernel's single stdin/stdout stream
+}
+
+var jupyterKernelsLock sync.Mutex
+var jupyterKernels = make(map[string]*jupyterKernelProc)
+
+func getOrStartJupyterKernel(kernelId string) (*jupyterKernelProc, error) {
+	jupyterKernelsLock.Lock()
+	defer jupyterKernelsLock.Unlock()
+	if proc, ok := jupyterKernels[kernelId]; ok {
+		return proc, nil
+	}
+	cmd := exec.Command("python3", "-u", "-c", jupyterDriverScript)
+	stdin, err := cmd.StdinPipe()
+	if err != nil {
+		return nil, fmt.Errorf("error creating kernel stdin pipe: %w", err)
+	}
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return nil, fmt.Errorf("error creating kernel stdout pipe: %w", err)
+	}
+	if err := cmd.Start(); err != nil {
+		return nil, fmt.Errorf("error starting python3 kernel (is python3 installed?): %w", err)
+	}
+	scanner := bufio.NewScanner(stdout)
+	scanner.Buffer(make([]byte, 64*1024), 16*1024*1024)
+	proc := &jupyterKernelProc{cmd: cmd, stdin: stdin, stdout: scanner}
+	jupyterKernels[kernelId] = proc
+	return proc, nil
+}
+
+// RemoteJupyterKernelExecuteCommand runs one code cell against the persistent kernel process
+// for data.KernelId (starting it if needed), streaming each output message as it's produced.
+// The channel closes after a "done" message or an error.
+func (impl *ServerImpl) RemoteJupyterKernelExecuteCommand(ctx context.Context, data wshrpc.CommandRemoteJupyterKernelExecuteData) chan wshrpc.RespOrErrorUnion[wshrpc.JupyterOutputData] {
+	ch := make(chan wshrpc.RespOrErrorUnion[wshrpc.JupyterOutputData], 16)
+	go func() {
+		defer close(ch)
+		proc, err := getOrStartJupyterKernel(data.KernelId)
+		if err != nil {
+			ch <- wshrpc.RespOrErrorUnion[wshrpc.JupyterOutputData]{Error: err}
+			return
+		}
+		proc.lock.Lock()
+		defer proc.lock.Unlock()
+		reqBytes, err := json.Marshal(map[string]string{"code": data.Code})
+		if err != nil {
+			ch <- wshrpc.RespOrErrorUnion[wshrpc.JupyterOutputData]{Error: err}
+			return
+		}
+		if _, err := proc.stdin.Write(append(reqBytes, '\n')); err != nil {
+			ch <- wshrpc.RespOrErrorUnion[wshrpc.JupyterOutputData]{Error: fmt.Errorf("error writing to kernel: %w", err)}
+			return
+		}
+		for proc.stdout.Scan() {
+			if ctx.Err() != nil {
+				return
+			}
+			var out wshrpc.JupyterOutputData
+			if err := json.Unmarshal(proc.stdout.Bytes(), &out); err != nil {
+				continue
+			}
+			ch <- wshrpc.RespOrErrorUnion[wshrpc.JupyterOutputData]{Response: out}
+			if out.Type == "done" {
+				return
+			}
+		}
+		if err := proc.stdout.Err(); err != nil {
+			ch <- wshrpc.RespOrErrorUnion[wshrpc.JupyterOutputData]{Error: fmt.Errorf("error reading from kernel: %w", err)}
+		}
+	}()
+	return ch
+}
+
+// RemoteJupyterKernelStopCommand terminates the persistent kernel process for kernelId, if
+// one is running. It is a no-op if no such kernel exists.
+func (impl *ServerImpl) RemoteJupyterKernelStopCommand(ctx context.Context, kernelId string) error {
+	jupyterKernelsLock.Lock()
+	proc, ok := jupyterKernels[kernelId]
+	if ok {
+		delete(jupyterKernels, kernelId)
+	}
+	jupyterKernelsLock.Unlock()
+	if !ok {
+		return nil
+	}
+	proc.stdin.Close()
+	return proc.cmd.Process.Kill()
+}
@@ -0,0 +1,52 @@
+// Copyright 2024, Command Line Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package wshremote
+
+import (
+	"context"
+	"fmt"
+
+	gnet "github.com/shirou/gopsutil/v4/net"
+	"github.com/shirou/gopsutil/v4/process"
+	"github.com/wavetermdev/waveterm/pkg/wshrpc"
+)
+
+func (impl *ServerImpl) RemoteListListenersCommand(ctx context.Context) ([]wshrpc.ListenerInfo, error) {
+	conns, err := gnet.ConnectionsWithContext(ctx, "inet")
+	if err != nil {
+		return nil, fmt.Errorf("cannot list listeners: %w", err)
+	}
+	procNames := make(map[int32]string)
+	rtn := make([]wshrpc.ListenerInfo, 0)
+	for _, conn := range conns {
+		if conn.Status != "LISTEN" && conn.Status != "NONE" {
+			continue
+		}
+		if conn.Status == "NONE" && conn.Type != 2 /* SOCK_DGRAM */ {
+			continue
+		}
+		protocol := "tcp"
+		if conn.Type == 2 {
+			protocol = "udp"
+		}
+		info := wshrpc.ListenerInfo{
+			Pid:       conn.Pid,
+			Protocol:  protocol,
+			LocalAddr: conn.Laddr.IP,
+			Port:      int32(conn.Laddr.Port),
+		}
+		if conn.Pid > 0 {
+			if name, ok := procNames[conn.Pid]; ok {
+				info.ProcessName = name
+			} else if proc, err := process.NewProcessWithContext(ctx, conn.Pid); err == nil {
+				if name, err := proc.NameWithContext(ctx); err == nil {
+					info.ProcessName = name
+					procNames[conn.Pid] = name
+				}
+			}
+		}
+		rtn = append(rtn, info)
+	}
+	return rtn, nil
+}
@@ -0,0 +1,111 @@
+// Copyright 2024, Command Line Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package wshremote
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/wavetermdev/waveterm/pkg/wavebase"
+	"github.com/wavetermdev/waveterm/pkg/wshrpc"
+)
+
+const logTailPollInterval = 500 * time.Millisecond
+
+var logLevelRe = regexp.MustCompile(`(?i)\b(error|warn(?:ing)?|info|debug|trace)\b`)
+
+// detectLogLevel makes a best-effort guess at a line's log level by looking for a
+// recognized level keyword anywhere in the line. Returns "" if none is found.
+func detectLogLevel(line string) string {
+	m := logLevelRe.FindStringSubmatch(line)
+	if m == nil {
+		return ""
+	}
+	level := strings.ToLower(m[1])
+	if strings.HasPrefix(level, "warn") {
+		return "warn"
+	}
+	return level
+}
+
+// extractJsonFields attempts to parse the line as a JSON object, flattening its top-level
+// values to strings. Returns nil if the line is not a JSON object.
+func extractJsonFields(line string) map[string]string {
+	var obj map[string]any
+	if err := json.Unmarshal([]byte(line), &obj); err != nil {
+		return nil
+	}
+	fields := make(map[string]string, len(obj))
+	for k, v := range obj {
+		if s, ok := v.(string); ok {
+			fields[k] = s
+		} else {
+			b, err := json.Marshal(v)
+			if err == nil {
+				fields[k] = string(b)
+			}
+		}
+	}
+	return fields
+}
+
+func emitLogLine(ch chan wshrpc.RespOrErrorUnion[wshrpc.LogLineData], line string, data wshrpc.CommandRemoteLogTailData) {
+	if line == "" {
+		return
+	}
+	if data.Filter != "" && !strings.Contains(strings.ToLower(line), strings.ToLower(data.Filter)) {
+		return
+	}
+	logLine := wshrpc.LogLineData{Line: line, Level: detectLogLevel(line)}
+	if data.JsonMode {
+		logLine.Fields = extractJsonFields(line)
+	}
+	ch <- wshrpc.RespOrErrorUnion[wshrpc.LogLineData]{Response: logLine}
+}
+
+// RemoteLogTailCommand reads a log file line-by-line, applying server-side level detection,
+// JSON field extraction, and filtering so only matching lines are shipped to the caller. When
+// Follow is set it keeps polling for newly appended lines until the context is cancelled.
+func (impl *ServerImpl) RemoteLogTailCommand(ctx context.Context, data wshrpc.CommandRemoteLogTailData) chan wshrpc.RespOrErrorUnion[wshrpc.LogLineData] {
+	ch := make(chan wshrpc.RespOrErrorUnion[wshrpc.LogLineData], 16)
+	go func() {
+		defer close(ch)
+		path := wavebase.ExpandHomeDirSafe(data.Source)
+		file, err := os.Open(path)
+		if err != nil {
+			ch <- wshrpc.RespOrErrorUnion[wshrpc.LogLineData]{Error: fmt.Errorf("opening log file: %w", err)}
+			return
+		}
+		defer file.Close()
+		reader := bufio.NewReader(file)
+		for {
+			line, err := reader.ReadString('\n')
+			if len(line) > 0 {
+				emitLogLine(ch, strings.TrimRight(line, "\r\n"), data)
+			}
+			if err != nil {
+				if err != io.EOF {
+					ch <- wshrpc.RespOrErrorUnion[wshrpc.LogLineData]{Error: err}
+					return
+				}
+				if !data.Follow {
+					return
+				}
+				select {
+				case <-ctx.Done():
+					return
+				case <-time.After(logTailPollInterval):
+				}
+			}
+		}
+	}()
+	return ch
+}
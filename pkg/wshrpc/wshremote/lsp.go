@@ -0,0 +1,94 @@
+// Copyright 2026, Command Line Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package wshremote
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/wavetermdev/waveterm/pkg/lspproxy"
+	"github.com/wavetermdev/waveterm/pkg/wshrpc"
+)
+
+var lspServersLock sync.Mutex
+var lspServers = make(map[string]*lspproxy.Server) // blockId -> running language server
+
+// getOrStartLspServer returns the running language server for blockId, launching command and
+// performing the LSP initialize handshake against rootUri if one isn't already running. Requests
+// for the same blockId with a different command/rootUri reuse the existing server -- callers are
+// expected to stop it first (RemoteLspStopCommand) if the codeedit block's file moves to a
+// different language or project.
+func getOrStartLspServer(blockId string, command string, rootUri string) (*lspproxy.Server, error) {
+	lspServersLock.Lock()
+	defer lspServersLock.Unlock()
+	if server, ok := lspServers[blockId]; ok {
+		return server, nil
+	}
+	server, err := lspproxy.Start(command)
+	if err != nil {
+		return nil, err
+	}
+	initParams := map[string]any{
+		"processId": nil,
+		"rootUri":   rootUri,
+		"capabilities": map[string]any{
+			"textDocument": map[string]any{
+				"completion": map[string]any{},
+				"hover":      map[string]any{},
+			},
+		},
+	}
+	if _, err := server.Request("initialize", initParams); err != nil {
+		server.Close()
+		return nil, fmt.Errorf("lsp initialize failed for %q: %w", command, err)
+	}
+	if err := server.Notify("initialized", map[string]any{}); err != nil {
+		server.Close()
+		return nil, fmt.Errorf("lsp initialized notification failed for %q: %w", command, err)
+	}
+	lspServers[blockId] = server
+	return server, nil
+}
+
+// RemoteLspRequestCommand proxies a JSON-RPC request to data.BlockId's language server, starting
+// it (and running the LSP initialize handshake) on first use.
+func (impl *ServerImpl) RemoteLspRequestCommand(ctx context.Context, data wshrpc.CommandRemoteLspRequestData) (wshrpc.CommandRemoteLspRequestRtnData, error) {
+	var rtn wshrpc.CommandRemoteLspRequestRtnData
+	server, err := getOrStartLspServer(data.BlockId, data.Command, data.RootUri)
+	if err != nil {
+		return rtn, err
+	}
+	result, err := server.Request(data.Method, data.Params)
+	if err != nil {
+		return rtn, err
+	}
+	rtn.Result = result
+	return rtn, nil
+}
+
+// RemoteLspNotifyCommand proxies a JSON-RPC notification to data.BlockId's language server, which
+// must already be running.
+func (impl *ServerImpl) RemoteLspNotifyCommand(ctx context.Context, data wshrpc.CommandRemoteLspNotifyData) error {
+	lspServersLock.Lock()
+	server, ok := lspServers[data.BlockId]
+	lspServersLock.Unlock()
+	if !ok {
+		return fmt.Errorf("no running lsp server for block %q", data.BlockId)
+	}
+	return server.Notify(data.Method, data.Params)
+}
+
+// RemoteLspStopCommand shuts down blockId's language server, if one is running. Called when a
+// codeedit block closes or switches to a file that the running server can no longer serve.
+func (impl *ServerImpl) RemoteLspStopCommand(ctx context.Context, blockId string) error {
+	lspServersLock.Lock()
+	server, ok := lspServers[blockId]
+	delete(lspServers, blockId)
+	lspServersLock.Unlock()
+	if !ok {
+		return nil
+	}
+	return server.Close()
+}
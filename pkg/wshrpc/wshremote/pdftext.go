@@ -0,0 +1,33 @@
+// Copyright 2026, Command Line Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package wshremote
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/wavetermdev/waveterm/pkg/pdftext"
+	"github.com/wavetermdev/waveterm/pkg/wavebase"
+)
+
+// RemotePdfTextCommand extracts the text content of a PDF at path (local or on this connection,
+// wherever this ServerImpl is running) for use by in-document search and, eventually, the AI
+// attachment path's text-context fallback. See pkg/pdftext for the extraction approach and its
+// limitations.
+func (impl *ServerImpl) RemotePdfTextCommand(ctx context.Context, path string) (string, error) {
+	cleanedPath := wavebase.ExpandHomeDirSafe(path)
+	finfo, err := os.Stat(cleanedPath)
+	if err != nil {
+		return "", fmt.Errorf("cannot stat file %q: %w", path, err)
+	}
+	if finfo.Size() > MaxFileSize {
+		return "", fmt.Errorf("file %q is too large to extract text from", path)
+	}
+	data, err := os.ReadFile(cleanedPath)
+	if err != nil {
+		return "", fmt.Errorf("cannot read file %q: %w", path, err)
+	}
+	return pdftext.ExtractText(data, pdftext.MaxExtractedTextBytes)
+}
@@ -0,0 +1,37 @@
+// Copyright 2024, Command Line Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+//go:build !windows
+
+package wshremote
+
+import (
+	"fmt"
+	"syscall"
+
+	"golang.org/x/sys/unix"
+)
+
+var signalNameMap = map[string]syscall.Signal{
+	"SIGHUP":  syscall.SIGHUP,
+	"SIGINT":  syscall.SIGINT,
+	"SIGQUIT": syscall.SIGQUIT,
+	"SIGKILL": syscall.SIGKILL,
+	"SIGTERM": syscall.SIGTERM,
+	"SIGUSR1": syscall.SIGUSR1,
+	"SIGUSR2": syscall.SIGUSR2,
+	"SIGSTOP": syscall.SIGSTOP,
+	"SIGCONT": syscall.SIGCONT,
+}
+
+func signalForName(name string) (syscall.Signal, error) {
+	sig, ok := signalNameMap[name]
+	if !ok {
+		return 0, fmt.Errorf("unsupported signal %q", name)
+	}
+	return sig, nil
+}
+
+func reniceProcess(pid int32, priority int) error {
+	return unix.Setpriority(unix.PRIO_PROCESS, int(pid), priority)
+}
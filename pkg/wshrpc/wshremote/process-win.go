@@ -0,0 +1,28 @@
+// Copyright 2024, Command Line Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+//go:build windows
+
+package wshremote
+
+import (
+	"fmt"
+	"syscall"
+)
+
+var signalNameMap = map[string]syscall.Signal{
+	"SIGKILL": syscall.SIGKILL,
+	"SIGTERM": syscall.SIGTERM,
+}
+
+func signalForName(name string) (syscall.Signal, error) {
+	sig, ok := signalNameMap[name]
+	if !ok {
+		return 0, fmt.Errorf("unsupported signal %q", name)
+	}
+	return sig, nil
+}
+
+func reniceProcess(pid int32, priority int) error {
+	return fmt.Errorf("renice is not supported on windows")
+}
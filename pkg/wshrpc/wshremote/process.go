@@ -0,0 +1,77 @@
+// Copyright 2024, Command Line Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package wshremote
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/shirou/gopsutil/v4/process"
+	"github.com/wavetermdev/waveterm/pkg/wshrpc"
+)
+
+func (impl *ServerImpl) RemoteListProcessesCommand(ctx context.Context) ([]wshrpc.ProcessInfo, error) {
+	procs, err := process.ProcessesWithContext(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("cannot list processes: %w", err)
+	}
+	rtn := make([]wshrpc.ProcessInfo, 0, len(procs))
+	for _, proc := range procs {
+		info := wshrpc.ProcessInfo{Pid: proc.Pid}
+		if name, err := proc.NameWithContext(ctx); err == nil {
+			info.Name = name
+		}
+		if ppid, err := proc.PpidWithContext(ctx); err == nil {
+			info.Ppid = ppid
+		}
+		if cmdline, err := proc.CmdlineWithContext(ctx); err == nil {
+			info.Cmdline = cmdline
+		}
+		if username, err := proc.UsernameWithContext(ctx); err == nil {
+			info.Username = username
+		}
+		if statuses, err := proc.StatusWithContext(ctx); err == nil && len(statuses) > 0 {
+			info.Status = statuses[0]
+		}
+		if nice, err := proc.NiceWithContext(ctx); err == nil {
+			info.Nice = nice
+		}
+		if cpuPercent, err := proc.CPUPercentWithContext(ctx); err == nil {
+			info.CpuPercent = cpuPercent
+		}
+		if memPercent, err := proc.MemoryPercentWithContext(ctx); err == nil {
+			info.MemPercent = memPercent
+		}
+		if memInfo, err := proc.MemoryInfoWithContext(ctx); err == nil && memInfo != nil {
+			info.MemRssKb = memInfo.RSS / 1024
+		}
+		if createTime, err := proc.CreateTimeWithContext(ctx); err == nil {
+			info.CreateTime = createTime
+		}
+		rtn = append(rtn, info)
+	}
+	return rtn, nil
+}
+
+func (impl *ServerImpl) RemoteProcessSignalCommand(ctx context.Context, data wshrpc.CommandRemoteProcessSignalData) error {
+	sig, err := signalForName(data.Signal)
+	if err != nil {
+		return err
+	}
+	proc, err := process.NewProcessWithContext(ctx, data.Pid)
+	if err != nil {
+		return fmt.Errorf("cannot find process %d: %w", data.Pid, err)
+	}
+	if err := proc.SendSignalWithContext(ctx, sig); err != nil {
+		return fmt.Errorf("cannot send %s to process %d: %w", data.Signal, data.Pid, err)
+	}
+	return nil
+}
+
+func (impl *ServerImpl) RemoteProcessReniceCommand(ctx context.Context, data wshrpc.CommandRemoteProcessReniceData) error {
+	if err := reniceProcess(data.Pid, int(data.Priority)); err != nil {
+		return fmt.Errorf("cannot renice process %d: %w", data.Pid, err)
+	}
+	return nil
+}
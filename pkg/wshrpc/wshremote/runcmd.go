@@ -0,0 +1,44 @@
+// Copyright 2026, Command Line Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package wshremote
+
+import (
+	"context"
+	"os/exec"
+	"time"
+
+	"github.com/wavetermdev/waveterm/pkg/shellexec"
+	"github.com/wavetermdev/waveterm/pkg/util/shellutil"
+	"github.com/wavetermdev/waveterm/pkg/waveobj"
+	"github.com/wavetermdev/waveterm/pkg/wshrpc"
+)
+
+// RemoteRunCommandCommand runs a one-off shell command synchronously on whichever side receives
+// the RPC call and returns its combined output. Routed to a specific connection (see
+// pkg/wshutil.MakeConnectionRouteId), this is how pkg/jobqueue executes a queued job's command on
+// that connection.
+func (impl *ServerImpl) RemoteRunCommandCommand(ctx context.Context, data wshrpc.CommandRemoteRunCommandData) (wshrpc.CommandRemoteRunCommandRtnData, error) {
+	timeoutMs := data.TimeoutMs
+	if timeoutMs <= 0 {
+		timeoutMs = 30000
+	}
+	cmdCtx, cancel := context.WithTimeout(ctx, time.Duration(timeoutMs)*time.Millisecond)
+	defer cancel()
+	shellPath := shellutil.DetectLocalShellPath()
+	ecmd := exec.CommandContext(cmdCtx, shellPath, "-c", data.CmdStr)
+	if data.Cwd != "" {
+		ecmd.Dir = data.Cwd
+	}
+	outputBytes, err := shellexec.RunSimpleCmdInPty(ecmd, waveobj.TermSize{})
+	rtn := wshrpc.CommandRemoteRunCommandRtnData{
+		Output:   string(outputBytes),
+		ExitCode: shellexec.ExitCodeFromWaitErr(err),
+	}
+	if err != nil {
+		if _, ok := err.(*exec.ExitError); !ok {
+			return rtn, err
+		}
+	}
+	return rtn, nil
+}
@@ -9,7 +9,10 @@ import (
 	"time"
 
 	"github.com/shirou/gopsutil/v4/cpu"
+	"github.com/shirou/gopsutil/v4/disk"
 	"github.com/shirou/gopsutil/v4/mem"
+	"github.com/shirou/gopsutil/v4/net"
+	"github.com/wavetermdev/waveterm/pkg/wavebase"
 	"github.com/wavetermdev/waveterm/pkg/wps"
 	"github.com/wavetermdev/waveterm/pkg/wshrpc"
 	"github.com/wavetermdev/waveterm/pkg/wshrpc/wshclient"
@@ -18,6 +21,12 @@ import (
 
 const BYTES_PER_GB = 1073741824
 
+// these mirror wconfig.DefaultSysinfoSampleRateMs/DefaultSysinfoRetention; duplicated here (rather
+// than imported) because this package also runs inside the remote connserver process, which has
+// no access to local wconfig
+const DefaultSampleRateMs = 1000
+const DefaultRetention = 1024
+
 func getCpuData(values map[string]float64) {
 	percentArr, err := cpu.Percent(0, false)
 	if err != nil {
@@ -46,27 +55,84 @@ func getMemData(values map[string]float64) {
 	values["mem:free"] = float64(memData.Free) / BYTES_PER_GB
 }
 
-func generateSingleServerData(client *wshutil.WshRpc, connName string) {
+func getDiskData(values map[string]float64) {
+	usage, err := disk.Usage(wavebase.GetHomeDir())
+	if err != nil {
+		return
+	}
+	values["disk:total"] = float64(usage.Total) / BYTES_PER_GB
+	values["disk:used"] = float64(usage.Used) / BYTES_PER_GB
+	values["disk:free"] = float64(usage.Free) / BYTES_PER_GB
+	values["disk:usedpercent"] = usage.UsedPercent
+}
+
+// netCounterState tracks the previous cumulative byte counters so getNetData can report a
+// bytes/sec rate instead of a monotonically increasing total.
+type netCounterState struct {
+	lastTs   time.Time
+	lastSent uint64
+	lastRecv uint64
+	valid    bool
+}
+
+func getNetData(values map[string]float64, state *netCounterState) {
+	counters, err := net.IOCounters(false)
+	if err != nil || len(counters) == 0 {
+		return
+	}
+	total := counters[0]
+	now := time.Now()
+	if state.valid {
+		elapsed := now.Sub(state.lastTs).Seconds()
+		if elapsed > 0 {
+			values["net:sent"] = float64(total.BytesSent-state.lastSent) / elapsed
+			values["net:recv"] = float64(total.BytesRecv-state.lastRecv) / elapsed
+		}
+	}
+	state.lastTs = now
+	state.lastSent = total.BytesSent
+	state.lastRecv = total.BytesRecv
+	state.valid = true
+}
+
+func generateSingleServerData(client *wshutil.WshRpc, connName string, retention int, netState *netCounterState) {
 	now := time.Now()
 	values := make(map[string]float64)
 	getCpuData(values)
 	getMemData(values)
+	getDiskData(values)
+	getNetData(values, netState)
 	tsData := wshrpc.TimeSeriesData{Ts: now.UnixMilli(), Values: values}
 	event := wps.WaveEvent{
 		Event:   wps.Event_SysInfo,
 		Scopes:  []string{connName},
 		Data:    tsData,
-		Persist: 1024,
+		Persist: retention,
 	}
 	wshclient.EventPublishCommand(client, event, &wshrpc.RpcOpts{NoResponse: true})
 }
 
+// RunSysInfoLoop samples cpu/mem/disk/net and publishes a sysinfo event every sampleRateMs, until
+// the process exits. sampleRateMs and retention come from the RpcContext this client was created
+// with (see wshrpc.RpcContext.SysinfoSampleRateMs/SysinfoRetention), which conncontroller resolves
+// once from local config when launching a connserver, since the connserver itself has no config
+// access of its own.
 func RunSysInfoLoop(client *wshutil.WshRpc, connName string) {
 	defer func() {
 		log.Printf("sysinfo loop ended conn:%s\n", connName)
 	}()
+	rpcCtx := client.GetRpcContext()
+	sampleRateMs := rpcCtx.SysinfoSampleRateMs
+	if sampleRateMs <= 0 {
+		sampleRateMs = DefaultSampleRateMs
+	}
+	retention := rpcCtx.SysinfoRetention
+	if retention <= 0 {
+		retention = DefaultRetention
+	}
+	netState := &netCounterState{}
 	for {
-		generateSingleServerData(client, connName)
-		time.Sleep(1 * time.Second)
+		generateSingleServerData(client, connName, retention, netState)
+		time.Sleep(time.Duration(sampleRateMs) * time.Millisecond)
 	}
 }
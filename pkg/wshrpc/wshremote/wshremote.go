@@ -14,7 +14,9 @@ import (
 	"os"
 	"path/filepath"
 	"strings"
+	"sync"
 
+	"github.com/wavetermdev/waveterm/pkg/util/ratelimit"
 	"github.com/wavetermdev/waveterm/pkg/util/utilfn"
 	"github.com/wavetermdev/waveterm/pkg/wavebase"
 	"github.com/wavetermdev/waveterm/pkg/wshrpc"
@@ -27,10 +29,29 @@ const DirChunkSize = 128
 
 type ServerImpl struct {
 	LogWriter io.Writer
+
+	bwLimiterLock sync.Mutex
+	bwLimiter     *ratelimit.Limiter
 }
 
 func (*ServerImpl) WshServerImpl() {}
 
+// throttle blocks until n bytes are permitted to transfer under bwLimit bytes/sec (0 means
+// unlimited). The limiter is shared across calls on this ServerImpl (one per connection) and its
+// rate is live-adjusted on every call, so a changed bwlimit setting takes effect on the very next
+// file operation without needing to reconnect.
+func (impl *ServerImpl) throttle(bwLimit int64, n int) {
+	impl.bwLimiterLock.Lock()
+	if impl.bwLimiter == nil {
+		impl.bwLimiter = ratelimit.New(bwLimit)
+	} else {
+		impl.bwLimiter.SetRate(bwLimit)
+	}
+	limiter := impl.bwLimiter
+	impl.bwLimiterLock.Unlock()
+	limiter.WaitN(n)
+}
+
 func (impl *ServerImpl) Log(format string, args ...interface{}) {
 	if impl.LogWriter != nil {
 		fmt.Fprintf(impl.LogWriter, format, args...)
@@ -118,7 +139,7 @@ func (impl *ServerImpl) remoteStreamFileDir(ctx context.Context, path string, by
 }
 
 // TODO make sure the read is in chunks of 3 bytes (so 4 bytes of base64) in order to make decoding more efficient
-func (impl *ServerImpl) remoteStreamFileRegular(ctx context.Context, path string, byteRange ByteRangeType, dataCallback func(fileInfo []*wshrpc.FileInfo, data []byte)) error {
+func (impl *ServerImpl) remoteStreamFileRegular(ctx context.Context, path string, byteRange ByteRangeType, bwLimit int64, dataCallback func(fileInfo []*wshrpc.FileInfo, data []byte)) error {
 	fd, err := os.Open(path)
 	if err != nil {
 		return fmt.Errorf("cannot open file %q: %w", path, err)
@@ -143,6 +164,7 @@ func (impl *ServerImpl) remoteStreamFileRegular(ctx context.Context, path string
 				n = int(byteRange.End - filePos)
 			}
 			filePos += int64(n)
+			impl.throttle(bwLimit, n)
 			dataCallback(nil, buf[:n])
 		}
 		if !byteRange.All && filePos >= byteRange.End {
@@ -181,7 +203,7 @@ func (impl *ServerImpl) remoteStreamFileInternal(ctx context.Context, data wshrp
 	if finfo.IsDir {
 		return impl.remoteStreamFileDir(ctx, path, byteRange, dataCallback)
 	} else {
-		return impl.remoteStreamFileRegular(ctx, path, byteRange, dataCallback)
+		return impl.remoteStreamFileRegular(ctx, path, byteRange, data.BwLimit, dataCallback)
 	}
 }
 
@@ -338,6 +360,39 @@ func (impl *ServerImpl) RemoteFileRenameCommand(ctx context.Context, pathTuple [
 	return nil
 }
 
+// RemoteFileCopyCommand copies a single regular file. It does not recurse into directories; the
+// service layer (FileService.BulkFileOp) calls it once per file when copying a selection.
+func (impl *ServerImpl) RemoteFileCopyCommand(ctx context.Context, data wshrpc.CommandRemoteFileCopyData) error {
+	cleanedSrc := filepath.Clean(wavebase.ExpandHomeDirSafe(data.SrcPath))
+	cleanedDest := filepath.Clean(wavebase.ExpandHomeDirSafe(data.DestPath))
+	srcInfo, err := os.Stat(cleanedSrc)
+	if err != nil {
+		return fmt.Errorf("cannot stat source file %q: %w", data.SrcPath, err)
+	}
+	if srcInfo.IsDir() {
+		return fmt.Errorf("cannot copy %q, directories are not supported", data.SrcPath)
+	}
+	if !data.Overwrite {
+		if _, err := os.Stat(cleanedDest); err == nil {
+			return fmt.Errorf("destination file path %q already exists", data.DestPath)
+		}
+	}
+	srcFile, err := os.Open(cleanedSrc)
+	if err != nil {
+		return fmt.Errorf("cannot open source file %q: %w", data.SrcPath, err)
+	}
+	defer srcFile.Close()
+	destFile, err := os.OpenFile(cleanedDest, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, srcInfo.Mode())
+	if err != nil {
+		return fmt.Errorf("cannot create destination file %q: %w", data.DestPath, err)
+	}
+	defer destFile.Close()
+	if _, err := io.Copy(destFile, srcFile); err != nil {
+		return fmt.Errorf("cannot copy %q to %q: %w", data.SrcPath, data.DestPath, err)
+	}
+	return nil
+}
+
 func (impl *ServerImpl) RemoteMkdirCommand(ctx context.Context, path string) error {
 	cleanedPath := filepath.Clean(wavebase.ExpandHomeDirSafe(path))
 	if stat, err := os.Stat(cleanedPath); err == nil {
@@ -353,10 +408,21 @@ func (impl *ServerImpl) RemoteMkdirCommand(ctx context.Context, path string) err
 	return nil
 }
 
-func (*ServerImpl) RemoteWriteFileCommand(ctx context.Context, data wshrpc.CommandRemoteWriteFileData) error {
+func (impl *ServerImpl) RemoteWriteFileCommand(ctx context.Context, data wshrpc.CommandRemoteWriteFileData) (wshrpc.CommandRemoteFileWriteRtnData, error) {
+	var rtn wshrpc.CommandRemoteFileWriteRtnData
 	path, err := wavebase.ExpandHomeDir(data.Path)
 	if err != nil {
-		return err
+		return rtn, err
+	}
+	if data.ExpectedModTime != 0 {
+		if curStat, statErr := os.Stat(path); statErr == nil {
+			curModTime := curStat.ModTime().UnixMilli()
+			if curModTime != data.ExpectedModTime {
+				rtn.Conflict = true
+				rtn.ModTime = curModTime
+				return rtn, nil
+			}
+		}
 	}
 	createMode := data.CreateMode
 	if createMode == 0 {
@@ -366,13 +432,17 @@ func (*ServerImpl) RemoteWriteFileCommand(ctx context.Context, data wshrpc.Comma
 	dataBytes := make([]byte, dataSize)
 	n, err := base64.StdEncoding.Decode(dataBytes, []byte(data.Data64))
 	if err != nil {
-		return fmt.Errorf("cannot decode base64 data: %w", err)
+		return rtn, fmt.Errorf("cannot decode base64 data: %w", err)
 	}
+	impl.throttle(data.BwLimit, n)
 	err = os.WriteFile(path, dataBytes[:n], createMode)
 	if err != nil {
-		return fmt.Errorf("cannot write file %q: %w", path, err)
+		return rtn, fmt.Errorf("cannot write file %q: %w", path, err)
 	}
-	return nil
+	if newStat, statErr := os.Stat(path); statErr == nil {
+		rtn.ModTime = newStat.ModTime().UnixMilli()
+	}
+	return rtn, nil
 }
 
 func (*ServerImpl) RemoteFileDeleteCommand(ctx context.Context, path string) error {
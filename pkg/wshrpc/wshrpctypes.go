@@ -12,10 +12,15 @@ import (
 	"os"
 	"reflect"
 
+	"github.com/wavetermdev/waveterm/pkg/diffutil"
 	"github.com/wavetermdev/waveterm/pkg/filestore"
+	"github.com/wavetermdev/waveterm/pkg/hooks"
 	"github.com/wavetermdev/waveterm/pkg/ijson"
+	"github.com/wavetermdev/waveterm/pkg/secretredact"
+	"github.com/wavetermdev/waveterm/pkg/termsearch"
 	"github.com/wavetermdev/waveterm/pkg/vdom"
 	"github.com/wavetermdev/waveterm/pkg/waveobj"
+	"github.com/wavetermdev/waveterm/pkg/wplugin"
 	"github.com/wavetermdev/waveterm/pkg/wps"
 )
 
@@ -29,50 +34,81 @@ const (
 )
 
 const (
-	Command_Authenticate         = "authenticate"    // special
-	Command_Dispose              = "dispose"         // special (disposes of the route, for multiproxy only)
-	Command_RouteAnnounce        = "routeannounce"   // special (for routing)
-	Command_RouteUnannounce      = "routeunannounce" // special (for routing)
-	Command_Message              = "message"
-	Command_GetMeta              = "getmeta"
-	Command_SetMeta              = "setmeta"
-	Command_SetView              = "setview"
-	Command_ControllerInput      = "controllerinput"
-	Command_ControllerRestart    = "controllerrestart"
-	Command_ControllerStop       = "controllerstop"
-	Command_ControllerResync     = "controllerresync"
-	Command_FileAppend           = "fileappend"
-	Command_FileAppendIJson      = "fileappendijson"
-	Command_ResolveIds           = "resolveids"
-	Command_BlockInfo            = "blockinfo"
-	Command_CreateBlock          = "createblock"
-	Command_DeleteBlock          = "deleteblock"
-	Command_FileWrite            = "filewrite"
-	Command_FileRead             = "fileread"
-	Command_EventPublish         = "eventpublish"
-	Command_EventRecv            = "eventrecv"
-	Command_EventSub             = "eventsub"
-	Command_EventUnsub           = "eventunsub"
-	Command_EventUnsubAll        = "eventunsuball"
-	Command_EventReadHistory     = "eventreadhistory"
-	Command_StreamTest           = "streamtest"
-	Command_StreamWaveAi         = "streamwaveai"
-	Command_StreamCpuData        = "streamcpudata"
-	Command_Test                 = "test"
-	Command_SetConfig            = "setconfig"
-	Command_SetConnectionsConfig = "connectionsconfig"
-	Command_RemoteStreamFile     = "remotestreamfile"
-	Command_RemoteFileInfo       = "remotefileinfo"
-	Command_RemoteFileTouch      = "remotefiletouch"
-	Command_RemoteWriteFile      = "remotewritefile"
-	Command_RemoteFileDelete     = "remotefiledelete"
-	Command_RemoteFileJoin       = "remotefilejoin"
-	Command_WaveInfo             = "waveinfo"
-	Command_WshActivity          = "wshactivity"
-	Command_Activity             = "activity"
-	Command_GetVar               = "getvar"
-	Command_SetVar               = "setvar"
-	Command_RemoteMkdir          = "remotemkdir"
+	Command_Authenticate               = "authenticate"    // special
+	Command_Dispose                    = "dispose"         // special (disposes of the route, for multiproxy only)
+	Command_RouteAnnounce              = "routeannounce"   // special (for routing)
+	Command_RouteUnannounce            = "routeunannounce" // special (for routing)
+	Command_Message                    = "message"
+	Command_GetMeta                    = "getmeta"
+	Command_SetMeta                    = "setmeta"
+	Command_GetMetaBulk                = "getmetabulk"
+	Command_SetMetaBulk                = "setmetabulk"
+	Command_SetView                    = "setview"
+	Command_ControllerInput            = "controllerinput"
+	Command_ControllerRestart          = "controllerrestart"
+	Command_ControllerStop             = "controllerstop"
+	Command_ControllerResync           = "controllerresync"
+	Command_FileAppend                 = "fileappend"
+	Command_FileAppendIJson            = "fileappendijson"
+	Command_ResolveIds                 = "resolveids"
+	Command_BlockInfo                  = "blockinfo"
+	Command_CreateBlock                = "createblock"
+	Command_DeleteBlock                = "deleteblock"
+	Command_FileWrite                  = "filewrite"
+	Command_FileRead                   = "fileread"
+	Command_SearchBlockFile            = "searchblockfile"
+	Command_TermExport                 = "termexport"
+	Command_ShareCommandOutput         = "sharecommandoutput"
+	Command_ControllerRestartAllFailed = "controllerrestartallfailed"
+	Command_ListOrphanedDetached       = "listorphaneddetached"
+	Command_CleanupDetached            = "cleanupdetached"
+	Command_GetOutputFoldState         = "getoutputfoldstate"
+	Command_EventPublish               = "eventpublish"
+	Command_EventRecv                  = "eventrecv"
+	Command_EventSub                   = "eventsub"
+	Command_EventUnsub                 = "eventunsub"
+	Command_EventUnsubAll              = "eventunsuball"
+	Command_EventReadHistory           = "eventreadhistory"
+	Command_StreamTest                 = "streamtest"
+	Command_StreamWaveAi               = "streamwaveai"
+	Command_StreamCpuData              = "streamcpudata"
+	Command_Test                       = "test"
+	Command_SetConfig                  = "setconfig"
+	Command_SetConnectionsConfig       = "connectionsconfig"
+	Command_RemoteStreamFile           = "remotestreamfile"
+	Command_RemoteFileInfo             = "remotefileinfo"
+	Command_RemoteFileTouch            = "remotefiletouch"
+	Command_RemoteWriteFile            = "remotewritefile"
+	Command_RemoteFileDelete           = "remotefiledelete"
+	Command_RemoteFileJoin             = "remotefilejoin"
+	Command_WaveInfo                   = "waveinfo"
+	Command_WshActivity                = "wshactivity"
+	Command_Activity                   = "activity"
+	Command_GetVar                     = "getvar"
+	Command_SetVar                     = "setvar"
+	Command_RemoteMkdir                = "remotemkdir"
+	Command_RemoteListProcesses        = "remotelistprocesses"
+	Command_RemoteProcessSignal        = "remoteprocesssignal"
+	Command_RemoteProcessRenice        = "remoteprocessrenice"
+	Command_RemoteRunCommand           = "remoteruncommand"
+	Command_RemoteListListeners        = "remotelistlisteners"
+	Command_RemoteGitStatus            = "remotegitstatus"
+	Command_RemoteGitPrStatus          = "remotegitprstatus"
+	Command_RemoteLogTail              = "remotelogtail"
+	Command_RemoteFileDataTable        = "remotefiledatatable"
+	Command_RemoteJupyterKernelExecute = "remotejupyterkernelexecute"
+	Command_RemoteJupyterKernelStop    = "remotejupyterkernelstop"
+	Command_RemoteHttpRequest          = "remotehttprequest"
+	Command_RemotePdfText              = "remotepdftext"
+	Command_RemoteImageOp              = "remoteimageop"
+	Command_RemoteArchiveList          = "remotearchivelist"
+	Command_RemoteArchiveReadEntry     = "remotearchivereadentry"
+	Command_RemoteArchiveExtract       = "remotearchiveextract"
+	Command_RemoteDiskUsage            = "remotediskusage"
+	Command_RemoteFileDiff             = "remotefilediff"
+	Command_RemoteLspRequest           = "remotelsprequest"
+	Command_RemoteLspNotify            = "remotelspnotify"
+	Command_RemoteLspStop              = "remotelspstop"
 
 	Command_ConnStatus       = "connstatus"
 	Command_WslStatus        = "wslstatus"
@@ -81,10 +117,18 @@ const (
 	Command_ConnConnect      = "connconnect"
 	Command_ConnDisconnect   = "conndisconnect"
 	Command_ConnList         = "connlist"
+	Command_ConnImportTags   = "connimporttags"
 	Command_WslList          = "wsllist"
 	Command_WslDefaultDistro = "wsldefaultdistro"
 	Command_DismissWshFail   = "dismisswshfail"
 
+	Command_ConnPortForwardOpen  = "connportforwardopen"
+	Command_ConnPortForwardClose = "connportforwardclose"
+	Command_ConnPortForwardList  = "connportforwardlist"
+
+	Command_DbQuery       = "dbquery"
+	Command_DbQueryCancel = "dbquerycancel"
+
 	Command_WorkspaceList = "workspacelist"
 
 	Command_WebSelector      = "webselector"
@@ -98,8 +142,81 @@ const (
 	Command_VDomUrlRequest      = "vdomurlrequest"
 
 	Command_AiSendMessage = "aisendmessage"
+	Command_Summarize     = "summarize"
+	Command_RedactText    = "redacttext"
+
+	Command_HooksList   = "hookslist"
+	Command_HooksAdd    = "hooksadd"
+	Command_HooksRemove = "hooksremove"
+
+	Command_SetBroadcastGroup = "setbroadcastgroup"
+	Command_SetTermRecording  = "settermrecording"
+
+	Command_WebShareStart     = "websharestart"
+	Command_WebShareStop      = "websharestop"
+	Command_WebShareStatus    = "websharestatus"
+	Command_WebShareRevokeAll = "websharerevokeall"
+
+	Command_SudoCacheSet    = "sudocacheset"
+	Command_SudoCacheClear  = "sudocacheclear"
+	Command_SudoCacheStatus = "sudocachestatus"
+
+	Command_IdleLockTouch         = "idlelocktouch"
+	Command_IdleLockSetPassphrase = "idlelocksetpassphrase"
+	Command_IdleLockUnlock        = "idlelockunlock"
+	Command_IdleLockStatus        = "idlelockstatus"
+
+	Command_ClientCapabilities = "clientcapabilities"
+
+	Command_ListTabs        = "listtabs"
+	Command_RunShellCommand = "runshellcommand"
+	Command_RunMultiCommand = "runmulticommand"
+	Command_GetMcpConfig    = "getmcpconfig"
+
+	Command_ListPlugins      = "listplugins"
+	Command_SetPluginEnabled = "setpluginenabled"
+
+	Command_RegisterVDomRenderer = "registervdomrenderer"
+
+	Command_ImportTermTheme = "importtermtheme"
+	Command_CheckFonts      = "checkfonts"
+
+	Command_ActionRegistry     = "actionregistry"
+	Command_KeybindingRegistry = "keybindingregistry"
+	Command_InputModeChords    = "inputmodechords"
+	Command_SearchCmdHistory   = "searchcmdhistory"
+
+	Command_SyncHistoryPush = "synchistorypush"
+	Command_SyncHistoryPull = "synchistorypull"
+
+	Command_ExportSettingsBundle = "exportsettingsbundle"
+	Command_ImportSettingsBundle = "importsettingsbundle"
+	Command_ApplySettingsBundle  = "applysettingsbundle"
+
+	Command_TelemetryInspect = "telemetryinspect"
+	Command_DebugCrashes     = "debugcrashes"
+	Command_DebugLogLevel    = "debugloglevel"
+	Command_DebugProfile     = "debugprofile"
+	Command_ClientDbStats    = "clientdbstats"
 )
 
+// ServerCapabilities lists the wshrpc feature set this wavesrv build
+// supports. Callers (e.g. a newer/older wsh or remote frontend) can check
+// this list before relying on a feature, so mixed-version setups (like
+// remote attach to an older server) can degrade gracefully instead of
+// sending commands or update types the other side doesn't understand.
+var ServerCapabilities = []string{
+	"broadcast",
+	"termrecording",
+	"webshare",
+	"cmdprovenance",
+	"summarize",
+	"hooks",
+	"mcp",
+	"plugins",
+	"vdomrenderers",
+}
+
 type RespOrErrorUnion[T any] struct {
 	Response T
 	Error    error
@@ -114,10 +231,28 @@ type WshRpcInterface interface {
 	MessageCommand(ctx context.Context, data CommandMessageData) error
 	GetMetaCommand(ctx context.Context, data CommandGetMetaData) (waveobj.MetaMapType, error)
 	SetMetaCommand(ctx context.Context, data CommandSetMetaData) error
+	GetMetaBulkCommand(ctx context.Context, data CommandGetMetaBulkData) ([]MetaBulkResult, error)
+	SetMetaBulkCommand(ctx context.Context, data CommandSetMetaBulkData) error
 	SetViewCommand(ctx context.Context, data CommandBlockSetViewData) error
 	ControllerInputCommand(ctx context.Context, data CommandBlockInputData) error
+	SetBroadcastGroupCommand(ctx context.Context, data CommandSetBroadcastGroupData) error
+	SetTermRecordingCommand(ctx context.Context, data CommandSetTermRecordingData) error
+	WebShareStartCommand(ctx context.Context, data CommandWebShareStartData) (CommandWebShareStatusData, error)
+	WebShareStopCommand(ctx context.Context, data CommandWebShareStopData) error
+	WebShareStatusCommand(ctx context.Context, data CommandWebShareStopData) (CommandWebShareStatusData, error)
+	WebShareRevokeAllCommand(ctx context.Context) (int, error)
+	SudoCacheSetCommand(ctx context.Context, data CommandSudoCacheSetData) error // plumbing only, see pkg/sudocache doc comment -- nothing in this tree calls it yet
+	SudoCacheClearCommand(ctx context.Context, connection string) error          // plumbing only, see pkg/sudocache doc comment -- nothing in this tree calls it yet
+	SudoCacheStatusCommand(ctx context.Context) ([]SudoCacheStatusEntry, error)  // plumbing only, see pkg/sudocache doc comment -- nothing in this tree calls it yet
+	IdleLockTouchCommand(ctx context.Context) error
+	IdleLockSetPassphraseCommand(ctx context.Context, passphrase string) error
+	IdleLockUnlockCommand(ctx context.Context, passphrase string) error
+	IdleLockStatusCommand(ctx context.Context) (IdleLockStatusData, error)
 	ControllerStopCommand(ctx context.Context, blockId string) error
 	ControllerResyncCommand(ctx context.Context, data CommandControllerResyncData) error
+	ControllerRestartAllFailedCommand(ctx context.Context, data CommandControllerRestartAllFailedData) (CommandControllerRestartAllFailedRtnData, error)
+	ListOrphanedDetachedCommand(ctx context.Context) (CommandListOrphanedDetachedRtnData, error)
+	CleanupDetachedCommand(ctx context.Context, blockId string) error
 	ResolveIdsCommand(ctx context.Context, data CommandResolveIdsData) (CommandResolveIdsRtnData, error)
 	CreateBlockCommand(ctx context.Context, data CommandCreateBlockData) (waveobj.ORef, error)
 	CreateSubBlockCommand(ctx context.Context, data CommandCreateSubBlockData) (waveobj.ORef, error)
@@ -130,6 +265,10 @@ type WshRpcInterface interface {
 	FileAppendIJsonCommand(ctx context.Context, data CommandAppendIJsonData) error
 	FileWriteCommand(ctx context.Context, data CommandFileData) error
 	FileReadCommand(ctx context.Context, data CommandFileData) (string, error)
+	SearchBlockFileCommand(ctx context.Context, data CommandSearchBlockFileData) (CommandSearchBlockFileRtnData, error)
+	TermExportCommand(ctx context.Context, data CommandTermExportData) (CommandTermExportRtnData, error)
+	ShareCommandOutputCommand(ctx context.Context, data CommandShareCommandOutputData) (CommandShareCommandOutputRtnData, error)
+	GetOutputFoldStateCommand(ctx context.Context, blockId string) (OutputFoldStateData, error)
 	FileInfoCommand(ctx context.Context, data CommandFileData) (*WaveFileInfo, error)
 	FileListCommand(ctx context.Context, data CommandFileListData) ([]*WaveFileInfo, error)
 	EventPublishCommand(ctx context.Context, data wps.WaveEvent) error
@@ -145,6 +284,7 @@ type WshRpcInterface interface {
 	SetConnectionsConfigCommand(ctx context.Context, data ConnConfigRequest) error
 	BlockInfoCommand(ctx context.Context, blockId string) (*BlockInfoData, error)
 	WaveInfoCommand(ctx context.Context) (*WaveInfoData, error)
+	ClientCapabilitiesCommand(ctx context.Context) (ClientCapabilitiesData, error)
 	WshActivityCommand(ct context.Context, data map[string]int) error
 	ActivityCommand(ctx context.Context, data ActivityUpdate) error
 	GetVarCommand(ctx context.Context, data CommandVarData) (*CommandVarResponseData, error)
@@ -159,9 +299,15 @@ type WshRpcInterface interface {
 	ConnConnectCommand(ctx context.Context, connRequest ConnRequest) error
 	ConnDisconnectCommand(ctx context.Context, connName string) error
 	ConnListCommand(ctx context.Context) ([]string, error)
+	ConnImportTagsCommand(ctx context.Context, connName string) (CommandConnImportTagsRtnData, error)
 	WslListCommand(ctx context.Context) ([]string, error)
 	WslDefaultDistroCommand(ctx context.Context) (string, error)
 	DismissWshFailCommand(ctx context.Context, connName string) error
+	ConnPortForwardOpenCommand(ctx context.Context, data CommandConnPortForwardOpenData) (ConnPortForwardInfo, error)
+	ConnPortForwardCloseCommand(ctx context.Context, forwardId string) error
+	ConnPortForwardListCommand(ctx context.Context) ([]ConnPortForwardInfo, error)
+	DbQueryCommand(ctx context.Context, data CommandDbQueryData) (DbQueryResult, error)
+	DbQueryCancelCommand(ctx context.Context, queryId string) error
 
 	// eventrecv is special, it's handled internally by WshRpc with EventListener
 	EventRecvCommand(ctx context.Context, data wps.WaveEvent) error
@@ -171,11 +317,34 @@ type WshRpcInterface interface {
 	RemoteFileInfoCommand(ctx context.Context, path string) (*FileInfo, error)
 	RemoteFileTouchCommand(ctx context.Context, path string) error
 	RemoteFileRenameCommand(ctx context.Context, pathTuple [2]string) error
+	RemoteFileCopyCommand(ctx context.Context, data CommandRemoteFileCopyData) error
 	RemoteFileDeleteCommand(ctx context.Context, path string) error
-	RemoteWriteFileCommand(ctx context.Context, data CommandRemoteWriteFileData) error
+	RemoteWriteFileCommand(ctx context.Context, data CommandRemoteWriteFileData) (CommandRemoteFileWriteRtnData, error)
 	RemoteFileJoinCommand(ctx context.Context, paths []string) (*FileInfo, error)
 	RemoteMkdirCommand(ctx context.Context, path string) error
 	RemoteStreamCpuDataCommand(ctx context.Context) chan RespOrErrorUnion[TimeSeriesData]
+	RemoteListProcessesCommand(ctx context.Context) ([]ProcessInfo, error)
+	RemoteProcessSignalCommand(ctx context.Context, data CommandRemoteProcessSignalData) error
+	RemoteProcessReniceCommand(ctx context.Context, data CommandRemoteProcessReniceData) error
+	RemoteRunCommandCommand(ctx context.Context, data CommandRemoteRunCommandData) (CommandRemoteRunCommandRtnData, error)
+	RemoteListListenersCommand(ctx context.Context) ([]ListenerInfo, error)
+	RemoteGitStatusCommand(ctx context.Context, path string) (GitStatusInfo, error)
+	RemoteGitPrStatusCommand(ctx context.Context, data CommandRemoteGitPrStatusData) chan RespOrErrorUnion[GitPrStatusData]
+	RemoteLogTailCommand(ctx context.Context, data CommandRemoteLogTailData) chan RespOrErrorUnion[LogLineData]
+	RemoteFileDataTableCommand(ctx context.Context, data CommandRemoteFileDataTableData) (FileDataTablePage, error)
+	RemoteJupyterKernelExecuteCommand(ctx context.Context, data CommandRemoteJupyterKernelExecuteData) chan RespOrErrorUnion[JupyterOutputData]
+	RemoteJupyterKernelStopCommand(ctx context.Context, kernelId string) error
+	RemoteHttpRequestCommand(ctx context.Context, data CommandRemoteHttpRequestData) (HttpResponseData, error)
+	RemotePdfTextCommand(ctx context.Context, path string) (string, error)
+	RemoteImageOpCommand(ctx context.Context, data CommandRemoteImageOpData) (ImageOpResult, error)
+	RemoteArchiveListCommand(ctx context.Context, path string) ([]ArchiveEntryInfo, error)
+	RemoteArchiveReadEntryCommand(ctx context.Context, data CommandRemoteArchiveReadEntryData) (ArchiveEntryContent, error)
+	RemoteArchiveExtractCommand(ctx context.Context, data CommandRemoteArchiveExtractData) error
+	RemoteDiskUsageCommand(ctx context.Context, data CommandRemoteDiskUsageData) chan RespOrErrorUnion[DiskUsageProgressData]
+	RemoteFileDiffCommand(ctx context.Context, data CommandRemoteFileDiffData) (CommandRemoteFileDiffRtnData, error)
+	RemoteLspRequestCommand(ctx context.Context, data CommandRemoteLspRequestData) (CommandRemoteLspRequestRtnData, error)
+	RemoteLspNotifyCommand(ctx context.Context, data CommandRemoteLspNotifyData) error
+	RemoteLspStopCommand(ctx context.Context, blockId string) error
 
 	// emain
 	WebSelectorCommand(ctx context.Context, data CommandWebSelectorData) ([]string, error)
@@ -184,6 +353,31 @@ type WshRpcInterface interface {
 
 	WorkspaceListCommand(ctx context.Context) ([]WorkspaceInfoData, error)
 	GetUpdateChannelCommand(ctx context.Context) (string, error)
+	ListTabsCommand(ctx context.Context) ([]TabListEntry, error)
+	RunShellCommandCommand(ctx context.Context, data CommandRunShellCommandData) (CommandRunShellCommandRtnData, error)
+	RunMultiCommandCommand(ctx context.Context, data CommandRunMultiCommandData) (CommandRunMultiCommandRtnData, error)
+	GetMcpConfigCommand(ctx context.Context) (McpConfigData, error)
+	ListPluginsCommand(ctx context.Context) ([]wplugin.PluginInfo, error)
+	SetPluginEnabledCommand(ctx context.Context, data CommandSetPluginEnabledData) error
+	RegisterVDomRendererCommand(ctx context.Context, data CommandRegisterVDomRendererData) error
+
+	ImportTermThemeCommand(ctx context.Context, data CommandImportTermThemeData) (string, error)
+
+	CheckFontsCommand(ctx context.Context) (FontStatusData, error)
+	ActionRegistryCommand(ctx context.Context) ([]ActionRegistryEntry, error)
+	KeybindingRegistryCommand(ctx context.Context, goos string) (KeybindingRegistryData, error)
+	InputModeChordsCommand(ctx context.Context) (InputModeChordsData, error)
+	SearchCmdHistoryCommand(ctx context.Context, data CommandSearchCmdHistoryData) (CommandSearchCmdHistoryRtnData, error)
+	SyncHistoryPushCommand(ctx context.Context) (int, error)
+	SyncHistoryPullCommand(ctx context.Context) (int, error)
+	ExportSettingsBundleCommand(ctx context.Context, data CommandExportSettingsBundleData) error
+	ImportSettingsBundleCommand(ctx context.Context, data CommandImportSettingsBundleData) ([]SettingsSyncConflict, error)
+	ApplySettingsBundleCommand(ctx context.Context, data CommandApplySettingsBundleData) error
+	TelemetryInspectCommand(ctx context.Context) (TelemetryInspectData, error)
+	DebugCrashesCommand(ctx context.Context) ([]CrashReportSummary, error)
+	DebugLogLevelCommand(ctx context.Context, data CommandDebugLogLevelData) (CommandDebugLogLevelRtnData, error)
+	DebugProfileCommand(ctx context.Context, data CommandDebugProfileData) (CommandDebugProfileRtnData, error)
+	ClientDbStatsCommand(ctx context.Context) ([]DbStats, error)
 
 	// terminal
 	VDomCreateContextCommand(ctx context.Context, data vdom.VDomCreateContext) (*waveobj.ORef, error)
@@ -191,6 +385,13 @@ type WshRpcInterface interface {
 
 	// ai
 	AiSendMessageCommand(ctx context.Context, data AiMessageData) error
+	SummarizeCommand(ctx context.Context, data CommandSummarizeData) (CommandSummarizeRtnData, error)
+	RedactTextCommand(ctx context.Context, data CommandRedactTextData) (CommandRedactTextRtnData, error)
+
+	// hooks
+	HooksListCommand(ctx context.Context) ([]hooks.HookDef, error)
+	HooksAddCommand(ctx context.Context, data hooks.HookDef) error
+	HooksRemoveCommand(ctx context.Context, index int) error
 
 	// proc
 	VDomRenderCommand(ctx context.Context, data vdom.VDomFrontendUpdate) chan RespOrErrorUnion[*vdom.VDomBackendUpdate]
@@ -220,6 +421,11 @@ type RpcContext struct {
 	BlockId    string `json:"blockid,omitempty"`
 	TabId      string `json:"tabid,omitempty"`
 	Conn       string `json:"conn,omitempty"`
+
+	// resolved once (from the local side's config) when a connserver is launched over ssh, since
+	// the connserver process itself has no access to local wconfig
+	SysinfoSampleRateMs int64 `json:"sysinfosampleratems,omitempty"`
+	SysinfoRetention    int   `json:"sysinforetention,omitempty"`
 }
 
 func HackRpcContextIntoData(dataPtr any, rpcContext RpcContext) {
@@ -275,6 +481,32 @@ type CommandGetMetaData struct {
 type CommandSetMetaData struct {
 	ORef waveobj.ORef        `json:"oref" wshcontext:"BlockORef"`
 	Meta waveobj.MetaMapType `json:"meta"`
+	// IfVersion, when set, makes the update conditional: it fails with no changes applied unless
+	// the object's current waveobj.GetVersion matches, so a read-getmeta-then-setmeta script can
+	// detect a concurrent modification instead of silently clobbering it.
+	IfVersion *int `json:"ifversion,omitempty"`
+}
+
+type CommandGetMetaBulkData struct {
+	ORefs []waveobj.ORef `json:"orefs"`
+}
+
+// MetaBulkResult is one entity's result within a GetMetaBulkCommand or SetMetaBulkCommand
+// response. Error is set (and Meta/Version left zero) when that one entity failed to resolve;
+// SetMetaBulkCommand never returns a per-item Error for a failed update, since a failed item
+// means the whole batch was rolled back (see CommandSetMetaBulkData).
+type MetaBulkResult struct {
+	ORef    waveobj.ORef        `json:"oref"`
+	Meta    waveobj.MetaMapType `json:"meta,omitempty"`
+	Version int                 `json:"version,omitempty"`
+	Error   string              `json:"error,omitempty"`
+}
+
+// CommandSetMetaBulkData applies every item in Items within a single transaction -- if any item's
+// IfVersion check fails (or any other error occurs), none of them are applied. This is what lets
+// an automation script reconfigure several blocks as one atomic operation.
+type CommandSetMetaBulkData struct {
+	Items []CommandSetMetaData `json:"items"`
 }
 
 type CommandResolveIdsData struct {
@@ -311,11 +543,91 @@ type CommandControllerResyncData struct {
 	RtOpts       *waveobj.RuntimeOpts `json:"rtopts,omitempty"`
 }
 
+// CommandControllerRestartAllFailedData force-restarts every block in TabId whose shell process
+// exited with a nonzero code -- the "restart all failed" variant of a single-block force restart
+// (see CommandControllerResyncData), e.g. after re-running a flaky build step across several
+// terminals at once.
+type CommandControllerRestartAllFailedData struct {
+	TabId string `json:"tabid" wshcontext:"TabId"`
+}
+
+// CommandControllerRestartAllFailedRtnData is ControllerRestartAllFailedCommand's result.
+type CommandControllerRestartAllFailedRtnData struct {
+	RestartedBlockIds []string `json:"restartedblockids,omitempty"`
+}
+
+// OrphanedDetachedCommandInfo describes a detached command's shell process that outlived a
+// wavesrv restart -- see blockcontroller.ListOrphanedDetached.
+type OrphanedDetachedCommandInfo struct {
+	BlockId string `json:"blockid"`
+	Pid     int    `json:"pid"`
+}
+
+// CommandListOrphanedDetachedRtnData is ListOrphanedDetachedCommand's result.
+type CommandListOrphanedDetachedRtnData struct {
+	Orphans []OrphanedDetachedCommandInfo `json:"orphans,omitempty"`
+}
+
 type CommandBlockInputData struct {
-	BlockId     string            `json:"blockid" wshcontext:"BlockId"`
-	InputData64 string            `json:"inputdata64,omitempty"`
-	SigName     string            `json:"signame,omitempty"`
-	TermSize    *waveobj.TermSize `json:"termsize,omitempty"`
+	BlockId       string            `json:"blockid" wshcontext:"BlockId"`
+	InputData64   string            `json:"inputdata64,omitempty"`
+	SigName       string            `json:"signame,omitempty"`
+	TermSize      *waveobj.TermSize `json:"termsize,omitempty"`
+	IsPaste       bool              `json:"ispaste,omitempty"`       // set when InputData64 came from a paste, not typed keystrokes -- gates the pasteguard check
+	PasteOverride bool              `json:"pasteoverride,omitempty"` // set to resend a flagged paste after the user confirmed it; writes it through and logs an audit line
+}
+
+type CommandSetBroadcastGroupData struct {
+	BlockId string `json:"blockid" wshcontext:"BlockId"`
+	Group   string `json:"group,omitempty"`
+}
+
+type CommandSetTermRecordingData struct {
+	BlockId string `json:"blockid" wshcontext:"BlockId"`
+	Enabled bool   `json:"enabled"`
+}
+
+type CommandWebShareStartData struct {
+	BlockId    string `json:"blockid" wshcontext:"BlockId"`
+	Role       string `json:"role,omitempty"` // "observer" (default) or "driver"
+	TtlMinutes int    `json:"ttlminutes,omitempty"`
+}
+
+type CommandWebShareStopData struct {
+	BlockId string `json:"blockid" wshcontext:"BlockId"`
+}
+
+type CommandWebShareStatusData struct {
+	Active      bool   `json:"active"`
+	Token       string `json:"token,omitempty"`
+	Role        string `json:"role,omitempty"`
+	ShareUrl    string `json:"shareurl,omitempty"`
+	ExpiresTs   int64  `json:"expirests,omitempty"`
+	ViewerCount int    `json:"viewercount,omitempty"`
+}
+
+// CommandSudoCacheSetData caches a sudo password for Connection (empty means the local machine)
+// for the configured TTL (see wconfig's ResolveSudoCacheTtl); the caller is responsible for
+// having actually confirmed the password with the user, since this package has no sudo-prompt
+// detection of its own.
+type CommandSudoCacheSetData struct {
+	Connection string `json:"connection,omitempty"`
+	Password   string `json:"password"`
+}
+
+// SudoCacheStatusEntry reports that a connection currently has a live cached sudo password,
+// without exposing it.
+type SudoCacheStatusEntry struct {
+	Connection string `json:"connection"`
+	ExpiresTs  int64  `json:"expirests"` // unix millis
+}
+
+// IdleLockStatusData is returned by IdleLockStatusCommand, which also evaluates whether the
+// configured idle timeout has just elapsed (see idlelock.ShouldLock) before reporting it.
+type IdleLockStatusData struct {
+	Locked         bool  `json:"locked"`
+	HasPassphrase  bool  `json:"haspassphrase"`
+	TimeoutMinutes int64 `json:"timeoutminutes,omitempty"`
 }
 
 type CommandFileDataAt struct {
@@ -330,6 +642,65 @@ type CommandFileData struct {
 	At       *CommandFileDataAt `json:"at,omitempty"` // if set, this turns read/write ops to ReadAt/WriteAt ops (len is only used for ReadAt)
 }
 
+// CommandSearchBlockFileData searches a block's stored PTY blob for pattern (see
+// termsearch.Search); Regex/CaseSensitive mirror termsearch.Options.
+type CommandSearchBlockFileData struct {
+	ZoneId        string `json:"zoneid" wshcontext:"BlockId"`
+	FileName      string `json:"filename"`
+	Pattern       string `json:"pattern"`
+	Regex         bool   `json:"regex,omitempty"`
+	CaseSensitive bool   `json:"casesensitive,omitempty"`
+}
+
+// CommandSearchBlockFileRtnData is SearchBlockFileCommand's result -- every match's byte
+// offset/length within the searched blockfile, in order, so the frontend can implement
+// find-next/find-prev without transferring the full scrollback to search client-side.
+type CommandSearchBlockFileRtnData struct {
+	Matches []termsearch.Match `json:"matches"`
+}
+
+// CommandTermExportData exports a slice of BlockId's terminal scrollback as Format (one of
+// ansiexport.FormatPlain/FormatAnsi/FormatHtml). StartLine is 0-indexed; NumLines <= 0 means to
+// the end of the scrollback.
+type CommandTermExportData struct {
+	BlockId   string `json:"blockid" wshcontext:"BlockId"`
+	Format    string `json:"format"`
+	StartLine int    `json:"startline,omitempty"`
+	NumLines  int    `json:"numlines,omitempty"`
+}
+
+// CommandTermExportRtnData is TermExportCommand's result. Text is plain text for
+// ansiexport.FormatPlain/FormatAnsi, or an HTML fragment for ansiexport.FormatHtml.
+type CommandTermExportRtnData struct {
+	Text      string `json:"text"`
+	LineCount int    `json:"linecount"` // total lines available, before StartLine/NumLines selection -- lets the frontend build a range picker
+}
+
+// CommandShareCommandOutputData renders BlockId's scrollback (see CommandTermExportData's
+// StartLine/NumLines, typically the range of a single command) as Format
+// (ansiexport.FormatPlain or ansiexport.FormatHtml), redacts likely secrets out of it, and writes
+// it to a local static snapshot file (see cmdshare.Create) -- a privacy-friendly, one-shot
+// replacement for a live webshare link when all the recipient needs is one command's output.
+type CommandShareCommandOutputData struct {
+	BlockId   string `json:"blockid" wshcontext:"BlockId"`
+	Format    string `json:"format"`
+	StartLine int    `json:"startline,omitempty"`
+	NumLines  int    `json:"numlines,omitempty"`
+}
+
+// CommandShareCommandOutputRtnData is ShareCommandOutputCommand's result.
+type CommandShareCommandOutputRtnData struct {
+	Path            string              `json:"path"`
+	RedactionReport secretredact.Report `json:"redactionreport,omitempty"`
+}
+
+// OutputFoldStateData is GetOutputFoldStateCommand's result -- see outputfold.State.
+type OutputFoldStateData struct {
+	LineCount      int  `json:"linecount"`
+	Folded         bool `json:"folded"`
+	ManualOverride bool `json:"manualoverride,omitempty"`
+}
+
 type WaveFileInfo struct {
 	ZoneId    string                 `json:"zoneid"`
 	Name      string                 `json:"name"`
@@ -390,15 +761,27 @@ type WaveAIPromptMessageType struct {
 }
 
 type WaveAIOptsType struct {
-	Model      string `json:"model"`
-	APIType    string `json:"apitype,omitempty"`
-	APIToken   string `json:"apitoken"`
-	OrgID      string `json:"orgid,omitempty"`
-	APIVersion string `json:"apiversion,omitempty"`
-	BaseURL    string `json:"baseurl,omitempty"`
-	MaxTokens  int    `json:"maxtokens,omitempty"`
-	MaxChoices int    `json:"maxchoices,omitempty"`
-	TimeoutMs  int    `json:"timeoutms,omitempty"`
+	Model      string   `json:"model"`
+	APIType    string   `json:"apitype,omitempty"`
+	APIToken   string   `json:"apitoken"`
+	APITokens  []string `json:"apitokens,omitempty"` // when set, RunAICommand rotates through these instead of APIToken
+	OrgID      string   `json:"orgid,omitempty"`
+	APIVersion string   `json:"apiversion,omitempty"`
+	BaseURL    string   `json:"baseurl,omitempty"`
+	MaxTokens  int      `json:"maxtokens,omitempty"`
+	MaxChoices int      `json:"maxchoices,omitempty"`
+	TimeoutMs  int      `json:"timeoutms,omitempty"`
+
+	// AuthHeaderTemplate, when set, is applied to every outgoing AI request instead of
+	// the backend's default auth header. One "Header-Name: value" pair per line; value
+	// may reference {{apitoken}} and {{orgid}}. Used for self-hosted gateways that expect
+	// a different auth scheme than the upstream provider.
+	AuthHeaderTemplate string `json:"authheadertemplate,omitempty"`
+
+	// UseMcpTools, when set, surfaces tools from the user's configured MCP servers (see
+	// wconfig mcpservers.json) to the model as function-calling tools. Only supported by the
+	// openai and google backends.
+	UseMcpTools bool `json:"usemcptools,omitempty"`
 }
 
 type WaveAIPacketType struct {
@@ -445,6 +828,7 @@ type FileInfo struct {
 type CommandRemoteStreamFileData struct {
 	Path      string `json:"path"`
 	ByteRange string `json:"byterange,omitempty"`
+	BwLimit   int64  `json:"bwlimit,omitempty"` // throttle reads to this many bytes/sec; 0 means unlimited
 }
 
 type CommandRemoteStreamFileRtnData struct {
@@ -456,20 +840,366 @@ type CommandRemoteWriteFileData struct {
 	Path       string      `json:"path"`
 	Data64     string      `json:"data64"`
 	CreateMode os.FileMode `json:"createmode,omitempty"`
+	BwLimit    int64       `json:"bwlimit,omitempty"` // throttle writes to this many bytes/sec; 0 means unlimited
+	// ExpectedModTime, when non-zero, is the unix-millis mtime the caller last read the file at
+	// (e.g. when it loaded the buffer it's now saving). If the file's current mtime doesn't match,
+	// the write is skipped and CommandRemoteFileWriteRtnData.Conflict is set instead of silently
+	// overwriting a change the caller never saw. Zero disables the check (the historical behavior).
+	ExpectedModTime int64 `json:"expectedmodtime,omitempty"`
+}
+
+// CommandRemoteFileWriteRtnData is the result of RemoteWriteFileCommand. When Conflict is set, the
+// file was NOT written; ModTime is the file's actual current mtime (unix millis), for the caller to
+// use as the new ExpectedModTime on a retry (e.g. after the user picks "overwrite"). When Conflict
+// is unset, ModTime is the mtime the file has right after this write, for the caller to remember as
+// the baseline for its next save.
+type CommandRemoteFileWriteRtnData struct {
+	Conflict bool  `json:"conflict,omitempty"`
+	ModTime  int64 `json:"modtime,omitempty"`
+}
+
+type ProcessInfo struct {
+	Pid        int32   `json:"pid"`
+	Ppid       int32   `json:"ppid"`
+	Name       string  `json:"name"`
+	Cmdline    string  `json:"cmdline,omitempty"`
+	Username   string  `json:"username,omitempty"`
+	Status     string  `json:"status,omitempty"`
+	Nice       int32   `json:"nice"`
+	CpuPercent float64 `json:"cpupercent"`
+	MemPercent float32 `json:"mempercent"`
+	MemRssKb   uint64  `json:"memrsskb"`
+	CreateTime int64   `json:"createtime,omitempty"` // unix ms
+}
+
+type CommandRemoteProcessSignalData struct {
+	Pid    int32  `json:"pid"`
+	Signal string `json:"signal"` // e.g. "SIGTERM", "SIGKILL"
+}
+
+type CommandRemoteProcessReniceData struct {
+	Pid      int32 `json:"pid"`
+	Priority int32 `json:"priority"` // nice value (lower is higher priority), unix range is roughly -20..19
+}
+
+// CommandRemoteRunCommandData runs a one-off shell command on whichever side receives the RPC
+// call (local or a specific connection, via RpcOpts.Route) -- see pkg/jobqueue, which uses this
+// to execute queued jobs.
+type CommandRemoteRunCommandData struct {
+	CmdStr    string `json:"cmdstr"`
+	Cwd       string `json:"cwd,omitempty"`
+	TimeoutMs int    `json:"timeoutms,omitempty"` // defaults to 30000
+}
+
+type CommandRemoteRunCommandRtnData struct {
+	Output   string `json:"output"`
+	ExitCode int    `json:"exitcode"`
+}
+
+type ListenerInfo struct {
+	Pid         int32  `json:"pid,omitempty"`
+	ProcessName string `json:"processname,omitempty"`
+	Protocol    string `json:"protocol"` // "tcp" or "udp"
+	LocalAddr   string `json:"localaddr"`
+	Port        int32  `json:"port"`
+}
+
+type GitStatusInfo struct {
+	IsRepo   bool   `json:"isrepo"`
+	Branch   string `json:"branch,omitempty"`
+	IsDirty  bool   `json:"isdirty,omitempty"`
+	Ahead    int    `json:"ahead,omitempty"`
+	Behind   int    `json:"behind,omitempty"`
+	RepoRoot string `json:"reporoot,omitempty"`
+}
+
+type CommandRemoteGitPrStatusData struct {
+	Path            string `json:"path"`
+	RefreshInterval int64  `json:"refreshinterval,omitempty"` // ms, 0 uses the default (30000)
+}
+
+type GitPrInfo struct {
+	Number     int    `json:"number"`
+	Title      string `json:"title"`
+	Url        string `json:"url"`
+	Author     string `json:"author,omitempty"`
+	Draft      bool   `json:"draft,omitempty"`
+	CiStatus   string `json:"cistatus,omitempty"` // "success", "failure", "pending", "" if unknown
+	HeadBranch string `json:"headbranch,omitempty"`
+}
+
+type GitPrStatusData struct {
+	Provider  string      `json:"provider"` // "github" or "gitlab"
+	Owner     string      `json:"owner"`
+	Repo      string      `json:"repo"`
+	PRs       []GitPrInfo `json:"prs"`
+	UpdatedTs int64       `json:"updatedts"` // unix ms
+}
+
+type CommandRemoteLogTailData struct {
+	Source   string `json:"source"` // file path to tail
+	Follow   bool   `json:"follow,omitempty"`
+	Filter   string `json:"filter,omitempty"`   // case-insensitive substring filter, evaluated server-side
+	JsonMode bool   `json:"jsonmode,omitempty"` // attempt to parse each line as a JSON object and extract its top-level fields
+}
+
+type LogLineData struct {
+	Line   string            `json:"line"`
+	Level  string            `json:"level,omitempty"` // "error", "warn", "info", "debug", "" if unknown
+	Fields map[string]string `json:"fields,omitempty"`
+}
+
+type CommandRemoteFileDataTableData struct {
+	Path       string            `json:"path"`
+	Offset     int               `json:"offset,omitempty"`
+	Limit      int               `json:"limit,omitempty"`
+	SortColumn string            `json:"sortcolumn,omitempty"`
+	SortDesc   bool              `json:"sortdesc,omitempty"`
+	Filters    map[string]string `json:"filters,omitempty"` // column name -> case-insensitive substring filter
+}
+
+type FileDataColumn struct {
+	Name string `json:"name"`
+	Type string `json:"type"` // "number", "bool", or "string", inferred by sampling the column's values
+}
+
+type FileDataTablePage struct {
+	Columns   []FileDataColumn `json:"columns"`
+	Rows      [][]string       `json:"rows"`
+	TotalRows int              `json:"totalrows"` // count after filtering, before paging
+	Offset    int              `json:"offset"`
+}
+
+type CommandRemoteJupyterKernelExecuteData struct {
+	KernelId string `json:"kernelid"` // identifies a persistent kernel process; cells sharing a KernelId share state
+	Code     string `json:"code"`
+}
+
+// JupyterOutputData is one piece of a cell's output, streamed as it's produced. Type is
+// "stream" (captured stdout/stderr text), "result" (the cell's trailing expression value),
+// "error" (an exception traceback), or "done" (terminal message, no Data/MimeType). MimeType
+// is "text/plain" or "text/html" (rich outputs beyond that, e.g. images, aren't supported by
+// this build's scoped-down kernel -- see pkg/wshrpc/wshremote/jupyterkernel.go).
+type JupyterOutputData struct {
+	Type     string `json:"type"`
+	MimeType string `json:"mimetype,omitempty"`
+	Data     string `json:"data,omitempty"`
+}
+
+type CommandRemoteHttpRequestData struct {
+	Method  string            `json:"method"`
+	Url     string            `json:"url"`
+	Headers map[string]string `json:"headers,omitempty"`
+	Body    string            `json:"body,omitempty"`
+}
+
+// HttpTimingData breaks a request down the way a basic request-level waterfall would: how long
+// DNS lookup, connection setup, TLS handshake, and waiting-for-first-byte each took, plus the
+// overall total. All values are 0 for phases that didn't apply (e.g. TlsMs for a plain-http URL,
+// or DnsMs/ConnectMs when a keep-alive connection was reused).
+type HttpTimingData struct {
+	DnsMs     int64 `json:"dnsms"`
+	ConnectMs int64 `json:"connectms"`
+	TlsMs     int64 `json:"tlsms"`
+	TtfbMs    int64 `json:"ttfbms"` // time to first response byte, measured from request start
+	TotalMs   int64 `json:"totalms"`
+}
+
+type HttpResponseData struct {
+	StatusCode int               `json:"statuscode"`
+	Status     string            `json:"status"`
+	Headers    map[string]string `json:"headers"`
+	Body       string            `json:"body"`
+	Timing     HttpTimingData    `json:"timing"`
+}
+
+// CommandRemoteImageOpData describes an edit-toolbar action in the image preview block. Ops are
+// applied in the order rotate, then resize, then format conversion (see pkg/imageops); zero
+// values mean "don't apply this op" except RotateDegrees, where 0 is itself a no-op rotation.
+type CommandRemoteImageOpData struct {
+	Path          string `json:"path"`
+	RotateDegrees int    `json:"rotatedegrees,omitempty"`
+	Width         int    `json:"width,omitempty"`
+	Height        int    `json:"height,omitempty"`
+	Format        string `json:"format,omitempty"` // "png", "jpeg", "gif"; blank keeps the source format
+	Quality       int    `json:"quality,omitempty"`
+}
+
+type ImageOpResult struct {
+	Data64   string `json:"data64"`
+	MimeType string `json:"mimetype"`
+}
+
+// ArchiveEntryInfo describes one entry of a zip/tar/tgz archive, as returned by
+// RemoteArchiveListCommand (see pkg/archiveops).
+type ArchiveEntryInfo struct {
+	Name     string `json:"name"`
+	Size     int64  `json:"size"`
+	IsDir    bool   `json:"isdir"`
+	ModTime  int64  `json:"modtime"`
+	MimeType string `json:"mimetype"`
+}
+
+type CommandRemoteArchiveReadEntryData struct {
+	Path      string `json:"path"`
+	EntryName string `json:"entryname"`
+}
+
+type ArchiveEntryContent struct {
+	Data64   string `json:"data64"`
+	MimeType string `json:"mimetype"`
+}
+
+// CommandRemoteFileCopyData copies a single file (not a directory) from SrcPath to DestPath. Used
+// as the per-item primitive for FileService.BulkFileOp's copy/move kinds (see
+// pkg/service/fileservice); Overwrite controls whether an existing DestPath is replaced.
+type CommandRemoteFileCopyData struct {
+	SrcPath   string `json:"srcpath"`
+	DestPath  string `json:"destpath"`
+	Overwrite bool   `json:"overwrite,omitempty"`
+}
+
+type CommandRemoteArchiveExtractData struct {
+	Path       string   `json:"path"`
+	EntryNames []string `json:"entrynames"`
+	DestDir    string   `json:"destdir"`
+}
+
+// CommandRemoteDiskUsageData requests a du-style size breakdown of Path. Exclude is a list of
+// glob patterns (see path/filepath.Match) matched against each entry's base name; matching
+// entries are skipped entirely. ForceRescan bypasses the scan cache kept by RemoteDiskUsageCommand
+// (see pkg/diskusage) and always walks the tree fresh.
+type CommandRemoteDiskUsageData struct {
+	Path        string   `json:"path"`
+	Exclude     []string `json:"exclude,omitempty"`
+	ForceRescan bool     `json:"forcerescan,omitempty"`
+}
+
+// DiskUsageNode mirrors pkg/diskusage.Node for the wire.
+type DiskUsageNode struct {
+	Name     string           `json:"name"`
+	Path     string           `json:"path"`
+	Size     int64            `json:"size"`
+	IsDir    bool             `json:"isdir"`
+	Children []*DiskUsageNode `json:"children,omitempty"`
+}
+
+// DiskUsageProgressData is streamed by RemoteDiskUsageCommand: zero or more progress events
+// (Done false) followed by exactly one final event (Done true) carrying the completed (or, if the
+// request context was cancelled, partial) Result tree.
+type DiskUsageProgressData struct {
+	Path         string         `json:"path"`
+	FilesScanned int            `json:"filesscanned,omitempty"`
+	CurrentPath  string         `json:"currentpath,omitempty"`
+	Done         bool           `json:"done,omitempty"`
+	Cached       bool           `json:"cached,omitempty"` // Result came from the scan cache rather than a fresh walk
+	Result       *DiskUsageNode `json:"result,omitempty"` // set only when Done
+}
+
+// CommandRemoteFileDiffData requests a line diff of Path1 against Path2, against Text2 (a literal
+// in-memory text, e.g. an unsaved editor buffer), or, when GitHead is set, against Path1's content
+// at the git HEAD revision of the repo containing it. Exactly one of Path2, Text2, or GitHead
+// should be set; Path2 and GitHead still require reading Path1 on whichever host the command is
+// routed to, so a single wsh invocation diffs two paths on the same connection; diffing across two
+// different connections isn't supported, since that would require stitching together two
+// independently-routed RPC calls instead of one.
+type CommandRemoteFileDiffData struct {
+	Path1   string `json:"path1"`
+	Path2   string `json:"path2,omitempty"`
+	Text2   string `json:"text2,omitempty"`
+	GitHead bool   `json:"githead,omitempty"`
+}
+
+// CommandRemoteFileDiffRtnData is the result of RemoteFileDiffCommand. Label1/Label2 are
+// human-readable identifiers for the two sides (file paths, or "<path> (HEAD)" for a git-head
+// comparison), for display above a side-by-side or unified rendering of Lines.
+type CommandRemoteFileDiffRtnData struct {
+	Label1 string              `json:"label1"`
+	Label2 string              `json:"label2"`
+	Lines  []diffutil.DiffLine `json:"lines"`
+}
+
+// CommandRemoteLspRequestData sends a JSON-RPC request to the language server for BlockId,
+// launching it (via Command, a shell command run on whichever host the call is routed to, e.g.
+// "gopls" or "typescript-language-server --stdio") and performing the LSP initialize handshake
+// against RootUri if it isn't already running. Method/Params are passed through verbatim, e.g.
+// "textDocument/completion" with an LSP CompletionParams payload -- this proxy doesn't interpret
+// LSP semantics, it just bridges stdio-framed JSON-RPC to wshrpc.
+type CommandRemoteLspRequestData struct {
+	BlockId string          `json:"blockid"`
+	Command string          `json:"command"`
+	RootUri string          `json:"rooturi"`
+	Method  string          `json:"method"`
+	Params  json.RawMessage `json:"params"`
+}
+
+// CommandRemoteLspRequestRtnData is the result of RemoteLspRequestCommand: the raw "result" field
+// of the language server's JSON-RPC response, left for the caller to unmarshal into the LSP type
+// appropriate for the request's Method.
+type CommandRemoteLspRequestRtnData struct {
+	Result json.RawMessage `json:"result"`
+}
+
+// CommandRemoteLspNotifyData sends a JSON-RPC notification (no response expected) to BlockId's
+// language server, e.g. "textDocument/didOpen" or "textDocument/didChange" to keep it in sync with
+// the editor buffer. The server must already be running (started by a prior RemoteLspRequestCommand).
+type CommandRemoteLspNotifyData struct {
+	BlockId string          `json:"blockid"`
+	Method  string          `json:"method"`
+	Params  json.RawMessage `json:"params"`
+}
+
+type CommandDbQueryData struct {
+	Connection string `json:"connection"` // key into config's dbconnections.json
+	Query      string `json:"query"`
+	Params     []any  `json:"params,omitempty"` // positional bind parameters
+	Offset     int    `json:"offset,omitempty"`
+	Limit      int    `json:"limit,omitempty"`
+	QueryId    string `json:"queryid,omitempty"` // caller-chosen id, passed to DbQueryCancelCommand to cancel this query
+}
+
+type DbQueryResult struct {
+	Columns []string   `json:"columns"`
+	Rows    [][]string `json:"rows"`
+	Offset  int        `json:"offset"`
+	HasMore bool       `json:"hasmore"` // true if more rows exist past Offset+len(Rows)
+}
+
+type CommandConnPortForwardOpenData struct {
+	Connection string `json:"connection"`
+	RemotePort int32  `json:"remoteport"`
+	RemoteHost string `json:"remotehost,omitempty"` // defaults to "localhost"
+}
+
+type ConnPortForwardInfo struct {
+	ForwardId  string `json:"forwardid"`
+	Connection string `json:"connection"`
+	LocalPort  int32  `json:"localport"`
+	RemoteHost string `json:"remotehost"`
+	RemotePort int32  `json:"remoteport"`
 }
 
 type ConnKeywords struct {
-	ConnWshEnabled          *bool `json:"conn:wshenabled,omitempty"`
-	ConnAskBeforeWshInstall *bool `json:"conn:askbeforewshinstall,omitempty"`
-	ConnOverrideConfig      bool  `json:"conn:overrideconfig,omitempty"`
+	ConnWshEnabled           *bool    `json:"conn:wshenabled,omitempty"`
+	ConnAskBeforeWshInstall  *bool    `json:"conn:askbeforewshinstall,omitempty"`
+	ConnOverrideConfig       bool     `json:"conn:overrideconfig,omitempty"`
+	ConnBwLimitBps           *int64   `json:"conn:bwlimitbps,omitempty"`           // per-connection cap on file copy/streaming throughput, in bytes/sec; overrides file:bwlimitbps, 0 means unlimited
+	ConnSysinfoSampleRateMs  *int64   `json:"conn:sysinfosampleratems,omitempty"`  // per-connection sysinfo sample interval, in ms; overrides sysinfo:sampleratems
+	ConnSysinfoRetention     *int     `json:"conn:sysinforetention,omitempty"`     // per-connection sysinfo history retention (number of points); overrides sysinfo:retention
+	ConnSudoCacheTtlMs       *int64   `json:"conn:sudocachettlms,omitempty"`       // per-connection sudo-password cache TTL, in ms; overrides the global conn:sudocachettlms setting; see ResolveSudoCacheTtl
+	ConnDisconnectOnIdleLock *bool    `json:"conn:disconnectonidlelock,omitempty"` // disconnect this connection when idle-lock triggers (see idlelock:timeoutminutes), for "sensitive remotes" in compliance environments
+	ConnTags                 []string `json:"conn:tags,omitempty"`                 // free-form inventory labels (e.g. "env:prod", "role:db"); see ResolveConnectionsByTag, importable from ssh_config via a "# wave:tags=..." comment
+	ConnNotes                string   `json:"conn:notes,omitempty"`                // free-form operator notes about this connection, shown in the connections switcher
 
 	DisplayHidden *bool   `json:"display:hidden,omitempty"`
 	DisplayOrder  float32 `json:"display:order,omitempty"`
 
-	TermClear      bool    `json:"term:*,omitempty"`
-	TermFontSize   float64 `json:"term:fontsize,omitempty"`
-	TermFontFamily string  `json:"term:fontfamily,omitempty"`
-	TermTheme      string  `json:"term:theme,omitempty"`
+	TermClear       bool    `json:"term:*,omitempty"`
+	TermFontSize    float64 `json:"term:fontsize,omitempty"`
+	TermFontFamily  string  `json:"term:fontfamily,omitempty"`
+	TermTheme       string  `json:"term:theme,omitempty"`
+	TermOsc52       *bool   `json:"term:osc52,omitempty"`       // per-connection allow/deny, overrides the global term:disableosc52 setting
+	TermPasteSafety *bool   `json:"term:pastesafety,omitempty"` // per-connection allowlist override for the pasteguard confirmation prompt; false skips it for this connection, overrides the global term:disablepastesafety setting
 
 	SshUser                         *string  `json:"ssh:user,omitempty"`
 	SshHostName                     *string  `json:"ssh:hostname,omitempty"`
@@ -525,15 +1255,25 @@ type ConnConfigRequest struct {
 	MetaMapType waveobj.MetaMapType `json:"metamaptype"`
 }
 
+// CommandConnImportTagsRtnData is ConnImportTagsCommand's result -- the tags/notes found (and
+// saved to connections.json) from a "# wave:tags=..." comment in ~/.ssh/config, if any.
+type CommandConnImportTagsRtnData struct {
+	Tags  []string `json:"tags,omitempty"`
+	Notes string   `json:"notes,omitempty"`
+	Found bool     `json:"found"`
+}
+
 type ConnStatus struct {
-	Status        string `json:"status"`
-	WshEnabled    bool   `json:"wshenabled"`
-	Connection    string `json:"connection"`
-	Connected     bool   `json:"connected"`
-	HasConnected  bool   `json:"hasconnected"` // true if it has *ever* connected successfully
-	ActiveConnNum int    `json:"activeconnnum"`
-	Error         string `json:"error,omitempty"`
-	WshError      string `json:"wsherror,omitempty"`
+	Status           string `json:"status"`
+	WshEnabled       bool   `json:"wshenabled"`
+	Connection       string `json:"connection"`
+	Connected        bool   `json:"connected"`
+	HasConnected     bool   `json:"hasconnected"` // true if it has *ever* connected successfully
+	ActiveConnNum    int    `json:"activeconnnum"`
+	Error            string `json:"error,omitempty"`
+	WshError         string `json:"wsherror,omitempty"`
+	InteractiveBytes int64  `json:"interactivebytes,omitempty"` // cumulative bytes sent on the interactive wsh rpc channel
+	BulkBytes        int64  `json:"bulkbytes,omitempty"`        // cumulative bytes sent on the bulk (file transfer) wsh rpc channel
 }
 
 type WebSelectorOpts struct {
@@ -558,11 +1298,19 @@ type BlockInfoData struct {
 }
 
 type WaveNotificationOptions struct {
-	Title  string `json:"title,omitempty"`
-	Body   string `json:"body,omitempty"`
-	Silent bool   `json:"silent,omitempty"`
+	Title   string `json:"title,omitempty"`
+	Body    string `json:"body,omitempty"`
+	Silent  bool   `json:"silent,omitempty"`
+	Urgency string `json:"urgency,omitempty"` // NotifyUrgencyLow, NotifyUrgencyNormal (default), or NotifyUrgencyCritical -- Linux-only, see Electron's Notification urgency option
 }
 
+// Urgency levels for WaveNotificationOptions.Urgency.
+const (
+	NotifyUrgencyLow      = "low"
+	NotifyUrgencyNormal   = "normal"
+	NotifyUrgencyCritical = "critical"
+)
+
 type VDomUrlRequestData struct {
 	Method  string            `json:"method"`
 	URL     string            `json:"url"`
@@ -577,11 +1325,19 @@ type VDomUrlRequestResponse struct {
 }
 
 type WaveInfoData struct {
-	Version   string `json:"version"`
-	ClientId  string `json:"clientid"`
-	BuildTime string `json:"buildtime"`
-	ConfigDir string `json:"configdir"`
-	DataDir   string `json:"datadir"`
+	Version           string   `json:"version"`
+	ClientId          string   `json:"clientid"`
+	BuildTime         string   `json:"buildtime"`
+	ConfigDir         string   `json:"configdir"`
+	DataDir           string   `json:"datadir"`
+	Profile           string   `json:"profile,omitempty"`           // active --profile name, empty when unprofiled
+	AvailableProfiles []string `json:"availableprofiles,omitempty"` // other profiles found alongside this one
+}
+
+type ClientCapabilitiesData struct {
+	Version      string   `json:"version"`
+	BuildTime    string   `json:"buildtime"`
+	Capabilities []string `json:"capabilities"`
 }
 
 type WorkspaceInfoData struct {
@@ -593,6 +1349,312 @@ type AiMessageData struct {
 	Message string `json:"message,omitempty"`
 }
 
+type CommandSummarizeData struct {
+	BlockId  string `json:"blockid" wshcontext:"BlockId"`
+	FileName string `json:"filename,omitempty"` // defaults to "term"
+}
+
+type CommandSummarizeRtnData struct {
+	Summary         string              `json:"summary"`
+	OutputHash      string              `json:"outputhash"`
+	CacheHit        bool                `json:"cachehit"`
+	RedactionReport secretredact.Report `json:"redactionreport,omitempty"`
+}
+
+type CommandRedactTextData struct {
+	Text string `json:"text"`
+}
+
+type CommandRedactTextRtnData struct {
+	Text   string              `json:"text"`
+	Report secretredact.Report `json:"report"`
+}
+
+type TabListEntry struct {
+	TabId       string `json:"tabid"`
+	Name        string `json:"name"`
+	WorkspaceId string `json:"workspaceid"`
+}
+
+type CommandRunShellCommandData struct {
+	CmdStr    string `json:"cmdstr"`
+	Cwd       string `json:"cwd,omitempty"`
+	TimeoutMs int    `json:"timeoutms,omitempty"` // defaults to 30000
+}
+
+type CommandRunShellCommandRtnData struct {
+	Output   string `json:"output"`
+	ExitCode int    `json:"exitcode"`
+}
+
+// CommandRunMultiCommandData runs the same shell command concurrently across a set of
+// connections -- a lightweight ansible-ad-hoc substitute, see "wsh run multi".
+type CommandRunMultiCommandData struct {
+	Connections []string `json:"connections"`
+	CmdStr      string   `json:"cmdstr"`
+	TimeoutMs   int      `json:"timeoutms,omitempty"` // defaults to 30000, applies per-connection
+}
+
+// MultiCommandResult is one connection's outcome from a CommandRunMultiCommandData request.
+type MultiCommandResult struct {
+	Connection string `json:"connection"`
+	Output     string `json:"output,omitempty"`
+	ExitCode   int    `json:"exitcode"`
+	Err        string `json:"err,omitempty"` // set if the RPC call itself failed (e.g. connection unreachable), as opposed to a nonzero ExitCode
+}
+
+type CommandRunMultiCommandRtnData struct {
+	Results []MultiCommandResult `json:"results"`
+}
+
+// McpConfigData reports the mcp:* permission toggles so "wsh mcp" knows which tools it's
+// allowed to advertise/run without having to parse wconfig itself (it runs as a separate process).
+type McpConfigData struct {
+	Enabled          bool `json:"enabled"`
+	AllowRunCommand  bool `json:"allowruncommand"`
+	AllowReadFile    bool `json:"allowreadfile"`
+	AllowListTabs    bool `json:"allowlisttabs"`
+	AllowFetchOutput bool `json:"allowfetchoutput"`
+}
+
+type CommandSetPluginEnabledData struct {
+	PluginName string `json:"pluginname"`
+	Enabled    bool   `json:"enabled"`
+}
+
+// CommandRegisterVDomRendererData registers (or updates) a custom preview renderer, persisted to
+// renderers.json. RendererId should be stable across runs of the same app (e.g. derived from its
+// RootComponentName) so re-registering on every launch just updates the existing entry.
+type CommandRegisterVDomRendererData struct {
+	RendererId   string                        `json:"rendererid"`
+	Registration vdom.VDomRendererRegistration `json:"registration"`
+	BlockDef     waveobj.BlockDef              `json:"blockdef"`
+}
+
+// CommandImportTermThemeData imports a base16 or iTerm (.itermcolors) color scheme file's raw
+// contents as a new entry in termthemes.json, keyed by ThemeId (generate one, e.g. from the
+// scheme's file name, if the caller doesn't already have one in mind).
+type CommandImportTermThemeData struct {
+	ThemeId string `json:"themeid"`
+	Format  string `json:"format"` // wconfig.TermThemeFormat_Base16 or wconfig.TermThemeFormat_ITerm
+	Data    string `json:"data"`   // raw file contents of the scheme being imported
+}
+
+// FontStatusInfo is one font family CheckFontsCommand looked up -- either term:fontfamily itself
+// (a comma-separated fallback list, the same convention CSS font-family uses) or one connection's
+// override of it -- and whether it was found installed or is bundled with the app (see
+// fontcheck.BundledFonts).
+type FontStatusInfo struct {
+	Family    string `json:"family"`
+	Installed bool   `json:"installed"`
+	Bundled   bool   `json:"bundled"`
+}
+
+// FontStatusData is CheckFontsCommand's result: the installed/bundled status of every font family
+// referenced by the global term:fontfamily setting or any connection's override of it, so the
+// frontend can warn about a configured font that isn't actually available instead of silently
+// falling back to the browser default.
+type FontStatusData struct {
+	Fonts []FontStatusInfo `json:"fonts"`
+}
+
+// ActionRegistryEntry is one fuzzy-searchable entry in the command palette: a launchable widget,
+// a togglable setting, or a configured connection. The frontend does the actual fuzzy matching
+// against Title/SubTitle/Keywords; this command just enumerates what's available.
+type ActionRegistryEntry struct {
+	Id       string   `json:"id"`
+	Category string   `json:"category"` // "widget", "setting", "connection"
+	Title    string   `json:"title"`
+	SubTitle string   `json:"subtitle,omitempty"`
+	Icon     string   `json:"icon,omitempty"`
+	Keywords []string `json:"keywords,omitempty"`
+}
+
+// KeybindingActionInfo mirrors pkg/wconfig.KeybindingAction -- one built-in action a keybinding's
+// Action field may name.
+type KeybindingActionInfo struct {
+	Id          string `json:"id"`
+	DisplayName string `json:"displayname"`
+	Category    string `json:"category"`
+}
+
+// ResolvedKeybinding is one configured binding with its chord already resolved for the requested
+// platform (see pkg/wconfig.ResolveKeybindingChord), so the frontend doesn't need to reimplement
+// the per-platform override logic.
+type ResolvedKeybinding struct {
+	Id       string `json:"id"`
+	Action   string `json:"action"`
+	Chord    string `json:"chord"`
+	Disabled bool   `json:"disabled,omitempty"`
+}
+
+// KeybindingConflictInfo mirrors pkg/wconfig.KeybindingConflict, already filtered to the
+// requested platform.
+type KeybindingConflictInfo struct {
+	Chord string   `json:"chord"`
+	Ids   []string `json:"ids"`
+}
+
+// KeybindingRegistryData is KeybindingRegistryCommand's result: the fixed action vocabulary, the
+// user's configured bindings with chords resolved for the requested platform, and any conflicts
+// among them on that platform.
+type KeybindingRegistryData struct {
+	Actions   []KeybindingActionInfo   `json:"actions"`
+	Bindings  []ResolvedKeybinding     `json:"bindings"`
+	Conflicts []KeybindingConflictInfo `json:"conflicts"`
+}
+
+// InputEditActionInfo mirrors pkg/wconfig.InputEditAction -- one line-editing operation covered by
+// the emacs/vi default chord sets.
+type InputEditActionInfo struct {
+	Id          string `json:"id"`
+	DisplayName string `json:"displayname"`
+}
+
+// InputModeChordsData is InputModeChordsCommand's result: the user's configured line-editing mode
+// (see SettingsType.EditorInputMode, defaulting to wconfig.InputModeEmacs), the action vocabulary,
+// and that mode's action-id-to-chord table, so a command-input field can bind its own key handler
+// without duplicating the default tables.
+type InputModeChordsData struct {
+	Mode    string                `json:"mode"`
+	Actions []InputEditActionInfo `json:"actions"`
+	Chords  map[string]string     `json:"chords"`
+}
+
+// CommandSearchCmdHistoryData scopes a command-history search. QueryType "cwd" restricts results
+// to Cwd, "connection" restricts to Connection, "block" restricts to BlockId, and anything else
+// ("" or "all") returns everything, most recent first. Cursor continues a prior search -- pass
+// the NextCursor from the previous CommandSearchCmdHistoryRtnData to fetch the page after it;
+// leave empty to start from the most recent item.
+type CommandSearchCmdHistoryData struct {
+	QueryType  string `json:"querytype,omitempty"`
+	Cwd        string `json:"cwd,omitempty"`
+	Connection string `json:"connection,omitempty"`
+	BlockId    string `json:"blockid,omitempty"`
+	MaxResults int    `json:"maxresults,omitempty"`
+	Cursor     string `json:"cursor,omitempty"`
+}
+
+// CommandSearchCmdHistoryRtnData is one page of a keyset-paginated history search. NextCursor is
+// an opaque token (pass it back as CommandSearchCmdHistoryData.Cursor to fetch the next page) --
+// it's empty once there are no more results, so callers can page until it's empty rather than
+// tracking an offset that drifts as new history is recorded concurrently.
+type CommandSearchCmdHistoryRtnData struct {
+	Items      []CmdHistoryEntry `json:"items"`
+	NextCursor string            `json:"nextcursor,omitempty"`
+}
+
+// CmdHistoryEntry is one recorded execution of a "cmd" controller block (see
+// pkg/blockcontroller and pkg/wstore's cmd_history table).
+type CmdHistoryEntry struct {
+	HistoryId  string `json:"historyid"`
+	Ts         int64  `json:"ts"`
+	BlockId    string `json:"blockid"`
+	Connection string `json:"connection"`
+	Cwd        string `json:"cwd"`
+	CmdStr     string `json:"cmdstr"`
+	ExitCode   int    `json:"exitcode"`
+	DurationMs int64  `json:"durationms"`
+}
+
+type CommandExportSettingsBundleData struct {
+	Path string `json:"path"`
+}
+
+type CommandImportSettingsBundleData struct {
+	Path string `json:"path"`
+}
+
+type CommandApplySettingsBundleData struct {
+	Path         string   `json:"path"`
+	AcceptedKeys []string `json:"acceptedkeys"`
+}
+
+// SettingsSyncConflict mirrors pkg/settingssync.Conflict -- one bundle key whose incoming value
+// differs from what's configured locally, surfaced by ImportSettingsBundleCommand so the UI can
+// prompt before ApplySettingsBundleCommand overwrites anything.
+type SettingsSyncConflict struct {
+	Category   string `json:"category"`
+	Key        string `json:"key"`
+	LocalJson  string `json:"localjson"`
+	RemoteJson string `json:"remotejson"`
+}
+
+// TelemetryInspectData reports the current granular telemetry settings and the exact JSON
+// payload that would be uploaded the next time telemetry is sent, so a local viewer can show
+// precisely what would go out before it does.
+type TelemetryInspectData struct {
+	TelemetryEnabled    bool   `json:"telemetryenabled"`
+	CrashReportsEnabled bool   `json:"crashreportsenabled"`
+	UsageCountsEnabled  bool   `json:"usagecountsenabled"`
+	AIMetadataEnabled   bool   `json:"aimetadataenabled"`
+	PendingPayloadJson  string `json:"pendingpayloadjson"`
+}
+
+// CrashReportSummary mirrors pkg/crashreport.CrashReport for the /debug:crashes listing -- the
+// full stack and log tail are included so the listing doubles as the detail view (there's no
+// separate "get one crash" command since the whole local crash directory is small).
+type CrashReportSummary struct {
+	Id        string   `json:"id"`
+	Timestamp int64    `json:"timestamp"`
+	DebugStr  string   `json:"debugstr"`
+	Recovered string   `json:"recovered"`
+	Stack     string   `json:"stack"`
+	Version   string   `json:"version"`
+	BuildTime string   `json:"buildtime"`
+	GoVersion string   `json:"goversion"`
+	OS        string   `json:"os"`
+	Arch      string   `json:"arch"`
+	LogTail   []string `json:"logtail,omitempty"`
+}
+
+// CommandDebugLogLevelData requests the current per-subsystem log levels (when Subsystem and Level
+// are both empty), or sets one (Subsystem may be "" to set the default level that subsystems
+// without their own override fall back to). Level must be one of "debug", "info", "warn", "error".
+type CommandDebugLogLevelData struct {
+	Subsystem string `json:"subsystem,omitempty"`
+	Level     string `json:"level,omitempty"`
+}
+
+// CommandDebugLogLevelRtnData reports the effective level for every subsystem with an override,
+// plus the default level under the "" key, after applying any requested change.
+type CommandDebugLogLevelRtnData struct {
+	Levels map[string]string `json:"levels"`
+}
+
+// CommandDebugProfileData requests a CPU or heap profile be captured to a file in the wave pprof
+// directory. Kind is "cpu" (samples for Seconds, default 30) or "heap" (an immediate snapshot,
+// Seconds is ignored).
+type CommandDebugProfileData struct {
+	Kind    string `json:"kind"`
+	Seconds int    `json:"seconds,omitempty"`
+}
+
+// CommandDebugProfileRtnData reports where the captured profile was written -- open it with
+// "go tool pprof <filepath>".
+type CommandDebugProfileRtnData struct {
+	FilePath string `json:"filepath"`
+}
+
+// DbTableStats mirrors pkg/wstore.TableStats / pkg/filestore.TableStats for the /client:dbstats
+// listing.
+type DbTableStats struct {
+	Name     string `json:"name"`
+	RowCount int64  `json:"rowcount"`
+}
+
+// DbStats mirrors pkg/wstore.DbStats / pkg/filestore.DbStats for the /client:dbstats listing --
+// one entry per sqlite store (wstore, filestore).
+type DbStats struct {
+	Name          string         `json:"name"`
+	FilePath      string         `json:"filepath"`
+	FileSizeBytes int64          `json:"filesizebytes"`
+	PageCount     int64          `json:"pagecount"`
+	PageSizeBytes int64          `json:"pagesizebytes"`
+	FreelistCount int64          `json:"freelistcount"`
+	Tables        []DbTableStats `json:"tables"`
+}
+
 type CommandVarData struct {
 	Key      string `json:"key"`
 	Val      string `json:"val,omitempty"`
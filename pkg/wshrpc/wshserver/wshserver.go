@@ -12,27 +12,56 @@ import (
 	"fmt"
 	"io/fs"
 	"log"
+	"log/slog"
+	"os"
+	"os/exec"
 	"path/filepath"
+	"reflect"
 	"regexp"
+	"runtime"
+	"runtime/pprof"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/skratchdot/open-golang/open"
+	"github.com/wavetermdev/waveterm/pkg/a11ystream"
+	"github.com/wavetermdev/waveterm/pkg/ansiexport"
 	"github.com/wavetermdev/waveterm/pkg/blockcontroller"
+	"github.com/wavetermdev/waveterm/pkg/cmdpolicy"
+	"github.com/wavetermdev/waveterm/pkg/cmdshare"
+	"github.com/wavetermdev/waveterm/pkg/crashreport"
+	"github.com/wavetermdev/waveterm/pkg/dbquery"
 	"github.com/wavetermdev/waveterm/pkg/filestore"
+	"github.com/wavetermdev/waveterm/pkg/fontcheck"
+	"github.com/wavetermdev/waveterm/pkg/histsync"
+	"github.com/wavetermdev/waveterm/pkg/hooks"
+	"github.com/wavetermdev/waveterm/pkg/idlelock"
+	"github.com/wavetermdev/waveterm/pkg/outputfold"
 	"github.com/wavetermdev/waveterm/pkg/panichandler"
 	"github.com/wavetermdev/waveterm/pkg/remote"
 	"github.com/wavetermdev/waveterm/pkg/remote/conncontroller"
+	"github.com/wavetermdev/waveterm/pkg/secretredact"
+	"github.com/wavetermdev/waveterm/pkg/settingssync"
+	"github.com/wavetermdev/waveterm/pkg/shellexec"
+	"github.com/wavetermdev/waveterm/pkg/sudocache"
 	"github.com/wavetermdev/waveterm/pkg/telemetry"
+	"github.com/wavetermdev/waveterm/pkg/termsearch"
 	"github.com/wavetermdev/waveterm/pkg/util/envutil"
+	"github.com/wavetermdev/waveterm/pkg/util/shellutil"
 	"github.com/wavetermdev/waveterm/pkg/util/utilfn"
 	"github.com/wavetermdev/waveterm/pkg/waveai"
 	"github.com/wavetermdev/waveterm/pkg/wavebase"
 	"github.com/wavetermdev/waveterm/pkg/waveobj"
+	"github.com/wavetermdev/waveterm/pkg/wcloud"
 	"github.com/wavetermdev/waveterm/pkg/wconfig"
 	"github.com/wavetermdev/waveterm/pkg/wcore"
+	"github.com/wavetermdev/waveterm/pkg/webshare"
+	"github.com/wavetermdev/waveterm/pkg/wlog"
+	"github.com/wavetermdev/waveterm/pkg/wplugin"
 	"github.com/wavetermdev/waveterm/pkg/wps"
 	"github.com/wavetermdev/waveterm/pkg/wshrpc"
+	"github.com/wavetermdev/waveterm/pkg/wshrpc/wshclient"
 	"github.com/wavetermdev/waveterm/pkg/wshutil"
 	"github.com/wavetermdev/waveterm/pkg/wsl"
 	"github.com/wavetermdev/waveterm/pkg/wstore"
@@ -81,6 +110,38 @@ func (ws *WshServer) StreamWaveAiCommand(ctx context.Context, request wshrpc.Wav
 	return waveai.RunAICommand(ctx, request)
 }
 
+// SummarizeCommand feeds a block's (possibly very large) output to the
+// configured AI backend using a map-reduce summarization strategy, caching
+// the result by the output's hash so repeat requests are free.
+func (ws *WshServer) SummarizeCommand(ctx context.Context, data wshrpc.CommandSummarizeData) (wshrpc.CommandSummarizeRtnData, error) {
+	fileName := data.FileName
+	if fileName == "" {
+		fileName = blockcontroller.BlockFile_Term
+	}
+	_, content, err := filestore.WFS.ReadFile(ctx, data.BlockId, fileName)
+	if err != nil {
+		return wshrpc.CommandSummarizeRtnData{}, fmt.Errorf("reading block output: %w", err)
+	}
+	if len(content) == 0 {
+		return wshrpc.CommandSummarizeRtnData{}, fmt.Errorf("block output is empty")
+	}
+	summary, hash, cacheHit, redactionReport, err := waveai.SummarizeOutput(ctx, waveai.DefaultOptsFromSettings(), string(content))
+	if err != nil {
+		return wshrpc.CommandSummarizeRtnData{}, err
+	}
+	return wshrpc.CommandSummarizeRtnData{Summary: summary, OutputHash: hash, CacheHit: cacheHit, RedactionReport: redactionReport}, nil
+}
+
+// RedactTextCommand runs text through the configured secretredact rules (see
+// wconfig.FullConfigType.RedactionRules), for the frontend to call before its own copy/export/
+// share actions on a block's output -- this tree has no unified export or session-recording
+// pipeline to hook a redaction pass into directly.
+func (ws *WshServer) RedactTextCommand(ctx context.Context, data wshrpc.CommandRedactTextData) (wshrpc.CommandRedactTextRtnData, error) {
+	rules := wconfig.GetWatcher().GetFullConfig().RedactionRules()
+	redacted, report := secretredact.Redact(data.Text, rules)
+	return wshrpc.CommandRedactTextRtnData{Text: redacted, Report: report}, nil
+}
+
 func MakePlotData(ctx context.Context, blockId string) error {
 	block, err := wstore.DBMustGet[*waveobj.Block](ctx, blockId)
 	if err != nil {
@@ -126,7 +187,7 @@ func (ws *WshServer) GetMetaCommand(ctx context.Context, data wshrpc.CommandGetM
 func (ws *WshServer) SetMetaCommand(ctx context.Context, data wshrpc.CommandSetMetaData) error {
 	log.Printf("SetMetaCommand: %s | %v\n", data.ORef, data.Meta)
 	oref := data.ORef
-	err := wstore.UpdateObjectMeta(ctx, oref, data.Meta, false)
+	err := wstore.UpdateObjectMetaBulk(ctx, []wstore.BulkMetaUpdate{{ORef: oref, Meta: data.Meta, IfVersion: data.IfVersion}})
 	if err != nil {
 		return fmt.Errorf("error updating object meta: %w", err)
 	}
@@ -134,6 +195,42 @@ func (ws *WshServer) SetMetaCommand(ctx context.Context, data wshrpc.CommandSetM
 	return nil
 }
 
+// GetMetaBulkCommand fetches metadata for several entities in one round trip. Each oref is
+// resolved independently -- a bad oref produces a MetaBulkResult.Error for that entry rather than
+// failing the whole call.
+func (ws *WshServer) GetMetaBulkCommand(ctx context.Context, data wshrpc.CommandGetMetaBulkData) ([]wshrpc.MetaBulkResult, error) {
+	rtn := make([]wshrpc.MetaBulkResult, len(data.ORefs))
+	for idx, oref := range data.ORefs {
+		obj, err := wstore.DBGetORef(ctx, oref)
+		if err != nil {
+			rtn[idx] = wshrpc.MetaBulkResult{ORef: oref, Error: err.Error()}
+			continue
+		}
+		if obj == nil {
+			rtn[idx] = wshrpc.MetaBulkResult{ORef: oref, Error: fmt.Sprintf("object not found: %s", oref)}
+			continue
+		}
+		rtn[idx] = wshrpc.MetaBulkResult{ORef: oref, Meta: waveobj.GetMeta(obj), Version: waveobj.GetVersion(obj)}
+	}
+	return rtn, nil
+}
+
+// SetMetaBulkCommand applies every item atomically (see wstore.UpdateObjectMetaBulk): if any
+// item's IfVersion check fails, none of the items are applied.
+func (ws *WshServer) SetMetaBulkCommand(ctx context.Context, data wshrpc.CommandSetMetaBulkData) error {
+	items := make([]wstore.BulkMetaUpdate, len(data.Items))
+	for idx, item := range data.Items {
+		items[idx] = wstore.BulkMetaUpdate{ORef: item.ORef, Meta: item.Meta, IfVersion: item.IfVersion}
+	}
+	if err := wstore.UpdateObjectMetaBulk(ctx, items); err != nil {
+		return fmt.Errorf("error updating object meta in bulk: %w", err)
+	}
+	for _, item := range data.Items {
+		sendWaveObjUpdate(item.ORef)
+	}
+	return nil
+}
+
 func sendWaveObjUpdate(oref waveobj.ORef) {
 	ctx, cancelFn := context.WithTimeout(context.Background(), 2*time.Second)
 	defer cancelFn()
@@ -240,14 +337,40 @@ func (ws *WshServer) ControllerResyncCommand(ctx context.Context, data wshrpc.Co
 	return blockcontroller.ResyncController(ctx, data.TabId, data.BlockId, data.RtOpts, data.ForceRestart)
 }
 
+func (ws *WshServer) ControllerRestartAllFailedCommand(ctx context.Context, data wshrpc.CommandControllerRestartAllFailedData) (wshrpc.CommandControllerRestartAllFailedRtnData, error) {
+	restarted, err := blockcontroller.RestartAllFailed(ctx, data.TabId)
+	if err != nil {
+		return wshrpc.CommandControllerRestartAllFailedRtnData{}, err
+	}
+	return wshrpc.CommandControllerRestartAllFailedRtnData{RestartedBlockIds: restarted}, nil
+}
+
+func (ws *WshServer) ListOrphanedDetachedCommand(ctx context.Context) (wshrpc.CommandListOrphanedDetachedRtnData, error) {
+	orphans, err := blockcontroller.ListOrphanedDetached(ctx)
+	if err != nil {
+		return wshrpc.CommandListOrphanedDetachedRtnData{}, err
+	}
+	rtnOrphans := make([]wshrpc.OrphanedDetachedCommandInfo, len(orphans))
+	for idx, orphan := range orphans {
+		rtnOrphans[idx] = wshrpc.OrphanedDetachedCommandInfo{BlockId: orphan.BlockId, Pid: orphan.Pid}
+	}
+	return wshrpc.CommandListOrphanedDetachedRtnData{Orphans: rtnOrphans}, nil
+}
+
+func (ws *WshServer) CleanupDetachedCommand(ctx context.Context, blockId string) error {
+	return blockcontroller.CleanupDetached(ctx, blockId)
+}
+
 func (ws *WshServer) ControllerInputCommand(ctx context.Context, data wshrpc.CommandBlockInputData) error {
 	bc := blockcontroller.GetBlockController(data.BlockId)
 	if bc == nil {
 		return fmt.Errorf("block controller not found for block %q", data.BlockId)
 	}
 	inputUnion := &blockcontroller.BlockInputUnion{
-		SigName:  data.SigName,
-		TermSize: data.TermSize,
+		SigName:       data.SigName,
+		TermSize:      data.TermSize,
+		IsPaste:       data.IsPaste,
+		PasteOverride: data.PasteOverride,
 	}
 	if len(data.InputData64) > 0 {
 		inputBuf := make([]byte, base64.StdEncoding.DecodedLen(len(data.InputData64)))
@@ -257,7 +380,159 @@ func (ws *WshServer) ControllerInputCommand(ctx context.Context, data wshrpc.Com
 		}
 		inputUnion.InputData = inputBuf[:nw]
 	}
-	return bc.SendInput(inputUnion)
+	if err := bc.SendInput(inputUnion); err != nil {
+		return err
+	}
+	if len(inputUnion.InputData) > 0 {
+		for _, peerBlockId := range blockcontroller.GetBroadcastPeers(data.BlockId) {
+			if peerBc := blockcontroller.GetBlockController(peerBlockId); peerBc != nil {
+				peerBc.SendInput(&blockcontroller.BlockInputUnion{InputData: inputUnion.InputData})
+			}
+		}
+	}
+	return nil
+}
+
+func (ws *WshServer) SetBroadcastGroupCommand(ctx context.Context, data wshrpc.CommandSetBroadcastGroupData) error {
+	blockcontroller.SetBroadcastGroup(data.BlockId, data.Group)
+	return nil
+}
+
+func (ws *WshServer) SetTermRecordingCommand(ctx context.Context, data wshrpc.CommandSetTermRecordingData) error {
+	return blockcontroller.SetRecording(data.BlockId, data.Enabled)
+}
+
+func (ws *WshServer) WebShareStartCommand(ctx context.Context, data wshrpc.CommandWebShareStartData) (wshrpc.CommandWebShareStatusData, error) {
+	info, err := webshare.StartShare(data.BlockId, data.Role, time.Duration(data.TtlMinutes)*time.Minute)
+	if err != nil {
+		return wshrpc.CommandWebShareStatusData{}, err
+	}
+	return webShareStatusFromInfo(info), nil
+}
+
+func (ws *WshServer) WebShareStopCommand(ctx context.Context, data wshrpc.CommandWebShareStopData) error {
+	return webshare.StopShare(data.BlockId)
+}
+
+func (ws *WshServer) WebShareStatusCommand(ctx context.Context, data wshrpc.CommandWebShareStopData) (wshrpc.CommandWebShareStatusData, error) {
+	info, ok := webshare.GetStatus(data.BlockId)
+	if !ok {
+		return wshrpc.CommandWebShareStatusData{}, nil
+	}
+	return webShareStatusFromInfo(info), nil
+}
+
+// WebShareRevokeAllCommand immediately revokes every active share link
+// across all blocks, returning how many were revoked.
+func (ws *WshServer) WebShareRevokeAllCommand(ctx context.Context) (int, error) {
+	return webshare.RevokeAll(), nil
+}
+
+// sudoCache holds every connection's cached sudo password for this wavesrv process's lifetime
+// (see pkg/sudocache's package doc for why it's process-memory only).
+// sudoCache backs the SudoCache* commands below. As pkg/sudocache's doc comment spells out, there
+// is no sudo-prompt-detection pipeline anywhere in this tree to call SudoCacheSetCommand, no wsh
+// subcommand, and no frontend caller either -- these three RPCs are unreachable plumbing, shipped
+// ahead of the half that would actually drive them. Don't build on top of this without also adding
+// that caller; treat it as scoped-down until then.
+var sudoCache = sudocache.NewCache()
+
+// SudoCacheSetCommand caches data.Password for data.Connection for the connection's configured
+// TTL (see wconfig.ResolveSudoCacheTtl). The caller must have already confirmed the password with
+// the user; this command only stores it. See the sudoCache var doc comment: nothing calls this yet.
+func (ws *WshServer) SudoCacheSetCommand(ctx context.Context, data wshrpc.CommandSudoCacheSetData) error {
+	ttl := wconfig.GetWatcher().GetFullConfig().ResolveSudoCacheTtl(data.Connection)
+	sudoCache.Set(data.Connection, data.Password, ttl)
+	return nil
+}
+
+// SudoCacheClearCommand evicts connection's cached sudo password, if any. An empty connection
+// clears only the local (no-connection) entry, matching CommandSudoCacheSetData's convention.
+func (ws *WshServer) SudoCacheClearCommand(ctx context.Context, connection string) error {
+	sudoCache.Clear(connection)
+	return nil
+}
+
+// SudoCacheStatusCommand lists every connection that currently has a live cached sudo password,
+// without exposing the passwords themselves.
+func (ws *WshServer) SudoCacheStatusCommand(ctx context.Context) ([]wshrpc.SudoCacheStatusEntry, error) {
+	status := sudoCache.Status()
+	rtn := make([]wshrpc.SudoCacheStatusEntry, len(status))
+	for idx, s := range status {
+		rtn[idx] = wshrpc.SudoCacheStatusEntry{Connection: s.Connection, ExpiresTs: s.ExpiresAt.UnixMilli()}
+	}
+	return rtn, nil
+}
+
+// idleLockMgr tracks this wavesrv process's idle-lock state for its lifetime (see pkg/idlelock's
+// package doc for why OS-level auth isn't handled here).
+var idleLockMgr = idlelock.NewManager()
+
+// IdleLockTouchCommand resets the idle clock, as if activity just happened. The frontend calls
+// this on user input so the idle timeout tracks actual inactivity rather than process uptime.
+func (ws *WshServer) IdleLockTouchCommand(ctx context.Context) error {
+	idleLockMgr.Touch()
+	return nil
+}
+
+// IdleLockSetPassphraseCommand hashes and stores passphrase for future unlock attempts. An empty
+// passphrase clears it, relying entirely on OS auth (handled upstream of this command) to gate
+// IdleLockUnlockCommand calls.
+func (ws *WshServer) IdleLockSetPassphraseCommand(ctx context.Context, passphrase string) error {
+	return idleLockMgr.SetPassphrase(passphrase)
+}
+
+// IdleLockUnlockCommand attempts to unlock with passphrase.
+func (ws *WshServer) IdleLockUnlockCommand(ctx context.Context, passphrase string) error {
+	return idleLockMgr.Unlock(passphrase)
+}
+
+// IdleLockStatusCommand evaluates whether the configured idle timeout has just elapsed and, if
+// so, locks the session and disconnects every connection whose ConnKeywords.ConnDisconnectOnIdleLock
+// is set, before reporting the current state. The frontend is expected to poll this periodically
+// (piggybacking on the existing once-a-minute activity timer is the natural fit).
+func (ws *WshServer) IdleLockStatusCommand(ctx context.Context) (wshrpc.IdleLockStatusData, error) {
+	config := wconfig.GetWatcher().GetFullConfig()
+	timeout := time.Duration(config.Settings.IdleLockTimeoutMinutes) * time.Minute
+	if !idleLockMgr.IsLocked() && idlelock.ShouldLock(idleLockMgr.IdleFor(), timeout) {
+		idleLockMgr.Lock()
+		ws.disconnectIdleLockSensitiveConns(ctx, config)
+	}
+	return wshrpc.IdleLockStatusData{
+		Locked:         idleLockMgr.IsLocked(),
+		HasPassphrase:  idleLockMgr.HasPassphrase(),
+		TimeoutMinutes: config.Settings.IdleLockTimeoutMinutes,
+	}, nil
+}
+
+func (ws *WshServer) disconnectIdleLockSensitiveConns(ctx context.Context, config wconfig.FullConfigType) {
+	for _, status := range conncontroller.GetAllConnStatus() {
+		if !status.Connected {
+			continue
+		}
+		connKeywords, ok := config.Connections[status.Connection]
+		if !ok || connKeywords.ConnDisconnectOnIdleLock == nil || !*connKeywords.ConnDisconnectOnIdleLock {
+			continue
+		}
+		if err := ws.ConnDisconnectCommand(ctx, status.Connection); err != nil {
+			log.Printf("idlelock: error disconnecting sensitive connection %q: %v\n", status.Connection, err)
+		}
+	}
+}
+
+// webShareStatusFromInfo builds the RPC-facing status from webshare's internal
+// ShareInfo. The share URL is a path on the local wave web server; exposing it
+// to remote viewers requires the user to front it with their own relay/tunnel,
+// since wavesrv only binds to 127.0.0.1.
+func webShareStatusFromInfo(info *webshare.ShareInfo) wshrpc.CommandWebShareStatusData {
+	return wshrpc.CommandWebShareStatusData{
+		Active:      !info.Revoked,
+		Token:       info.Token,
+		Role:        info.Role,
+		ShareUrl:    "/share/" + info.Token,
+		ExpiresTs:   info.ExpiresTs,
+		ViewerCount: info.ViewerCount,
+	}
 }
 
 func (ws *WshServer) FileCreateCommand(ctx context.Context, data wshrpc.CommandFileCreateData) error {
@@ -441,6 +716,95 @@ func (ws *WshServer) FileReadCommand(ctx context.Context, data wshrpc.CommandFil
 	}
 }
 
+func (ws *WshServer) GetOutputFoldStateCommand(ctx context.Context, blockId string) (wshrpc.OutputFoldStateData, error) {
+	block, err := wstore.DBGet[*waveobj.Block](ctx, blockId)
+	if err != nil {
+		return wshrpc.OutputFoldStateData{}, fmt.Errorf("error getting block: %w", err)
+	}
+	var manualFolded *bool
+	if v, ok := block.Meta[waveobj.MetaKey_CmdOutputFolded].(bool); ok {
+		manualFolded = &v
+	}
+	lineCount := 0
+	_, dataBuf, err := filestore.WFS.ReadFile(ctx, blockId, blockcontroller.BlockFile_Term)
+	if err == nil {
+		var lb a11ystream.LineBuffer
+		lineCount = len(lb.Feed(dataBuf))
+	} else if err != fs.ErrNotExist {
+		return wshrpc.OutputFoldStateData{}, fmt.Errorf("error reading blockfile: %w", err)
+	}
+	autoFoldLines := wconfig.GetWatcher().GetFullConfig().Settings.TermAutoFoldLines
+	state := outputfold.ComputeFoldState(lineCount, manualFolded, autoFoldLines)
+	return wshrpc.OutputFoldStateData{LineCount: state.LineCount, Folded: state.Folded, ManualOverride: state.ManualOverride}, nil
+}
+
+func (ws *WshServer) SearchBlockFileCommand(ctx context.Context, data wshrpc.CommandSearchBlockFileData) (wshrpc.CommandSearchBlockFileRtnData, error) {
+	_, dataBuf, err := filestore.WFS.ReadFile(ctx, data.ZoneId, data.FileName)
+	if err == fs.ErrNotExist {
+		return wshrpc.CommandSearchBlockFileRtnData{}, fmt.Errorf("NOTFOUND: %w", err)
+	}
+	if err != nil {
+		return wshrpc.CommandSearchBlockFileRtnData{}, fmt.Errorf("error reading blockfile: %w", err)
+	}
+	matches, err := termsearch.Search(dataBuf, data.Pattern, termsearch.Options{Regex: data.Regex, CaseSensitive: data.CaseSensitive})
+	if err != nil {
+		return wshrpc.CommandSearchBlockFileRtnData{}, err
+	}
+	return wshrpc.CommandSearchBlockFileRtnData{Matches: matches}, nil
+}
+
+// renderTermExport reads blockId's raw pty scrollback and renders it per format/startLine/
+// numLines, shared by TermExportCommand and ShareCommandOutputCommand. lineCount is the total
+// number of lines available before the startLine/numLines selection was applied.
+func renderTermExport(ctx context.Context, blockId string, format string, startLine int, numLines int) (text string, lineCount int, err error) {
+	_, dataBuf, err := filestore.WFS.ReadFile(ctx, blockId, blockcontroller.BlockFile_Term)
+	if err == fs.ErrNotExist {
+		return "", 0, nil
+	}
+	if err != nil {
+		return "", 0, fmt.Errorf("error reading blockfile: %w", err)
+	}
+	var allLines []string
+	switch format {
+	case ansiexport.FormatPlain, ansiexport.FormatHtml:
+		allLines = ansiexport.PlainLines(dataBuf)
+	case ansiexport.FormatAnsi:
+		allLines = ansiexport.AnsiLines(dataBuf)
+	default:
+		return "", 0, fmt.Errorf("unknown export format %q", format)
+	}
+	selected := ansiexport.SelectRange(allLines, startLine, numLines)
+	if format == ansiexport.FormatHtml {
+		text = ansiexport.ToHTML(selected)
+	} else {
+		text = strings.Join(selected, "\n")
+	}
+	return text, len(allLines), nil
+}
+
+func (ws *WshServer) TermExportCommand(ctx context.Context, data wshrpc.CommandTermExportData) (wshrpc.CommandTermExportRtnData, error) {
+	text, lineCount, err := renderTermExport(ctx, data.BlockId, data.Format, data.StartLine, data.NumLines)
+	if err != nil {
+		return wshrpc.CommandTermExportRtnData{}, err
+	}
+	return wshrpc.CommandTermExportRtnData{Text: text, LineCount: lineCount}, nil
+}
+
+// ShareCommandOutputCommand renders a block's scrollback (typically one command's output range,
+// see CommandShareCommandOutputData) to a redacted, static snapshot file on disk -- see
+// pkg/cmdshare.
+func (ws *WshServer) ShareCommandOutputCommand(ctx context.Context, data wshrpc.CommandShareCommandOutputData) (wshrpc.CommandShareCommandOutputRtnData, error) {
+	text, _, err := renderTermExport(ctx, data.BlockId, data.Format, data.StartLine, data.NumLines)
+	if err != nil {
+		return wshrpc.CommandShareCommandOutputRtnData{}, err
+	}
+	snapshot, err := cmdshare.Create(data.BlockId, data.Format, text)
+	if err != nil {
+		return wshrpc.CommandShareCommandOutputRtnData{}, fmt.Errorf("error creating share snapshot: %w", err)
+	}
+	return wshrpc.CommandShareCommandOutputRtnData{Path: snapshot.Path, RedactionReport: snapshot.RedactionReport}, nil
+}
+
 func (ws *WshServer) FileAppendCommand(ctx context.Context, data wshrpc.CommandFileData) error {
 	dataBuf, err := base64.StdEncoding.DecodeString(data.Data64)
 	if err != nil {
@@ -581,6 +945,18 @@ func (ws *WshServer) SetConfigCommand(ctx context.Context, data wshrpc.MetaSetti
 	return wconfig.SetBaseConfigValue(data.MetaMapType)
 }
 
+func (ws *WshServer) HooksListCommand(ctx context.Context) ([]hooks.HookDef, error) {
+	return hooks.ListHooks()
+}
+
+func (ws *WshServer) HooksAddCommand(ctx context.Context, data hooks.HookDef) error {
+	return hooks.AddHook(data)
+}
+
+func (ws *WshServer) HooksRemoveCommand(ctx context.Context, index int) error {
+	return hooks.RemoveHook(index)
+}
+
 func (ws *WshServer) SetConnectionsConfigCommand(ctx context.Context, data wshrpc.ConnConfigRequest) error {
 	log.Printf("SET CONNECTIONS CONFIG: %v\n", data)
 	return wconfig.SetConnectionsConfigValue(data.Host, data.MetaMapType)
@@ -669,6 +1045,30 @@ func (ws *WshServer) ConnListCommand(ctx context.Context) ([]string, error) {
 	return conncontroller.GetConnectionsList()
 }
 
+// ConnImportTagsCommand looks for a "# wave:tags=..." comment on connName's Host line in
+// ~/.ssh/config (see remote.ImportConnTagsFromSshConfig) and, if found, saves the tags/notes it
+// specifies into connections.json (merging into any tags/notes already set there).
+func (ws *WshServer) ConnImportTagsCommand(ctx context.Context, connName string) (wshrpc.CommandConnImportTagsRtnData, error) {
+	tags, notes, err := remote.ImportConnTagsFromSshConfig(connName)
+	if err != nil {
+		return wshrpc.CommandConnImportTagsRtnData{}, fmt.Errorf("reading ssh config: %w", err)
+	}
+	if len(tags) == 0 && notes == "" {
+		return wshrpc.CommandConnImportTagsRtnData{Found: false}, nil
+	}
+	toMerge := waveobj.MetaMapType{}
+	if len(tags) > 0 {
+		toMerge["conn:tags"] = tags
+	}
+	if notes != "" {
+		toMerge["conn:notes"] = notes
+	}
+	if err := wconfig.SetConnectionsConfigValue(connName, toMerge); err != nil {
+		return wshrpc.CommandConnImportTagsRtnData{}, fmt.Errorf("saving imported tags: %w", err)
+	}
+	return wshrpc.CommandConnImportTagsRtnData{Tags: tags, Notes: notes, Found: true}, nil
+}
+
 func (ws *WshServer) WslListCommand(ctx context.Context) ([]string, error) {
 	distros, err := wsl.RegisteredDistros(ctx)
 	if err != nil {
@@ -696,6 +1096,26 @@ func (ws *WshServer) WslDefaultDistroCommand(ctx context.Context) (string, error
 	return distro.Name(), nil
 }
 
+func (ws *WshServer) ConnPortForwardOpenCommand(ctx context.Context, data wshrpc.CommandConnPortForwardOpenData) (wshrpc.ConnPortForwardInfo, error) {
+	return conncontroller.OpenPortForward(ctx, data.Connection, data.RemoteHost, data.RemotePort)
+}
+
+func (ws *WshServer) ConnPortForwardCloseCommand(ctx context.Context, forwardId string) error {
+	return conncontroller.ClosePortForward(forwardId)
+}
+
+func (ws *WshServer) ConnPortForwardListCommand(ctx context.Context) ([]wshrpc.ConnPortForwardInfo, error) {
+	return conncontroller.ListPortForwards(), nil
+}
+
+func (ws *WshServer) DbQueryCommand(ctx context.Context, data wshrpc.CommandDbQueryData) (wshrpc.DbQueryResult, error) {
+	return dbquery.Query(ctx, data)
+}
+
+func (ws *WshServer) DbQueryCancelCommand(ctx context.Context, queryId string) error {
+	return dbquery.CancelQuery(queryId)
+}
+
 /**
  * Dismisses the WshFail Command in runtime memory on the backend
  */
@@ -746,12 +1166,28 @@ func (ws *WshServer) WaveInfoCommand(ctx context.Context) (*wshrpc.WaveInfoData,
 	if err != nil {
 		return nil, fmt.Errorf("error getting client: %w", err)
 	}
+	availableProfiles, err := wavebase.ListProfiles()
+	if err != nil {
+		log.Printf("error listing profiles: %v\n", err)
+	}
 	return &wshrpc.WaveInfoData{
-		Version:   wavebase.WaveVersion,
-		ClientId:  client.OID,
-		BuildTime: wavebase.BuildTime,
-		ConfigDir: wavebase.GetWaveConfigDir(),
-		DataDir:   wavebase.GetWaveDataDir(),
+		Version:           wavebase.WaveVersion,
+		ClientId:          client.OID,
+		BuildTime:         wavebase.BuildTime,
+		ConfigDir:         wavebase.GetWaveConfigDir(),
+		DataDir:           wavebase.GetWaveDataDir(),
+		Profile:           wavebase.GetActiveProfile(),
+		AvailableProfiles: availableProfiles,
+	}, nil
+}
+
+// ClientCapabilitiesCommand returns the feature set this wavesrv build
+// supports, so callers can negotiate instead of assuming feature parity.
+func (ws *WshServer) ClientCapabilitiesCommand(ctx context.Context) (wshrpc.ClientCapabilitiesData, error) {
+	return wshrpc.ClientCapabilitiesData{
+		Version:      wavebase.WaveVersion,
+		BuildTime:    wavebase.BuildTime,
+		Capabilities: wshrpc.ServerCapabilities,
 	}, nil
 }
 
@@ -774,6 +1210,605 @@ func (ws *WshServer) WorkspaceListCommand(ctx context.Context) ([]wshrpc.Workspa
 	return rtn, nil
 }
 
+// ListTabsCommand and RunShellCommandCommand exist primarily to back the "wsh mcp" MCP server
+// (see cmd/wsh/cmd/wshcmd-mcp.go), so each checks the corresponding mcp:* permission toggle
+// before doing any work, the same way handleGatewayService checks gateway:enabled.
+func (ws *WshServer) ListTabsCommand(ctx context.Context) ([]wshrpc.TabListEntry, error) {
+	settings := wconfig.GetWatcher().GetFullConfig().Settings
+	if !settings.McpEnabled || !settings.McpAllowListTabs {
+		return nil, fmt.Errorf("list_tabs capability is not enabled (set mcp:enabled and mcp:allowlisttabs)")
+	}
+	tabs, err := wstore.DBGetAllObjsByType[*waveobj.Tab](ctx, waveobj.OType_Tab)
+	if err != nil {
+		return nil, fmt.Errorf("error listing tabs: %w", err)
+	}
+	var rtn []wshrpc.TabListEntry
+	for _, tab := range tabs {
+		workspaceId, err := wstore.DBFindWorkspaceForTabId(ctx, tab.OID)
+		if err != nil {
+			// orphaned tab (not attached to any workspace); still report it
+			workspaceId = ""
+		}
+		rtn = append(rtn, wshrpc.TabListEntry{
+			TabId:       tab.OID,
+			Name:        tab.Name,
+			WorkspaceId: workspaceId,
+		})
+	}
+	return rtn, nil
+}
+
+// RunShellCommandCommand runs a one-off shell command synchronously (not attached to any
+// block/terminal) and returns its combined output, for use by external automation like MCP.
+func (ws *WshServer) RunShellCommandCommand(ctx context.Context, data wshrpc.CommandRunShellCommandData) (wshrpc.CommandRunShellCommandRtnData, error) {
+	fullConfig := wconfig.GetWatcher().GetFullConfig()
+	settings := fullConfig.Settings
+	if !settings.McpEnabled || !settings.McpAllowRunCommand {
+		return wshrpc.CommandRunShellCommandRtnData{}, fmt.Errorf("run_command capability is not enabled (set mcp:enabled and mcp:allowruncommand)")
+	}
+	// this command always runs locally, so it's evaluated with an empty connection name --
+	// policy rules scoped to a specific Connection never apply to it, only global ones do
+	verdict := cmdpolicy.Evaluate(fullConfig.CmdPolicyRules(), "", data.CmdStr, time.Now())
+	if !verdict.Allowed {
+		log.Printf("cmdpolicy: denied run_command %q: %s\n", data.CmdStr, verdict.Reason)
+		return wshrpc.CommandRunShellCommandRtnData{}, fmt.Errorf("%s", verdict.Reason)
+	}
+	timeoutMs := data.TimeoutMs
+	if timeoutMs <= 0 {
+		timeoutMs = 30000
+	}
+	cmdCtx, cancel := context.WithTimeout(ctx, time.Duration(timeoutMs)*time.Millisecond)
+	defer cancel()
+	shellPath := shellutil.DetectLocalShellPath()
+	ecmd := exec.CommandContext(cmdCtx, shellPath, "-c", data.CmdStr)
+	if data.Cwd != "" {
+		ecmd.Dir = data.Cwd
+	}
+	outputBytes, err := shellexec.RunSimpleCmdInPty(ecmd, waveobj.TermSize{})
+	rtn := wshrpc.CommandRunShellCommandRtnData{
+		Output:   string(outputBytes),
+		ExitCode: shellexec.ExitCodeFromWaitErr(err),
+	}
+	if err != nil {
+		if _, ok := err.(*exec.ExitError); !ok {
+			return rtn, fmt.Errorf("error running command: %w", err)
+		}
+	}
+	return rtn, nil
+}
+
+// RunMultiCommandCommand runs data.CmdStr concurrently on every connection in data.Connections by
+// routing a RemoteRunCommandCommand to each (see pkg/wshrpc/wshremote.RemoteRunCommandCommand),
+// and aggregates the per-connection results once every connection has finished -- "wsh run
+// multi"'s ansible-ad-hoc-style fan-out.
+func (ws *WshServer) RunMultiCommandCommand(ctx context.Context, data wshrpc.CommandRunMultiCommandData) (wshrpc.CommandRunMultiCommandRtnData, error) {
+	if len(data.Connections) == 0 {
+		return wshrpc.CommandRunMultiCommandRtnData{}, fmt.Errorf("no connections specified")
+	}
+	connections := resolveMultiCommandConnections(data.Connections)
+	if len(connections) == 0 {
+		return wshrpc.CommandRunMultiCommandRtnData{}, fmt.Errorf("no connections matched %v", data.Connections)
+	}
+	results := make([]wshrpc.MultiCommandResult, len(connections))
+	var wg sync.WaitGroup
+	client := wshclient.GetBareRpcClient()
+	for idx, connName := range connections {
+		wg.Add(1)
+		go func(idx int, connName string) {
+			defer wg.Done()
+			connRoute := wshutil.MakeConnectionRouteId(connName)
+			runData := wshrpc.CommandRemoteRunCommandData{CmdStr: data.CmdStr, TimeoutMs: data.TimeoutMs}
+			rtn, err := wshclient.RemoteRunCommandCommand(client, runData, &wshrpc.RpcOpts{Route: connRoute})
+			if err != nil {
+				results[idx] = wshrpc.MultiCommandResult{Connection: connName, Err: err.Error()}
+				return
+			}
+			results[idx] = wshrpc.MultiCommandResult{Connection: connName, Output: rtn.Output, ExitCode: rtn.ExitCode}
+		}(idx, connName)
+	}
+	wg.Wait()
+	return wshrpc.CommandRunMultiCommandRtnData{Results: results}, nil
+}
+
+// resolveMultiCommandConnections expands any "tag:xxx" entry in rawConnections into every
+// configured connection carrying that tag (see wconfig.FullConfigType.ResolveConnectionsByTag),
+// passing literal connection names through unchanged, and dedupes the result.
+func resolveMultiCommandConnections(rawConnections []string) []string {
+	fullConfig := wconfig.GetWatcher().GetFullConfig()
+	seen := make(map[string]bool)
+	var rtn []string
+	for _, entry := range rawConnections {
+		var names []string
+		if tag, ok := strings.CutPrefix(entry, "tag:"); ok {
+			names = fullConfig.ResolveConnectionsByTag(tag)
+		} else {
+			names = []string{entry}
+		}
+		for _, name := range names {
+			if !seen[name] {
+				seen[name] = true
+				rtn = append(rtn, name)
+			}
+		}
+	}
+	return rtn
+}
+
+// GetMcpConfigCommand lets "wsh mcp" (a separate process from wavesrv) read the mcp:*
+// permission toggles without needing its own wconfig watcher.
+func (ws *WshServer) GetMcpConfigCommand(ctx context.Context) (wshrpc.McpConfigData, error) {
+	settings := wconfig.GetWatcher().GetFullConfig().Settings
+	return wshrpc.McpConfigData{
+		Enabled:          settings.McpEnabled,
+		AllowRunCommand:  settings.McpAllowRunCommand,
+		AllowReadFile:    settings.McpAllowReadFile,
+		AllowListTabs:    settings.McpAllowListTabs,
+		AllowFetchOutput: settings.McpAllowFetchOutput,
+	}, nil
+}
+
+// ListPluginsCommand discovers block-view plugins from the plugins directory (see pkg/wplugin)
+// and reports each one's enabled state (from the "plugins" config part) and version
+// compatibility, so the frontend can render an enable/disable UI and skip loading incompatible
+// or disabled plugins' frontend bundles.
+func (ws *WshServer) ListPluginsCommand(ctx context.Context) ([]wplugin.PluginInfo, error) {
+	manifests, dirs, discoverErrs := wplugin.DiscoverPlugins(wplugin.PluginsDir())
+	for _, err := range discoverErrs {
+		log.Printf("error loading plugin: %v", err)
+	}
+	pluginSettings := wconfig.GetWatcher().GetFullConfig().Plugins
+	var rtn []wplugin.PluginInfo
+	for idx, manifest := range manifests {
+		compatible, incompatMsg := wplugin.CheckVersionCompat(manifest, wavebase.WaveVersion)
+		rtn = append(rtn, wplugin.PluginInfo{
+			Manifest:    manifest,
+			Dir:         dirs[idx],
+			Enabled:     pluginSettings[manifest.Name].Enabled,
+			Compatible:  compatible,
+			IncompatMsg: incompatMsg,
+		})
+	}
+	return rtn, nil
+}
+
+func (ws *WshServer) SetPluginEnabledCommand(ctx context.Context, data wshrpc.CommandSetPluginEnabledData) error {
+	return wconfig.SetPluginEnabled(data.PluginName, data.Enabled)
+}
+
+func (ws *WshServer) RegisterVDomRendererCommand(ctx context.Context, data wshrpc.CommandRegisterVDomRendererData) error {
+	if data.RendererId == "" {
+		return fmt.Errorf("rendererid is required")
+	}
+	rendererConfig := wconfig.RendererConfigType{
+		MimeTypes:   data.Registration.MimeTypes,
+		Extensions:  data.Registration.Extensions,
+		DisplayName: data.Registration.DisplayName,
+		Priority:    data.Registration.Priority,
+		BlockDef:    data.BlockDef,
+	}
+	jsonBytes, err := json.Marshal(rendererConfig)
+	if err != nil {
+		return fmt.Errorf("error marshaling renderer config: %w", err)
+	}
+	var toMerge waveobj.MetaMapType
+	if err := json.Unmarshal(jsonBytes, &toMerge); err != nil {
+		return fmt.Errorf("error unmarshaling renderer config: %w", err)
+	}
+	return wconfig.SetRendererConfigValue(data.RendererId, toMerge)
+}
+
+// ImportTermThemeCommand converts a base16 or iTerm color scheme file (see
+// wconfig.ImportBase16Scheme/wconfig.ImportITermColorScheme) to a TermThemeType and saves it to
+// termthemes.json under data.ThemeId, returning that id.
+func (ws *WshServer) ImportTermThemeCommand(ctx context.Context, data wshrpc.CommandImportTermThemeData) (string, error) {
+	if data.ThemeId == "" {
+		return "", fmt.Errorf("themeid is required")
+	}
+	theme, err := wconfig.ImportTermTheme(data.Format, []byte(data.Data))
+	if err != nil {
+		return "", fmt.Errorf("error importing term theme: %w", err)
+	}
+	if err := wconfig.SetTermThemeConfigValue(data.ThemeId, theme); err != nil {
+		return "", fmt.Errorf("error saving term theme: %w", err)
+	}
+	return data.ThemeId, nil
+}
+
+// CheckFontsCommand reports the installed/bundled status (see fontcheck.IsFontInstalled) of the
+// global term:fontfamily setting and every connection's override of it, so the frontend can warn
+// about a configured font (or one entry in its comma-separated fallback list) that isn't actually
+// available.
+func (ws *WshServer) CheckFontsCommand(ctx context.Context) (wshrpc.FontStatusData, error) {
+	fullConfig := wconfig.GetWatcher().GetFullConfig()
+	seen := make(map[string]bool)
+	var families []string
+	addFamilies := func(spec string) {
+		for _, family := range strings.Split(spec, ",") {
+			family = strings.TrimSpace(family)
+			if family == "" || seen[family] {
+				continue
+			}
+			seen[family] = true
+			families = append(families, family)
+		}
+	}
+	addFamilies(fullConfig.Settings.TermFontFamily)
+	for _, connKeywords := range fullConfig.Connections {
+		addFamilies(connKeywords.TermFontFamily)
+	}
+	var rtn wshrpc.FontStatusData
+	for _, status := range fontcheck.CheckFonts(families) {
+		rtn.Fonts = append(rtn.Fonts, wshrpc.FontStatusInfo{
+			Family:    status.Family,
+			Installed: status.Installed,
+			Bundled:   status.Bundled,
+		})
+	}
+	return rtn, nil
+}
+
+func widgetActionEntries(widgets map[string]wconfig.WidgetConfigType) []wshrpc.ActionRegistryEntry {
+	var rtn []wshrpc.ActionRegistryEntry
+	for id, widget := range widgets {
+		title := widget.Label
+		if title == "" {
+			title = id
+		}
+		rtn = append(rtn, wshrpc.ActionRegistryEntry{
+			Id:       "widget:" + id,
+			Category: "widget",
+			Title:    title,
+			SubTitle: widget.Description,
+			Icon:     widget.Icon,
+			Keywords: []string{id, title},
+		})
+	}
+	return rtn
+}
+
+// settingsToggleActionEntries reflects over SettingsType's json tags to list its boolean toggles
+// (skipping "section:*" clear-keys), so new settings automatically show up in the palette without
+// needing a second hand-maintained list.
+func settingsToggleActionEntries() []wshrpc.ActionRegistryEntry {
+	var rtn []wshrpc.ActionRegistryEntry
+	settingsType := reflect.TypeOf(wconfig.SettingsType{})
+	for i := 0; i < settingsType.NumField(); i++ {
+		field := settingsType.Field(i)
+		if field.Type.Kind() != reflect.Bool {
+			continue
+		}
+		jsonTag := strings.Split(field.Tag.Get("json"), ",")[0]
+		if jsonTag == "" || jsonTag == "-" || strings.HasSuffix(jsonTag, ":*") {
+			continue
+		}
+		section := jsonTag
+		if idx := strings.Index(jsonTag, ":"); idx != -1 {
+			section = jsonTag[:idx]
+		}
+		rtn = append(rtn, wshrpc.ActionRegistryEntry{
+			Id:       "setting:" + jsonTag,
+			Category: "setting",
+			Title:    jsonTag,
+			SubTitle: "Toggle Setting",
+			Keywords: []string{section, jsonTag},
+		})
+	}
+	return rtn
+}
+
+// ActionRegistryCommand enumerates launchable widgets, togglable settings, and configured
+// connections as fuzzy-searchable entries for a frontend command palette. It does not implement
+// the fuzzy matching itself -- the frontend searches Title/SubTitle/Keywords locally against this
+// list, the same way it already filters the widget sidebar and connection dropdowns.
+func (ws *WshServer) ActionRegistryCommand(ctx context.Context) ([]wshrpc.ActionRegistryEntry, error) {
+	fullConfig := wconfig.GetWatcher().GetFullConfig()
+	var rtn []wshrpc.ActionRegistryEntry
+	rtn = append(rtn, widgetActionEntries(fullConfig.Widgets)...)
+	rtn = append(rtn, widgetActionEntries(fullConfig.DefaultWidgets)...)
+	rtn = append(rtn, settingsToggleActionEntries()...)
+	for connName := range fullConfig.Connections {
+		rtn = append(rtn, wshrpc.ActionRegistryEntry{
+			Id:       "connection:" + connName,
+			Category: "connection",
+			Title:    connName,
+			SubTitle: "Connection",
+			Keywords: []string{connName},
+		})
+	}
+	return rtn, nil
+}
+
+// KeybindingRegistryCommand reports the known keybinding action vocabulary (pkg/wconfig.
+// KeybindingActions), the user's configured bindings with their chords resolved for goos (one of
+// "darwin", "windows", "linux"), and any conflicts among them on that platform, so a settings UI
+// can render and edit keybindings.json without reimplementing the per-platform resolution or
+// conflict logic.
+func (ws *WshServer) KeybindingRegistryCommand(ctx context.Context, goos string) (wshrpc.KeybindingRegistryData, error) {
+	fullConfig := wconfig.GetWatcher().GetFullConfig()
+	rtn := wshrpc.KeybindingRegistryData{}
+	for _, action := range wconfig.KeybindingActions {
+		rtn.Actions = append(rtn.Actions, wshrpc.KeybindingActionInfo{Id: action.Id, DisplayName: action.DisplayName, Category: action.Category})
+	}
+	for id, kb := range fullConfig.Keybindings {
+		rtn.Bindings = append(rtn.Bindings, wshrpc.ResolvedKeybinding{
+			Id:       id,
+			Action:   kb.Action,
+			Chord:    wconfig.ResolveKeybindingChord(kb, goos),
+			Disabled: kb.Disabled,
+		})
+	}
+	for _, conflict := range wconfig.DetectKeybindingConflicts(fullConfig.Keybindings) {
+		if conflict.Platform != goos {
+			continue
+		}
+		rtn.Conflicts = append(rtn.Conflicts, wshrpc.KeybindingConflictInfo{Chord: conflict.Chord, Ids: conflict.Ids})
+	}
+	return rtn, nil
+}
+
+// InputModeChordsCommand reports the user's configured line-editing mode (see
+// wconfig.SettingsType.EditorInputMode) and that mode's action vocabulary and default chord table,
+// so command-input fields (e.g. the AI chat box) can offer vi/emacs-consistent editing without each
+// reimplementing the default bindings.
+func (ws *WshServer) InputModeChordsCommand(ctx context.Context) (wshrpc.InputModeChordsData, error) {
+	fullConfig := wconfig.GetWatcher().GetFullConfig()
+	mode := fullConfig.Settings.EditorInputMode
+	if mode == "" {
+		mode = wconfig.InputModeEmacs
+	}
+	rtn := wshrpc.InputModeChordsData{
+		Mode:   mode,
+		Chords: wconfig.GetInputModeChords(mode),
+	}
+	for _, action := range wconfig.InputEditActions {
+		rtn.Actions = append(rtn.Actions, wshrpc.InputEditActionInfo{Id: action.Id, DisplayName: action.DisplayName})
+	}
+	return rtn, nil
+}
+
+func (ws *WshServer) SearchCmdHistoryCommand(ctx context.Context, data wshrpc.CommandSearchCmdHistoryData) (wshrpc.CommandSearchCmdHistoryRtnData, error) {
+	result, err := wstore.SearchCmdHistory(ctx, wstore.CmdHistoryQuery{
+		QueryType:  data.QueryType,
+		Cwd:        data.Cwd,
+		Connection: data.Connection,
+		BlockId:    data.BlockId,
+		MaxResults: data.MaxResults,
+		Cursor:     data.Cursor,
+	})
+	if err != nil {
+		return wshrpc.CommandSearchCmdHistoryRtnData{}, fmt.Errorf("error searching command history: %w", err)
+	}
+	rtn := wshrpc.CommandSearchCmdHistoryRtnData{
+		Items:      make([]wshrpc.CmdHistoryEntry, len(result.Items)),
+		NextCursor: result.NextCursor,
+	}
+	for idx, item := range result.Items {
+		rtn.Items[idx] = wshrpc.CmdHistoryEntry{
+			HistoryId:  item.HistoryId,
+			Ts:         item.Ts,
+			BlockId:    item.BlockId,
+			Connection: item.Connection,
+			Cwd:        item.Cwd,
+			CmdStr:     item.CmdStr,
+			ExitCode:   item.ExitCode,
+			DurationMs: item.DurationMs,
+		}
+	}
+	return rtn, nil
+}
+
+func (ws *WshServer) SyncHistoryPushCommand(ctx context.Context) (int, error) {
+	return histsync.Push(ctx)
+}
+
+func (ws *WshServer) SyncHistoryPullCommand(ctx context.Context) (int, error) {
+	return histsync.Pull(ctx)
+}
+
+func (ws *WshServer) ExportSettingsBundleCommand(ctx context.Context, data wshrpc.CommandExportSettingsBundleData) error {
+	return settingssync.ExportToFile(ctx, data.Path, time.Now().UnixMilli())
+}
+
+func (ws *WshServer) ImportSettingsBundleCommand(ctx context.Context, data wshrpc.CommandImportSettingsBundleData) ([]wshrpc.SettingsSyncConflict, error) {
+	bundle, err := settingssync.ReadBundleFile(data.Path)
+	if err != nil {
+		return nil, err
+	}
+	conflicts := settingssync.DiffBundle(bundle)
+	rtn := make([]wshrpc.SettingsSyncConflict, len(conflicts))
+	for idx, conflict := range conflicts {
+		rtn[idx] = wshrpc.SettingsSyncConflict{
+			Category:   conflict.Category,
+			Key:        conflict.Key,
+			LocalJson:  conflict.LocalJson,
+			RemoteJson: conflict.RemoteJson,
+		}
+	}
+	return rtn, nil
+}
+
+func (ws *WshServer) ApplySettingsBundleCommand(ctx context.Context, data wshrpc.CommandApplySettingsBundleData) error {
+	bundle, err := settingssync.ReadBundleFile(data.Path)
+	if err != nil {
+		return err
+	}
+	acceptedKeys := make(map[string]bool)
+	for _, key := range data.AcceptedKeys {
+		acceptedKeys[key] = true
+	}
+	return settingssync.ApplyBundle(bundle, acceptedKeys)
+}
+
+func (ws *WshServer) TelemetryInspectCommand(ctx context.Context) (wshrpc.TelemetryInspectData, error) {
+	client, err := wstore.DBGetSingleton[*waveobj.Client](ctx)
+	if err != nil {
+		return wshrpc.TelemetryInspectData{}, fmt.Errorf("error getting client data: %w", err)
+	}
+	payloadJson, err := wcloud.BuildTelemetryPreviewJson(ctx, client.OID)
+	if err != nil {
+		return wshrpc.TelemetryInspectData{}, err
+	}
+	return wshrpc.TelemetryInspectData{
+		TelemetryEnabled:    telemetry.IsTelemetryEnabled(),
+		CrashReportsEnabled: telemetry.IsCrashReportsEnabled(),
+		UsageCountsEnabled:  telemetry.IsUsageCountsEnabled(),
+		AIMetadataEnabled:   telemetry.IsAIMetadataEnabled(),
+		PendingPayloadJson:  payloadJson,
+	}, nil
+}
+
+func (ws *WshServer) DebugCrashesCommand(ctx context.Context) ([]wshrpc.CrashReportSummary, error) {
+	reports, err := crashreport.List()
+	if err != nil {
+		return nil, err
+	}
+	rtn := make([]wshrpc.CrashReportSummary, len(reports))
+	for idx, report := range reports {
+		rtn[idx] = wshrpc.CrashReportSummary{
+			Id:        report.Id,
+			Timestamp: report.Timestamp,
+			DebugStr:  report.DebugStr,
+			Recovered: report.Recovered,
+			Stack:     report.Stack,
+			Version:   report.Version,
+			BuildTime: report.BuildTime,
+			GoVersion: report.GoVersion,
+			OS:        report.OS,
+			Arch:      report.Arch,
+			LogTail:   report.LogTail,
+		}
+	}
+	return rtn, nil
+}
+
+func levelToString(level slog.Level) string {
+	switch level {
+	case wlog.LevelDebug:
+		return "debug"
+	case wlog.LevelWarn:
+		return "warn"
+	case wlog.LevelError:
+		return "error"
+	default:
+		return "info"
+	}
+}
+
+func levelFromString(level string) (slog.Level, error) {
+	switch strings.ToLower(level) {
+	case "debug":
+		return wlog.LevelDebug, nil
+	case "info":
+		return wlog.LevelInfo, nil
+	case "warn", "warning":
+		return wlog.LevelWarn, nil
+	case "error":
+		return wlog.LevelError, nil
+	default:
+		return 0, fmt.Errorf("invalid log level %q (must be debug, info, warn, or error)", level)
+	}
+}
+
+func (ws *WshServer) DebugLogLevelCommand(ctx context.Context, data wshrpc.CommandDebugLogLevelData) (wshrpc.CommandDebugLogLevelRtnData, error) {
+	if data.Level != "" {
+		level, err := levelFromString(data.Level)
+		if err != nil {
+			return wshrpc.CommandDebugLogLevelRtnData{}, err
+		}
+		wlog.SetLevel(data.Subsystem, level)
+	}
+	levels := wlog.GetLevels()
+	rtn := make(map[string]string, len(levels))
+	for subsystem, level := range levels {
+		rtn[subsystem] = levelToString(level)
+	}
+	return wshrpc.CommandDebugLogLevelRtnData{Levels: rtn}, nil
+}
+
+func (ws *WshServer) DebugProfileCommand(ctx context.Context, data wshrpc.CommandDebugProfileData) (wshrpc.CommandDebugProfileRtnData, error) {
+	seconds := data.Seconds
+	if seconds <= 0 {
+		seconds = 30
+	}
+	ts := time.Now().UnixMilli()
+	switch data.Kind {
+	case "cpu":
+		filePath := filepath.Join(wavebase.GetWavePprofDir(), fmt.Sprintf("cpu-%d.pprof", ts))
+		file, err := os.Create(filePath)
+		if err != nil {
+			return wshrpc.CommandDebugProfileRtnData{}, fmt.Errorf("error creating profile file: %w", err)
+		}
+		if err := pprof.StartCPUProfile(file); err != nil {
+			file.Close()
+			return wshrpc.CommandDebugProfileRtnData{}, fmt.Errorf("error starting cpu profile: %w", err)
+		}
+		time.Sleep(time.Duration(seconds) * time.Second)
+		pprof.StopCPUProfile()
+		if err := file.Close(); err != nil {
+			return wshrpc.CommandDebugProfileRtnData{}, fmt.Errorf("error closing profile file: %w", err)
+		}
+		return wshrpc.CommandDebugProfileRtnData{FilePath: filePath}, nil
+	case "heap":
+		filePath := filepath.Join(wavebase.GetWavePprofDir(), fmt.Sprintf("heap-%d.pprof", ts))
+		file, err := os.Create(filePath)
+		if err != nil {
+			return wshrpc.CommandDebugProfileRtnData{}, fmt.Errorf("error creating profile file: %w", err)
+		}
+		defer file.Close()
+		runtime.GC()
+		if err := pprof.WriteHeapProfile(file); err != nil {
+			return wshrpc.CommandDebugProfileRtnData{}, fmt.Errorf("error writing heap profile: %w", err)
+		}
+		return wshrpc.CommandDebugProfileRtnData{FilePath: filePath}, nil
+	default:
+		return wshrpc.CommandDebugProfileRtnData{}, fmt.Errorf("invalid profile kind %q (must be cpu or heap)", data.Kind)
+	}
+}
+
+func wstoreDbStatsToWshrpc(stats wstore.DbStats) wshrpc.DbStats {
+	tables := make([]wshrpc.DbTableStats, len(stats.Tables))
+	for idx, table := range stats.Tables {
+		tables[idx] = wshrpc.DbTableStats{Name: table.Name, RowCount: table.RowCount}
+	}
+	return wshrpc.DbStats{
+		Name:          stats.Name,
+		FilePath:      stats.FilePath,
+		FileSizeBytes: stats.FileSizeBytes,
+		PageCount:     stats.PageCount,
+		PageSizeBytes: stats.PageSizeBytes,
+		FreelistCount: stats.FreelistCount,
+		Tables:        tables,
+	}
+}
+
+func filestoreDbStatsToWshrpc(stats filestore.DbStats) wshrpc.DbStats {
+	tables := make([]wshrpc.DbTableStats, len(stats.Tables))
+	for idx, table := range stats.Tables {
+		tables[idx] = wshrpc.DbTableStats{Name: table.Name, RowCount: table.RowCount}
+	}
+	return wshrpc.DbStats{
+		Name:          stats.Name,
+		FilePath:      stats.FilePath,
+		FileSizeBytes: stats.FileSizeBytes,
+		PageCount:     stats.PageCount,
+		PageSizeBytes: stats.PageSizeBytes,
+		FreelistCount: stats.FreelistCount,
+		Tables:        tables,
+	}
+}
+
+func (ws *WshServer) ClientDbStatsCommand(ctx context.Context) ([]wshrpc.DbStats, error) {
+	wstoreStats, err := wstore.GetDbStats(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("error getting wstore db stats: %w", err)
+	}
+	filestoreStats, err := filestore.GetDbStats(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("error getting filestore db stats: %w", err)
+	}
+	return []wshrpc.DbStats{wstoreDbStatsToWshrpc(wstoreStats), filestoreDbStatsToWshrpc(filestoreStats)}, nil
+}
+
 var wshActivityRe = regexp.MustCompile(`^[a-z:#]+$`)
 
 func (ws *WshServer) WshActivityCommand(ctx context.Context, data map[string]int) error {
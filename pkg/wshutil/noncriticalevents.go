@@ -0,0 +1,90 @@
+// Copyright 2026, Command Line Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package wshutil
+
+import (
+	"encoding/json"
+	"sync"
+
+	"github.com/wavetermdev/waveterm/pkg/panichandler"
+	"github.com/wavetermdev/waveterm/pkg/wps"
+	"github.com/wavetermdev/waveterm/pkg/wshrpc"
+)
+
+// events in this set are point-in-time status, not a log -- a newer one always supersedes an
+// older undelivered one, so it's safe (and under backpressure, desirable) to drop the older one
+// rather than block the publisher or fall further behind
+var nonCriticalEvents = map[string]bool{
+	wps.Event_SysInfo:          true,
+	wps.Event_ControllerStatus: true,
+}
+
+// latestEventMailbox delivers only the most recently set event to a single drain goroutine: if
+// set() is called again before the drain goroutine wakes up and takes the pending event, the
+// earlier one is dropped. This is what gives non-critical events backpressure without blocking
+// the publisher and without an unbounded (or even bounded-but-stale) queue.
+type latestEventMailbox struct {
+	mu      sync.Mutex
+	pending *wps.WaveEvent
+	wake    chan struct{}
+	stop    chan struct{}
+}
+
+func newLatestEventMailbox(routeId string, rpc AbstractRpcClient) *latestEventMailbox {
+	m := &latestEventMailbox{
+		wake: make(chan struct{}, 1),
+		stop: make(chan struct{}),
+	}
+	go m.drainLoop(routeId, rpc)
+	return m
+}
+
+func (m *latestEventMailbox) set(event wps.WaveEvent) {
+	m.mu.Lock()
+	m.pending = &event
+	m.mu.Unlock()
+	select {
+	case m.wake <- struct{}{}:
+	default:
+	}
+}
+
+func (m *latestEventMailbox) takePending() (wps.WaveEvent, bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.pending == nil {
+		return wps.WaveEvent{}, false
+	}
+	event := *m.pending
+	m.pending = nil
+	return event, true
+}
+
+func (m *latestEventMailbox) close() {
+	close(m.stop)
+}
+
+func (m *latestEventMailbox) drainLoop(routeId string, rpc AbstractRpcClient) {
+	defer func() {
+		panichandler.PanicHandler("latestEventMailbox:drainLoop", recover())
+	}()
+	for {
+		select {
+		case <-m.stop:
+			return
+		case <-m.wake:
+		}
+		for {
+			event, ok := m.takePending()
+			if !ok {
+				break
+			}
+			msgBytes, err := json.Marshal(RpcMessage{Command: wshrpc.Command_EventRecv, Route: routeId, Data: event})
+			if err != nil {
+				continue
+			}
+			rpc.SendRpcMessage(msgBytes)
+		}
+	}
+}
@@ -0,0 +1,117 @@
+// Copyright 2026, Command Line Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package wshutil
+
+import (
+	"sync/atomic"
+
+	"github.com/wavetermdev/waveterm/pkg/panichandler"
+	"github.com/wavetermdev/waveterm/pkg/wshrpc"
+)
+
+// wshoutputsched.go gives a WshRpc's outgoing packets two lanes, bulk and interactive, so a large
+// response (e.g. a file stream's chunked data) queued ahead of a small one (e.g. a keystroke echo
+// or a compgen response) can't make the small one wait behind it -- the connection this WshRpc
+// sits on (a websocket, domain socket, or ssh-forwarded pipe) only has room for one packet at a
+// time, so somebody has to go first, and it should be whichever lane has something interactive
+// waiting.
+
+// bulkCommands are the commands whose responses are large enough (or chunked/streamed enough)
+// that they shouldn't be allowed to delay everything else sharing the connection.
+var bulkCommands = map[string]bool{
+	wshrpc.Command_RemoteStreamFile: true,
+	wshrpc.Command_RemoteWriteFile:  true,
+}
+
+func isBulkCommand(command string) bool {
+	return bulkCommands[command]
+}
+
+// ChannelThroughput reports cumulative bytes written to each output lane, for display (e.g. `wsh
+// conn status`).
+type ChannelThroughput struct {
+	InteractiveBytes int64 `json:"interactivebytes"`
+	BulkBytes        int64 `json:"bulkbytes"`
+}
+
+type outputScheduler struct {
+	highCh           chan []byte
+	lowCh            chan []byte
+	interactiveBytes atomic.Int64
+	bulkBytes        atomic.Int64
+}
+
+func newOutputScheduler(outputCh chan []byte) *outputScheduler {
+	sched := &outputScheduler{
+		highCh: make(chan []byte, DefaultOutputChSize),
+		lowCh:  make(chan []byte, DefaultOutputChSize),
+	}
+	go sched.run(outputCh)
+	return sched
+}
+
+// send enqueues msgBytes on the lane for command, blocking only if that lane is full (the other
+// lane is unaffected, which is the whole point).
+func (sched *outputScheduler) send(msgBytes []byte, command string) {
+	if isBulkCommand(command) {
+		sched.bulkBytes.Add(int64(len(msgBytes)))
+		sched.lowCh <- msgBytes
+		return
+	}
+	sched.interactiveBytes.Add(int64(len(msgBytes)))
+	sched.highCh <- msgBytes
+}
+
+func (sched *outputScheduler) throughput() ChannelThroughput {
+	return ChannelThroughput{
+		InteractiveBytes: sched.interactiveBytes.Load(),
+		BulkBytes:        sched.bulkBytes.Load(),
+	}
+}
+
+// close shuts down the high lane (callers stop producing once the owning WshRpc's InputCh closes)
+// so run() can drain what remains and close outputCh in turn.
+func (sched *outputScheduler) close() {
+	close(sched.highCh)
+	close(sched.lowCh)
+}
+
+// run forwards queued packets to outputCh, always preferring the interactive lane when both have
+// something ready -- a non-blocking check first, falling back to a blocking select so the
+// goroutine doesn't spin when both lanes are empty.
+func (sched *outputScheduler) run(outputCh chan []byte) {
+	defer func() {
+		panichandler.PanicHandler("outputScheduler:run", recover())
+	}()
+	defer close(outputCh)
+	highOpen, lowOpen := true, true
+	for highOpen || lowOpen {
+		if highOpen {
+			select {
+			case msg, ok := <-sched.highCh:
+				if !ok {
+					highOpen = false
+					continue
+				}
+				outputCh <- msg
+				continue
+			default:
+			}
+		}
+		select {
+		case msg, ok := <-sched.highCh:
+			if !ok {
+				highOpen = false
+				continue
+			}
+			outputCh <- msg
+		case msg, ok := <-sched.lowCh:
+			if !ok {
+				lowOpen = false
+				continue
+			}
+			outputCh <- msg
+		}
+	}
+}
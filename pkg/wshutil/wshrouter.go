@@ -40,13 +40,14 @@ type msgAndRoute struct {
 }
 
 type WshRouter struct {
-	Lock             *sync.Mutex
-	RouteMap         map[string]AbstractRpcClient // routeid => client
-	UpstreamClient   AbstractRpcClient            // upstream client (if we are not the terminal router)
-	AnnouncedRoutes  map[string]string            // routeid => local routeid
-	RpcMap           map[string]*routeInfo        // rpcid => routeinfo
-	SimpleRequestMap map[string]chan *RpcMessage  // simple reqid => response channel
-	InputCh          chan msgAndRoute
+	Lock              *sync.Mutex
+	RouteMap          map[string]AbstractRpcClient   // routeid => client
+	UpstreamClient    AbstractRpcClient              // upstream client (if we are not the terminal router)
+	AnnouncedRoutes   map[string]string              // routeid => local routeid
+	RpcMap            map[string]*routeInfo          // rpcid => routeinfo
+	SimpleRequestMap  map[string]chan *RpcMessage    // simple reqid => response channel
+	NonCriticalMboxes map[string]*latestEventMailbox // routeid => drop-oldest mailbox for noncritical events
+	InputCh           chan msgAndRoute
 }
 
 func MakeConnectionRouteId(connId string) string {
@@ -73,12 +74,13 @@ var DefaultRouter = NewWshRouter()
 
 func NewWshRouter() *WshRouter {
 	rtn := &WshRouter{
-		Lock:             &sync.Mutex{},
-		RouteMap:         make(map[string]AbstractRpcClient),
-		AnnouncedRoutes:  make(map[string]string),
-		RpcMap:           make(map[string]*routeInfo),
-		SimpleRequestMap: make(map[string]chan *RpcMessage),
-		InputCh:          make(chan msgAndRoute, DefaultInputChSize),
+		Lock:              &sync.Mutex{},
+		RouteMap:          make(map[string]AbstractRpcClient),
+		AnnouncedRoutes:   make(map[string]string),
+		RpcMap:            make(map[string]*routeInfo),
+		SimpleRequestMap:  make(map[string]chan *RpcMessage),
+		NonCriticalMboxes: make(map[string]*latestEventMailbox),
+		InputCh:           make(chan msgAndRoute, DefaultInputChSize),
 	}
 	go rtn.runServer()
 	return rtn
@@ -99,6 +101,10 @@ func (router *WshRouter) SendEvent(routeId string, event wps.WaveEvent) {
 	if rpc == nil {
 		return
 	}
+	if nonCriticalEvents[event.Event] {
+		router.getOrCreateMailbox(routeId, rpc).set(event)
+		return
+	}
 	msg := RpcMessage{
 		Command: wshrpc.Command_EventRecv,
 		Route:   routeId,
@@ -112,6 +118,21 @@ func (router *WshRouter) SendEvent(routeId string, event wps.WaveEvent) {
 	rpc.SendRpcMessage(msgBytes)
 }
 
+// getOrCreateMailbox returns the drop-oldest mailbox for routeId, creating its drain goroutine
+// on first use. Noncritical events (e.g. sysinfo polling) are status, not a log, so a slow or
+// backed-up route should see only the latest value rather than fall further behind or block
+// the publishing goroutine.
+func (router *WshRouter) getOrCreateMailbox(routeId string, rpc AbstractRpcClient) *latestEventMailbox {
+	router.Lock.Lock()
+	defer router.Lock.Unlock()
+	mbox := router.NonCriticalMboxes[routeId]
+	if mbox == nil {
+		mbox = newLatestEventMailbox(routeId, rpc)
+		router.NonCriticalMboxes[routeId] = mbox
+	}
+	return mbox
+}
+
 func (router *WshRouter) handleNoRoute(msg RpcMessage) {
 	nrErr := noRouteErr(msg.Route)
 	if msg.ReqId == "" {
@@ -347,6 +368,10 @@ func (router *WshRouter) UnregisterRoute(routeId string) {
 			delete(router.AnnouncedRoutes, routeId)
 		}
 	}
+	if mbox := router.NonCriticalMboxes[routeId]; mbox != nil {
+		mbox.close()
+		delete(router.NonCriticalMboxes, routeId)
+	}
 	go func() {
 		defer func() {
 			panichandler.PanicHandler("WshRouter:unregisterRoute:routegone", recover())
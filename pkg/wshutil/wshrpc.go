@@ -52,6 +52,7 @@ type WshRpc struct {
 	ResponseHandlerMap map[string]*RpcResponseHandler // reqId => handler
 	Debug              bool
 	DebugName          string
+	outputSched        *outputScheduler
 }
 
 type wshRpcContextKey struct{}
@@ -212,11 +213,18 @@ func MakeWshRpc(inputCh chan []byte, outputCh chan []byte, rpcCtx wshrpc.RpcCont
 		ServerImpl:         serverImpl,
 		ResponseHandlerMap: make(map[string]*RpcResponseHandler),
 	}
+	rtn.outputSched = newOutputScheduler(outputCh)
 	rtn.RpcContext.Store(&rpcCtx)
 	go rtn.runServer()
 	return rtn
 }
 
+// GetThroughput returns cumulative bytes sent on the interactive and bulk output lanes (see
+// wshoutputsched.go), for surfacing in e.g. `wsh conn status`.
+func (w *WshRpc) GetThroughput() ChannelThroughput {
+	return w.outputSched.throughput()
+}
+
 func (w *WshRpc) ClientId() string {
 	return w.clientId
 }
@@ -326,7 +334,7 @@ func (w *WshRpc) handleRequest(req *RpcMessage) {
 }
 
 func (w *WshRpc) runServer() {
-	defer close(w.OutputCh)
+	defer w.outputSched.close()
 	for msgBytes := range w.InputCh {
 		if w.Debug {
 			log.Printf("[%s] received message: %s\n", w.DebugName, string(msgBytes))
@@ -472,8 +480,8 @@ func (handler *RpcRequestHandler) SendCancel() {
 		ReqId:     handler.reqId,
 		AuthToken: handler.w.GetAuthToken(),
 	}
-	barr, _ := json.Marshal(msg) // will never fail
-	handler.w.OutputCh <- barr
+	barr, _ := json.Marshal(msg)         // will never fail
+	handler.w.outputSched.send(barr, "") // cancels are small and urgent, always interactive priority
 	handler.finalize()
 }
 
@@ -567,7 +575,7 @@ func (handler *RpcResponseHandler) SendMessage(msg string) {
 		AuthToken: handler.w.GetAuthToken(),
 	}
 	msgBytes, _ := json.Marshal(rpcMsg) // will never fail
-	handler.w.OutputCh <- msgBytes
+	handler.w.outputSched.send(msgBytes, wshrpc.Command_Message)
 }
 
 func (handler *RpcResponseHandler) SendResponse(data any, done bool) error {
@@ -593,7 +601,7 @@ func (handler *RpcResponseHandler) SendResponse(data any, done bool) error {
 	if err != nil {
 		return err
 	}
-	handler.w.OutputCh <- barr
+	handler.w.outputSched.send(barr, handler.command)
 	return nil
 }
 
@@ -611,7 +619,7 @@ func (handler *RpcResponseHandler) SendResponseError(err error) {
 		AuthToken: handler.w.GetAuthToken(),
 	}
 	barr, _ := json.Marshal(msg) // will never fail
-	handler.w.OutputCh <- barr
+	handler.w.outputSched.send(barr, handler.command)
 }
 
 func (handler *RpcResponseHandler) IsCanceled() bool {
@@ -678,6 +686,6 @@ func (w *WshRpc) SendComplexRequest(command string, data any, opts *wshrpc.RpcOp
 		return nil, err
 	}
 	handler.respCh = w.registerRpc(handler.ctx, handler.reqId)
-	w.OutputCh <- barr
+	w.outputSched.send(barr, command)
 	return handler, nil
 }
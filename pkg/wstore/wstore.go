@@ -33,24 +33,54 @@ func UpdateTabName(ctx context.Context, tabId, name string) error {
 
 func UpdateObjectMeta(ctx context.Context, oref waveobj.ORef, meta waveobj.MetaMapType, mergeSpecial bool) error {
 	return WithTx(ctx, func(tx *TxWrap) error {
-		if oref.IsEmpty() {
-			return fmt.Errorf("empty object reference")
-		}
-		obj, _ := DBGetORef(tx.Context(), oref)
-		if obj == nil {
-			return ErrNotFound
-		}
-		objMeta := waveobj.GetMeta(obj)
-		if objMeta == nil {
-			objMeta = make(map[string]any)
+		return updateObjectMetaTx(tx, oref, meta, mergeSpecial, nil)
+	})
+}
+
+// BulkMetaUpdate is one item of a UpdateObjectMetaBulk call: the same (oref, meta, mergeSpecial)
+// triple UpdateObjectMeta takes, plus an optional IfVersion conditional-update check.
+type BulkMetaUpdate struct {
+	ORef         waveobj.ORef
+	Meta         waveobj.MetaMapType
+	MergeSpecial bool
+	IfVersion    *int
+}
+
+// UpdateObjectMetaBulk applies every item in a single transaction: if any item's IfVersion check
+// fails (or any other error occurs), the whole batch is rolled back and none of it is applied --
+// this is what makes a multi-block dashboard reconfiguration atomic.
+func UpdateObjectMetaBulk(ctx context.Context, items []BulkMetaUpdate) error {
+	return WithTx(ctx, func(tx *TxWrap) error {
+		for _, item := range items {
+			if err := updateObjectMetaTx(tx, item.ORef, item.Meta, item.MergeSpecial, item.IfVersion); err != nil {
+				return fmt.Errorf("updating %s: %w", item.ORef, err)
+			}
 		}
-		newMeta := waveobj.MergeMeta(objMeta, meta, mergeSpecial)
-		waveobj.SetMeta(obj, newMeta)
-		DBUpdate(tx.Context(), obj)
 		return nil
 	})
 }
 
+func updateObjectMetaTx(tx *TxWrap, oref waveobj.ORef, meta waveobj.MetaMapType, mergeSpecial bool, ifVersion *int) error {
+	if oref.IsEmpty() {
+		return fmt.Errorf("empty object reference")
+	}
+	obj, _ := DBGetORef(tx.Context(), oref)
+	if obj == nil {
+		return ErrNotFound
+	}
+	if ifVersion != nil && waveobj.GetVersion(obj) != *ifVersion {
+		return fmt.Errorf("version mismatch: expected %d, got %d", *ifVersion, waveobj.GetVersion(obj))
+	}
+	objMeta := waveobj.GetMeta(obj)
+	if objMeta == nil {
+		objMeta = make(map[string]any)
+	}
+	newMeta := waveobj.MergeMeta(objMeta, meta, mergeSpecial)
+	waveobj.SetMeta(obj, newMeta)
+	DBUpdate(tx.Context(), obj)
+	return nil
+}
+
 func MoveBlockToTab(ctx context.Context, currentTabId string, newTabId string, blockId string) error {
 	return WithTx(ctx, func(tx *TxWrap) error {
 		block, _ := DBGet[*waveobj.Block](tx.Context(), blockId)
@@ -0,0 +1,216 @@
+// Copyright 2024, Command Line Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package wstore
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/wavetermdev/waveterm/pkg/waveobj"
+)
+
+// CmdHistoryItem is one recorded execution of a "cmd" controller block (see
+// pkg/blockcontroller), stored in the cmd_history table so it can be scoped by cwd,
+// connection, or device later (e.g. for Ctrl-R search or history sync, see pkg/histsync).
+// Interactive shell blocks don't have a discrete command string to record, so only
+// cmd-controller runs are captured here.
+type CmdHistoryItem struct {
+	HistoryId  string `db:"historyid" json:"historyid"`
+	Ts         int64  `db:"ts" json:"ts"`
+	BlockId    string `db:"blockid" json:"blockid"`
+	Connection string `db:"connection" json:"connection"`
+	Cwd        string `db:"cwd" json:"cwd"`
+	CmdStr     string `db:"cmdstr" json:"cmdstr"`
+	ExitCode   int    `db:"exitcode" json:"exitcode"`
+	DurationMs int64  `db:"durationms" json:"durationms"`
+	DeviceId   string `db:"deviceid" json:"deviceid"`
+	Synced     bool   `db:"synced" json:"synced"`
+}
+
+// GetLocalDeviceId returns a stable identifier for this installation, used to tag locally
+// recorded history items so other devices can filter by origin after a sync. The singleton
+// Client object's OID is already used this way for one-time state (see Client.HasOldHistory),
+// so it's reused here instead of minting a second identifier.
+func GetLocalDeviceId(ctx context.Context) (string, error) {
+	client, err := DBGetSingleton[*waveobj.Client](ctx)
+	if err != nil {
+		return "", err
+	}
+	return client.OID, nil
+}
+
+func AddCmdHistoryItem(ctx context.Context, item CmdHistoryItem) error {
+	if item.HistoryId == "" {
+		item.HistoryId = uuid.New().String()
+	}
+	if item.DeviceId == "" {
+		deviceId, err := GetLocalDeviceId(ctx)
+		if err != nil {
+			return err
+		}
+		item.DeviceId = deviceId
+	}
+	return WithTx(ctx, func(tx *TxWrap) error {
+		query := `INSERT INTO cmd_history (historyid, ts, blockid, connection, cwd, cmdstr, exitcode, durationms, deviceid, synced)
+		                            VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`
+		tx.Exec(query, item.HistoryId, item.Ts, item.BlockId, item.Connection, item.Cwd, item.CmdStr, item.ExitCode, item.DurationMs, item.DeviceId, item.Synced)
+		return nil
+	})
+}
+
+// AddSyncedCmdHistoryItem inserts a history item pulled from the sync server (see
+// pkg/histsync), ignoring it if a record with the same historyid already exists. Since every
+// record's identity is its historyid, this insert-or-ignore is all the "conflict-free merging"
+// a pull needs -- there's no field to reconcile, just a set union across devices.
+func AddSyncedCmdHistoryItem(ctx context.Context, item CmdHistoryItem) error {
+	return WithTx(ctx, func(tx *TxWrap) error {
+		query := `INSERT OR IGNORE INTO cmd_history (historyid, ts, blockid, connection, cwd, cmdstr, exitcode, durationms, deviceid, synced)
+		                                      VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`
+		tx.Exec(query, item.HistoryId, item.Ts, item.BlockId, item.Connection, item.Cwd, item.CmdStr, item.ExitCode, item.DurationMs, item.DeviceId, true)
+		return nil
+	})
+}
+
+// CmdHistoryQuery scopes a history search by query type: "cwd" restricts to Cwd, "connection"
+// restricts to Connection, "block" restricts to BlockId, "device" restricts to DeviceId, and
+// anything else ("" or "all") returns everything, most recent first (bounded by MaxResults,
+// default 100). Cursor continues a prior search -- pass the NextCursor from the previous
+// CmdHistorySearchResult to resume after it; leave empty to start from the most recent item.
+type CmdHistoryQuery struct {
+	QueryType  string `json:"querytype,omitempty"`
+	Cwd        string `json:"cwd,omitempty"`
+	Connection string `json:"connection,omitempty"`
+	BlockId    string `json:"blockid,omitempty"`
+	DeviceId   string `json:"deviceid,omitempty"`
+	MaxResults int    `json:"maxresults,omitempty"`
+	Cursor     string `json:"cursor,omitempty"`
+}
+
+// CmdHistorySearchResult is one page of a keyset-paginated search. NextCursor is empty once
+// there's no further page.
+type CmdHistorySearchResult struct {
+	Items      []CmdHistoryItem
+	NextCursor string
+}
+
+// encodeHistoryCursor packs the keyset position (ts, historyid) of the last item on a page into
+// an opaque token. Packing both fields (rather than just ts) breaks ties between items recorded
+// in the same millisecond, which an offset-based LIMIT/OFFSET query can't do without skipping or
+// repeating rows as new history is inserted between pages.
+func encodeHistoryCursor(ts int64, historyId string) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(fmt.Sprintf("%d:%s", ts, historyId)))
+}
+
+func decodeHistoryCursor(cursor string) (ts int64, historyId string, ok bool) {
+	raw, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return 0, "", false
+	}
+	tsStr, historyId, found := strings.Cut(string(raw), ":")
+	if !found {
+		return 0, "", false
+	}
+	ts, err = strconv.ParseInt(tsStr, 10, 64)
+	if err != nil {
+		return 0, "", false
+	}
+	return ts, historyId, true
+}
+
+func SearchCmdHistory(ctx context.Context, q CmdHistoryQuery) (CmdHistorySearchResult, error) {
+	maxResults := q.MaxResults
+	if maxResults <= 0 {
+		maxResults = 100
+	}
+	conds := []string{}
+	args := []any{}
+	switch q.QueryType {
+	case "cwd":
+		conds = append(conds, "cwd = ?")
+		args = append(args, q.Cwd)
+	case "connection":
+		conds = append(conds, "connection = ?")
+		args = append(args, q.Connection)
+	case "block":
+		conds = append(conds, "blockid = ?")
+		args = append(args, q.BlockId)
+	case "device":
+		conds = append(conds, "deviceid = ?")
+		args = append(args, q.DeviceId)
+	}
+	if q.Cursor != "" {
+		cursorTs, cursorHistoryId, ok := decodeHistoryCursor(q.Cursor)
+		if ok {
+			conds = append(conds, "(ts < ? OR (ts = ? AND historyid < ?))")
+			args = append(args, cursorTs, cursorTs, cursorHistoryId)
+		}
+	}
+	whereClause := ""
+	if len(conds) > 0 {
+		whereClause = "WHERE " + strings.Join(conds, " AND ")
+	}
+	// fetch one extra row so we know whether a further page exists without a separate count query
+	args = append(args, maxResults+1)
+	return WithTxRtn(ctx, func(tx *TxWrap) (CmdHistorySearchResult, error) {
+		var items []CmdHistoryItem
+		query := `SELECT historyid, ts, blockid, connection, cwd, cmdstr, exitcode, durationms, deviceid, synced
+		            FROM cmd_history ` + whereClause + `
+		           ORDER BY ts DESC, historyid DESC
+		           LIMIT ?`
+		tx.Select(&items, query, args...)
+		var result CmdHistorySearchResult
+		if len(items) > maxResults {
+			last := items[maxResults-1]
+			result.NextCursor = encodeHistoryCursor(last.Ts, last.HistoryId)
+			items = items[:maxResults]
+		}
+		result.Items = items
+		return result, nil
+	})
+}
+
+// GetUnsyncedCmdHistory returns locally recorded history items (from this device) that haven't
+// been pushed to the sync server yet (see pkg/histsync).
+func GetUnsyncedCmdHistory(ctx context.Context, deviceId string, maxResults int) ([]CmdHistoryItem, error) {
+	if maxResults <= 0 {
+		maxResults = 1000
+	}
+	return WithTxRtn(ctx, func(tx *TxWrap) ([]CmdHistoryItem, error) {
+		var rtn []CmdHistoryItem
+		query := `SELECT historyid, ts, blockid, connection, cwd, cmdstr, exitcode, durationms, deviceid, synced
+		            FROM cmd_history
+		           WHERE deviceid = ? AND synced = 0
+		           ORDER BY ts ASC
+		           LIMIT ?`
+		tx.Select(&rtn, query, deviceId, maxResults)
+		return rtn, nil
+	})
+}
+
+// MarkCmdHistorySynced flags history items as pushed, so GetUnsyncedCmdHistory won't return
+// them again on the next sync.
+func MarkCmdHistorySynced(ctx context.Context, historyIds []string) error {
+	if len(historyIds) == 0 {
+		return nil
+	}
+	return WithTx(ctx, func(tx *TxWrap) error {
+		query := `UPDATE cmd_history SET synced = 1 WHERE historyid = ?`
+		for _, historyId := range historyIds {
+			tx.Exec(query, historyId)
+		}
+		return nil
+	})
+}
+
+// GetMaxCmdHistoryTs returns the newest ts across all local history, used as the "since" cursor
+// for a sync pull so we only fetch records we don't already have.
+func GetMaxCmdHistoryTs(ctx context.Context) (int64, error) {
+	return WithTxRtn(ctx, func(tx *TxWrap) (int64, error) {
+		return tx.GetInt64(`SELECT COALESCE(MAX(ts), 0) FROM cmd_history`), nil
+	})
+}
@@ -37,6 +37,9 @@ func InitWStore() error {
 	if err != nil {
 		return err
 	}
+	checkIntegrityOnStartup()
+	go maintenanceLoop()
+	go backupLoop()
 	log.Printf("wstore initialized\n")
 	return nil
 }
@@ -53,6 +56,10 @@ func MakeDB(ctx context.Context) (*sqlx.DB, error) {
 		return nil, err
 	}
 	rtn.DB.SetMaxOpenConns(1)
+	// only takes effect on a brand new (table-less) db -- converting an existing db to
+	// incremental auto_vacuum requires a full VACUUM, which RunIncrementalVacuum deliberately
+	// doesn't do automatically (see wstore_maint.go)
+	rtn.ExecContext(ctx, "PRAGMA auto_vacuum=INCREMENTAL")
 	return rtn, nil
 }
 
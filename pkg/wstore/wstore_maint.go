@@ -0,0 +1,169 @@
+// Copyright 2025, Command Line Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package wstore
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/wavetermdev/waveterm/pkg/util/dbbackup"
+	"github.com/wavetermdev/waveterm/pkg/wavebase"
+)
+
+// MaintenanceInterval is how often the background goroutine started by InitWStore runs an
+// incremental vacuum pass.
+const MaintenanceInterval = 1 * time.Hour
+
+// IncrementalVacuumPages caps how many freelist pages are reclaimed per pass, so a single pass
+// can't block other db access for long even on a large db.
+const IncrementalVacuumPages = 500
+
+// BackupStoreName identifies this store's backup files (see pkg/util/dbbackup), distinct from
+// WStoreDBName so a backup file never collides with the live db even if they end up in the same
+// directory.
+const BackupStoreName = "wstore"
+
+// BackupInterval is how often the background goroutine started by InitWStore takes an online
+// backup snapshot.
+const BackupInterval = 6 * time.Hour
+
+// MaxBackups caps how many backup snapshots are kept -- older ones are pruned after each backup.
+const MaxBackups = 10
+
+// TableStats reports the row count for a single table, used by DbStats.
+type TableStats struct {
+	Name     string `json:"name"`
+	RowCount int64  `json:"rowcount"`
+}
+
+// DbStats summarizes the on-disk size and per-table row counts of the wstore db, to back the
+// "/client:dbstats" command.
+type DbStats struct {
+	Name          string       `json:"name"`
+	FilePath      string       `json:"filepath"`
+	FileSizeBytes int64        `json:"filesizebytes"`
+	PageCount     int64        `json:"pagecount"`
+	PageSizeBytes int64        `json:"pagesizebytes"`
+	FreelistCount int64        `json:"freelistcount"`
+	Tables        []TableStats `json:"tables"`
+}
+
+// RunIntegrityCheck runs "PRAGMA integrity_check" and returns "ok" if the db is healthy, or the
+// list of problems found otherwise.
+func RunIntegrityCheck(ctx context.Context) (string, error) {
+	return WithTxRtn(ctx, func(tx *TxWrap) (string, error) {
+		var rows []string
+		tx.Select(&rows, `PRAGMA integrity_check`)
+		if len(rows) == 1 && rows[0] == "ok" {
+			return "ok", nil
+		}
+		result := ""
+		for i, row := range rows {
+			if i > 0 {
+				result += "; "
+			}
+			result += row
+		}
+		return result, nil
+	})
+}
+
+// checkIntegrityOnStartup runs once from InitWStore and logs recovery guidance if the db is
+// corrupt -- it does not attempt to repair anything automatically, since that risks losing data
+// the user would rather recover manually from a backup.
+func checkIntegrityOnStartup() {
+	ctx, cancelFn := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancelFn()
+	result, err := RunIntegrityCheck(ctx)
+	if err != nil {
+		log.Printf("[wstore] error running integrity check: %v\n", err)
+		return
+	}
+	if result == "ok" {
+		return
+	}
+	log.Printf("[wstore] WARNING: database integrity check failed: %s\n", result)
+	log.Printf("[wstore] the wstore database at %s may be corrupt. recommended recovery steps:\n", GetDBName())
+	log.Printf("[wstore]   1. stop wave and back up the file (and its -wal/-shm siblings) before doing anything else\n")
+	log.Printf("[wstore]   2. try `sqlite3 %s \".recover\"` to dump recoverable rows into a fresh database\n", GetDBName())
+	log.Printf("[wstore]   3. if recovery fails, restore from your most recent backup of the wave data directory\n")
+}
+
+// RunIncrementalVacuum reclaims up to maxPages freed pages back to the filesystem. It is a no-op
+// (returns immediately, reclaiming nothing) unless the db's auto_vacuum mode is "incremental",
+// which is only set on freshly created databases (see MakeDB) -- converting an existing database
+// requires a full VACUUM, which this intentionally avoids running automatically since it can take
+// a long time and briefly doubles disk usage.
+func RunIncrementalVacuum(ctx context.Context, maxPages int) error {
+	return WithTx(ctx, func(tx *TxWrap) error {
+		tx.Exec(fmt.Sprintf("PRAGMA incremental_vacuum(%d)", maxPages))
+		return nil
+	})
+}
+
+func maintenanceLoop() {
+	for {
+		time.Sleep(MaintenanceInterval)
+		ctx, cancelFn := context.WithTimeout(context.Background(), 30*time.Second)
+		err := RunIncrementalVacuum(ctx, IncrementalVacuumPages)
+		cancelFn()
+		if err != nil {
+			log.Printf("[wstore] error running incremental vacuum: %v\n", err)
+		}
+	}
+}
+
+// RunBackup takes an online backup snapshot of the wstore db into the wave backup directory and
+// prunes old snapshots beyond MaxBackups. Returns the path to the new backup file.
+func RunBackup(ctx context.Context) (string, error) {
+	destPath := filepath.Join(wavebase.GetWaveBackupDir(), dbbackup.BackupFileName(BackupStoreName, time.Now().UnixMilli()))
+	if err := dbbackup.BackupSQLiteDB(ctx, globalDB.DB, destPath); err != nil {
+		return "", fmt.Errorf("error backing up wstore db: %w", err)
+	}
+	if err := dbbackup.PruneBackups(wavebase.GetWaveBackupDir(), BackupStoreName, MaxBackups); err != nil {
+		log.Printf("[wstore] error pruning old backups: %v\n", err)
+	}
+	return destPath, nil
+}
+
+func backupLoop() {
+	for {
+		time.Sleep(BackupInterval)
+		ctx, cancelFn := context.WithTimeout(context.Background(), 2*time.Minute)
+		_, err := RunBackup(ctx)
+		cancelFn()
+		if err != nil {
+			log.Printf("[wstore] error running scheduled backup: %v\n", err)
+		}
+	}
+}
+
+// GetDbStats reports the wstore db's on-disk size and per-table row counts.
+func GetDbStats(ctx context.Context) (DbStats, error) {
+	dbName := GetDBName()
+	rtn := DbStats{Name: "wstore", FilePath: dbName}
+	if info, err := os.Stat(dbName); err == nil {
+		rtn.FileSizeBytes = info.Size()
+	}
+	_, err := WithTxRtn(ctx, func(tx *TxWrap) (bool, error) {
+		rtn.PageCount = tx.GetInt64(`PRAGMA page_count`)
+		rtn.PageSizeBytes = tx.GetInt64(`PRAGMA page_size`)
+		rtn.FreelistCount = tx.GetInt64(`PRAGMA freelist_count`)
+		var tableNames []string
+		tx.Select(&tableNames, `SELECT name FROM sqlite_master WHERE type = 'table' AND name NOT LIKE 'sqlite_%' ORDER BY name`)
+		for _, tableName := range tableNames {
+			rowCount := tx.GetInt64(fmt.Sprintf("SELECT COUNT(*) FROM %q", tableName))
+			rtn.Tables = append(rtn.Tables, TableStats{Name: tableName, RowCount: rowCount})
+		}
+		return true, nil
+	})
+	if err != nil {
+		return DbStats{}, err
+	}
+	return rtn, nil
+}